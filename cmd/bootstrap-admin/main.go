@@ -0,0 +1,42 @@
+// Command bootstrap-admin creates the first admin of a fresh installation, later admins
+// are invited by an existing admin. The password is read from BOOTSTRAP_ADMIN_PASSWORD
+// when -password is not given.
+//
+//	go run ./cmd/bootstrap-admin -email admin@example.com -name Admin
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/williamchang80/sea-apd/dto/request/admin"
+	"github.com/williamchang80/sea-apd/infrastructure/db"
+	memory "github.com/williamchang80/sea-apd/repository/memory/auth"
+	"github.com/williamchang80/sea-apd/repository/postgres/user"
+	"github.com/williamchang80/sea-apd/routes"
+	"github.com/williamchang80/sea-apd/usecase/auth"
+	usecase "github.com/williamchang80/sea-apd/usecase/user"
+)
+
+func main() {
+	name := flag.String("name", "", "name of the admin")
+	email := flag.String("email", "", "email of the admin")
+	password := flag.String("password", os.Getenv("BOOTSTRAP_ADMIN_PASSWORD"), "password of the admin")
+	flag.Parse()
+
+	db := db.Postgres()
+	routes.MigrateUsers(db)
+	repo := user.NewUserRepository(db)
+	u := usecase.NewAdminUseCase(repo, auth.NewAuthUsecase(repo, memory.NewLoginAttemptStore()))
+	if err := u.BootstrapAdmin(context.Background(), admin.BootstrapAdminRequest{
+		Name:     *name,
+		Email:    *email,
+		Password: *password,
+	}); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(*email + " is now an admin")
+}
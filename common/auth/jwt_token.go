@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"errors"
 	"github.com/dgrijalva/jwt-go"
 	"github.com/williamchang80/sea-apd/domain/user"
 	"os"
@@ -8,12 +9,27 @@ import (
 	"time"
 )
 
+// defaultTokenLifetime is how long a login token is valid unless AUTH_TOKEN_LIFETIME says otherwise
+const defaultTokenLifetime = 24 * time.Hour
+
+// TokenLifetime returns how long the tokens of GenerateToken are valid, AUTH_TOKEN_LIFETIME
+// like "12h" replaces the default
+func TokenLifetime() time.Duration {
+	lifetime, err := time.ParseDuration(os.Getenv("AUTH_TOKEN_LIFETIME"))
+	if err != nil || lifetime <= 0 {
+		return defaultTokenLifetime
+	}
+	return lifetime
+}
+
 func GenerateToken(user *user.User) (string, error) {
+	now := time.Now()
 	atClaims := jwt.MapClaims{
 		"authorized": true,
 		"user_id":    user.ID,
 		"user_role":  user.Role,
-		"exp":        time.Now().Add(time.Second).Unix(),
+		"iat":        now.Unix(),
+		"exp":        now.Add(TokenLifetime()).Unix(),
 	}
 	at := jwt.NewWithClaims(jwt.SigningMethodHS256, atClaims)
 	secretKey := GetSecretKey()
@@ -36,15 +52,27 @@ func GetValidBearerToken(token string) string {
 	return token
 }
 
-func IsValidTokenLifetime(t string) bool {
+// ParseToken returns the claims of a signed token that has not expired yet
+func ParseToken(t string) (jwt.MapClaims, error) {
 	secretKey := GetSecretKey()
 	validBearerToken := GetValidBearerToken(t)
 	claims := jwt.MapClaims{}
 	token, err := jwt.ParseWithClaims(validBearerToken, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
 		return []byte(secretKey), nil
 	})
-	if token == nil || err != nil || claims.Valid() != nil {
-		return false
+	if token == nil || err != nil {
+		return nil, errors.New("token is not valid")
 	}
-	return true
+	if err := claims.Valid(); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func IsValidTokenLifetime(t string) bool {
+	_, err := ParseToken(t)
+	return err == nil
 }
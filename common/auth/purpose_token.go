@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	// EmailVerificationPurpose signs the links that confirm the email of a user
+	EmailVerificationPurpose = "email_verification"
+	// LoginChallengePurpose signs the challenge answered with a two-factor code at login
+	LoginChallengePurpose = "login_challenge"
+)
+
+// purposeKey derives a signing key for the purpose from SECRET_AUTH_KEY, so a token
+// signed for one purpose is neither an access token nor valid for another purpose
+func purposeKey(purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(GetSecretKey()))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// GeneratePurposeToken signs the subject for the purpose until expiresAt
+func GeneratePurposeToken(purpose string, subject string, expiresAt time.Time) (string, error) {
+	claims := jwt.StandardClaims{
+		Subject:   subject,
+		Audience:  purpose,
+		IssuedAt:  time.Now().Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(purposeKey(purpose))
+}
+
+// ParsePurposeToken returns the subject of a token signed for the purpose that has not
+// expired yet
+func ParsePurposeToken(purpose string, t string) (string, error) {
+	claims := jwt.StandardClaims{}
+	token, err := jwt.ParseWithClaims(t, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return purposeKey(purpose), nil
+	})
+	if token == nil || err != nil || !claims.VerifyAudience(purpose, true) || claims.Subject == "" {
+		return "", errors.New("token is not valid")
+	}
+	return claims.Subject, nil
+}
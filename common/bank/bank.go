@@ -0,0 +1,63 @@
+package bank
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/williamchang80/sea-apd/domain/apperror"
+)
+
+// Bank is a payout bank merchants can register accounts at. Account numbers of every
+// bank have a fixed number of digits.
+type Bank struct {
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	AccountLength int    `json:"account_length"`
+}
+
+var SupportedBanks = []Bank{
+	{Code: "002", Name: "BRI", AccountLength: 15},
+	{Code: "008", Name: "Mandiri", AccountLength: 13},
+	{Code: "009", Name: "BNI", AccountLength: 10},
+	{Code: "013", Name: "Permata", AccountLength: 10},
+	{Code: "014", Name: "BCA", AccountLength: 10},
+	{Code: "022", Name: "CIMB Niaga", AccountLength: 13},
+}
+
+var ErrUnsupportedBank = apperror.Validation("bank is not supported")
+
+func GetBank(code string) (*Bank, error) {
+	for _, b := range SupportedBanks {
+		if b.Code == code {
+			return &b, nil
+		}
+	}
+	return nil, ErrUnsupportedBank
+}
+
+// NormalizeAccountNumber drops the spaces and dashes people type to group digits
+func NormalizeAccountNumber(number string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, number)
+}
+
+// ValidateAccountNumber checks the normalized account number against the format of the bank
+func (b Bank) ValidateAccountNumber(number string) error {
+	if len(number) != b.AccountLength {
+		return apperror.Validation(b.Name + " account numbers have " + strconv.Itoa(b.AccountLength) + " digits")
+	}
+	for _, r := range number {
+		if !unicode.IsDigit(r) {
+			return apperror.Validation("account number can only contain digits")
+		}
+	}
+	if strings.Count(number, number[:1]) == len(number) {
+		return apperror.Validation("account number is not valid")
+	}
+	return nil
+}
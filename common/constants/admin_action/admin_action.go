@@ -0,0 +1,47 @@
+package admin_action
+
+type AdminAction int
+
+const (
+	SEARCH_USERS AdminAction = iota
+	SEARCH_MERCHANTS
+	SEARCH_TRANSACTIONS
+	VIEW_TRANSACTION
+	FORCE_TRANSACTION_STATUS
+	BAN_USER
+	UNBAN_USER
+	ADJUST_BALANCE
+	VIEW_KPIS
+	UNLOCK_USER
+	OTHER
+)
+
+var AdminActionList = []string{
+	"search users",
+	"search merchants",
+	"search transactions",
+	"view transaction",
+	"force transaction status",
+	"ban user",
+	"unban user",
+	"adjust balance",
+	"view kpis",
+	"unlock user",
+	"other",
+}
+
+func ToString(a AdminAction) string {
+	if a < SEARCH_USERS || a > OTHER {
+		return ""
+	}
+	return AdminActionList[a]
+}
+
+func ParseToEnum(src string) AdminAction {
+	for i, action := range AdminActionList {
+		if action == src {
+			return AdminAction(i)
+		}
+	}
+	return OTHER
+}
@@ -0,0 +1,37 @@
+package bank_account_status
+
+type BankAccountStatus int
+
+const (
+	PENDING BankAccountStatus = iota
+	VERIFIED
+	REJECTED
+	OTHER
+)
+
+var BankAccountStatusList = []string{
+	"pending",
+	"verified",
+	"rejected",
+	"other",
+}
+
+func ToString(bs BankAccountStatus) string {
+	if bs < PENDING || bs > REJECTED {
+		return ""
+	}
+	return BankAccountStatusList[bs]
+}
+
+func ParseToEnum(src string) BankAccountStatus {
+	bankAccountStatusMap := map[string]BankAccountStatus{
+		"pending":  PENDING,
+		"verified": VERIFIED,
+		"rejected": REJECTED,
+		"other":    OTHER,
+	}
+	if val, exist := bankAccountStatusMap[src]; exist {
+		return val
+	}
+	return bankAccountStatusMap["other"]
+}
@@ -0,0 +1,34 @@
+package catalogue_format
+
+type CatalogueFormat int
+
+const (
+	CSV CatalogueFormat = iota
+	JSONL
+	OTHER
+)
+
+var CatalogueFormatList = []string{
+	"csv",
+	"jsonl",
+	"other",
+}
+
+func ToString(cf CatalogueFormat) string {
+	if cf < CSV || cf > JSONL {
+		return ""
+	}
+	return CatalogueFormatList[cf]
+}
+
+func ParseToEnum(src string) CatalogueFormat {
+	catalogueFormatMap := map[string]CatalogueFormat{
+		"csv":   CSV,
+		"jsonl": JSONL,
+		"other": OTHER,
+	}
+	if val, exist := catalogueFormatMap[src]; exist {
+		return val
+	}
+	return catalogueFormatMap["other"]
+}
@@ -0,0 +1,46 @@
+package document_type
+
+type DocumentType int
+
+const (
+	ID_CARD DocumentType = iota
+	BUSINESS_LICENCE
+	BANK_ACCOUNT_PROOF
+	OTHER
+)
+
+var DocumentTypeList = []string{
+	"id card",
+	"business licence",
+	"bank account proof",
+	"other",
+}
+
+func ToString(dt DocumentType) string {
+	if dt < ID_CARD || dt > BANK_ACCOUNT_PROOF {
+		return ""
+	}
+	return DocumentTypeList[dt]
+}
+
+func ParseToEnum(src string) DocumentType {
+	documentTypeMap := map[string]DocumentType{
+		"id card":            ID_CARD,
+		"business licence":   BUSINESS_LICENCE,
+		"bank account proof": BANK_ACCOUNT_PROOF,
+		"other":              OTHER,
+	}
+	if val, exist := documentTypeMap[src]; exist {
+		return val
+	}
+	return documentTypeMap["other"]
+}
+
+// GetRequiredDocumentTypes lists the documents a merchant must upload before review
+func GetRequiredDocumentTypes() []string {
+	var required []string
+	for _, dt := range []DocumentType{ID_CARD, BUSINESS_LICENCE, BANK_ACCOUNT_PROOF} {
+		required = append(required, ToString(dt))
+	}
+	return required
+}
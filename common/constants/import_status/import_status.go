@@ -0,0 +1,40 @@
+package import_status
+
+type ImportStatus int
+
+const (
+	PENDING ImportStatus = iota
+	PROCESSING
+	COMPLETED
+	FAILED
+	OTHER
+)
+
+var ImportStatusList = []string{
+	"pending",
+	"processing",
+	"completed",
+	"failed",
+	"other",
+}
+
+func ToString(is ImportStatus) string {
+	if is < PENDING || is > OTHER {
+		return ""
+	}
+	return ImportStatusList[is]
+}
+
+func ParseToEnum(src string) ImportStatus {
+	importStatusMap := map[string]ImportStatus{
+		"pending":    PENDING,
+		"processing": PROCESSING,
+		"completed":  COMPLETED,
+		"failed":     FAILED,
+		"other":      OTHER,
+	}
+	if val, exist := importStatusMap[src]; exist {
+		return val
+	}
+	return importStatusMap["other"]
+}
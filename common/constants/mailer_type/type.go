@@ -5,4 +5,9 @@ type MailType int
 const (
 	TRANSACTION MailType = iota
 	AUTH
+	BANK_ACCOUNT
+	ADMIN_INVITATION
+	EMAIL_VERIFICATION
+	PASSWORD_RESET
+	ACCOUNT_LOCKED
 )
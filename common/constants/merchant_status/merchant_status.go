@@ -6,6 +6,8 @@ const (
 	WAITING MerchantApprovalStatus = iota
 	DECLINED
 	ACCEPTED
+	SUSPENDED
+	CLOSED
 	OTHER
 )
 
@@ -13,11 +15,13 @@ var MerchantStatusList = []string{
 	"waiting",
 	"declined",
 	"accepted",
+	"suspended",
+	"closed",
 	"other",
 }
 
 func ToString(ms MerchantApprovalStatus) string {
-	if ms < WAITING || ms > ACCEPTED {
+	if ms < WAITING || ms > CLOSED {
 		return ""
 	}
 	return MerchantStatusList[ms]
@@ -25,10 +29,12 @@ func ToString(ms MerchantApprovalStatus) string {
 
 func ParseToEnum(src string) MerchantApprovalStatus {
 	transactionStatusMap := map[string]MerchantApprovalStatus{
-		"waiting":  WAITING,
-		"declined": DECLINED,
-		"accepted": ACCEPTED,
-		"other":    OTHER,
+		"waiting":   WAITING,
+		"declined":  DECLINED,
+		"accepted":  ACCEPTED,
+		"suspended": SUSPENDED,
+		"closed":    CLOSED,
+		"other":     OTHER,
 	}
 	if val, exist := transactionStatusMap[src]; exist {
 		return val
@@ -0,0 +1,37 @@
+package product_sort
+
+type ProductSort int
+
+const (
+	RELEVANCE ProductSort = iota
+	PRICE_ASC
+	PRICE_DESC
+	OTHER
+)
+
+var ProductSortList = []string{
+	"relevance",
+	"price_asc",
+	"price_desc",
+	"other",
+}
+
+func ToString(ps ProductSort) string {
+	if ps < RELEVANCE || ps > PRICE_DESC {
+		return ""
+	}
+	return ProductSortList[ps]
+}
+
+func ParseToEnum(src string) ProductSort {
+	productSortMap := map[string]ProductSort{
+		"relevance":  RELEVANCE,
+		"price_asc":  PRICE_ASC,
+		"price_desc": PRICE_DESC,
+		"other":      OTHER,
+	}
+	if val, exist := productSortMap[src]; exist {
+		return val
+	}
+	return productSortMap["other"]
+}
@@ -7,4 +7,6 @@ const (
 	UNPROCESSABLE_ENTITY = "validation error"
 	UNAUTHENTICED = "unauthenticated"
 	UNAUTHORIZED = "unauthorized"
+	FORBIDDEN = "forbidden"
+	TOO_MANY_REQUESTS = "too many requests, try again later"
 )
@@ -0,0 +1,42 @@
+package time_bucket
+
+type TimeBucket int
+
+const (
+	DAY TimeBucket = iota
+	WEEK
+	MONTH
+	OTHER
+)
+
+var TimeBucketList = []string{
+	"day",
+	"week",
+	"month",
+	"other",
+}
+
+func ToString(tb TimeBucket) string {
+	if tb < DAY || tb > OTHER {
+		return ""
+	}
+	return TimeBucketList[tb]
+}
+
+func ParseToEnum(src string) TimeBucket {
+	timeBucketMap := map[string]TimeBucket{
+		"day":   DAY,
+		"week":  WEEK,
+		"month": MONTH,
+		"other": OTHER,
+	}
+	if val, exist := timeBucketMap[src]; exist {
+		return val
+	}
+	return timeBucketMap["other"]
+}
+
+// GetRollupBuckets lists the buckets kept in the sales rollup table
+func GetRollupBuckets() []string {
+	return []string{ToString(DAY), ToString(WEEK), ToString(MONTH)}
+}
@@ -9,6 +9,7 @@ const (
 	DECLINED
 	WAITING_DELIVERY
 	ACCEPTED
+	COMPLETED
 	OTHER
 )
 
@@ -19,6 +20,7 @@ var TransactionStatusList = []string{
 	"declined",
 	"waiting delivery",
 	"accepted",
+	"completed",
 	"other",
 }
 
@@ -37,6 +39,7 @@ func ParseToEnum(src string) TransactionStatus {
 		"declined":             DECLINED,
 		"waiting delivery":     WAITING_DELIVERY,
 		"accepted":             ACCEPTED,
+		"completed":            COMPLETED,
 		"other":                OTHER,
 	}
 	if val, exist := transactionStatusMap[src]; exist {
@@ -47,6 +50,7 @@ func ParseToEnum(src string) TransactionStatus {
 
 func GetStatusListForTransactionHistory() []string {
 	transactionHistoryStatusEnumList := []TransactionStatus{
+		COMPLETED,
 		ACCEPTED,
 		DECLINED,
 		WAITING_DELIVERY,
@@ -58,3 +62,9 @@ func GetStatusListForTransactionHistory() []string {
 	}
 	return transactionHistoryStatusList
 }
+
+// GetStatusListForSales returns the statuses of transactions counted as sales, the goods
+// of an accepted transaction are on their way and only a decline can still undo it
+func GetStatusListForSales() []string {
+	return []string{ToString(ACCEPTED), ToString(COMPLETED)}
+}
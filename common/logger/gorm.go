@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+const contextSetting = "logger:context"
+
+// gormLogger writes the queries and errors of gorm with the request of ctx
+type gormLogger struct {
+	ctx context.Context
+}
+
+// Print receives ("sql", source, duration, query, vars, rows) for every query when the
+// log mode is on and ("log", source, values...) for errors
+func (g gormLogger) Print(values ...interface{}) {
+	if len(values) < 2 {
+		return
+	}
+	fields := Fields{"source": values[1]}
+	if values[0] == "sql" && len(values) == 6 {
+		if duration, ok := values[2].(time.Duration); ok {
+			fields["latency_ms"] = float64(duration) / float64(time.Millisecond)
+		}
+		fields["query"] = values[3]
+		fields["rows"] = values[5]
+		Debug(g.ctx, "sql query", fields)
+		return
+	}
+	Error(g.ctx, fmt.Sprint(values[2:]...), fields)
+}
+
+// Gorm returns a session of db that logs with the request of ctx, the queries are only
+// logged at debug level. The values of the queries are left out as they hold passwords
+// and tokens. The callbacks of db find ctx with GormContext.
+func Gorm(ctx context.Context, db *gorm.DB) *gorm.DB {
+	session := db.New()
+	session.SetLogger(gormLogger{ctx: ctx})
+	if IsDebug() {
+		session.LogMode(true)
+	}
+	return session.Set(contextSetting, ctx)
+}
+
+// GormContext returns the context of the session the scope runs in
+func GormContext(scope *gorm.Scope) context.Context {
+	if ctx, ok := scope.Get(contextSetting); ok {
+		return ctx.(context.Context)
+	}
+	return context.Background()
+}
@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/labstack/gommon/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Fields are the values a log line carries next to its message
+type Fields map[string]interface{}
+
+type contextKey int
+
+const (
+	requestIdKey contextKey = iota
+	userIdKey
+)
+
+var base = newLogger()
+
+// newLogger writes one json object per line at the level set in LOG_LEVEL, info by default
+func newLogger() *log.Logger {
+	l := log.New("")
+	l.SetHeader(`{"time":"${time_rfc3339_nano}","level":"${level}"}`)
+	l.SetLevel(ParseLevel(os.Getenv("LOG_LEVEL")))
+	return l
+}
+
+// ParseLevel returns the level of debug, info, warn, error or off
+func ParseLevel(level string) log.Lvl {
+	switch strings.ToLower(level) {
+	case "debug":
+		return log.DEBUG
+	case "warn":
+		return log.WARN
+	case "error":
+		return log.ERROR
+	case "off":
+		return log.OFF
+	}
+	return log.INFO
+}
+
+// SetOutput redirects the log lines, e.g. to a buffer in tests
+func SetOutput(w io.Writer) {
+	base.SetOutput(w)
+}
+
+// IsDebug tells whether debug lines like the sql queries are written
+func IsDebug() bool {
+	return base.Level() <= log.DEBUG
+}
+
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey, requestId)
+}
+
+func RequestId(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey).(string)
+	return requestId
+}
+
+func WithUserId(ctx context.Context, userId string) context.Context {
+	return context.WithValue(ctx, userIdKey, userId)
+}
+
+func UserId(ctx context.Context) string {
+	userId, _ := ctx.Value(userIdKey).(string)
+	return userId
+}
+
+// entry merges the fields with the message and the request, user and span of ctx
+func entry(ctx context.Context, message string, fields Fields) log.JSON {
+	j := log.JSON{}
+	for k, v := range fields {
+		j[k] = v
+	}
+	j["message"] = message
+	if requestId := RequestId(ctx); requestId != "" {
+		j["request_id"] = requestId
+	}
+	if userId := UserId(ctx); userId != "" {
+		j["user_id"] = userId
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		j["trace_id"] = span.TraceID().String()
+		j["span_id"] = span.SpanID().String()
+	}
+	return j
+}
+
+func Debug(ctx context.Context, message string, fields Fields) {
+	base.Debugj(entry(ctx, message, fields))
+}
+
+func Info(ctx context.Context, message string, fields Fields) {
+	base.Infoj(entry(ctx, message, fields))
+}
+
+func Warn(ctx context.Context, message string, fields Fields) {
+	base.Warnj(entry(ctx, message, fields))
+}
+
+func Error(ctx context.Context, message string, fields Fields) {
+	base.Errorj(entry(ctx, message, fields))
+}
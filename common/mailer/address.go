@@ -0,0 +1,28 @@
+package mailer
+
+import (
+	"net"
+	"os"
+	"strings"
+
+	"github.com/badoux/checkmail"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+)
+
+var ErrInvalidAddress = apperror.Validation("email address is not valid")
+
+// ValidateAddress checks the syntax of the email address. With EMAIL_CHECK_MX=true the
+// domain also has to accept mail, which needs DNS and is off by default.
+func ValidateAddress(email string) error {
+	if err := checkmail.ValidateFormat(email); err != nil {
+		return ErrInvalidAddress
+	}
+	if os.Getenv("EMAIL_CHECK_MX") != "true" {
+		return nil
+	}
+	host := email[strings.LastIndex(email, "@")+1:]
+	if mx, err := net.LookupMX(host); err != nil || len(mx) == 0 {
+		return apperror.Validation("email domain does not accept mail")
+	}
+	return nil
+}
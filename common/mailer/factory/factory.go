@@ -4,7 +4,9 @@ import (
 	"github.com/williamchang80/sea-apd/common/constants/mailer_type"
 	mailer3 "github.com/williamchang80/sea-apd/common/mailer"
 	"github.com/williamchang80/sea-apd/usecase/auth/mailer"
+	mailer4 "github.com/williamchang80/sea-apd/usecase/bank_account/mailer"
 	mailer2 "github.com/williamchang80/sea-apd/usecase/transaction/mailer"
+	mailer5 "github.com/williamchang80/sea-apd/usecase/user/mailer"
 )
 
 type MailFactory interface {
@@ -17,6 +19,16 @@ func CreateMailerFactory(mailType mailer_type.MailType) MailFactory {
 		return &mailer.AuthMailer{}
 	case mailer_type.TRANSACTION:
 		return &mailer2.TransactionMailer{}
+	case mailer_type.BANK_ACCOUNT:
+		return &mailer4.BankAccountMailer{}
+	case mailer_type.ADMIN_INVITATION:
+		return &mailer5.AdminInvitationMailer{}
+	case mailer_type.EMAIL_VERIFICATION:
+		return &mailer5.EmailVerificationMailer{}
+	case mailer_type.PASSWORD_RESET:
+		return &mailer.PasswordResetMailer{}
+	case mailer_type.ACCOUNT_LOCKED:
+		return &mailer.AccountLockedMailer{}
 	}
 	return nil
 }
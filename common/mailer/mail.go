@@ -2,9 +2,13 @@ package mailer
 
 import (
 	"context"
-	"fmt"
-	"github.com/labstack/gommon/log"
+	"errors"
 	"github.com/mailgun/mailgun-go/v4"
+	"github.com/williamchang80/sea-apd/common/logger"
+	"github.com/williamchang80/sea-apd/common/metrics"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"os"
 	"time"
 )
@@ -28,6 +32,7 @@ func InitMail() {
 	DOMAIN_NAME := os.Getenv("DOMAIN_NAME")
 	if Mailer == nil {
 		Mailer = mailgun.NewMailgun(DOMAIN_NAME, API_KEY)
+		Mailer.SetClient(tracing.NewClient())
 	}
 }
 
@@ -36,13 +41,32 @@ func CreateMailer(mail Mail) *mailgun.Message {
 	return m
 }
 
-func SendEmail(mails []Mail) error {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
-	defer cancel()
+func SendEmail(ctx context.Context, mails []Mail) error {
+	if Mailer == nil {
+		return errors.New("mailer is not initialised")
+	}
 	for _, mail := range mails {
-		message := CreateMailer(mail)
-		Mailer.Send(ctx, message)
-		log.Info(fmt.Sprintf("Mail sent from %v to %v !", mail.Sender, mail.Recipient))
+		send(ctx, mail)
 	}
 	return nil
 }
+
+func send(ctx context.Context, mail Mail) {
+	ctx, span := tracing.Start(ctx, "mailer.SendEmail",
+		trace.WithAttributes(attribute.String("mail.subject", mail.Subject)))
+	defer span.End()
+	// the request may end before the mail is sent, only its ids and span are kept
+	sendCtx, cancel := context.WithTimeout(trace.ContextWithSpan(context.Background(), span), time.Second*10)
+	defer cancel()
+	message := CreateMailer(mail)
+	fields := logger.Fields{"sender": mail.Sender, "recipient": mail.Recipient, "subject": mail.Subject}
+	if _, _, err := Mailer.Send(sendCtx, message); err != nil {
+		tracing.Fail(span, err)
+		fields["error"] = err.Error()
+		logger.Error(ctx, "sending mail failed", fields)
+		metrics.MailsSent.WithLabelValues("failure").Inc()
+		return
+	}
+	logger.Info(ctx, "mail sent", fields)
+	metrics.MailsSent.WithLabelValues("success").Inc()
+}
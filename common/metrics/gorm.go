@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+const startKey = "metrics:start"
+
+// InstrumentGorm times every create, query, update, delete and raw query of db. The
+// callbacks are shared by all sessions of db, so it is called once per connection.
+func InstrumentGorm(db *gorm.DB) {
+	callbacks := db.Callback()
+	callbacks.Create().Before("gorm:create").Register("metrics:start_create", start)
+	callbacks.Create().After("gorm:create").Register("metrics:observe_create", observe("create"))
+	callbacks.Query().Before("gorm:query").Register("metrics:start_query", start)
+	callbacks.Query().After("gorm:query").Register("metrics:observe_query", observe("query"))
+	callbacks.Update().Before("gorm:update").Register("metrics:start_update", start)
+	callbacks.Update().After("gorm:update").Register("metrics:observe_update", observe("update"))
+	callbacks.Delete().Before("gorm:delete").Register("metrics:start_delete", start)
+	callbacks.Delete().After("gorm:delete").Register("metrics:observe_delete", observe("delete"))
+	callbacks.RowQuery().Before("gorm:row_query").Register("metrics:start_row_query", start)
+	callbacks.RowQuery().After("gorm:row_query").Register("metrics:observe_row_query", observe("row_query"))
+}
+
+func start(scope *gorm.Scope) {
+	scope.Set(startKey, time.Now())
+}
+
+func observe(operation string) func(scope *gorm.Scope) {
+	return func(scope *gorm.Scope) {
+		started, ok := scope.Get(startKey)
+		if !ok {
+			return
+		}
+		table := ""
+		if scope.Value != nil {
+			table = scope.TableName()
+		}
+		DbQueryDuration.WithLabelValues(operation, table).Observe(time.Since(started.(time.Time)).Seconds())
+	}
+}
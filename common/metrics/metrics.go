@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "sea_apd"
+
+var (
+	HttpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Requests served per route and status.",
+	}, []string{"method", "route", "status"})
+
+	HttpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of the requests per route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	DbQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Duration of the database queries per operation and table.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	MailsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "mails_sent_total",
+		Help:      "Mails handed to the mail provider per result, success or failure.",
+	}, []string{"result"})
+
+	ObserverErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "observer_errors_total",
+		Help:      "Errors of the observers notified about transaction events.",
+	}, []string{"observer"})
+
+	TransactionTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transaction_status_transitions_total",
+		Help:      "Stored status changes of transactions, new transactions come from none.",
+	}, []string{"from", "to"})
+
+	GmvCredited = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gmv_credited_total",
+		Help:      "Amount of the transactions credited to merchant balances.",
+	})
+
+	GmvReversed = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gmv_reversed_total",
+		Help:      "Amount debited from merchant balances when credited transactions are reverted.",
+	})
+
+	Withdrawals = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "withdrawals_total",
+		Help:      "Withdrawals of merchants to their bank accounts.",
+	})
+
+	WithdrawalVolume = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "withdrawal_amount_total",
+		Help:      "Amount withdrawn by merchants to their bank accounts.",
+	})
+)
+
+// Status returns the label of a status, statuses of new rows have none
+func Status(status string) string {
+	if status == "" {
+		return "none"
+	}
+	return status
+}
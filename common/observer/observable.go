@@ -1,6 +1,10 @@
 package observer
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/williamchang80/sea-apd/common/metrics"
 	merchant3 "github.com/williamchang80/sea-apd/domain/merchant"
 	"github.com/williamchang80/sea-apd/domain/transaction"
 )
@@ -13,10 +17,11 @@ func (o *TransactionObservable) AddObserver(obs TransactionObserver) {
 	o.Observers = append(o.Observers, obs)
 }
 
-func (o *TransactionObservable) NotifyAll(transaction transaction.Transaction,
+func (o *TransactionObservable) NotifyAll(ctx context.Context, transaction transaction.Transaction,
 	u merchant3.MerchantUsecase) error {
 	for _, ob := range o.Observers {
-		if err := ob.Update(transaction, u); err != nil {
+		if err := ob.Update(ctx, transaction, u); err != nil {
+			metrics.ObserverErrors.WithLabelValues(fmt.Sprintf("%T", ob)).Inc()
 			return err
 		}
 	}
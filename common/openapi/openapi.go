@@ -0,0 +1,207 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const bearerAuth = "bearerAuth"
+
+// Operation documents one route, the schemas of its request and response are read from
+// the types of Request and Response
+type Operation struct {
+	Method  string
+	Path    string
+	Tag     string
+	Summary string
+	// Admin routes need the bearer token of an admin
+	Admin bool
+	// Authenticated routes need the bearer token of any user
+	Authenticated bool
+	// Query lists the parameters the handler reads one by one
+	Query []string
+	// Request is bound by the handler, its query tags are parameters and its json tags the body
+	Request interface{}
+	// Files are the multipart fields of uploads, the request is then sent as a form. The
+	// handlers tell which of them are missing.
+	Files []string
+	// Status of the success, 200 when not set
+	Status   int
+	Response interface{}
+	// Download lists the content types of a success that is a file instead of json
+	Download []string
+}
+
+// Spec is the api the document describes
+type Spec struct {
+	Title       string
+	Version     string
+	Description string
+	ServerUrl   string
+	// Error is the body of failed requests and Validation the one of invalid requests
+	Error      interface{}
+	Validation interface{}
+	Operations []Operation
+}
+
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Servers    []Server                        `json:"servers,omitempty"`
+	Tags       []Tag                           `json:"tags,omitempty"`
+	Paths      map[string]map[string]*Endpoint `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Server struct {
+	Url string `json:"url"`
+}
+
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// Endpoint documents one method of a path
+type Endpoint struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	OperationId string                `json:"operationId,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *Body                 `json:"requestBody,omitempty"`
+	Responses   map[string]Body       `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// Body is a request body or a response
+type Body struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Document builds the OpenAPI 3 document of the spec
+func (s Spec) Document() Document {
+	schemas := newSchemas()
+	doc := Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: s.Title, Version: s.Version, Description: s.Description},
+		Paths:   map[string]map[string]*Endpoint{},
+		Components: Components{
+			Schemas: schemas.components,
+			SecuritySchemes: map[string]SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+	if s.ServerUrl != "" {
+		doc.Servers = []Server{{Url: s.ServerUrl}}
+	}
+	tags := map[string]bool{}
+	for _, op := range s.Operations {
+		if op.Tag != "" && !tags[op.Tag] {
+			tags[op.Tag] = true
+			doc.Tags = append(doc.Tags, Tag{Name: op.Tag})
+		}
+		path := NormalizePath(op.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]*Endpoint{}
+		}
+		doc.Paths[path][strings.ToLower(op.Method)] = s.pathItem(schemas, op)
+	}
+	return doc
+}
+
+func (s Spec) pathItem(schemas *schemas, op Operation) *Endpoint {
+	item := &Endpoint{
+		Summary:     op.Summary,
+		OperationId: strings.ToLower(op.Method) + strings.NewReplacer("/", "_", "-", "_").Replace(NormalizePath(op.Path)),
+		Responses:   map[string]Body{},
+	}
+	if op.Tag != "" {
+		item.Tags = []string{op.Tag}
+	}
+	for _, name := range op.Query {
+		item.Parameters = append(item.Parameters, Parameter{Name: name, In: "query", Schema: &Schema{Type: "string"}})
+	}
+	if op.Request != nil {
+		params, body := schemas.request(reflect.TypeOf(op.Request), op.Files)
+		item.Parameters = append(item.Parameters, params...)
+		item.RequestBody = body
+		item.Responses[strconv.Itoa(http.StatusUnprocessableEntity)] = s.response(schemas,
+			"the request failed validation", s.Validation)
+	}
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := s.response(schemas, http.StatusText(status), op.Response)
+	if len(op.Download) > 0 {
+		success.Content = map[string]MediaType{}
+		for _, contentType := range op.Download {
+			success.Content[contentType] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+		}
+	}
+	item.Responses[strconv.Itoa(status)] = success
+	if op.Admin {
+		item.Security = []map[string][]string{{bearerAuth: {}}}
+		item.Responses[strconv.Itoa(http.StatusUnauthorized)] = s.response(schemas,
+			"the token is missing or its session ended", s.Error)
+		item.Responses[strconv.Itoa(http.StatusForbidden)] = s.response(schemas, "the user is not an admin", s.Error)
+	} else if op.Authenticated {
+		item.Security = []map[string][]string{{bearerAuth: {}}}
+		item.Responses[strconv.Itoa(http.StatusUnauthorized)] = s.response(schemas,
+			"the token is missing or its session ended", s.Error)
+	}
+	item.Responses[strconv.Itoa(http.StatusTooManyRequests)] = s.response(schemas,
+		"the client ran out of requests, Retry-After tells when to retry", s.Error)
+	item.Responses["default"] = s.response(schemas, "the request failed", s.Error)
+	return item
+}
+
+func (s Spec) response(schemas *schemas, description string, body interface{}) Body {
+	response := Body{Description: description}
+	if body != nil {
+		response.Content = map[string]MediaType{
+			"application/json": {Schema: schemas.of(reflect.TypeOf(body))},
+		}
+	}
+	return response
+}
+
+// NormalizePath gives the paths registered without their leading slash one
+func NormalizePath(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		return "/" + path
+	}
+	return path
+}
@@ -0,0 +1,216 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/williamchang80/sea-apd/common/validation"
+)
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	marshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+// schemas turns go types into schemas the way encoding/json writes them, named structs
+// are kept once in the components and referenced
+type schemas struct {
+	components map[string]*Schema
+}
+
+func newSchemas() *schemas {
+	return &schemas{components: map[string]*Schema{}}
+}
+
+// field is a struct field as it appears in json, a query or a form
+type field struct {
+	name     string
+	required bool
+	schema   *Schema
+}
+
+func (s *schemas) of(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Ptr {
+		schema := *s.of(t.Elem())
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return &schema
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Implements(marshalerType), reflect.PtrTo(t).Implements(marshalerType),
+		t.Implements(textMarshalType), reflect.PtrTo(t).Implements(textMarshalType):
+		return &Schema{Type: "string"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.of(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.of(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t, "json")
+		}
+		name := schemaName(t)
+		if _, exist := s.components[name]; !exist {
+			// taken before the fields so recursive types end in a reference
+			s.components[name] = &Schema{}
+			*s.components[name] = *s.object(t, "json")
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	// interfaces hold any value
+	return &Schema{}
+}
+
+func (s *schemas) object(t reflect.Type, tag string) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, f := range s.fields(t, tag) {
+		schema.Properties[f.name] = f.schema
+		if f.required {
+			schema.Required = append(schema.Required, f.name)
+		}
+	}
+	return schema
+}
+
+// fields lists the fields of t named after tag, the fields of embedded structs are
+// promoted like encoding/json does
+func (s *schemas) fields(t reflect.Type, tag string) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get(tag), ",")[0]
+		if name == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+		fieldType := f.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if f.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			fields = append(fields, s.fields(fieldType, tag)...)
+			continue
+		}
+		if f.PkgPath != "" || (name == "" && tag != "json") {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		schema, required := s.validated(s.of(f.Type), f.Tag.Get("validate"))
+		fields = append(fields, field{name: name, required: required, schema: schema})
+	}
+	return fields
+}
+
+// validated adds the formats and enums of the validate tag to the schema of a field
+func (s *schemas) validated(schema *Schema, tag string) (*Schema, bool) {
+	if tag == "" || schema.Ref != "" {
+		return schema, strings.Contains(tag, "required")
+	}
+	required := false
+	target := schema
+	for _, rule := range strings.Split(tag, ",") {
+		name := strings.SplitN(rule, "=", 2)[0]
+		param := strings.TrimPrefix(rule, name+"=")
+		switch name {
+		case "dive":
+			// the rules after dive are the ones of the items
+			if target.Items == nil {
+				return schema, required
+			}
+			copied := *target.Items
+			target.Items = &copied
+			target = target.Items
+		case "required":
+			required = required || target == schema
+		case "uuid", "email":
+			target.Format = name
+		case "datetime":
+			target.Format = "date"
+		case "oneof":
+			target.Enum = strings.Fields(param)
+		case "enum":
+			target.Enum = validation.Enum(param)
+		}
+	}
+	return schema, required
+}
+
+// request returns the query parameters and the body of the bound request type t
+func (s *schemas) request(t reflect.Type, files []string) ([]Parameter, *Body) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if query := s.fields(t, "query"); len(query) > 0 {
+		params := make([]Parameter, len(query))
+		for i, f := range query {
+			params[i] = Parameter{Name: f.name, In: "query", Required: f.required, Schema: f.schema}
+		}
+		return params, nil
+	}
+	if len(files) == 0 {
+		return nil, &Body{Required: true, Content: map[string]MediaType{
+			"application/json": {Schema: s.of(t)},
+		}}
+	}
+	form := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, f := range append(s.fields(t, "form"), s.fields(t, "json")...) {
+		if _, exist := form.Properties[f.name]; exist || f.schema.Type == "" && f.schema.Ref == "" {
+			continue
+		}
+		form.Properties[f.name] = f.schema
+		if f.required {
+			form.Required = append(form.Required, f.name)
+		}
+	}
+	for _, name := range files {
+		form.Properties[name] = &Schema{Type: "string", Format: "binary"}
+	}
+	return nil, &Body{Required: true, Content: map[string]MediaType{
+		"multipart/form-data": {Schema: form},
+	}}
+}
+
+// schemaName names the component of t after its last two package folders, e.g.
+// response.product.GetProductsResponse, as the dto and domain packages share names
+func schemaName(t reflect.Type) string {
+	path := strings.Split(t.PkgPath(), "/")
+	if len(path) > 2 {
+		path = path[len(path)-2:]
+	}
+	return strings.Join(append(path, t.Name()), ".")
+}
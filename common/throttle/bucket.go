@@ -0,0 +1,136 @@
+package throttle
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Policy lets a client burst Limit requests and refills them evenly over Period
+type Policy struct {
+	Limit  int
+	Period time.Duration
+}
+
+// Result is the state of the bucket after a request took its token
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is the time until the next token when the request was refused
+	RetryAfter time.Duration
+}
+
+// Store keeps the token buckets of the clients. The memory store suits a single instance
+// of the app, a shared store lets every instance draw from the same buckets.
+type Store interface {
+	Take(ctx context.Context, key string, policy Policy) (Result, error)
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time
+}
+
+// MemoryStore keeps the buckets in the memory of this process
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+	now     func() time.Time
+}
+
+const sweepInterval = time.Minute
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}, now: time.Now}
+}
+
+// Take refills the bucket of the key for the time since its last request and takes a token
+func (s *MemoryStore) Take(ctx context.Context, key string, policy Policy) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.sweep(now)
+	capacity := float64(policy.Limit)
+	perToken := policy.Period / time.Duration(policy.Limit)
+	b, exist := s.buckets[key]
+	if !exist {
+		b = &bucket{tokens: capacity, updated: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(capacity, b.tokens+float64(now.Sub(b.updated))/float64(perToken))
+	b.updated = now
+	result := Result{Limit: policy.Limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - b.tokens) * float64(perToken))
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = time.Duration((capacity - b.tokens) * float64(perToken))
+	b.full = now.Add(result.Reset)
+	return result, nil
+}
+
+// sweep drops the buckets that refilled completely, they start full again anyway
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.swept) < sweepInterval {
+		return
+	}
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+	s.swept = now
+}
+
+// ParsePolicy reads a policy like 10/m, 300/h or 5/30s
+func ParsePolicy(policy string) (Policy, error) {
+	parts := strings.SplitN(strings.TrimSpace(policy), "/", 2)
+	if len(parts) != 2 {
+		return Policy{}, fmt.Errorf("rate limit %q is not like 10/m", policy)
+	}
+	limit, err := strconv.Atoi(parts[0])
+	if err != nil || limit <= 0 {
+		return Policy{}, fmt.Errorf("rate limit %q needs a positive limit", policy)
+	}
+	periods := map[string]time.Duration{"s": time.Second, "m": time.Minute, "h": time.Hour}
+	period, exist := periods[parts[1]]
+	if !exist {
+		if period, err = time.ParseDuration(parts[1]); err != nil || period <= 0 {
+			return Policy{}, fmt.Errorf("rate limit %q needs a period like s, m, h or 30s", policy)
+		}
+	}
+	return Policy{Limit: limit, Period: period}, nil
+}
+
+// ParsePolicies reads policies like "*=300/m; POST /api/transaction=10/m" into a map of
+// their routes, * is the policy of the routes without one of their own
+func ParsePolicies(policies string) (map[string]Policy, error) {
+	parsed := map[string]Policy{}
+	for _, entry := range strings.Split(policies, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		separator := strings.LastIndex(entry, "=")
+		if separator < 0 {
+			return nil, fmt.Errorf("rate limit %q is not like POST /api/transaction=10/m", entry)
+		}
+		policy, err := ParsePolicy(entry[separator+1:])
+		if err != nil {
+			return nil, err
+		}
+		parsed[strings.Join(strings.Fields(entry[:separator]), " ")] = policy
+	}
+	return parsed, nil
+}
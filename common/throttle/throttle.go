@@ -0,0 +1,53 @@
+package throttle
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter allows limit hits per key within a fixed window. The counts live in the memory
+// of this process, every instance of the app throttles on its own.
+type Limiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	hits   map[string]*counter
+	swept  time.Time
+}
+
+type counter struct {
+	start time.Time
+	count int
+}
+
+func NewLimiter(limit int, window time.Duration) *Limiter {
+	return &Limiter{limit: limit, window: window, hits: map[string]*counter{}}
+}
+
+// Allow counts a hit for the key and tells whether it is within the limit
+func (l *Limiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.sweep(now)
+	c, exist := l.hits[key]
+	if !exist || now.Sub(c.start) >= l.window {
+		c = &counter{start: now}
+		l.hits[key] = c
+	}
+	c.count++
+	return c.count <= l.limit
+}
+
+// sweep drops the counters of finished windows once per window so idle keys do not pile up
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < l.window {
+		return
+	}
+	for key, c := range l.hits {
+		if now.Sub(c.start) >= l.window {
+			delete(l.hits, key)
+		}
+	}
+	l.swept = now
+}
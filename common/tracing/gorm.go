@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/williamchang80/sea-apd/common/logger"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const spanKey = "tracing:span"
+
+// InstrumentGorm opens a span for every create, query, update, delete and raw query of
+// db under the request of its session, see logger.Gorm. The statement is kept without its
+// values. The callbacks are shared by all sessions of db, so it is called once per connection.
+func InstrumentGorm(db *gorm.DB) {
+	callbacks := db.Callback()
+	callbacks.Create().Before("gorm:create").Register("tracing:start_create", start("create"))
+	callbacks.Create().After("gorm:create").Register("tracing:end_create", end)
+	callbacks.Query().Before("gorm:query").Register("tracing:start_query", start("query"))
+	callbacks.Query().After("gorm:query").Register("tracing:end_query", end)
+	callbacks.Update().Before("gorm:update").Register("tracing:start_update", start("update"))
+	callbacks.Update().After("gorm:update").Register("tracing:end_update", end)
+	callbacks.Delete().Before("gorm:delete").Register("tracing:start_delete", start("delete"))
+	callbacks.Delete().After("gorm:delete").Register("tracing:end_delete", end)
+	callbacks.RowQuery().Before("gorm:row_query").Register("tracing:start_row_query", start("row_query"))
+	callbacks.RowQuery().After("gorm:row_query").Register("tracing:end_row_query", end)
+}
+
+func start(operation string) func(scope *gorm.Scope) {
+	return func(scope *gorm.Scope) {
+		table := ""
+		if scope.Value != nil {
+			table = scope.TableName()
+		}
+		_, span := Start(logger.GormContext(scope), "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBOperation(operation), semconv.DBSQLTable(table)))
+		scope.Set(spanKey, span)
+	}
+}
+
+func end(scope *gorm.Scope) {
+	value, ok := scope.Get(spanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	span.SetAttributes(semconv.DBStatement(scope.SQL))
+	if err := scope.DB().Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+		Fail(span, err)
+	}
+	span.End()
+}
@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Transport opens a span for every outgoing request, like the mails and webhooks, and
+// passes its trace context on in the traceparent header
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// NewClient returns a client whose requests are traced under the context they carry
+func NewClient() *http.Client {
+	return &http.Client{Transport: Transport{Base: http.DefaultTransport}}
+}
+
+func (t Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPMethod(req.Method), semconv.ServerAddress(req.URL.Hostname()),
+			semconv.URLPath(req.URL.Path)))
+	defer span.End()
+
+	// a round tripper must not change the request it is given
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	res, err := t.Base.RoundTrip(req)
+	if err != nil {
+		Fail(span, err)
+		return nil, err
+	}
+	span.SetAttributes(semconv.HTTPStatusCode(res.StatusCode))
+	if res.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, fmt.Sprintf("status %v", res.StatusCode))
+	}
+	return res, nil
+}
@@ -0,0 +1,192 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/common/constants/bank_account_status"
+	"github.com/williamchang80/sea-apd/common/constants/catalogue_format"
+	"github.com/williamchang80/sea-apd/common/constants/document_type"
+	"github.com/williamchang80/sea-apd/common/constants/merchant_status"
+	"github.com/williamchang80/sea-apd/common/constants/product_sort"
+	"github.com/williamchang80/sea-apd/common/constants/time_bucket"
+	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
+	"github.com/williamchang80/sea-apd/common/constants/user_role"
+)
+
+// enums are the constants the enum tag validates against, e.g. `validate:"enum=user_role"`.
+// The last entry of every list is "other", which is never valid.
+var enums = map[string][]string{
+	"bank_account_status": bank_account_status.BankAccountStatusList,
+	"catalogue_format":    catalogue_format.CatalogueFormatList,
+	"document_type":       document_type.DocumentTypeList,
+	"merchant_status":     merchant_status.MerchantStatusList,
+	"product_sort":        product_sort.ProductSortList,
+	"time_bucket":         time_bucket.TimeBucketList,
+	"transaction_status":  transaction_status.TransactionStatusList,
+	"user_role":           user_role.UserRoleList,
+}
+
+// FieldError tells why the value of a request field was refused
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// Errors are the failed fields of a request
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, f := range e {
+		messages[i] = f.Field + " " + f.Reason
+	}
+	return strings.Join(messages, ", ")
+}
+
+// Validator checks requests against their validate tags, fields are named like in the
+// request, after their json, query or form tag
+type Validator struct {
+	validate *validator.Validate
+}
+
+func NewValidator() *Validator {
+	v := validator.New()
+	v.RegisterTagNameFunc(fieldName)
+	v.RegisterValidation("enum", validateEnum)
+	return &Validator{validate: v}
+}
+
+// Enum returns the values the enum tag of name accepts, e.g. for the api docs
+func Enum(name string) []string {
+	list := enums[name]
+	if len(list) == 0 {
+		return nil
+	}
+	return list[:len(list)-1]
+}
+
+func fieldName(f reflect.StructField) string {
+	for _, tag := range []string{"json", "query", "form"} {
+		name := strings.Split(f.Tag.Get(tag), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+func validateEnum(fl validator.FieldLevel) bool {
+	list, exist := enums[fl.Param()]
+	if !exist {
+		panic("validation: unknown enum " + fl.Param())
+	}
+	valid := list[:len(list)-1]
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int() >= 0 && field.Int() < int64(len(valid))
+	case reflect.String:
+		value := strings.ToLower(field.String())
+		for _, v := range valid {
+			if v == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// snakeCase names a struct field like its json tag, e.g. PasswordConfirmation becomes
+// password_confirmation
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func reason(f validator.FieldError) string {
+	switch f.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be an email address"
+	case "uuid":
+		return "must be a uuid"
+	case "numeric":
+		return "must be a number"
+	case "datetime":
+		return "must be a date like " + f.Param()
+	case "eqfield":
+		return "must be the same as " + snakeCase(f.Param())
+	case "oneof":
+		return "must be one of " + strings.Join(strings.Fields(f.Param()), ", ")
+	case "enum":
+		list := enums[f.Param()]
+		if f.Kind() == reflect.String {
+			return "must be one of " + strings.Join(list[:len(list)-1], ", ")
+		}
+		return fmt.Sprintf("must be between 0 and %d", len(list)-2)
+	case "min", "max":
+		bound := "at least"
+		if f.Tag() == "max" {
+			bound = "at most"
+		}
+		switch f.Kind() {
+		case reflect.String:
+			return fmt.Sprintf("must have %s %s characters", bound, f.Param())
+		case reflect.Slice, reflect.Map:
+			return fmt.Sprintf("must have %s %s items", bound, f.Param())
+		}
+		return fmt.Sprintf("must be %s %s", bound, f.Param())
+	}
+	return "is not valid"
+}
+
+// Validate returns Errors with every failed field of the struct
+func (v *Validator) Validate(i interface{}) error {
+	err := v.validate.Struct(i)
+	failed, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+	errs := make(Errors, len(failed))
+	for idx, f := range failed {
+		field := f.Namespace()
+		if dot := strings.Index(field, "."); dot >= 0 {
+			field = field[dot+1:]
+		}
+		errs[idx] = FieldError{Field: field, Reason: reason(f)}
+	}
+	return errs
+}
+
+// Binder binds the request like the echo binder does and validates the result, so
+// handlers only ever see valid requests
+type Binder struct {
+	echo.DefaultBinder
+	validator *Validator
+}
+
+func NewBinder(v *Validator) *Binder {
+	return &Binder{validator: v}
+}
+
+func (b *Binder) Bind(i interface{}, c echo.Context) error {
+	if err := b.DefaultBinder.Bind(i, c); err != nil {
+		return err
+	}
+	return b.validator.Validate(i)
+}
@@ -0,0 +1,97 @@
+package address
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo"
+	message "github.com/williamchang80/sea-apd/common/constants/response"
+	"github.com/williamchang80/sea-apd/controller/middleware"
+	"github.com/williamchang80/sea-apd/domain/address"
+	"github.com/williamchang80/sea-apd/dto/domain"
+	request "github.com/williamchang80/sea-apd/dto/request/address"
+	response "github.com/williamchang80/sea-apd/dto/response/address"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+)
+
+type AddressController struct {
+	usecase address.AddressUsecase
+}
+
+func NewAddressController(e *echo.Echo, a address.AddressUsecase) address.AddressController {
+	c := &AddressController{usecase: a}
+	e.GET("/api/user/addresses", c.GetAddresses, middleware.Authenticated)
+	e.POST("/api/user/address", c.CreateAddress, middleware.Authenticated)
+	e.PUT("/api/user/address", c.UpdateAddress, middleware.Authenticated)
+	e.DELETE("/api/user/address", c.DeleteAddress, middleware.Authenticated)
+	e.PUT("/api/user/address/default", c.SetDefaultAddress, middleware.Authenticated)
+	return c
+}
+
+func success(c echo.Context, code int) error {
+	return c.JSON(code, &base.BaseResponse{
+		Code:    code,
+		Message: message.SUCCESS,
+	})
+}
+
+func (a *AddressController) GetAddresses(c echo.Context) error {
+	addresses, err := a.usecase.GetAddresses(c.Request().Context(), middleware.GetUserId(c))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetAddressesResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: domain.AddressListDto{Addresses: addresses},
+	})
+}
+
+func (a *AddressController) CreateAddress(c echo.Context) error {
+	var addressRequest request.AddressRequest
+	if err := c.Bind(&addressRequest); err != nil {
+		return err
+	}
+	addressRequest.CustomerId = middleware.GetUserId(c)
+	if err := a.usecase.CreateAddress(c.Request().Context(), addressRequest); err != nil {
+		return err
+	}
+	return success(c, http.StatusCreated)
+}
+
+func (a *AddressController) UpdateAddress(c echo.Context) error {
+	var addressRequest request.UpdateAddressRequest
+	if err := c.Bind(&addressRequest); err != nil {
+		return err
+	}
+	addressRequest.CustomerId = middleware.GetUserId(c)
+	if err := a.usecase.UpdateAddress(c.Request().Context(), addressRequest); err != nil {
+		return err
+	}
+	return success(c, http.StatusOK)
+}
+
+func (a *AddressController) DeleteAddress(c echo.Context) error {
+	var actionRequest request.AddressActionRequest
+	if err := c.Bind(&actionRequest); err != nil {
+		return err
+	}
+	actionRequest.CustomerId = middleware.GetUserId(c)
+	if err := a.usecase.DeleteAddress(c.Request().Context(), actionRequest); err != nil {
+		return err
+	}
+	return success(c, http.StatusOK)
+}
+
+func (a *AddressController) SetDefaultAddress(c echo.Context) error {
+	var actionRequest request.AddressActionRequest
+	if err := c.Bind(&actionRequest); err != nil {
+		return err
+	}
+	actionRequest.CustomerId = middleware.GetUserId(c)
+	if err := a.usecase.SetDefaultAddress(c.Request().Context(), actionRequest); err != nil {
+		return err
+	}
+	return success(c, http.StatusOK)
+}
@@ -0,0 +1,91 @@
+package address
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/controller/middleware"
+	"github.com/williamchang80/sea-apd/mocks/usecase/address"
+)
+
+func TestAddressController_CustomerOfToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	body := `{"address_id": "1", "customer_id": "1", "recipient_name": "Mock Recipient",
+		"phone": "081234567890", "street": "Jl. Mock No. 1", "city": "Jakarta Selatan",
+		"province": "DKI Jakarta", "postal_code": "12345"}`
+	handlers := []struct {
+		name    string
+		method  string
+		path    string
+		success int
+		handler func(*AddressController) echo.HandlerFunc
+	}{
+		{
+			name:    "GetAddresses",
+			method:  echo.GET,
+			path:    "/api/user/addresses?customerId=1",
+			success: http.StatusOK,
+			handler: func(c *AddressController) echo.HandlerFunc { return c.GetAddresses },
+		},
+		{
+			name:    "CreateAddress",
+			method:  echo.POST,
+			path:    "/api/user/address",
+			success: http.StatusCreated,
+			handler: func(c *AddressController) echo.HandlerFunc { return c.CreateAddress },
+		},
+		{
+			name:    "UpdateAddress",
+			method:  echo.PUT,
+			path:    "/api/user/address",
+			success: http.StatusOK,
+			handler: func(c *AddressController) echo.HandlerFunc { return c.UpdateAddress },
+		},
+		{
+			name:    "DeleteAddress",
+			method:  echo.DELETE,
+			path:    "/api/user/address",
+			success: http.StatusOK,
+			handler: func(c *AddressController) echo.HandlerFunc { return c.DeleteAddress },
+		},
+		{
+			name:    "SetDefaultAddress",
+			method:  echo.PUT,
+			path:    "/api/user/address/default",
+			success: http.StatusOK,
+			handler: func(c *AddressController) echo.HandlerFunc { return c.SetDefaultAddress },
+		},
+	}
+	for _, h := range handlers {
+		for _, userId := range []string{"1", ""} {
+			name := h.name + " with the customer of the token"
+			want := h.success
+			if userId == "" {
+				name = h.name + " failed with the customer of the body or query only"
+				want = http.StatusBadRequest
+			}
+			t.Run(name, func(t *testing.T) {
+				e := echo.New()
+				req := httptest.NewRequest(h.method, h.path, strings.NewReader(body))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				rec := httptest.NewRecorder()
+				ctx := e.NewContext(req, rec)
+				if userId != "" {
+					ctx.Set(middleware.UserIdKey, userId)
+				}
+				controller := &AddressController{usecase: address.NewMockUsecase(ctrl)}
+				if err := h.handler(controller)(ctx); err != nil {
+					middleware.ErrorHandler(err, ctx)
+				}
+				if rec.Code != want {
+					t.Errorf("%v() status = %v, want %v", h.name, rec.Code, want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package analytics
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo"
+	message "github.com/williamchang80/sea-apd/common/constants/response"
+	"github.com/williamchang80/sea-apd/domain/analytics"
+	request "github.com/williamchang80/sea-apd/dto/request/analytics"
+	response "github.com/williamchang80/sea-apd/dto/response/analytics"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+)
+
+type AnalyticsController struct {
+	usecase analytics.AnalyticsUsecase
+}
+
+func NewAnalyticsController(e *echo.Echo, a analytics.AnalyticsUsecase) analytics.AnalyticsController {
+	c := &AnalyticsController{usecase: a}
+	e.GET("/api/merchant/analytics/sales", c.GetSalesReport)
+	e.GET("/api/merchant/analytics/top-products", c.GetTopProducts)
+	e.GET("/api/merchant/analytics/conversion", c.GetConversion)
+	return c
+}
+
+func (a *AnalyticsController) GetSalesReport(c echo.Context) error {
+	var analyticsRequest request.AnalyticsRequest
+	if err := c.Bind(&analyticsRequest); err != nil {
+		return err
+	}
+	report, err := a.usecase.GetSalesReport(c.Request().Context(), analyticsRequest)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetSalesReportResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: *report,
+	})
+}
+
+func (a *AnalyticsController) GetTopProducts(c echo.Context) error {
+	var analyticsRequest request.AnalyticsRequest
+	if err := c.Bind(&analyticsRequest); err != nil {
+		return err
+	}
+	products, err := a.usecase.GetTopProducts(c.Request().Context(), analyticsRequest)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetTopProductsResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: products,
+	})
+}
+
+func (a *AnalyticsController) GetConversion(c echo.Context) error {
+	var analyticsRequest request.AnalyticsRequest
+	if err := c.Bind(&analyticsRequest); err != nil {
+		return err
+	}
+	conversion, err := a.usecase.GetConversion(c.Request().Context(), analyticsRequest)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetConversionResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: *conversion,
+	})
+}
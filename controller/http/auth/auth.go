@@ -3,6 +3,7 @@ package auth
 import (
 	"github.com/labstack/echo"
 	"github.com/williamchang80/sea-apd/common/constants/response"
+	"github.com/williamchang80/sea-apd/controller/middleware"
 	"github.com/williamchang80/sea-apd/domain/auth"
 	request "github.com/williamchang80/sea-apd/dto/request/auth"
 	auth_response "github.com/williamchang80/sea-apd/dto/response/auth"
@@ -17,24 +18,150 @@ type AuthController struct {
 func NewAuthController(echo *echo.Echo, a auth.AuthUsecase) auth.AuthController {
 	c := AuthController{usecase: a}
 	echo.POST("api/auth/login", c.Login)
+	echo.POST("api/auth/password/forgot", c.ForgotPassword)
+	echo.POST("api/auth/password/reset", c.ResetPassword)
+	echo.POST("api/auth/login/2fa", c.VerifyTwoFactorLogin)
+	echo.POST("api/auth/2fa/enroll", c.EnrollTwoFactor)
+	echo.POST("api/auth/2fa/confirm", c.ConfirmTwoFactor)
+	echo.POST("api/auth/2fa/disable", c.DisableTwoFactor)
+	echo.POST("api/auth/2fa/recovery-codes", c.RegenerateRecoveryCodes)
 	return c
 }
 
 func (a AuthController) Login(context echo.Context) error {
 	var loginRequest request.LoginRequest
-	context.Bind(&loginRequest)
-	authToken, err := a.usecase.Login(loginRequest)
+	if err := context.Bind(&loginRequest); err != nil {
+		return err
+	}
+	loginRequest.Ip = middleware.ClientIp(context)
+	result, err := a.usecase.Login(context.Request().Context(), loginRequest)
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, auth_response.LoginResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: response.SUCCESS,
+		},
+		Token:          result.Token,
+		ChallengeToken: result.ChallengeToken,
+	})
+}
+
+func (a AuthController) VerifyTwoFactorLogin(context echo.Context) error {
+	var twoFactorRequest request.TwoFactorLoginRequest
+	if err := context.Bind(&twoFactorRequest); err != nil {
+		return err
+	}
+	token, err := a.usecase.VerifyTwoFactorLogin(context.Request().Context(), twoFactorRequest)
 	if err != nil {
-		return context.JSON(http.StatusUnauthorized, base.BaseResponse{
-			Code:    http.StatusUnauthorized,
-			Message: response.UNAUTHENTICED,
-		})
+		return err
 	}
 	return context.JSON(http.StatusOK, auth_response.LoginResponse{
 		BaseResponse: base.BaseResponse{
 			Code:    http.StatusOK,
 			Message: response.SUCCESS,
 		},
-		Token: authToken,
+		Token: token,
+	})
+}
+
+func (a AuthController) EnrollTwoFactor(context echo.Context) error {
+	var loginRequest request.LoginRequest
+	if err := context.Bind(&loginRequest); err != nil {
+		return err
+	}
+	loginRequest.Ip = middleware.ClientIp(context)
+	enrolment, err := a.usecase.EnrollTwoFactor(context.Request().Context(), loginRequest)
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, auth_response.EnrollTwoFactorResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: response.SUCCESS,
+		},
+		Data: *enrolment,
+	})
+}
+
+func recoveryCodesResponse(context echo.Context, codes []string) error {
+	return context.JSON(http.StatusOK, auth_response.RecoveryCodesResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: response.SUCCESS,
+		},
+		Data: codes,
+	})
+}
+
+func (a AuthController) ConfirmTwoFactor(context echo.Context) error {
+	var twoFactorRequest request.TwoFactorRequest
+	if err := context.Bind(&twoFactorRequest); err != nil {
+		return err
+	}
+	twoFactorRequest.Ip = middleware.ClientIp(context)
+	codes, err := a.usecase.ConfirmTwoFactor(context.Request().Context(), twoFactorRequest)
+	if err != nil {
+		return err
+	}
+	return recoveryCodesResponse(context, codes)
+}
+
+func (a AuthController) DisableTwoFactor(context echo.Context) error {
+	var twoFactorRequest request.TwoFactorRequest
+	if err := context.Bind(&twoFactorRequest); err != nil {
+		return err
+	}
+	twoFactorRequest.Ip = middleware.ClientIp(context)
+	if err := a.usecase.DisableTwoFactor(context.Request().Context(), twoFactorRequest); err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: response.SUCCESS,
+	})
+}
+
+func (a AuthController) RegenerateRecoveryCodes(context echo.Context) error {
+	var twoFactorRequest request.TwoFactorRequest
+	if err := context.Bind(&twoFactorRequest); err != nil {
+		return err
+	}
+	twoFactorRequest.Ip = middleware.ClientIp(context)
+	codes, err := a.usecase.RegenerateRecoveryCodes(context.Request().Context(), twoFactorRequest)
+	if err != nil {
+		return err
+	}
+	return recoveryCodesResponse(context, codes)
+}
+
+func (a AuthController) ForgotPassword(context echo.Context) error {
+	var forgotRequest request.ForgotPasswordRequest
+	if err := context.Bind(&forgotRequest); err != nil {
+		return err
+	}
+	forgotRequest.Ip = middleware.ClientIp(context)
+	if err := a.usecase.ForgotPassword(context.Request().Context(), forgotRequest); err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: response.SUCCESS,
+	})
+}
+
+func (a AuthController) ResetPassword(context echo.Context) error {
+	var resetRequest request.ResetPasswordRequest
+	if err := context.Bind(&resetRequest); err != nil {
+		return err
+	}
+	resetRequest.Ip = middleware.ClientIp(context)
+	if err := a.usecase.ResetPassword(context.Request().Context(), resetRequest); err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: response.SUCCESS,
 	})
 }
@@ -0,0 +1,209 @@
+package backoffice
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo"
+	message "github.com/williamchang80/sea-apd/common/constants/response"
+	"github.com/williamchang80/sea-apd/controller/middleware"
+	"github.com/williamchang80/sea-apd/domain/backoffice"
+	request "github.com/williamchang80/sea-apd/dto/request/backoffice"
+	transaction "github.com/williamchang80/sea-apd/dto/request/transaction"
+	response "github.com/williamchang80/sea-apd/dto/response/backoffice"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+)
+
+type BackofficeController struct {
+	usecase backoffice.BackofficeUsecase
+}
+
+// NewBackofficeController registers the back-office routes, all of them require the
+// token of an admin
+func NewBackofficeController(e *echo.Echo, b backoffice.BackofficeUsecase) backoffice.BackofficeController {
+	c := &BackofficeController{usecase: b}
+	g := e.Group("/api/admin", middleware.AdminOnly)
+	g.GET("/users", c.SearchUsers)
+	g.GET("/merchants", c.SearchMerchants)
+	g.GET("/transactions", c.SearchTransactions)
+	g.GET("/transaction", c.GetTransactionDetail)
+	g.PUT("/transaction/status", c.ForceTransactionStatus)
+	g.POST("/user/ban", c.BanUser)
+	g.POST("/user/unban", c.UnbanUser)
+	g.POST("/user/unlock", c.UnlockUser)
+	g.POST("/merchant/balance", c.AdjustMerchantBalance)
+	g.GET("/kpis", c.GetPlatformKpis)
+	g.GET("/audits", c.GetAudits)
+	return c
+}
+
+func success(c echo.Context) error {
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
+
+func (b *BackofficeController) SearchUsers(c echo.Context) error {
+	var searchRequest request.UserSearchRequest
+	if err := c.Bind(&searchRequest); err != nil {
+		return err
+	}
+	searchRequest.AdminId = middleware.GetUserId(c)
+	users, err := b.usecase.SearchUsers(c.Request().Context(), searchRequest)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.SearchUsersResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: users,
+	})
+}
+
+func (b *BackofficeController) SearchMerchants(c echo.Context) error {
+	var searchRequest request.MerchantSearchRequest
+	if err := c.Bind(&searchRequest); err != nil {
+		return err
+	}
+	searchRequest.AdminId = middleware.GetUserId(c)
+	merchants, err := b.usecase.SearchMerchants(c.Request().Context(), searchRequest)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.SearchMerchantsResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: merchants,
+	})
+}
+
+func (b *BackofficeController) SearchTransactions(c echo.Context) error {
+	var searchRequest request.TransactionSearchRequest
+	if err := c.Bind(&searchRequest); err != nil {
+		return err
+	}
+	searchRequest.AdminId = middleware.GetUserId(c)
+	transactions, err := b.usecase.SearchTransactions(c.Request().Context(), searchRequest)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.SearchTransactionsResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: transactions,
+	})
+}
+
+func (b *BackofficeController) GetTransactionDetail(c echo.Context) error {
+	transactionId := c.QueryParam("transactionId")
+	detail, err := b.usecase.GetTransactionDetail(c.Request().Context(), middleware.GetUserId(c), transactionId)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetTransactionDetailResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: *detail,
+	})
+}
+
+func (b *BackofficeController) ForceTransactionStatus(c echo.Context) error {
+	var statusRequest transaction.ForceTransactionStatusRequest
+	if err := c.Bind(&statusRequest); err != nil {
+		return err
+	}
+	statusRequest.AdminId = middleware.GetUserId(c)
+	if err := b.usecase.ForceTransactionStatus(c.Request().Context(), statusRequest); err != nil {
+		return err
+	}
+	return success(c)
+}
+
+func (b *BackofficeController) BanUser(c echo.Context) error {
+	var banRequest request.BanUserRequest
+	if err := c.Bind(&banRequest); err != nil {
+		return err
+	}
+	banRequest.AdminId = middleware.GetUserId(c)
+	if err := b.usecase.BanUser(c.Request().Context(), banRequest); err != nil {
+		return err
+	}
+	return success(c)
+}
+
+func (b *BackofficeController) UnbanUser(c echo.Context) error {
+	var banRequest request.BanUserRequest
+	if err := c.Bind(&banRequest); err != nil {
+		return err
+	}
+	banRequest.AdminId = middleware.GetUserId(c)
+	if err := b.usecase.UnbanUser(c.Request().Context(), banRequest); err != nil {
+		return err
+	}
+	return success(c)
+}
+
+func (b *BackofficeController) UnlockUser(c echo.Context) error {
+	var unlockRequest request.BanUserRequest
+	if err := c.Bind(&unlockRequest); err != nil {
+		return err
+	}
+	unlockRequest.AdminId = middleware.GetUserId(c)
+	if err := b.usecase.UnlockUser(c.Request().Context(), unlockRequest); err != nil {
+		return err
+	}
+	return success(c)
+}
+
+func (b *BackofficeController) AdjustMerchantBalance(c echo.Context) error {
+	var adjustRequest request.AdjustBalanceRequest
+	if err := c.Bind(&adjustRequest); err != nil {
+		return err
+	}
+	adjustRequest.AdminId = middleware.GetUserId(c)
+	if err := b.usecase.AdjustMerchantBalance(c.Request().Context(), adjustRequest); err != nil {
+		return err
+	}
+	return success(c)
+}
+
+func (b *BackofficeController) GetPlatformKpis(c echo.Context) error {
+	kpis, err := b.usecase.GetPlatformKpis(c.Request().Context(), middleware.GetUserId(c))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetPlatformKpisResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: *kpis,
+	})
+}
+
+func (b *BackofficeController) GetAudits(c echo.Context) error {
+	var auditRequest request.AuditSearchRequest
+	if err := c.Bind(&auditRequest); err != nil {
+		return err
+	}
+	auditRequest.AdminId = middleware.GetUserId(c)
+	audits, err := b.usecase.GetAudits(c.Request().Context(), auditRequest)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetAuditsResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: audits,
+	})
+}
@@ -0,0 +1,119 @@
+package bank_account
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo"
+	message "github.com/williamchang80/sea-apd/common/constants/response"
+	"github.com/williamchang80/sea-apd/controller/middleware"
+	"github.com/williamchang80/sea-apd/domain/bank_account"
+	"github.com/williamchang80/sea-apd/dto/domain"
+	request "github.com/williamchang80/sea-apd/dto/request/bank_account"
+	response "github.com/williamchang80/sea-apd/dto/response/bank_account"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+)
+
+type BankAccountController struct {
+	usecase bank_account.BankAccountUsecase
+}
+
+// NewBankAccountController registers the bank account routes, verifying an account
+// requires the token of an admin
+func NewBankAccountController(e *echo.Echo, b bank_account.BankAccountUsecase) bank_account.BankAccountController {
+	c := &BankAccountController{usecase: b}
+	e.GET("/api/banks", c.GetSupportedBanks)
+	e.GET("/api/merchant/bank-accounts", c.GetBankAccounts)
+	e.POST("/api/merchant/bank-account", c.CreateBankAccount)
+	e.PUT("/api/merchant/bank-account", c.UpdateBankAccount)
+	e.DELETE("/api/merchant/bank-account", c.DeleteBankAccount)
+	e.PUT("/api/merchant/bank-account/default", c.SetDefaultBankAccount)
+	e.PUT("/api/admin/bank-account/status", c.VerifyBankAccount, middleware.AdminOnly)
+	return c
+}
+
+func success(c echo.Context, code int) error {
+	return c.JSON(code, &base.BaseResponse{
+		Code:    code,
+		Message: message.SUCCESS,
+	})
+}
+
+func (b *BankAccountController) GetSupportedBanks(c echo.Context) error {
+	return c.JSON(http.StatusOK, &response.GetSupportedBanksResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: domain.BankListDto{Banks: b.usecase.GetSupportedBanks(c.Request().Context())},
+	})
+}
+
+func (b *BankAccountController) GetBankAccounts(c echo.Context) error {
+	merchantId := c.QueryParam("merchantId")
+	accounts, err := b.usecase.GetBankAccounts(c.Request().Context(), merchantId)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetBankAccountsResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: domain.BankAccountListDto{BankAccounts: accounts},
+	})
+}
+
+func (b *BankAccountController) CreateBankAccount(c echo.Context) error {
+	var accountRequest request.BankAccountRequest
+	if err := c.Bind(&accountRequest); err != nil {
+		return err
+	}
+	if err := b.usecase.CreateBankAccount(c.Request().Context(), accountRequest); err != nil {
+		return err
+	}
+	return success(c, http.StatusCreated)
+}
+
+func (b *BankAccountController) UpdateBankAccount(c echo.Context) error {
+	var accountRequest request.UpdateBankAccountRequest
+	if err := c.Bind(&accountRequest); err != nil {
+		return err
+	}
+	if err := b.usecase.UpdateBankAccount(c.Request().Context(), accountRequest); err != nil {
+		return err
+	}
+	return success(c, http.StatusOK)
+}
+
+func (b *BankAccountController) DeleteBankAccount(c echo.Context) error {
+	var actionRequest request.BankAccountActionRequest
+	if err := c.Bind(&actionRequest); err != nil {
+		return err
+	}
+	if err := b.usecase.DeleteBankAccount(c.Request().Context(), actionRequest); err != nil {
+		return err
+	}
+	return success(c, http.StatusOK)
+}
+
+func (b *BankAccountController) SetDefaultBankAccount(c echo.Context) error {
+	var actionRequest request.BankAccountActionRequest
+	if err := c.Bind(&actionRequest); err != nil {
+		return err
+	}
+	if err := b.usecase.SetDefaultBankAccount(c.Request().Context(), actionRequest); err != nil {
+		return err
+	}
+	return success(c, http.StatusOK)
+}
+
+func (b *BankAccountController) VerifyBankAccount(c echo.Context) error {
+	var verifyRequest request.VerifyBankAccountRequest
+	if err := c.Bind(&verifyRequest); err != nil {
+		return err
+	}
+	if err := b.usecase.VerifyBankAccount(c.Request().Context(), verifyRequest); err != nil {
+		return err
+	}
+	return success(c, http.StatusOK)
+}
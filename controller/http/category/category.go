@@ -0,0 +1,100 @@
+package category
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo"
+	message "github.com/williamchang80/sea-apd/common/constants/response"
+	"github.com/williamchang80/sea-apd/controller/middleware"
+	"github.com/williamchang80/sea-apd/domain/category"
+	"github.com/williamchang80/sea-apd/dto/domain"
+	request "github.com/williamchang80/sea-apd/dto/request/category"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+	response "github.com/williamchang80/sea-apd/dto/response/category"
+	"github.com/williamchang80/sea-apd/dto/response/product"
+)
+
+type CategoryController struct {
+	usecase category.CategoryUsecase
+}
+
+// NewCategoryController registers the category routes, changing the tree requires the
+// token of an admin
+func NewCategoryController(e *echo.Echo, u category.CategoryUsecase) category.CategoryController {
+	c := &CategoryController{usecase: u}
+	e.GET("/api/categories", c.GetCategoryTree)
+	e.GET("/api/category/products", c.GetProductsByCategory)
+	g := e.Group("/api/admin", middleware.AdminOnly)
+	g.POST("/category", c.CreateCategory)
+	g.PUT("/category", c.UpdateCategory)
+	g.DELETE("/category", c.DeleteCategory)
+	return c
+}
+
+func (cc *CategoryController) CreateCategory(c echo.Context) error {
+	var categoryRequest request.CategoryRequest
+	if err := c.Bind(&categoryRequest); err != nil {
+		return err
+	}
+	if err := cc.usecase.CreateCategory(c.Request().Context(), categoryRequest); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, &base.BaseResponse{
+		Code:    http.StatusCreated,
+		Message: message.SUCCESS,
+	})
+}
+
+func (cc *CategoryController) UpdateCategory(c echo.Context) error {
+	var categoryRequest request.UpdateCategoryRequest
+	if err := c.Bind(&categoryRequest); err != nil {
+		return err
+	}
+	if err := cc.usecase.UpdateCategory(c.Request().Context(), categoryRequest); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
+
+func (cc *CategoryController) DeleteCategory(c echo.Context) error {
+	id := c.QueryParam("categoryId")
+	if err := cc.usecase.DeleteCategory(c.Request().Context(), id); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
+
+func (cc *CategoryController) GetCategoryTree(c echo.Context) error {
+	categories, err := cc.usecase.GetCategoryTree(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetCategoryTreeResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: categories,
+	})
+}
+
+func (cc *CategoryController) GetProductsByCategory(c echo.Context) error {
+	id := c.QueryParam("categoryId")
+	products, err := cc.usecase.GetProductsByCategory(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &product.GetProductsResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: domain.ProductListDto{Products: products},
+	})
+}
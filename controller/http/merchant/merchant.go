@@ -5,6 +5,8 @@ import (
 
 	"github.com/labstack/echo"
 	message "github.com/williamchang80/sea-apd/common/constants/response"
+	"github.com/williamchang80/sea-apd/controller/middleware"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	"github.com/williamchang80/sea-apd/domain/merchant"
 	"github.com/williamchang80/sea-apd/dto/domain"
 	request "github.com/williamchang80/sea-apd/dto/request/merchant"
@@ -16,45 +18,58 @@ type MerchantController struct {
 	usecase merchant.MerchantUsecase
 }
 
+// NewMerchantController registers the merchant routes, reviewing, suspending and
+// reactivating merchants and reading their documents require the token of an admin
 func NewMerchantController(e *echo.Echo, m merchant.MerchantUsecase) merchant.MerchantController {
 	c := &MerchantController{usecase: m}
 	e.GET("/api/merchant/balance", c.GetMerchantBalance)
 	e.POST("/api/merchant", c.RegisterMerchant)
 	e.GET("/api/merchant", c.GetMerchantById)
 	e.GET("/api/merchants", c.GetMerchants)
-	e.PUT("/api/merchant/status", c.UpdateMerchantApprovalStatus)
+	e.PUT("/api/merchant/status", c.UpdateMerchantApprovalStatus, middleware.AdminOnly)
 	e.PUT("/api/merchant", c.UpdateMerchant)
+	e.POST("/api/merchant/document", c.UploadMerchantDocument)
+	e.GET("/api/merchant/documents", c.GetMerchantDocuments)
+	e.POST("/api/merchant/resubmit", c.ResubmitMerchant)
+	e.GET("/api/merchant/reviews", c.GetMerchantReviews)
+	g := e.Group("/api/admin", middleware.AdminOnly)
+	g.GET("/merchants/review", c.GetReviewQueue)
+	g.GET("/merchant/document", c.ReadMerchantDocument)
+	g.PUT("/merchant/suspend", c.SuspendMerchant)
+	g.PUT("/merchant/reactivate", c.ReactivateMerchant)
+	e.POST("/api/merchant/close", c.CloseMerchant)
 	return c
 }
 
 func (m *MerchantController) GetMerchantBalance(e echo.Context) error {
 	merchantId := e.QueryParam("merchantId")
-	balance, err := m.usecase.GetMerchantBalance(merchantId)
+	balance, err := m.usecase.GetMerchantBalance(e.Request().Context(), merchantId)
 	if err != nil {
-		return e.JSON(http.StatusNotFound, &base.BaseResponse{
-			Code:    http.StatusNotFound,
-			Message: message.NOT_FOUND,
-		})
+		return err
+	}
+	pendingBalance, err := m.usecase.GetMerchantPendingBalance(e.Request().Context(), merchantId)
+	if err != nil {
+		return err
 	}
 	return e.JSON(http.StatusOK, &response.GetMerchantBalanceResponse{
 		BaseResponse: base.BaseResponse{
 			Code:    http.StatusOK,
 			Message: message.SUCCESS,
 		}, Data: domain.MerchantBalanceDto{
-			Balance: balance,
+			Balance:        balance,
+			PendingBalance: pendingBalance,
 		},
 	})
 }
 
 func (m *MerchantController) RegisterMerchant(c echo.Context) error {
 	var merchantRequest request.MerchantRequest
-	c.Bind(&merchantRequest)
+	if err := c.Bind(&merchantRequest); err != nil {
+		return err
+	}
 
-	if err := m.usecase.RegisterMerchant(merchantRequest); err != nil {
-		return c.JSON(http.StatusUnprocessableEntity, &base.BaseResponse{
-			Code:    http.StatusBadRequest,
-			Message: message.BAD_REQUEST,
-		})
+	if err := m.usecase.RegisterMerchant(c.Request().Context(), merchantRequest); err != nil {
+		return err
 	}
 	return c.JSON(http.StatusOK, &base.BaseResponse{
 		Code:    http.StatusCreated,
@@ -64,12 +79,9 @@ func (m *MerchantController) RegisterMerchant(c echo.Context) error {
 
 func (m *MerchantController) GetMerchantById(context echo.Context) error {
 	id := context.QueryParam("merchantId")
-	merch, err := m.usecase.GetMerchantById(id)
+	merch, err := m.usecase.GetMerchantById(context.Request().Context(), id)
 	if err != nil {
-		return context.JSON(http.StatusNotFound, &base.BaseResponse{
-			Code:    http.StatusNotFound,
-			Message: message.NOT_FOUND,
-		})
+		return err
 	}
 	return context.JSON(http.StatusOK, &response.GetMerchantByIdResponse{
 		BaseResponse: base.BaseResponse{
@@ -83,12 +95,9 @@ func (m *MerchantController) GetMerchantById(context echo.Context) error {
 }
 
 func (m *MerchantController) GetMerchants(c echo.Context) error {
-	merchants, err := m.usecase.GetMerchants()
+	merchants, err := m.usecase.GetMerchants(c.Request().Context())
 	if err != nil {
-		c.JSON(http.StatusNotFound, &base.BaseResponse{
-			Code:    http.StatusNotFound,
-			Message: message.NOT_FOUND,
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, &response.GetMerchantsResponse{
@@ -102,13 +111,13 @@ func (m *MerchantController) GetMerchants(c echo.Context) error {
 
 func (m *MerchantController) UpdateMerchantApprovalStatus(c echo.Context) error {
 	var request request.UpdateMerchantApprovalStatusRequest
-	c.Bind(&request)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	request.AdminId = middleware.GetUserId(c)
 
-	if err := m.usecase.UpdateMerchantApprovalStatus(request); err != nil {
-		return c.JSON(http.StatusNotFound, &base.BaseResponse{
-			Code:    http.StatusNotFound,
-			Message: message.NOT_FOUND,
-		})
+	if err := m.usecase.UpdateMerchantApprovalStatus(c.Request().Context(), request); err != nil {
+		return err
 	}
 	return c.JSON(http.StatusOK, &base.BaseResponse{
 		Code:    http.StatusCreated,
@@ -118,16 +127,154 @@ func (m *MerchantController) UpdateMerchantApprovalStatus(c echo.Context) error
 
 func (m *MerchantController) UpdateMerchant(c echo.Context) error {
 	var request request.UpdateMerchantRequest
-	c.Bind(&request)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
 
-	if err := m.usecase.UpdateMerchant(request); err != nil {
-		return c.JSON(http.StatusNotFound, &base.BaseResponse{
-			Code:    http.StatusNotFound,
-			Message: message.NOT_FOUND,
-		})
+	if err := m.usecase.UpdateMerchant(c.Request().Context(), request); err != nil {
+		return err
 	}
 	return c.JSON(http.StatusOK, &base.BaseResponse{
 		Code:    http.StatusCreated,
 		Message: message.SUCCESS,
 	})
 }
+
+func (m *MerchantController) UploadMerchantDocument(c echo.Context) error {
+	var documentRequest request.MerchantDocumentRequest
+	if err := c.Bind(&documentRequest); err != nil {
+		return err
+	}
+	fileHeader, err := c.FormFile("document")
+	if err != nil {
+		return apperror.Validation("document is required")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return apperror.Validation("file cannot be read")
+	}
+	defer file.Close()
+	documentRequest.File = file
+	documentRequest.FileName = fileHeader.Filename
+
+	if err := m.usecase.UploadMerchantDocument(c.Request().Context(), documentRequest); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, &base.BaseResponse{
+		Code:    http.StatusCreated,
+		Message: message.SUCCESS,
+	})
+}
+
+func (m *MerchantController) GetMerchantDocuments(c echo.Context) error {
+	merchantId := c.QueryParam("merchantId")
+	documents, err := m.usecase.GetMerchantDocuments(c.Request().Context(), merchantId)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetMerchantDocumentsResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: domain.MerchantDocumentListDto{Documents: documents},
+	})
+}
+
+func (m *MerchantController) ReadMerchantDocument(c echo.Context) error {
+	documentId := c.QueryParam("documentId")
+	document, data, err := m.usecase.ReadMerchantDocument(c.Request().Context(), documentId)
+	if err != nil {
+		return err
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		"inline; filename=\""+document.FileName+"\"")
+	return c.Blob(http.StatusOK, document.ContentType, data)
+}
+
+func (m *MerchantController) GetReviewQueue(c echo.Context) error {
+	applications, err := m.usecase.GetReviewQueue(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetReviewQueueResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: domain.MerchantApplicationListDto{Applications: applications},
+	})
+}
+
+func (m *MerchantController) ResubmitMerchant(c echo.Context) error {
+	var resubmitRequest request.ResubmitMerchantRequest
+	if err := c.Bind(&resubmitRequest); err != nil {
+		return err
+	}
+	if err := m.usecase.ResubmitMerchant(c.Request().Context(), resubmitRequest); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
+
+func (m *MerchantController) GetMerchantReviews(c echo.Context) error {
+	merchantId := c.QueryParam("merchantId")
+	reviews, err := m.usecase.GetMerchantReviews(c.Request().Context(), merchantId)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetMerchantReviewsResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: domain.MerchantReviewListDto{Reviews: reviews},
+	})
+}
+
+func (m *MerchantController) SuspendMerchant(c echo.Context) error {
+	var suspendRequest request.SuspendMerchantRequest
+	if err := c.Bind(&suspendRequest); err != nil {
+		return err
+	}
+	suspendRequest.AdminId = middleware.GetUserId(c)
+	if err := m.usecase.SuspendMerchant(c.Request().Context(), suspendRequest); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
+
+func (m *MerchantController) ReactivateMerchant(c echo.Context) error {
+	var reactivateRequest request.ReactivateMerchantRequest
+	if err := c.Bind(&reactivateRequest); err != nil {
+		return err
+	}
+	reactivateRequest.AdminId = middleware.GetUserId(c)
+	if err := m.usecase.ReactivateMerchant(c.Request().Context(), reactivateRequest); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
+
+func (m *MerchantController) CloseMerchant(c echo.Context) error {
+	var closeRequest request.CloseMerchantRequest
+	if err := c.Bind(&closeRequest); err != nil {
+		return err
+	}
+	if err := m.usecase.CloseMerchant(c.Request().Context(), closeRequest); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
@@ -1,6 +1,7 @@
 package merchant
 
 import (
+	"github.com/williamchang80/sea-apd/controller/middleware"
 	"github.com/williamchang80/sea-apd/mocks/usecase/user"
 	"net/http"
 	"net/http/httptest"
@@ -41,7 +42,7 @@ func TestNewMerchantController(t *testing.T) {
 				ctx: ctx,
 			},
 			want: &MerchantController{
-				usecase: merchant2.NewMerchantUsecase(repo, userUsecase),
+				usecase: merchant2.NewMerchantUsecase(repo, userUsecase, nil),
 			},
 			initMock: func() domain.MerchantUsecase {
 				c := merchant_mock_usecase.NewMockUsecase(ctrl)
@@ -85,7 +86,7 @@ func TestMerchantController_GetMerchantBalance(t *testing.T) {
 				},
 			},
 			wantErr:    false,
-			wantStatus: http.StatusNotFound,
+			wantStatus: http.StatusBadRequest,
 			initMock: func() domain.MerchantUsecase {
 				return merchant_mock_usecase.NewMockUsecase(ctrl)
 			},
@@ -119,7 +120,10 @@ func TestMerchantController_GetMerchantBalance(t *testing.T) {
 			rec := httptest.NewRecorder()
 			ctx := c.NewContext(req, rec)
 			controller := NewMerchantController(c, mock)
-			if controller.GetMerchantBalance(ctx); (rec.Code != tt.wantStatus) || tt.wantErr {
+			if err := controller.GetMerchantBalance(ctx); err != nil {
+				middleware.ErrorHandler(err, ctx)
+			}
+			if rec.Code != tt.wantStatus || tt.wantErr {
 				t.Errorf("GetMerchantBalance() error= %v, want %v", rec.Code, tt.wantStatus)
 			}
 		})
@@ -1,13 +1,20 @@
 package product
 
 import (
+	"bytes"
+	"path/filepath"
+	"strings"
+
 	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/common/constants/import_status"
 	message "github.com/williamchang80/sea-apd/common/constants/response"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	"github.com/williamchang80/sea-apd/domain/product"
 	"github.com/williamchang80/sea-apd/dto/domain"
 	request "github.com/williamchang80/sea-apd/dto/request/product"
 	"github.com/williamchang80/sea-apd/dto/response/base"
 	response "github.com/williamchang80/sea-apd/dto/response/product"
+	"mime/multipart"
 	"net/http"
 )
 
@@ -25,16 +32,22 @@ func NewProductController(e *echo.Echo, p product.ProductUsecase) product.Produc
 	e.PUT("/api/product", c.UpdateProduct)
 	e.DELETE("/api/product", c.DeleteProduct)
 	e.GET("/api/merchant/products", c.GetProductsByMerchant)
+	e.GET("/api/products/search", c.SearchProducts)
+	e.GET("/api/products/tag", c.GetProductsByTag)
+	e.PUT("/api/product/options", c.SetProductOptions)
+	e.POST("/api/product/variant", c.CreateVariant)
+	e.PUT("/api/product/variant", c.UpdateVariant)
+	e.DELETE("/api/product/variant", c.DeleteVariant)
+	e.POST("/api/products/import", c.ImportProducts)
+	e.GET("/api/products/import", c.GetImportJob)
+	e.GET("/api/products/export", c.ExportProducts)
 	return c
 }
 
 func (p *ProductController) GetProducts(c echo.Context) error {
-	products, err := p.usecase.GetProducts()
+	products, err := p.usecase.GetProducts(c.Request().Context())
 	if err != nil {
-		c.JSON(http.StatusNotFound, &base.BaseResponse{
-			Code:    http.StatusNotFound,
-			Message: message.NOT_FOUND,
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, &response.GetProductsResponse{
@@ -46,14 +59,30 @@ func (p *ProductController) GetProducts(c echo.Context) error {
 	})
 }
 
+// openProductImage returns the optional multipart "image" file, nil when the request has none
+func openProductImage(c echo.Context) multipart.File {
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		return nil
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil
+	}
+	return file
+}
+
 func (p *ProductController) CreateProduct(c echo.Context) error {
 	var productRequest request.ProductRequest
-	c.Bind(&productRequest)
-	if err := p.usecase.CreateProduct(productRequest); err != nil {
-		return c.JSON(http.StatusUnprocessableEntity, &base.BaseResponse{
-			Code:    http.StatusBadRequest,
-			Message: message.BAD_REQUEST,
-		})
+	if err := c.Bind(&productRequest); err != nil {
+		return err
+	}
+	if image := openProductImage(c); image != nil {
+		defer image.Close()
+		productRequest.Image = image
+	}
+	if err := p.usecase.CreateProduct(c.Request().Context(), productRequest); err != nil {
+		return err
 	}
 	return c.JSON(http.StatusOK, &base.BaseResponse{
 		Code:    http.StatusCreated,
@@ -63,12 +92,9 @@ func (p *ProductController) CreateProduct(c echo.Context) error {
 
 func (p *ProductController) GetProductById(context echo.Context) error {
 	id := context.QueryParam("productId")
-	product, err := p.usecase.GetProductById(id)
+	product, err := p.usecase.GetProductById(context.Request().Context(), id)
 	if err != nil {
-		return context.JSON(http.StatusNotFound, &base.BaseResponse{
-			Code:    http.StatusNotFound,
-			Message: message.NOT_FOUND,
-		})
+		return err
 	}
 	return context.JSON(http.StatusOK, &response.GetProductByIdResponse{
 		BaseResponse: base.BaseResponse{
@@ -83,14 +109,17 @@ func (p *ProductController) GetProductById(context echo.Context) error {
 
 func (p *ProductController) UpdateProduct(context echo.Context) error {
 	var productRequest request.ProductRequest
-	context.Bind(&productRequest)
+	if err := context.Bind(&productRequest); err != nil {
+		return err
+	}
+	if image := openProductImage(context); image != nil {
+		defer image.Close()
+		productRequest.Image = image
+	}
 	productId := context.FormValue("productId")
-	err := p.usecase.UpdateProduct(productId, productRequest)
+	err := p.usecase.UpdateProduct(context.Request().Context(), productId, productRequest)
 	if err != nil {
-		return context.JSON(http.StatusBadRequest, &base.BaseResponse{
-			Code:    http.StatusBadRequest,
-			Message: message.BAD_REQUEST,
-		})
+		return err
 	}
 	return context.JSON(http.StatusOK, &base.BaseResponse{
 		Code:    http.StatusOK,
@@ -100,12 +129,9 @@ func (p *ProductController) UpdateProduct(context echo.Context) error {
 
 func (p *ProductController) DeleteProduct(context echo.Context) error {
 	id := context.QueryParam("productId")
-	err := p.usecase.DeleteProduct(id)
+	err := p.usecase.DeleteProduct(context.Request().Context(), id)
 	if err != nil {
-		return context.JSON(http.StatusNotFound, &base.BaseResponse{
-			Code:    http.StatusNotFound,
-			Message: message.NOT_FOUND,
-		})
+		return err
 	}
 	return context.JSON(http.StatusOK, &base.BaseResponse{
 		Code:    http.StatusOK,
@@ -115,12 +141,9 @@ func (p *ProductController) DeleteProduct(context echo.Context) error {
 
 func (p *ProductController) GetProductsByMerchant(c echo.Context) error {
 	merchantId := c.QueryParam("merchantId")
-	products, err := p.usecase.GetProductsByMerchant(merchantId)
+	products, err := p.usecase.GetProductsByMerchant(c.Request().Context(), merchantId)
 	if err != nil {
-		c.JSON(http.StatusNotFound, &base.BaseResponse{
-			Code:    http.StatusNotFound,
-			Message: message.NOT_FOUND,
-		})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, &response.GetProductsResponse{
@@ -131,3 +154,166 @@ func (p *ProductController) GetProductsByMerchant(c echo.Context) error {
 		Data: domain.ProductListDto{Products: products},
 	})
 }
+
+func (p *ProductController) SearchProducts(c echo.Context) error {
+	var searchRequest request.ProductSearchRequest
+	if err := c.Bind(&searchRequest); err != nil {
+		return err
+	}
+	products, err := p.usecase.SearchProducts(c.Request().Context(), searchRequest)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetProductsResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: domain.ProductListDto{Products: products},
+	})
+}
+
+func (p *ProductController) GetProductsByTag(c echo.Context) error {
+	tag := c.QueryParam("tag")
+	products, err := p.usecase.GetProductsByTag(c.Request().Context(), tag)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetProductsResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: domain.ProductListDto{Products: products},
+	})
+}
+
+func (p *ProductController) SetProductOptions(c echo.Context) error {
+	var optionsRequest request.ProductOptionsRequest
+	if err := c.Bind(&optionsRequest); err != nil {
+		return err
+	}
+	if err := p.usecase.SetProductOptions(c.Request().Context(), optionsRequest); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
+
+func (p *ProductController) CreateVariant(c echo.Context) error {
+	var variantRequest request.VariantRequest
+	if err := c.Bind(&variantRequest); err != nil {
+		return err
+	}
+	if err := p.usecase.CreateVariant(c.Request().Context(), variantRequest); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, &base.BaseResponse{
+		Code:    http.StatusCreated,
+		Message: message.SUCCESS,
+	})
+}
+
+func (p *ProductController) UpdateVariant(c echo.Context) error {
+	var variantRequest request.UpdateVariantRequest
+	if err := c.Bind(&variantRequest); err != nil {
+		return err
+	}
+	if err := p.usecase.UpdateVariant(c.Request().Context(), variantRequest); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
+
+func (p *ProductController) DeleteVariant(c echo.Context) error {
+	id := c.QueryParam("variantId")
+	if err := p.usecase.DeleteVariant(c.Request().Context(), id); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
+
+// catalogueFormatOf picks the format from the file extension when the request names none
+func catalogueFormatOf(format string, filename string) string {
+	if format != "" {
+		return format
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".jsonl", ".ndjson":
+		return "jsonl"
+	}
+	return "csv"
+}
+
+func (p *ProductController) ImportProducts(c echo.Context) error {
+	var importRequest request.ImportProductsRequest
+	if err := c.Bind(&importRequest); err != nil {
+		return err
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return apperror.Validation("file is required")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return apperror.Validation("file cannot be read")
+	}
+	defer file.Close()
+	importRequest.File = file
+	importRequest.Format = catalogueFormatOf(importRequest.Format, fileHeader.Filename)
+
+	job, err := p.usecase.ImportProducts(c.Request().Context(), importRequest)
+	if err != nil {
+		return err
+	}
+	code := http.StatusOK
+	if job.Status == import_status.ToString(import_status.PENDING) {
+		code = http.StatusAccepted
+	}
+	return c.JSON(code, &response.GetImportJobResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    code,
+			Message: message.SUCCESS,
+		},
+		Data: domain.ImportJobDto{Job: job},
+	})
+}
+
+func (p *ProductController) GetImportJob(c echo.Context) error {
+	jobId := c.QueryParam("jobId")
+	job, err := p.usecase.GetImportJob(c.Request().Context(), jobId)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetImportJobResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: domain.ImportJobDto{Job: job},
+	})
+}
+
+func (p *ProductController) ExportProducts(c echo.Context) error {
+	merchantId := c.QueryParam("merchantId")
+	format := strings.ToLower(catalogueFormatOf(c.QueryParam("format"), ""))
+	var buffer bytes.Buffer
+	if err := p.usecase.ExportProducts(c.Request().Context(), merchantId, format, &buffer); err != nil {
+		return err
+	}
+	contentType := "text/csv"
+	if format == "jsonl" {
+		contentType = "application/x-ndjson"
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		"attachment; filename=\"products."+format+"\"")
+	return c.Blob(http.StatusOK, contentType, buffer.Bytes())
+}
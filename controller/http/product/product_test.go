@@ -1,14 +1,19 @@
 package product
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/common/validation"
+	"github.com/williamchang80/sea-apd/controller/middleware"
 	domain "github.com/williamchang80/sea-apd/domain/product"
 	request "github.com/williamchang80/sea-apd/dto/request/product"
+	"github.com/williamchang80/sea-apd/dto/response/base"
 	product_mock_repository "github.com/williamchang80/sea-apd/mocks/repository/product"
 	product_mock_usecase "github.com/williamchang80/sea-apd/mocks/usecase/product"
 	"github.com/williamchang80/sea-apd/usecase/product"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -49,7 +54,7 @@ func TestNewProductController(t *testing.T) {
 				ctx: ctx,
 			},
 			want: &ProductController{
-				usecase: product.NewProductUseCase(repo),
+				usecase: product.NewProductUseCase(repo, nil, nil),
 			},
 			initMock: func() domain.ProductUsecase {
 				c := product_mock_usecase.NewMockUsecase(ctrl)
@@ -145,7 +150,7 @@ func TestProductController_CreateProduct(t *testing.T) {
 				ctx:     echo.New(),
 				request: request.ProductRequest{},
 			},
-			wantErr: false,
+			wantErr: true,
 			initMock: func() domain.ProductUsecase {
 				c := product_mock_usecase.NewMockUsecase(ctrl)
 				return c
@@ -172,6 +177,36 @@ func TestProductController_CreateProduct(t *testing.T) {
 	}
 }
 
+func TestProductController_CreateProductValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	e := echo.New()
+	validator := validation.NewValidator()
+	e.Binder = validation.NewBinder(validator)
+	data, _ := json.Marshal(request.ProductRequest{Name: "Mock name", Price: -1, MerchantId: "1"})
+	req := httptest.NewRequest(echo.POST, "/api/product", strings.NewReader(string(data)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	controller := NewProductController(e, product_mock_usecase.NewMockUsecase(ctrl))
+
+	err := controller.CreateProduct(ctx)
+	want := validation.Errors{
+		{Field: "price", Reason: "must be at least 0"},
+		{Field: "merchant_id", Reason: "must be a uuid"},
+	}
+	if !reflect.DeepEqual(err, want) {
+		t.Fatalf("CreateProduct() error = %v, want %v", err, want)
+	}
+	middleware.ErrorHandler(err, ctx)
+	var got base.ValidationErrorResponse
+	json.Unmarshal(rec.Body.Bytes(), &got)
+	if rec.Code != http.StatusUnprocessableEntity || got.Code != http.StatusUnprocessableEntity ||
+		!reflect.DeepEqual(got.Errors, want) {
+		t.Errorf("ErrorHandler() = %d %+v, want %d with %v", rec.Code, got, http.StatusUnprocessableEntity, want)
+	}
+}
+
 func TestProductController_DeleteProduct(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	type args struct {
@@ -211,7 +246,7 @@ func TestProductController_DeleteProduct(t *testing.T) {
 					return q
 				},
 			},
-			wantErr: false,
+			wantErr: true,
 			initMock: func() domain.ProductUsecase {
 				c := product_mock_usecase.NewMockUsecase(ctrl)
 				return c
@@ -275,7 +310,7 @@ func TestProductController_UpdateProduct(t *testing.T) {
 			args: args{
 				ctx: echo.New(),
 			},
-			wantErr: false,
+			wantErr: true,
 			initMock: func() domain.ProductUsecase {
 				c := product_mock_usecase.NewMockUsecase(ctrl)
 				return c
@@ -340,7 +375,7 @@ func TestProductController_GetProductById(t *testing.T) {
 					return q
 				},
 			},
-			wantErr: false,
+			wantErr: true,
 			initMock: func() domain.ProductUsecase {
 				c := product_mock_usecase.NewMockUsecase(ctrl)
 				return c
@@ -403,7 +438,7 @@ func TestProductController_GetProductsByMerchant(t *testing.T) {
 					return q
 				},
 			},
-			wantErr: false,
+			wantErr: true,
 			initMock: func() domain.ProductUsecase {
 				c := product_mock_usecase.NewMockUsecase(ctrl)
 				return c
@@ -425,3 +460,75 @@ func TestProductController_GetProductsByMerchant(t *testing.T) {
 		})
 	}
 }
+
+func TestProductController_ImportProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name       string
+		merchantId string
+		withFile   bool
+		wantCode   int
+	}{
+		{name: "success", merchantId: mockId, withFile: true, wantCode: http.StatusOK},
+		{name: "fail without file", merchantId: mockId, withFile: false, wantCode: http.StatusBadRequest},
+		{name: "fail with empty merchant id", merchantId: "", withFile: true, wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := echo.New()
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			writer.WriteField("merchant_id", tt.merchantId)
+			if tt.withFile {
+				part, _ := writer.CreateFormFile("file", "products.csv")
+				part.Write([]byte("sku,name,price,stock\nA,Shirt,10,1\n"))
+			}
+			writer.Close()
+			req := httptest.NewRequest(echo.POST, "/api/products/import", body)
+			req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+			rec := httptest.NewRecorder()
+			ctx := c.NewContext(req, rec)
+			controller := NewProductController(c, product_mock_usecase.NewMockUsecase(ctrl))
+			if err := controller.ImportProducts(ctx); err != nil {
+				middleware.ErrorHandler(err, ctx)
+			}
+			if rec.Code != tt.wantCode {
+				t.Errorf("ImportProducts() code = %v, want %v", rec.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestProductController_ExportProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name       string
+		merchantId string
+		wantCode   int
+	}{
+		{name: "success", merchantId: mockId, wantCode: http.StatusOK},
+		{name: "fail with empty merchant id", merchantId: "", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := echo.New()
+			q := make(url.Values)
+			q.Set("merchantId", tt.merchantId)
+			req := httptest.NewRequest(echo.GET, "/api/products/export?"+q.Encode(), nil)
+			rec := httptest.NewRecorder()
+			ctx := c.NewContext(req, rec)
+			controller := NewProductController(c, product_mock_usecase.NewMockUsecase(ctrl))
+			if err := controller.ExportProducts(ctx); err != nil {
+				middleware.ErrorHandler(err, ctx)
+			}
+			if rec.Code != tt.wantCode {
+				t.Errorf("ExportProducts() code = %v, want %v", rec.Code, tt.wantCode)
+			}
+			if tt.wantCode == http.StatusOK && rec.Header().Get(echo.HeaderContentType) != "text/csv" {
+				t.Errorf("ExportProducts() content type = %v", rec.Header().Get(echo.HeaderContentType))
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package shipping
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo"
+	message "github.com/williamchang80/sea-apd/common/constants/response"
+	"github.com/williamchang80/sea-apd/domain/shipping"
+	"github.com/williamchang80/sea-apd/dto/domain"
+	request "github.com/williamchang80/sea-apd/dto/request/shipping"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+	response "github.com/williamchang80/sea-apd/dto/response/shipping"
+)
+
+type ShippingController struct {
+	usecase shipping.ShippingUsecase
+}
+
+func NewShippingController(e *echo.Echo, s shipping.ShippingUsecase) shipping.ShippingController {
+	c := &ShippingController{usecase: s}
+	e.GET("/api/merchant/shipping-rates", c.GetShippingRates)
+	e.PUT("/api/merchant/shipping-rates", c.UpdateShippingRates)
+	return c
+}
+
+func (s *ShippingController) GetShippingRates(c echo.Context) error {
+	merchantId := c.QueryParam("merchantId")
+	rates, err := s.usecase.GetShippingRates(c.Request().Context(), merchantId)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetShippingRatesResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: domain.ShippingRateListDto{Rates: rates},
+	})
+}
+
+func (s *ShippingController) UpdateShippingRates(c echo.Context) error {
+	var ratesRequest request.UpdateShippingRatesRequest
+	if err := c.Bind(&ratesRequest); err != nil {
+		return err
+	}
+	if err := s.usecase.UpdateShippingRates(c.Request().Context(), ratesRequest); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
@@ -3,6 +3,7 @@ package transaction
 import (
 	"github.com/labstack/echo"
 	message "github.com/williamchang80/sea-apd/common/constants/response"
+	"github.com/williamchang80/sea-apd/controller/middleware"
 	"github.com/williamchang80/sea-apd/domain/transaction"
 	"github.com/williamchang80/sea-apd/dto/domain"
 	transaction2 "github.com/williamchang80/sea-apd/dto/request/transaction"
@@ -23,18 +24,23 @@ func NewTransactionController(e *echo.Echo, t transaction.TransactionUsecase) tr
 	e.GET("/api/transactions/history", c.GetTransactionHistory)
 	e.GET("/api/transactions/request", c.GetMerchantRequestItem)
 	e.POST("/api/transaction/payment", c.PayTransaction)
+	e.POST("/api/cart", c.AddToCart)
+	e.GET("/api/cart", c.GetCart)
+	e.POST("/api/cart/checkout", c.Checkout)
+	e.GET("/api/cart/shipping", c.QuoteShipping)
+	e.POST("/api/transaction/shipment", c.ShipTransaction)
+	e.POST("/api/transaction/delivery", c.ConfirmDelivery, middleware.Authenticated)
 	return c
 }
 
 func (t *TransactionController) CreateTransaction(c echo.Context) error {
 	var request transaction2.TransactionRequest
-	c.Bind(&request)
-	err := t.usecase.CreateTransaction(request)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	err := t.usecase.CreateTransaction(c.Request().Context(), request)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, &base.BaseResponse{
-			Code:    http.StatusNotFound,
-			Message: message.NOT_FOUND,
-		})
+		return err
 	}
 	return c.JSON(http.StatusOK, &base.BaseResponse{
 		Code:    http.StatusOK,
@@ -44,13 +50,12 @@ func (t *TransactionController) CreateTransaction(c echo.Context) error {
 
 func (t *TransactionController) UpdateTransactionStatus(c echo.Context) error {
 	var request transaction2.UpdateTransactionRequest
-	c.Bind(&request)
-	err := t.usecase.UpdateTransactionStatus(request)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	err := t.usecase.UpdateTransactionStatus(c.Request().Context(), request)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, &base.BaseResponse{
-			Code:    http.StatusNotFound,
-			Message: message.NOT_FOUND,
-		})
+		return err
 	}
 	return c.JSON(http.StatusOK, &base.BaseResponse{
 		Code:    http.StatusOK,
@@ -60,12 +65,9 @@ func (t *TransactionController) UpdateTransactionStatus(c echo.Context) error {
 
 func (t *TransactionController) GetTransactionById(c echo.Context) error {
 	id := c.QueryParam("transactionId")
-	tr, err := t.usecase.GetTransactionById(id)
+	tr, err := t.usecase.GetTransactionById(c.Request().Context(), id)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, &base.BaseResponse{
-			Code:    http.StatusNotFound,
-			Message: message.NOT_FOUND,
-		})
+		return err
 	}
 	return c.JSON(http.StatusOK, response.GetTransactionByIdResponse{
 		BaseResponse: base.BaseResponse{
@@ -80,12 +82,9 @@ func (t *TransactionController) GetTransactionById(c echo.Context) error {
 
 func (t *TransactionController) GetTransactionHistory(c echo.Context) error {
 	id := c.QueryParam("userId")
-	tr, err := t.usecase.GetTransactionHistory(id)
+	tr, err := t.usecase.GetTransactionHistory(c.Request().Context(), id)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, &base.BaseResponse{
-			Code:    http.StatusNotFound,
-			Message: message.NOT_FOUND,
-		})
+		return err
 	}
 	return c.JSON(http.StatusOK, response.GetTransactionHistoryResponse{
 		BaseResponse: base.BaseResponse{
@@ -98,12 +97,9 @@ func (t *TransactionController) GetTransactionHistory(c echo.Context) error {
 
 func (t *TransactionController) GetMerchantRequestItem(c echo.Context) error {
 	id := c.QueryParam("merchantId")
-	tr, err := t.usecase.GetMerchantRequestItem(id)
+	tr, err := t.usecase.GetMerchantRequestItem(c.Request().Context(), id)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, &base.BaseResponse{
-			Code:    http.StatusNotFound,
-			Message: message.NOT_FOUND,
-		})
+		return err
 	}
 	return c.JSON(http.StatusOK, response.GetTransactionHistoryResponse{
 		BaseResponse: base.BaseResponse{
@@ -116,16 +112,105 @@ func (t *TransactionController) GetMerchantRequestItem(c echo.Context) error {
 
 func (t *TransactionController) PayTransaction(c echo.Context) error {
 	var request transaction2.PaymentRequest
-	c.Bind(&request)
-	err := t.usecase.PayTransaction(request)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	err := t.usecase.PayTransaction(c.Request().Context(), request)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, &base.BaseResponse{
-			Code:    http.StatusNotFound,
-			Message: message.NOT_FOUND,
-		})
+		return err
 	}
 	return c.JSON(http.StatusOK, base.BaseResponse{
 		Code:    http.StatusOK,
 		Message: message.SUCCESS,
 	})
 }
+
+func (t *TransactionController) AddToCart(c echo.Context) error {
+	var request transaction2.CartItemRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	if err := t.usecase.AddToCart(c.Request().Context(), request); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
+
+func (t *TransactionController) GetCart(c echo.Context) error {
+	id := c.QueryParam("customerId")
+	carts, err := t.usecase.GetCart(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.GetTransactionHistoryResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: carts,
+	})
+}
+
+func (t *TransactionController) Checkout(c echo.Context) error {
+	var request transaction2.CheckoutRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	if err := t.usecase.Checkout(c.Request().Context(), request); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
+
+func (t *TransactionController) QuoteShipping(c echo.Context) error {
+	quote, err := t.usecase.QuoteShipping(c.Request().Context(), transaction2.CheckoutRequest{
+		CustomerId:    c.QueryParam("customerId"),
+		TransactionId: c.QueryParam("transactionId"),
+		AddressId:     c.QueryParam("addressId"),
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.GetShippingQuoteResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: *quote,
+	})
+}
+
+func (t *TransactionController) ShipTransaction(c echo.Context) error {
+	var request transaction2.ShipmentRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	if err := t.usecase.ShipTransaction(c.Request().Context(), request); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
+
+func (t *TransactionController) ConfirmDelivery(c echo.Context) error {
+	var request transaction2.ConfirmDeliveryRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	request.CustomerId = middleware.GetUserId(c)
+	if err := t.usecase.ConfirmDelivery(c.Request().Context(), request); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/labstack/echo"
 	message "github.com/williamchang80/sea-apd/common/constants/response"
 	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
+	"github.com/williamchang80/sea-apd/controller/middleware"
 	domain "github.com/williamchang80/sea-apd/domain/transaction"
 	"github.com/williamchang80/sea-apd/dto/request/transaction"
 	request "github.com/williamchang80/sea-apd/dto/request/transaction"
@@ -56,7 +57,7 @@ func TestNewTransactionController(t *testing.T) {
 				ctx: ctx,
 			},
 			want: &TransactionController{
-				usecase: transaction_usecase.NewTransactionUsecase(repo, nil, nil),
+				usecase: transaction_usecase.NewTransactionUsecase(repo, nil, nil, nil, nil, nil),
 			},
 			initMock: func() domain.TransactionUsecase {
 				return transaction_mock_usecase.NewMockUsecase(ctrl)
@@ -110,7 +111,7 @@ func TestTransactionController_CreateTransaction(t *testing.T) {
 				ctx:     echo.New(),
 				request: request.TransactionRequest{},
 			},
-			wantErr: false,
+			wantErr: true,
 			want: base.BaseResponse{
 				Code:    http.StatusNotFound,
 				Message: message.NOT_FOUND,
@@ -162,8 +163,8 @@ func TestTransactionController_UpdateTransactionStatus(t *testing.T) {
 			},
 			wantErr: false,
 			want: base.BaseResponse{
-				Code:    http.StatusNotFound,
-				Message: message.NOT_FOUND,
+				Code:    http.StatusBadRequest,
+				Message: message.BAD_REQUEST,
 			},
 			initMock: func() domain.TransactionUsecase {
 				return transaction_mock_usecase.NewMockUsecase(ctrl)
@@ -198,7 +199,10 @@ func TestTransactionController_UpdateTransactionStatus(t *testing.T) {
 			rec := httptest.NewRecorder()
 			ctx := c.NewContext(req, rec)
 			controller := NewTransactionController(c, mock)
-			if controller.UpdateTransactionStatus(ctx); (rec.Code != tt.want.Code) || tt.wantErr {
+			if err := controller.UpdateTransactionStatus(ctx); err != nil {
+				middleware.ErrorHandler(err, ctx)
+			}
+			if rec.Code != tt.want.Code || tt.wantErr {
 				t.Errorf("UpdateTransactionStatus() error= %v, want %v", rec.Code, tt.want.Code)
 			}
 		})
@@ -229,7 +233,7 @@ func TestTransactionController_GetTransactionById(t *testing.T) {
 				},
 			},
 			wantErr:    false,
-			wantStatus: http.StatusNotFound,
+			wantStatus: http.StatusBadRequest,
 			initMock: func() domain.TransactionUsecase {
 				return transaction_mock_usecase.NewMockUsecase(ctrl)
 			},
@@ -263,7 +267,10 @@ func TestTransactionController_GetTransactionById(t *testing.T) {
 			rec := httptest.NewRecorder()
 			ctx := c.NewContext(req, rec)
 			controller := NewTransactionController(c, mock)
-			if controller.GetTransactionById(ctx); (rec.Code != tt.wantStatus) || tt.wantErr {
+			if err := controller.GetTransactionById(ctx); err != nil {
+				middleware.ErrorHandler(err, ctx)
+			}
+			if rec.Code != tt.wantStatus || tt.wantErr {
 				t.Errorf("GetTransactionById() error= %v, want %v", rec.Code, tt.wantStatus)
 			}
 		})
@@ -294,7 +301,7 @@ func TestTransactionController_GetTransactionHistory(t *testing.T) {
 				},
 			},
 			wantErr:    false,
-			wantStatus: http.StatusNotFound,
+			wantStatus: http.StatusBadRequest,
 			initMock: func() domain.TransactionUsecase {
 				return transaction_mock_usecase.NewMockUsecase(ctrl)
 			},
@@ -328,9 +335,53 @@ func TestTransactionController_GetTransactionHistory(t *testing.T) {
 			rec := httptest.NewRecorder()
 			ctx := c.NewContext(req, rec)
 			controller := NewTransactionController(c, mock)
-			if controller.GetTransactionHistory(ctx); (rec.Code != tt.wantStatus) || tt.wantErr {
+			if err := controller.GetTransactionHistory(ctx); err != nil {
+				middleware.ErrorHandler(err, ctx)
+			}
+			if rec.Code != tt.wantStatus || tt.wantErr {
 				t.Errorf("GetTransactionHistory() error= %v, want %v", rec.Code, tt.wantStatus)
 			}
 		})
 	}
 }
+
+func TestTransactionController_ConfirmDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name   string
+		userId string
+		want   int
+	}{
+		{
+			name:   "success with the customer of the token",
+			userId: "1",
+			want:   http.StatusOK,
+		},
+		{
+			name:   "failed with the customer of the body only",
+			userId: "",
+			want:   http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := echo.New()
+			body := `{"transaction_id": "1", "customer_id": "1"}`
+			req := httptest.NewRequest(echo.POST, "/api/transaction/delivery", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			ctx := c.NewContext(req, rec)
+			if tt.userId != "" {
+				ctx.Set(middleware.UserIdKey, tt.userId)
+			}
+			controller := NewTransactionController(c, transaction_mock_usecase.NewMockUsecase(ctrl))
+			if err := controller.ConfirmDelivery(ctx); err != nil {
+				middleware.ErrorHandler(err, ctx)
+			}
+			if rec.Code != tt.want {
+				t.Errorf("ConfirmDelivery() status = %v, want %v", rec.Code, tt.want)
+			}
+		})
+	}
+}
@@ -23,12 +23,9 @@ func NewTransferController(e *echo.Echo, t transfer.TransferUsecase) transfer.Tr
 
 func (t TransferController) GetTransferHistory(ctx echo.Context) error {
 	userId := ctx.QueryParam("merchantId")
-	transfers, err := t.usecase.GetTransferHistory(userId)
+	transfers, err := t.usecase.GetTransferHistory(ctx.Request().Context(), userId)
 	if err != nil {
-		return ctx.JSON(http.StatusUnprocessableEntity, &base.BaseResponse{
-			Code:    http.StatusUnprocessableEntity,
-			Message: message.UNPROCESSABLE_ENTITY,
-		})
+		return err
 	}
 	return ctx.JSON(http.StatusOK, &transfer2.GetTransferResponse{
 		BaseResponse: base.BaseResponse{
@@ -41,12 +38,11 @@ func (t TransferController) GetTransferHistory(ctx echo.Context) error {
 
 func (t TransferController) CreateTransferHistory(ctx echo.Context) error {
 	var request request.CreateTransferHistoryRequest
-	ctx.Bind(&request)
-	if err := t.usecase.CreateTransferHistory(request); err != nil {
-		return ctx.JSON(http.StatusUnprocessableEntity, &base.BaseResponse{
-			Code:    http.StatusUnprocessableEntity,
-			Message: message.UNPROCESSABLE_ENTITY,
-		})
+	if err := ctx.Bind(&request); err != nil {
+		return err
+	}
+	if err := t.usecase.CreateTransferHistory(ctx.Request().Context(), request); err != nil {
+		return err
 	}
 	return ctx.JSON(http.StatusCreated, &base.BaseResponse{
 		Code:    http.StatusCreated,
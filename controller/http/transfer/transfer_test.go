@@ -20,10 +20,9 @@ import (
 var (
 	mockId = "1"
 	mockCreateTransferRequest = transfer.CreateTransferHistoryRequest{
-		BankNumber: "Mock Number",
-		BankName:   "Mock Name",
-		Amount:     1000,
-		MerchantId: "1",
+		BankAccountId: "1",
+		Amount:        1000,
+		MerchantId:    "1",
 	}
 )
 
@@ -47,7 +46,7 @@ func TestNewTransferController(t *testing.T) {
 				ctx: ctx,
 			},
 			want: &TransferController{
-				usecase: transfer_usecase.NewTransferUsecase(repo, nil),
+				usecase: transfer_usecase.NewTransferUsecase(repo, nil, nil),
 			},
 			initMock: func() domain.TransferUsecase {
 				return transfer_mock_usecase.NewMockUsecase(ctrl)
@@ -105,7 +104,7 @@ func TestTransferController_GetTransferHistory(t *testing.T) {
 					return q
 				},
 			},
-			wantErr: false,
+			wantErr: true,
 			initMock: func() domain.TransferUsecase {
 				c := transfer_mock_usecase.NewMockUsecase(ctrl)
 				return c
@@ -161,7 +160,7 @@ func TestTransferController_CreateTransferHistory(t *testing.T) {
 				ctx:     echo.New(),
 				request: transfer.CreateTransferHistoryRequest{},
 			},
-			wantErr: false,
+			wantErr: true,
 			initMock: func() domain.TransferUsecase {
 				c := transfer_mock_usecase.NewMockUsecase(ctrl)
 				return c
@@ -3,8 +3,10 @@ package user
 import (
 	"github.com/labstack/echo"
 	message "github.com/williamchang80/sea-apd/common/constants/response"
+	"github.com/williamchang80/sea-apd/controller/middleware"
 	"github.com/williamchang80/sea-apd/domain/user"
 	"github.com/williamchang80/sea-apd/dto/request/admin"
+	response "github.com/williamchang80/sea-apd/dto/response/admin"
 	"github.com/williamchang80/sea-apd/dto/response/base"
 
 	"net/http"
@@ -20,21 +22,110 @@ func NewAdminController(e *echo.Echo, a user.AdminUsecase) user.AdminController
 	c := &AdminController{
 		usecase: a,
 	}
-	e.POST("api/user/admin", c.RegisterAdmin)
+	e.POST("api/user/admin", c.AcceptAdminInvitation)
+	g := e.Group("/api/admin", middleware.AdminOnly)
+	g.POST("/invitation", c.InviteAdmin)
+	g.GET("/invitations", c.GetAdminInvitations)
+	g.DELETE("/invitation", c.RevokeAdminInvitation)
+	g.GET("/2fa-policy", c.GetTwoFactorPolicies)
+	g.PUT("/2fa-policy", c.SetTwoFactorPolicy)
 
 	return c
 }
 
-// RegisterAdmin ...
-func (a *AdminController) RegisterAdmin(c echo.Context) error {
+// AcceptAdminInvitation ...
+func (a *AdminController) AcceptAdminInvitation(c echo.Context) error {
 	var adminRequest admin.Admin
-	c.Bind(&adminRequest)
+	if err := c.Bind(&adminRequest); err != nil {
+		return err
+	}
+
+	if err := a.usecase.AcceptAdminInvitation(c.Request().Context(), adminRequest); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
+
+// InviteAdmin ...
+func (a *AdminController) InviteAdmin(c echo.Context) error {
+	var inviteRequest admin.InviteAdminRequest
+	if err := c.Bind(&inviteRequest); err != nil {
+		return err
+	}
+	inviteRequest.AdminId = middleware.GetUserId(c)
+
+	invitation, err := a.usecase.InviteAdmin(c.Request().Context(), inviteRequest)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.InviteAdminResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: *invitation,
+	})
+}
+
+// GetAdminInvitations ...
+func (a *AdminController) GetAdminInvitations(c echo.Context) error {
+	invitations, err := a.usecase.GetAdminInvitations(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetAdminInvitationsResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: invitations,
+	})
+}
+
+// RevokeAdminInvitation ...
+func (a *AdminController) RevokeAdminInvitation(c echo.Context) error {
+	revokeRequest := admin.RevokeInvitationRequest{
+		AdminId:      middleware.GetUserId(c),
+		InvitationId: c.QueryParam("invitationId"),
+	}
+
+	if err := a.usecase.RevokeAdminInvitation(c.Request().Context(), revokeRequest); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
+
+// GetTwoFactorPolicies ...
+func (a *AdminController) GetTwoFactorPolicies(c echo.Context) error {
+	policies, err := a.usecase.GetTwoFactorPolicies(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, &response.GetTwoFactorPoliciesResponse{
+		BaseResponse: base.BaseResponse{
+			Code:    http.StatusOK,
+			Message: message.SUCCESS,
+		},
+		Data: policies,
+	})
+}
+
+// SetTwoFactorPolicy ...
+func (a *AdminController) SetTwoFactorPolicy(c echo.Context) error {
+	var policyRequest admin.TwoFactorPolicyRequest
+	if err := c.Bind(&policyRequest); err != nil {
+		return err
+	}
+	policyRequest.AdminId = middleware.GetUserId(c)
 
-	if err := a.usecase.RegisterAdmin(adminRequest); err != nil {
-		return c.JSON(http.StatusBadRequest, &base.BaseResponse{
-			Code:    http.StatusBadRequest,
-			Message: err.Error(),
-		})
+	if err := a.usecase.SetTwoFactorPolicy(c.Request().Context(), policyRequest); err != nil {
+		return err
 	}
 	return c.JSON(http.StatusOK, &base.BaseResponse{
 		Code:    http.StatusOK,
@@ -20,19 +20,20 @@ func NewUserController(e *echo.Echo, uc user.UserUsecase) user.UserController {
 	}
 	e.POST("api/auth/register", c.CreateUser)
 	e.PUT("api/user", c.UpdateUser)
+	e.GET("api/auth/verify-email", c.VerifyEmail)
+	e.POST("api/auth/verify-email/resend", c.ResendVerification)
 	return c
 }
 
 func (u UserController) CreateUser(context echo.Context) error {
 	var request auth.RegisterUserRequest
-	context.Bind(&request)
+	if err := context.Bind(&request); err != nil {
+		return err
+	}
 
-	err := u.usecase.CreateUser(request)
+	err := u.usecase.CreateUser(context.Request().Context(), request)
 	if err != nil {
-		return context.JSON(http.StatusBadRequest, base.BaseResponse{
-			Code:    http.StatusBadRequest,
-			Message: err.Error(),
-		})
+		return err
 	}
 	return context.JSON(http.StatusOK, base.BaseResponse{
 		Code:    http.StatusOK,
@@ -42,13 +43,12 @@ func (u UserController) CreateUser(context echo.Context) error {
 
 func (u UserController) UpdateUser(c echo.Context) error {
 	var request user2.UpdateUserRequest
-	c.Bind(&request)
-	err := u.usecase.UpdateUser(request)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	err := u.usecase.UpdateUser(c.Request().Context(), request)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, base.BaseResponse{
-			Code:    http.StatusBadRequest,
-			Message: err.Error(),
-		})
+		return err
 	}
 	return c.JSON(http.StatusOK, base.BaseResponse{
 		Code:    http.StatusOK,
@@ -56,3 +56,29 @@ func (u UserController) UpdateUser(c echo.Context) error {
 	})
 
 }
+
+func (u UserController) VerifyEmail(c echo.Context) error {
+	err := u.usecase.VerifyEmail(c.Request().Context(), c.QueryParam("token"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
+
+func (u UserController) ResendVerification(c echo.Context) error {
+	var request user2.ResendVerificationRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	err := u.usecase.ResendVerification(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, base.BaseResponse{
+		Code:    http.StatusOK,
+		Message: message.SUCCESS,
+	})
+}
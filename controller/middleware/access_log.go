@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/common/logger"
+)
+
+// AccessLog writes a line for every request with its status and latency. Errors are
+// written by the error handler first so their status is the one logged.
+func AccessLog(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		if err := next(c); err != nil {
+			c.Error(err)
+		}
+		request := c.Request()
+		response := c.Response()
+		fields := logger.Fields{
+			"method":     request.Method,
+			"path":       request.URL.Path,
+			"route":      c.Path(),
+			"status":     response.Status,
+			"bytes_out":  response.Size,
+			"ip":         c.RealIP(),
+			"latency_ms": float64(time.Since(start)) / float64(time.Millisecond),
+		}
+		if response.Status >= http.StatusInternalServerError {
+			logger.Error(request.Context(), "request", fields)
+		} else {
+			logger.Info(request.Context(), "request", fields)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/common/logger"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+)
+
+func TestAccessLog(t *testing.T) {
+	tests := []struct {
+		name          string
+		requestId     string
+		handlerErr    error
+		wantStatus    int
+		wantRequestId string
+	}{
+		{
+			name:          "success with request id of nginx",
+			requestId:     "nginx-id",
+			wantStatus:    http.StatusOK,
+			wantRequestId: "nginx-id",
+		},
+		{
+			name:       "success without request id",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "failed handler logs the status of its error",
+			requestId:     "nginx-id",
+			handlerErr:    apperror.NotFound("product not found"),
+			wantStatus:    http.StatusNotFound,
+			wantRequestId: "nginx-id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			logger.SetOutput(&out)
+			defer logger.SetOutput(os.Stdout)
+			e := echo.New()
+			e.HTTPErrorHandler = ErrorHandler
+			e.Use(RequestId, AccessLog)
+			e.GET("/api/product", func(c echo.Context) error {
+				if tt.handlerErr != nil {
+					return tt.handlerErr
+				}
+				return c.NoContent(http.StatusOK)
+			})
+			req := httptest.NewRequest(echo.GET, "/api/product", nil)
+			if tt.requestId != "" {
+				req.Header.Set(echo.HeaderXRequestID, tt.requestId)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			requestId := rec.Header().Get(echo.HeaderXRequestID)
+			if requestId == "" || (tt.wantRequestId != "" && requestId != tt.wantRequestId) {
+				t.Errorf("RequestId() header = %q, want %q", requestId, tt.wantRequestId)
+			}
+			var line map[string]interface{}
+			if err := json.Unmarshal(out.Bytes(), &line); err != nil {
+				t.Fatalf("AccessLog() line = %s", out.String())
+			}
+			if line["request_id"] != requestId || line["status"] != float64(tt.wantStatus) ||
+				line["route"] != "/api/product" || line["latency_ms"] == nil {
+				t.Errorf("AccessLog() line = %v, want request id %v and status %v", line, requestId, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/common/auth"
+	"github.com/williamchang80/sea-apd/common/constants/user_role"
+	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/user"
+)
+
+// mockSessions revoked the sessions of revokedUserId
+type mockSessions struct{}
+
+const revokedUserId = "revoked"
+
+func (m mockSessions) ValidateSession(ctx context.Context, userId string, issuedAt time.Time) error {
+	if userId == revokedUserId {
+		return user.ErrSessionRevoked
+	}
+	return nil
+}
+
+func TestSessions(t *testing.T) {
+	InitSessions(mockSessions{})
+	defer InitSessions(nil)
+	token := func(userId string) string {
+		t, _ := auth.GenerateToken(&user.User{Base: domain.Base{ID: userId},
+			Role: user_role.ToString(user_role.CUSTOMER)})
+		return "Bearer " + t
+	}
+	expired, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id":   "1",
+		"user_role": user_role.ToString(user_role.CUSTOMER),
+		"iat":       time.Now().Add(-2 * time.Hour).Unix(),
+		"exp":       time.Now().Add(-time.Hour).Unix(),
+	}).SignedString([]byte(auth.GetSecretKey()))
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantUserId    string
+	}{
+		{name: "without token", authorization: "", wantStatus: http.StatusOK},
+		{name: "with valid token", authorization: token("1"), wantStatus: http.StatusOK, wantUserId: "1"},
+		{name: "with malformed token", authorization: "Bearer malformed", wantStatus: http.StatusOK},
+		{name: "with expired token", authorization: "Bearer " + expired, wantStatus: http.StatusOK},
+		{name: "with revoked session", authorization: token(revokedUserId), wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = ErrorHandler
+			var userId string
+			e.GET("/api/products", func(c echo.Context) error {
+				userId = GetUserId(c)
+				return c.NoContent(http.StatusOK)
+			}, Sessions)
+			req := httptest.NewRequest(http.MethodGet, "/api/products", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Sessions() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if userId != tt.wantUserId {
+				t.Errorf("GetUserId() = %v, want %v", userId, tt.wantUserId)
+			}
+		})
+	}
+}
+
+func TestAdminOnly(t *testing.T) {
+	token := func(role user_role.UserRole) string {
+		t, _ := auth.GenerateToken(&user.User{Base: domain.Base{ID: "1"}, Role: user_role.ToString(role)})
+		return "Bearer " + t
+	}
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantUserId    string
+	}{
+		{name: "without token", authorization: "", wantStatus: http.StatusUnauthorized},
+		{name: "with customer token", authorization: token(user_role.CUSTOMER), wantStatus: http.StatusForbidden},
+		{name: "with admin token", authorization: token(user_role.ADMIN), wantStatus: http.StatusOK, wantUserId: "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = ErrorHandler
+			var userId string
+			e.GET("/api/admin/users", func(c echo.Context) error {
+				userId = GetUserId(c)
+				return c.NoContent(http.StatusOK)
+			}, AdminOnly)
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/users", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("AdminOnly() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if userId != tt.wantUserId {
+				t.Errorf("GetUserId() = %v, want %v", userId, tt.wantUserId)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo"
+)
+
+var trustedProxies []*net.IPNet
+
+// InitTrustedProxies sets the networks ClientIp reads X-Forwarded-For from
+func InitTrustedProxies(networks []*net.IPNet) {
+	trustedProxies = networks
+}
+
+// ClientIp returns the address of the client of the request. Unlike echo's RealIP it
+// cannot be changed by the client, so per ip limits and lockouts key on it.
+func ClientIp(c echo.Context) string {
+	return clientIp(c.Request(), trustedProxies)
+}
+
+// clientIp trusts X-Forwarded-For only from the proxies, nginx sets it to the address
+// of the client. Other peers could send any address in it.
+func clientIp(request *http.Request, trustedProxies []*net.IPNet) string {
+	peer, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		peer = request.RemoteAddr
+	}
+	forwarded := request.Header.Get(echo.HeaderXForwardedFor)
+	if forwarded == "" || !isTrusted(net.ParseIP(peer), trustedProxies) {
+		return peer
+	}
+	// the proxies append the address they received the request from
+	addresses := strings.Split(forwarded, ",")
+	for i := len(addresses) - 1; i >= 0; i-- {
+		address := strings.TrimSpace(addresses[i])
+		if i == 0 || !isTrusted(net.ParseIP(address), trustedProxies) {
+			return address
+		}
+	}
+	return peer
+}
+
+func isTrusted(ip net.IP, trustedProxies []*net.IPNet) bool {
+	for _, network := range trustedProxies {
+		if ip != nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
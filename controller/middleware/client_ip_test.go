@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+)
+
+func TestClientIp(t *testing.T) {
+	_, proxy, _ := net.ParseCIDR("10.0.0.0/8")
+	InitTrustedProxies([]*net.IPNet{proxy})
+	defer InitTrustedProxies(nil)
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		want         string
+	}{
+		{name: "without proxy", remoteAddr: "203.0.113.1:1234", want: "203.0.113.1"},
+		{name: "with forwarded for from untrusted peer", remoteAddr: "203.0.113.1:1234",
+			forwardedFor: "198.51.100.7", want: "203.0.113.1"},
+		{name: "with forwarded for from trusted proxy", remoteAddr: "10.0.0.2:1234",
+			forwardedFor: "198.51.100.7", want: "198.51.100.7"},
+		{name: "with spoofed entry before the one of the proxy", remoteAddr: "10.0.0.2:1234",
+			forwardedFor: "192.0.2.9, 198.51.100.7", want: "198.51.100.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/login", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set(echo.HeaderXForwardedFor, tt.forwardedFor)
+			}
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+			if got := ClientIp(c); got != tt.want {
+				t.Errorf("ClientIp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo"
+	message "github.com/williamchang80/sea-apd/common/constants/response"
+	"github.com/williamchang80/sea-apd/common/logger"
+	"github.com/williamchang80/sea-apd/common/validation"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+)
+
+// statusCodes is the http status of every error kind
+var statusCodes = map[apperror.Kind]int{
+	apperror.INTERNAL:             http.StatusInternalServerError,
+	apperror.VALIDATION:           http.StatusBadRequest,
+	apperror.UNAUTHENTICATED:      http.StatusUnauthorized,
+	apperror.FORBIDDEN:            http.StatusForbidden,
+	apperror.NOT_FOUND:            http.StatusNotFound,
+	apperror.CONFLICT:             http.StatusConflict,
+	apperror.INVALID_TRANSITION:   http.StatusConflict,
+	apperror.INSUFFICIENT_BALANCE: http.StatusUnprocessableEntity,
+	apperror.TOO_MANY_REQUESTS:    http.StatusTooManyRequests,
+}
+
+// StatusCode returns the http status the error is answered with
+func StatusCode(err error) int {
+	if _, ok := err.(validation.Errors); ok {
+		return http.StatusUnprocessableEntity
+	}
+	if e, ok := err.(*echo.HTTPError); ok {
+		return e.Code
+	}
+	return statusCodes[apperror.From(err).Kind]
+}
+
+// errorCode returns the code of the first kind answered with the status, so errors of
+// echo itself like unknown routes carry a code as well
+func errorCode(status int) string {
+	for kind := apperror.INTERNAL; kind <= apperror.TOO_MANY_REQUESTS; kind++ {
+		if statusCodes[kind] == status {
+			return apperror.ToString(kind)
+		}
+	}
+	return ""
+}
+
+// ErrorHandler writes the errors returned by handlers in the BaseResponse envelope.
+// Failed validations become a 422 listing every failed field, domain errors get the
+// status of their kind and internal errors are logged instead of shown.
+func ErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+	code := StatusCode(err)
+	var body interface{}
+	switch e := err.(type) {
+	case validation.Errors:
+		body = base.ValidationErrorResponse{
+			BaseResponse: base.BaseResponse{
+				Code:    code,
+				Message: message.UNPROCESSABLE_ENTITY,
+				Error:   apperror.ToString(apperror.VALIDATION),
+			},
+			Errors: e,
+		}
+	case *echo.HTTPError:
+		body = base.BaseResponse{Code: code, Message: fmt.Sprint(e.Message), Error: errorCode(code)}
+	default:
+		appErr := apperror.From(err)
+		if appErr.Kind == apperror.INTERNAL {
+			logger.Error(c.Request().Context(), err.Error(), nil)
+		}
+		body = base.BaseResponse{Code: code, Message: appErr.Message, Error: appErr.Code}
+	}
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(code)
+	} else {
+		err = c.JSON(code, body)
+	}
+	if err != nil {
+		logger.Error(c.Request().Context(), err.Error(), nil)
+	}
+}
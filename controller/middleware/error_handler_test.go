@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/common/validation"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/user"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+)
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantError   string
+		wantMessage string
+	}{
+		{
+			name:        "validation",
+			err:         apperror.Validation("name cannot be empty"),
+			wantStatus:  http.StatusBadRequest,
+			wantError:   "validation_failed",
+			wantMessage: "name cannot be empty",
+		},
+		{
+			name:        "field validation",
+			err:         validation.Errors{{Field: "name", Reason: "is required"}},
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "validation_failed",
+			wantMessage: "validation error",
+		},
+		{
+			name:        "not found",
+			err:         apperror.NotFound("product not found"),
+			wantStatus:  http.StatusNotFound,
+			wantError:   "not_found",
+			wantMessage: "product not found",
+		},
+		{
+			name:        "record not found",
+			err:         gorm.ErrRecordNotFound,
+			wantStatus:  http.StatusNotFound,
+			wantError:   "not_found",
+			wantMessage: "record not found",
+		},
+		{
+			name:        "error with own code",
+			err:         user.ErrUserBanned,
+			wantStatus:  http.StatusForbidden,
+			wantError:   "user_banned",
+			wantMessage: "user is banned",
+		},
+		{
+			name:        "invalid transition",
+			err:         apperror.InvalidTransition("transaction is already paid"),
+			wantStatus:  http.StatusConflict,
+			wantError:   "invalid_transition",
+			wantMessage: "transaction is already paid",
+		},
+		{
+			name:        "insufficient balance",
+			err:         apperror.InsufficientBalance("balance is not enough"),
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "insufficient_balance",
+			wantMessage: "balance is not enough",
+		},
+		{
+			name:        "echo error",
+			err:         echo.ErrNotFound,
+			wantStatus:  http.StatusNotFound,
+			wantError:   "not_found",
+			wantMessage: "Not Found",
+		},
+		{
+			name:        "untyped error is hidden",
+			err:         errors.New("pq: connection refused"),
+			wantStatus:  http.StatusInternalServerError,
+			wantError:   "internal",
+			wantMessage: "internal server error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(echo.GET, "/", nil), rec)
+			ErrorHandler(tt.err, c)
+			var got base.BaseResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("ErrorHandler() body = %s", rec.Body.String())
+			}
+			if rec.Code != tt.wantStatus || got.Code != tt.wantStatus {
+				t.Errorf("ErrorHandler() status = %d, code = %d, want %d", rec.Code, got.Code, tt.wantStatus)
+			}
+			if got.Error != tt.wantError || got.Message != tt.wantMessage {
+				t.Errorf("ErrorHandler() = %+v, want error %v message %v", got, tt.wantError, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/common/metrics"
+)
+
+// unmatchedRoute labels the requests no route matched, echo leaves their raw path as the
+// route which would make a series of every path
+const unmatchedRoute = "unmatched"
+
+// Metrics counts the requests and their latency per route. Errors are written by the
+// error handler first so their status is the one counted.
+func Metrics(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+		if err != nil {
+			c.Error(err)
+		}
+		route := matchedRoute(c, err)
+		method := c.Request().Method
+		metrics.HttpRequests.WithLabelValues(method, route, strconv.Itoa(c.Response().Status)).Inc()
+		metrics.HttpRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+		return nil
+	}
+}
+
+// matchedRoute returns the route that served the request, or unmatchedRoute
+func matchedRoute(c echo.Context, err error) string {
+	if c.Path() == "" || err == echo.ErrNotFound || err == echo.ErrMethodNotAllowed {
+		return unmatchedRoute
+	}
+	return c.Path()
+}
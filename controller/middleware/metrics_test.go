@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/williamchang80/sea-apd/common/metrics"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+)
+
+func TestMetrics(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		handlerErr error
+		wantRoute  string
+		wantStatus string
+	}{
+		{
+			name:       "success counted per route",
+			path:       "/api/product/1",
+			wantRoute:  "/api/product/:id",
+			wantStatus: "200",
+		},
+		{
+			name:       "failed handler counted with the status of its error",
+			path:       "/api/product/2",
+			handlerErr: apperror.NotFound("product not found"),
+			wantRoute:  "/api/product/:id",
+			wantStatus: "404",
+		},
+		{
+			name:       "unmatched path counted as one route",
+			path:       "/api/unknown",
+			wantRoute:  unmatchedRoute,
+			wantStatus: "404",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = ErrorHandler
+			e.Use(Metrics)
+			e.GET("/api/product/:id", func(c echo.Context) error {
+				if tt.handlerErr != nil {
+					return tt.handlerErr
+				}
+				return c.NoContent(http.StatusOK)
+			})
+			counter := metrics.HttpRequests.WithLabelValues(echo.GET, tt.wantRoute, tt.wantStatus)
+			before := testutil.ToFloat64(counter)
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(echo.GET, tt.path, nil))
+
+			if got := testutil.ToFloat64(counter) - before; got != 1 {
+				t.Errorf("Metrics() counted %v requests of route %s and status %s, want 1",
+					got, tt.wantRoute, tt.wantStatus)
+			}
+		})
+	}
+}
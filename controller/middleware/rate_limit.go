@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/common/auth"
+	message "github.com/williamchang80/sea-apd/common/constants/response"
+	"github.com/williamchang80/sea-apd/common/logger"
+	"github.com/williamchang80/sea-apd/common/throttle"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+)
+
+// DefaultRoutePolicy is the key of the policy of the routes without one of their own
+const DefaultRoutePolicy = "*"
+
+// RateLimitConfig sets the policies of the routes, keyed like "POST /api/transaction"
+type RateLimitConfig struct {
+	Store    throttle.Store
+	Policies map[string]throttle.Policy
+	// TrustedProxies may set X-Forwarded-For, the requests of other peers are keyed by
+	// the address they come from
+	TrustedProxies []*net.IPNet
+}
+
+// RateLimit gives every user, or every ip for requests without a valid token, a token
+// bucket per route policy. The responses tell the state of the bucket in the RateLimit
+// headers and refused requests when to retry.
+func RateLimit(config RateLimitConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			route := c.Request().Method + " " + c.Path()
+			policy, exist := config.Policies[route]
+			if !exist {
+				route = DefaultRoutePolicy
+				if policy, exist = config.Policies[route]; !exist {
+					return next(c)
+				}
+			}
+			ctx := c.Request().Context()
+			result, err := config.Store.Take(ctx, route+"|"+clientKey(c, config.TrustedProxies), policy)
+			if err != nil {
+				// a failing store lets the requests through rather than taking the app down
+				logger.Error(ctx, "rate limit store failed", logger.Fields{"error": err.Error()})
+				return next(c)
+			}
+			header := c.Response().Header()
+			header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			header.Set("RateLimit-Reset", seconds(result.Reset))
+			header.Set("RateLimit-Policy", strconv.Itoa(policy.Limit)+";w="+seconds(policy.Period))
+			if !result.Allowed {
+				header.Set("Retry-After", seconds(result.RetryAfter))
+				return apperror.TooManyRequests(message.TOO_MANY_REQUESTS)
+			}
+			return next(c)
+		}
+	}
+}
+
+// seconds rounds d up to whole seconds as the headers count in seconds
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// clientKey names the user of the token of the request, or its ip
+func clientKey(c echo.Context, trustedProxies []*net.IPNet) string {
+	if claims, err := auth.ParseToken(c.Request().Header.Get(echo.HeaderAuthorization)); err == nil {
+		if userId, _ := claims["user_id"].(string); userId != "" {
+			return "user:" + userId
+		}
+	}
+	return "ip:" + clientIp(c.Request(), trustedProxies)
+}
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/common/auth"
+	"github.com/williamchang80/sea-apd/common/throttle"
+	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/user"
+)
+
+type rateLimitedRequest struct {
+	method        string
+	remoteAddr    string
+	forwardedFor  string
+	authorization string
+	wantCode      int
+	wantRemaining string
+}
+
+func TestRateLimit(t *testing.T) {
+	os.Setenv("SECRET_AUTH_KEY", "rate-limit-test")
+	token, err := auth.GenerateToken(&user.User{Base: domain.Base{ID: "user-1"}, Role: "customer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, proxies, _ := net.ParseCIDR("172.16.0.0/12")
+	policies := map[string]throttle.Policy{
+		DefaultRoutePolicy:      {Limit: 3, Period: time.Minute},
+		"POST /api/transaction": {Limit: 2, Period: time.Minute},
+	}
+	tests := []struct {
+		name     string
+		requests []rateLimitedRequest
+	}{
+		{
+			name: "client is refused once its bucket of the route is empty",
+			requests: []rateLimitedRequest{
+				{method: echo.POST, remoteAddr: "203.0.113.1:1000", wantCode: http.StatusOK, wantRemaining: "1"},
+				{method: echo.POST, remoteAddr: "203.0.113.1:1001", wantCode: http.StatusOK, wantRemaining: "0"},
+				{method: echo.POST, remoteAddr: "203.0.113.1:1002", wantCode: http.StatusTooManyRequests, wantRemaining: "0"},
+				{method: echo.POST, remoteAddr: "203.0.113.2:1000", wantCode: http.StatusOK, wantRemaining: "1"},
+			},
+		},
+		{
+			name: "routes without a policy share the default one",
+			requests: []rateLimitedRequest{
+				{method: echo.GET, remoteAddr: "203.0.113.1:1000", wantCode: http.StatusOK, wantRemaining: "2"},
+				{method: echo.GET, remoteAddr: "203.0.113.1:1000", wantCode: http.StatusOK, wantRemaining: "1"},
+			},
+		},
+		{
+			name: "forwarded address of a trusted proxy is the client",
+			requests: []rateLimitedRequest{
+				{method: echo.POST, remoteAddr: "172.18.0.5:1000", forwardedFor: "198.51.100.1", wantCode: http.StatusOK, wantRemaining: "1"},
+				{method: echo.POST, remoteAddr: "172.18.0.5:1000", forwardedFor: "198.51.100.2", wantCode: http.StatusOK, wantRemaining: "1"},
+				{method: echo.POST, remoteAddr: "172.18.0.5:1000", forwardedFor: "198.51.100.1", wantCode: http.StatusOK, wantRemaining: "0"},
+			},
+		},
+		{
+			name: "forwarded address of any other peer is ignored",
+			requests: []rateLimitedRequest{
+				{method: echo.POST, remoteAddr: "203.0.113.1:1000", forwardedFor: "198.51.100.1", wantCode: http.StatusOK, wantRemaining: "1"},
+				{method: echo.POST, remoteAddr: "203.0.113.1:1000", forwardedFor: "198.51.100.2", wantCode: http.StatusOK, wantRemaining: "0"},
+			},
+		},
+		{
+			name: "user of a valid token is the client on any address",
+			requests: []rateLimitedRequest{
+				{method: echo.POST, remoteAddr: "203.0.113.1:1000", authorization: "Bearer " + token, wantCode: http.StatusOK, wantRemaining: "1"},
+				{method: echo.POST, remoteAddr: "203.0.113.2:1000", authorization: "Bearer " + token, wantCode: http.StatusOK, wantRemaining: "0"},
+				{method: echo.POST, remoteAddr: "203.0.113.1:1000", wantCode: http.StatusOK, wantRemaining: "1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = ErrorHandler
+			e.Use(RateLimit(RateLimitConfig{
+				Store:          throttle.NewMemoryStore(),
+				Policies:       policies,
+				TrustedProxies: []*net.IPNet{proxies},
+			}))
+			handler := func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}
+			e.GET("/api/products", handler)
+			e.POST("/api/transaction", handler)
+			for i, r := range tt.requests {
+				path := "/api/transaction"
+				if r.method == echo.GET {
+					path = "/api/products"
+				}
+				req := httptest.NewRequest(r.method, path, nil)
+				req.RemoteAddr = r.remoteAddr
+				if r.forwardedFor != "" {
+					req.Header.Set(echo.HeaderXForwardedFor, r.forwardedFor)
+				}
+				if r.authorization != "" {
+					req.Header.Set(echo.HeaderAuthorization, r.authorization)
+				}
+				rec := httptest.NewRecorder()
+				e.ServeHTTP(rec, req)
+				if rec.Code != r.wantCode || rec.Header().Get("RateLimit-Remaining") != r.wantRemaining {
+					t.Errorf("RateLimit() request %v code = %v remaining %v, want %v remaining %v", i, rec.Code,
+						rec.Header().Get("RateLimit-Remaining"), r.wantCode, r.wantRemaining)
+				}
+				retryAfter := rec.Header().Get("Retry-After")
+				if (r.wantCode == http.StatusTooManyRequests) != (retryAfter == "30") {
+					t.Errorf("RateLimit() request %v Retry-After = %q", i, retryAfter)
+				}
+			}
+		})
+	}
+}
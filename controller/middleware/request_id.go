@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"github.com/labstack/echo"
+	uuid "github.com/satori/go.uuid"
+	"github.com/williamchang80/sea-apd/common/logger"
+)
+
+// maxRequestIdLength keeps clients from filling the logs through the header
+const maxRequestIdLength = 128
+
+// RequestId keeps the X-Request-ID nginx sets or creates one, the id is sent back and
+// carried by every log line of the request
+func RequestId(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		requestId := c.Request().Header.Get(echo.HeaderXRequestID)
+		if requestId == "" || len(requestId) > maxRequestIdLength {
+			requestId = uuid.NewV4().String()
+		}
+		c.Response().Header().Set(echo.HeaderXRequestID, requestId)
+		c.SetRequest(c.Request().WithContext(logger.WithRequestId(c.Request().Context(), requestId)))
+		return next(c)
+	}
+}
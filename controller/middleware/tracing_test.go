@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func TestTracing(t *testing.T) {
+	const traceId = "4bf92f3577b34da6a3ce929d0e0e4736"
+	const parentId = "00f067aa0ba902b7"
+	tests := []struct {
+		name        string
+		traceparent string
+		handlerErr  error
+		wantName    string
+		wantStatus  int
+		wantCode    codes.Code
+	}{
+		{
+			name:        "success continues the trace of the traceparent header",
+			traceparent: "00-" + traceId + "-" + parentId + "-01",
+			wantName:    "GET /api/product/:id",
+			wantStatus:  http.StatusOK,
+			wantCode:    codes.Unset,
+		},
+		{
+			name:       "client error leaves the span unset",
+			handlerErr: apperror.NotFound("product not found"),
+			wantName:   "GET /api/product/:id",
+			wantStatus: http.StatusNotFound,
+			wantCode:   codes.Unset,
+		},
+		{
+			name:       "internal error fails the span",
+			handlerErr: errors.New("pq: connection refused"),
+			wantName:   "GET /api/product/:id",
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   codes.Error,
+		},
+	}
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			otel.SetTracerProvider(provider)
+			defer provider.Shutdown(context.Background())
+
+			e := echo.New()
+			e.HTTPErrorHandler = ErrorHandler
+			e.Use(Tracing)
+			e.GET("/api/product/:id", func(c echo.Context) error {
+				if tt.handlerErr != nil {
+					return tt.handlerErr
+				}
+				return c.NoContent(http.StatusOK)
+			})
+			req := httptest.NewRequest(echo.GET, "/api/product/1", nil)
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("Tracing() ended %v spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name() != tt.wantName || span.Status().Code != tt.wantCode {
+				t.Errorf("Tracing() span = %v with status %v, want %v with status %v",
+					span.Name(), span.Status().Code, tt.wantName, tt.wantCode)
+			}
+			var status int64
+			for _, attr := range span.Attributes() {
+				if attr.Key == semconv.HTTPStatusCodeKey {
+					status = attr.Value.AsInt64()
+				}
+			}
+			if status != int64(tt.wantStatus) {
+				t.Errorf("Tracing() status = %v, want %v", status, tt.wantStatus)
+			}
+			if tt.traceparent != "" && (span.SpanContext().TraceID().String() != traceId ||
+				span.Parent().SpanID().String() != parentId) {
+				t.Errorf("Tracing() trace = %v under %v, want %v under %v", span.SpanContext().TraceID(),
+					span.Parent().SpanID(), traceId, parentId)
+			}
+		})
+	}
+}
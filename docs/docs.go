@@ -0,0 +1,65 @@
+package docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/williamchang80/sea-apd/common/openapi"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+)
+
+// swaggerUi loads swagger ui from its cdn, nginx serves the docs under /docs
+const swaggerUi = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>sea-apd api</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5.9.0/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5.9.0/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "/docs/openapi.json", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`
+
+// Spec describes the api of the controllers, try it out sends the requests to
+// APP_BASE_URL, or to the host of the docs when it is not set
+func Spec() openapi.Spec {
+	return openapi.Spec{
+		Title:       "sea-apd",
+		Version:     "1.0",
+		Description: "Marketplace api of sea-apd. Failed requests carry the machine readable error code.",
+		ServerUrl:   os.Getenv("APP_BASE_URL"),
+		Error:       base.BaseResponse{},
+		Validation:  base.ValidationErrorResponse{},
+		Operations:  operations,
+	}
+}
+
+// Handler serves swagger ui on /docs and the document on /docs/openapi.json
+func Handler() http.Handler {
+	document, err := json.MarshalIndent(Spec().Document(), "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/docs/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(document)
+	})
+	mux.HandleFunc("/docs/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/docs/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(swaggerUi))
+	})
+	mux.Handle("/", http.RedirectHandler("/docs/", http.StatusFound))
+	return mux
+}
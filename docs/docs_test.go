@@ -0,0 +1,233 @@
+package docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo"
+	middleware2 "github.com/labstack/echo/middleware"
+	auth2 "github.com/williamchang80/sea-apd/common/auth"
+	"github.com/williamchang80/sea-apd/common/constants/user_role"
+	"github.com/williamchang80/sea-apd/common/openapi"
+	"github.com/williamchang80/sea-apd/controller/http/address"
+	"github.com/williamchang80/sea-apd/controller/http/analytics"
+	"github.com/williamchang80/sea-apd/controller/http/auth"
+	"github.com/williamchang80/sea-apd/controller/http/backoffice"
+	"github.com/williamchang80/sea-apd/controller/http/bank_account"
+	"github.com/williamchang80/sea-apd/controller/http/category"
+	"github.com/williamchang80/sea-apd/controller/http/merchant"
+	"github.com/williamchang80/sea-apd/controller/http/product"
+	"github.com/williamchang80/sea-apd/controller/http/shipping"
+	"github.com/williamchang80/sea-apd/controller/http/transaction"
+	"github.com/williamchang80/sea-apd/controller/http/transfer"
+	"github.com/williamchang80/sea-apd/controller/http/user"
+	"github.com/williamchang80/sea-apd/controller/middleware"
+	"github.com/williamchang80/sea-apd/domain"
+	user2 "github.com/williamchang80/sea-apd/domain/user"
+)
+
+// newServer registers every controller, the controllers only use their usecases once a
+// request gets past the middlewares and their nil usecases panic into a 500 then
+func newServer() *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.ErrorHandler
+	e.Use(middleware2.Recover())
+	address.NewAddressController(e, nil)
+	analytics.NewAnalyticsController(e, nil)
+	auth.NewAuthController(e, nil)
+	backoffice.NewBackofficeController(e, nil)
+	bank_account.NewBankAccountController(e, nil)
+	category.NewCategoryController(e, nil)
+	merchant.NewMerchantController(e, nil)
+	product.NewProductController(e, nil)
+	shipping.NewShippingController(e, nil)
+	transaction.NewTransactionController(e, nil)
+	transfer.NewTransferController(e, nil)
+	user.NewUserController(e, nil)
+	user.NewAdminController(e, nil)
+	return e
+}
+
+// registeredRoutes returns the routes the controllers register
+func registeredRoutes() map[string]bool {
+	routes := map[string]bool{}
+	for _, r := range newServer().Routes() {
+		// the middlewares of groups are registered as routes of every method
+		if strings.Contains(r.Name, "(*Group).Use") {
+			continue
+		}
+		routes[r.Method+" "+openapi.NormalizePath(r.Path)] = true
+	}
+	return routes
+}
+
+func TestOperations(t *testing.T) {
+	routes := registeredRoutes()
+	documented := map[string]bool{}
+	for _, op := range operations {
+		route := op.Method + " " + op.Path
+		if documented[route] {
+			t.Errorf("operations documents %v twice", route)
+		}
+		documented[route] = true
+		if !routes[route] {
+			t.Errorf("operations documents %v, which no controller registers", route)
+		}
+		if op.Summary == "" || op.Tag == "" || (op.Response == nil && len(op.Download) == 0) {
+			t.Errorf("operations lacks the summary, tag or response of %v", route)
+		}
+	}
+	for route := range routes {
+		if !documented[route] {
+			t.Errorf("route %v is not documented in operations", route)
+		}
+	}
+}
+
+// TestAdminOperations calls every admin route without a token and with the token of a
+// customer, neither may get through
+func TestAdminOperations(t *testing.T) {
+	e := newServer()
+	customerToken, err := auth2.GenerateToken(&user2.User{Base: domain.Base{ID: "customer"},
+		Role: user_role.ToString(user_role.CUSTOMER)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// login tokens have to outlive the requests that follow the login, expiry is checked
+	// in whole seconds
+	time.Sleep(2100 * time.Millisecond)
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "without token", authorization: "", wantStatus: http.StatusUnauthorized},
+		{name: "with customer token", authorization: "Bearer " + customerToken, wantStatus: http.StatusForbidden},
+	}
+	for _, op := range operations {
+		if strings.HasPrefix(op.Path, "/api/admin/") && !op.Admin {
+			t.Errorf("operations does not mark %v %v as admin", op.Method, op.Path)
+		}
+		if !op.Admin {
+			continue
+		}
+		for _, tt := range tests {
+			t.Run(op.Method+" "+op.Path+" "+tt.name, func(t *testing.T) {
+				req := httptest.NewRequest(op.Method, op.Path, nil)
+				if tt.authorization != "" {
+					req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+				}
+				rec := httptest.NewRecorder()
+				e.ServeHTTP(rec, req)
+				if rec.Code != tt.wantStatus {
+					t.Errorf("%v %v status = %v, want %v", op.Method, op.Path, rec.Code, tt.wantStatus)
+				}
+			})
+		}
+	}
+}
+
+// TestAuthenticatedOperations calls every route of a user without a token and with a token
+// that is not valid, neither may get through
+func TestAuthenticatedOperations(t *testing.T) {
+	e := newServer()
+	for _, op := range operations {
+		if !op.Authenticated {
+			continue
+		}
+		for _, authorization := range []string{"", "Bearer malformed"} {
+			t.Run(op.Method+" "+op.Path+" "+authorization, func(t *testing.T) {
+				req := httptest.NewRequest(op.Method, op.Path, nil)
+				if authorization != "" {
+					req.Header.Set(echo.HeaderAuthorization, authorization)
+				}
+				rec := httptest.NewRecorder()
+				e.ServeHTTP(rec, req)
+				if rec.Code != http.StatusUnauthorized {
+					t.Errorf("%v %v status = %v, want %v", op.Method, op.Path, rec.Code, http.StatusUnauthorized)
+				}
+			})
+		}
+	}
+}
+
+// refs collects the $ref values of the document
+func refs(value interface{}, found map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				found[ref] = true
+			}
+			refs(child, found)
+		}
+	case []interface{}:
+		for _, child := range v {
+			refs(child, found)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		wantCode        int
+		wantContentType string
+	}{
+		{
+			name:            "swagger ui",
+			path:            "/docs/",
+			wantCode:        http.StatusOK,
+			wantContentType: "text/html; charset=utf-8",
+		},
+		{
+			name:            "openapi document",
+			path:            "/docs/openapi.json",
+			wantCode:        http.StatusOK,
+			wantContentType: "application/json",
+		},
+		{
+			name:     "docs without slash",
+			path:     "/docs",
+			wantCode: http.StatusMovedPermanently,
+		},
+		{
+			name:     "root of the docs port",
+			path:     "/",
+			wantCode: http.StatusFound,
+		},
+	}
+	handler := Handler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.wantCode {
+				t.Errorf("Handler() code = %v, want %v", rec.Code, tt.wantCode)
+			}
+			if tt.wantContentType != "" && rec.Header().Get("Content-Type") != tt.wantContentType {
+				t.Errorf("Handler() content type = %v, want %v", rec.Header().Get("Content-Type"), tt.wantContentType)
+			}
+			if tt.wantContentType != "application/json" {
+				return
+			}
+			var document map[string]interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &document); err != nil {
+				t.Fatalf("Handler() document is not json: %v", err)
+			}
+			schemas := document["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+			found := map[string]bool{}
+			refs(document, found)
+			for ref := range found {
+				if schemas[strings.TrimPrefix(ref, "#/components/schemas/")] == nil {
+					t.Errorf("Handler() document refers to the missing schema %v", ref)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,831 @@
+package docs
+
+import (
+	"net/http"
+
+	"github.com/williamchang80/sea-apd/common/openapi"
+	address_request "github.com/williamchang80/sea-apd/dto/request/address"
+	admin_request "github.com/williamchang80/sea-apd/dto/request/admin"
+	analytics_request "github.com/williamchang80/sea-apd/dto/request/analytics"
+	auth_request "github.com/williamchang80/sea-apd/dto/request/auth"
+	backoffice_request "github.com/williamchang80/sea-apd/dto/request/backoffice"
+	bank_account_request "github.com/williamchang80/sea-apd/dto/request/bank_account"
+	category_request "github.com/williamchang80/sea-apd/dto/request/category"
+	merchant_request "github.com/williamchang80/sea-apd/dto/request/merchant"
+	product_request "github.com/williamchang80/sea-apd/dto/request/product"
+	shipping_request "github.com/williamchang80/sea-apd/dto/request/shipping"
+	transaction_request "github.com/williamchang80/sea-apd/dto/request/transaction"
+	transfer_request "github.com/williamchang80/sea-apd/dto/request/transfer"
+	user_request "github.com/williamchang80/sea-apd/dto/request/user"
+	address_response "github.com/williamchang80/sea-apd/dto/response/address"
+	admin_response "github.com/williamchang80/sea-apd/dto/response/admin"
+	analytics_response "github.com/williamchang80/sea-apd/dto/response/analytics"
+	auth_response "github.com/williamchang80/sea-apd/dto/response/auth"
+	backoffice_response "github.com/williamchang80/sea-apd/dto/response/backoffice"
+	bank_account_response "github.com/williamchang80/sea-apd/dto/response/bank_account"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+	category_response "github.com/williamchang80/sea-apd/dto/response/category"
+	merchant_response "github.com/williamchang80/sea-apd/dto/response/merchant"
+	product_response "github.com/williamchang80/sea-apd/dto/response/product"
+	shipping_response "github.com/williamchang80/sea-apd/dto/response/shipping"
+	transaction_response "github.com/williamchang80/sea-apd/dto/response/transaction"
+	transfer_response "github.com/williamchang80/sea-apd/dto/response/transfer"
+)
+
+// operations documents every route the controllers register, TestOperations fails when
+// a route is added or removed without its entry here
+var operations = []openapi.Operation{
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/auth/2fa/confirm",
+		Tag:      "auth",
+		Summary:  "Confirm the two factor enrolment",
+		Request:  auth_request.TwoFactorRequest{},
+		Response: auth_response.RecoveryCodesResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/auth/2fa/disable",
+		Tag:      "auth",
+		Summary:  "Disable two factor",
+		Request:  auth_request.TwoFactorRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/auth/2fa/enroll",
+		Tag:      "auth",
+		Summary:  "Start the two factor enrolment",
+		Request:  auth_request.LoginRequest{},
+		Response: auth_response.EnrollTwoFactorResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/auth/2fa/recovery-codes",
+		Tag:      "auth",
+		Summary:  "Regenerate recovery codes",
+		Request:  auth_request.TwoFactorRequest{},
+		Response: auth_response.RecoveryCodesResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/auth/login",
+		Tag:      "auth",
+		Summary:  "Log in",
+		Request:  auth_request.LoginRequest{},
+		Response: auth_response.LoginResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/auth/login/2fa",
+		Tag:      "auth",
+		Summary:  "Log in with a two factor code",
+		Request:  auth_request.TwoFactorLoginRequest{},
+		Response: auth_response.LoginResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/auth/password/forgot",
+		Tag:      "auth",
+		Summary:  "Send a password reset mail",
+		Request:  auth_request.ForgotPasswordRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/auth/password/reset",
+		Tag:      "auth",
+		Summary:  "Reset password",
+		Request:  auth_request.ResetPasswordRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/auth/register",
+		Tag:      "user",
+		Summary:  "Register a user",
+		Request:  auth_request.RegisterUserRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/auth/verify-email",
+		Tag:      "user",
+		Summary:  "Verify email",
+		Query:    []string{"token"},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/auth/verify-email/resend",
+		Tag:      "user",
+		Summary:  "Resend the verification mail",
+		Request:  user_request.ResendVerificationRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/user",
+		Tag:      "user",
+		Summary:  "Update user",
+		Request:  user_request.UpdateUserRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/admin/2fa-policy",
+		Tag:      "admin",
+		Summary:  "Get two factor policies",
+		Admin:    true,
+		Response: admin_response.GetTwoFactorPoliciesResponse{},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/admin/2fa-policy",
+		Tag:      "admin",
+		Summary:  "Set two factor policy",
+		Admin:    true,
+		Request:  admin_request.TwoFactorPolicyRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodDelete,
+		Path:     "/api/admin/invitation",
+		Tag:      "admin",
+		Summary:  "Revoke admin invitation",
+		Admin:    true,
+		Query:    []string{"invitationId"},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/admin/invitation",
+		Tag:      "admin",
+		Summary:  "Invite admin",
+		Admin:    true,
+		Request:  admin_request.InviteAdminRequest{},
+		Response: admin_response.InviteAdminResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/admin/invitations",
+		Tag:      "admin",
+		Summary:  "Get admin invitations",
+		Admin:    true,
+		Response: admin_response.GetAdminInvitationsResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/user/admin",
+		Tag:      "admin",
+		Summary:  "Accept admin invitation",
+		Request:  admin_request.Admin{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/admin/merchant/document",
+		Tag:      "merchant",
+		Summary:  "Download a merchant document",
+		Admin:    true,
+		Query:    []string{"documentId"},
+		Download: []string{"application/octet-stream"},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/admin/merchant/reactivate",
+		Tag:      "merchant",
+		Summary:  "Reactivate merchant",
+		Admin:    true,
+		Request:  merchant_request.ReactivateMerchantRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/admin/merchant/suspend",
+		Tag:      "merchant",
+		Summary:  "Suspend merchant",
+		Admin:    true,
+		Request:  merchant_request.SuspendMerchantRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/admin/merchants/review",
+		Tag:      "merchant",
+		Summary:  "Get the merchants waiting for review",
+		Admin:    true,
+		Response: merchant_response.GetReviewQueueResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/merchant",
+		Tag:      "merchant",
+		Summary:  "Get merchant by id",
+		Query:    []string{"merchantId"},
+		Response: merchant_response.GetMerchantByIdResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/merchant",
+		Tag:      "merchant",
+		Summary:  "Register merchant",
+		Request:  merchant_request.MerchantRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/merchant",
+		Tag:      "merchant",
+		Summary:  "Update merchant",
+		Request:  merchant_request.UpdateMerchantRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/merchant/balance",
+		Tag:      "merchant",
+		Summary:  "Get the available and pending balance of a merchant",
+		Query:    []string{"merchantId"},
+		Response: merchant_response.GetMerchantBalanceResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/merchant/close",
+		Tag:      "merchant",
+		Summary:  "Close merchant",
+		Request:  merchant_request.CloseMerchantRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/merchant/document",
+		Tag:      "merchant",
+		Summary:  "Upload merchant document",
+		Request:  merchant_request.MerchantDocumentRequest{},
+		Files:    []string{"document"},
+		Status:   http.StatusCreated,
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/merchant/documents",
+		Tag:      "merchant",
+		Summary:  "Get merchant documents",
+		Query:    []string{"merchantId"},
+		Response: merchant_response.GetMerchantDocumentsResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/merchant/resubmit",
+		Tag:      "merchant",
+		Summary:  "Resubmit merchant",
+		Request:  merchant_request.ResubmitMerchantRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/merchant/reviews",
+		Tag:      "merchant",
+		Summary:  "Get merchant reviews",
+		Query:    []string{"merchantId"},
+		Response: merchant_response.GetMerchantReviewsResponse{},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/merchant/status",
+		Tag:      "merchant",
+		Summary:  "Approve or reject a merchant",
+		Admin:    true,
+		Request:  merchant_request.UpdateMerchantApprovalStatusRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/merchants",
+		Tag:      "merchant",
+		Summary:  "Get merchants",
+		Response: merchant_response.GetMerchantsResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/merchant/products",
+		Tag:      "product",
+		Summary:  "Get products by merchant",
+		Query:    []string{"merchantId"},
+		Response: product_response.GetProductsResponse{},
+	},
+	{
+		Method:   http.MethodDelete,
+		Path:     "/api/product",
+		Tag:      "product",
+		Summary:  "Delete product",
+		Query:    []string{"productId"},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/product",
+		Tag:      "product",
+		Summary:  "Get product by id",
+		Query:    []string{"productId"},
+		Response: product_response.GetProductByIdResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/product",
+		Tag:      "product",
+		Summary:  "Create product",
+		Request:  product_request.ProductRequest{},
+		Files:    []string{"image"},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/product",
+		Tag:      "product",
+		Summary:  "Update product",
+		Query:    []string{"productId"},
+		Request:  product_request.ProductRequest{},
+		Files:    []string{"image"},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/product/options",
+		Tag:      "product",
+		Summary:  "Set product options",
+		Request:  product_request.ProductOptionsRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodDelete,
+		Path:     "/api/product/variant",
+		Tag:      "product",
+		Summary:  "Delete variant",
+		Query:    []string{"variantId"},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/product/variant",
+		Tag:      "product",
+		Summary:  "Create variant",
+		Request:  product_request.VariantRequest{},
+		Status:   http.StatusCreated,
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/product/variant",
+		Tag:      "product",
+		Summary:  "Update variant",
+		Request:  product_request.UpdateVariantRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/products",
+		Tag:      "product",
+		Summary:  "Get products",
+		Response: product_response.GetProductsResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/products/export",
+		Tag:      "product",
+		Summary:  "Export products as csv or jsonl",
+		Query:    []string{"merchantId", "format"},
+		Download: []string{"text/csv", "application/x-ndjson"},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/products/import",
+		Tag:      "product",
+		Summary:  "Get the state of a product import",
+		Query:    []string{"jobId"},
+		Response: product_response.GetImportJobResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/products/import",
+		Tag:      "product",
+		Summary:  "Import products, answers 202 while the import is pending",
+		Request:  product_request.ImportProductsRequest{},
+		Files:    []string{"file"},
+		Response: product_response.GetImportJobResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/products/search",
+		Tag:      "product",
+		Summary:  "Search products",
+		Request:  product_request.ProductSearchRequest{},
+		Response: product_response.GetProductsResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/products/tag",
+		Tag:      "product",
+		Summary:  "Get products by tag",
+		Query:    []string{"tag"},
+		Response: product_response.GetProductsResponse{},
+	},
+	{
+		Method:   http.MethodDelete,
+		Path:     "/api/admin/category",
+		Tag:      "category",
+		Summary:  "Delete category",
+		Admin:    true,
+		Query:    []string{"categoryId"},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/admin/category",
+		Tag:      "category",
+		Summary:  "Create category",
+		Admin:    true,
+		Request:  category_request.CategoryRequest{},
+		Status:   http.StatusCreated,
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/admin/category",
+		Tag:      "category",
+		Summary:  "Update category",
+		Admin:    true,
+		Request:  category_request.UpdateCategoryRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/categories",
+		Tag:      "category",
+		Summary:  "Get category tree",
+		Response: category_response.GetCategoryTreeResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/category/products",
+		Tag:      "category",
+		Summary:  "Get products by category",
+		Query:    []string{"categoryId"},
+		Response: product_response.GetProductsResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/cart",
+		Tag:      "transaction",
+		Summary:  "Get cart",
+		Query:    []string{"customerId"},
+		Response: transaction_response.GetTransactionHistoryResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/cart",
+		Tag:      "transaction",
+		Summary:  "Add a product to the cart",
+		Request:  transaction_request.CartItemRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/cart/checkout",
+		Tag:      "transaction",
+		Summary:  "Check out the cart",
+		Request:  transaction_request.CheckoutRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/cart/shipping",
+		Tag:      "transaction",
+		Summary:  "Quote the shipping of the cart",
+		Query:    []string{"customerId", "transactionId", "addressId"},
+		Response: transaction_response.GetShippingQuoteResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/transaction",
+		Tag:      "transaction",
+		Summary:  "Get transaction by id",
+		Query:    []string{"transactionId"},
+		Response: transaction_response.GetTransactionByIdResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/transaction",
+		Tag:      "transaction",
+		Summary:  "Create transaction",
+		Request:  transaction_request.TransactionRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:        http.MethodPost,
+		Path:          "/api/transaction/delivery",
+		Tag:           "transaction",
+		Summary:       "Confirm the delivery of a shipped transaction of the customer and complete it",
+		Authenticated: true,
+		Request:       transaction_request.ConfirmDeliveryRequest{},
+		Response:      base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/transaction/payment",
+		Tag:      "transaction",
+		Summary:  "Pay transaction",
+		Request:  transaction_request.PaymentRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/transaction/shipment",
+		Tag:      "transaction",
+		Summary:  "Ship a transaction with its courier and tracking number",
+		Request:  transaction_request.ShipmentRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/transaction/status",
+		Tag:      "transaction",
+		Summary:  "Update transaction status",
+		Request:  transaction_request.UpdateTransactionRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/transactions/history",
+		Tag:      "transaction",
+		Summary:  "Get transaction history",
+		Query:    []string{"userId"},
+		Response: transaction_response.GetTransactionHistoryResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/transactions/request",
+		Tag:      "transaction",
+		Summary:  "Get the paid transactions waiting for the merchant",
+		Query:    []string{"merchantId"},
+		Response: transaction_response.GetTransactionHistoryResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/transfer",
+		Tag:      "transfer",
+		Summary:  "Withdraw the merchant balance",
+		Request:  transfer_request.CreateTransferHistoryRequest{},
+		Status:   http.StatusCreated,
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/transfers",
+		Tag:      "transfer",
+		Summary:  "Get the withdrawals of a merchant",
+		Query:    []string{"merchantId"},
+		Response: transfer_response.GetTransferResponse{},
+	},
+	{
+		Method:        http.MethodDelete,
+		Path:          "/api/user/address",
+		Tag:           "address",
+		Summary:       "Delete address",
+		Authenticated: true,
+		Request:       address_request.AddressActionRequest{},
+		Response:      base.BaseResponse{},
+	},
+	{
+		Method:        http.MethodPost,
+		Path:          "/api/user/address",
+		Tag:           "address",
+		Summary:       "Create address",
+		Authenticated: true,
+		Request:       address_request.AddressRequest{},
+		Status:        http.StatusCreated,
+		Response:      base.BaseResponse{},
+	},
+	{
+		Method:        http.MethodPut,
+		Path:          "/api/user/address",
+		Tag:           "address",
+		Summary:       "Update address",
+		Authenticated: true,
+		Request:       address_request.UpdateAddressRequest{},
+		Response:      base.BaseResponse{},
+	},
+	{
+		Method:        http.MethodPut,
+		Path:          "/api/user/address/default",
+		Tag:           "address",
+		Summary:       "Set default address",
+		Authenticated: true,
+		Request:       address_request.AddressActionRequest{},
+		Response:      base.BaseResponse{},
+	},
+	{
+		Method:        http.MethodGet,
+		Path:          "/api/user/addresses",
+		Tag:           "address",
+		Summary:       "Get the address book of a customer",
+		Authenticated: true,
+		Response:      address_response.GetAddressesResponse{},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/admin/bank-account/status",
+		Tag:      "bank_account",
+		Summary:  "Verify bank account",
+		Admin:    true,
+		Request:  bank_account_request.VerifyBankAccountRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/banks",
+		Tag:      "bank_account",
+		Summary:  "Get supported banks",
+		Response: bank_account_response.GetSupportedBanksResponse{},
+	},
+	{
+		Method:   http.MethodDelete,
+		Path:     "/api/merchant/bank-account",
+		Tag:      "bank_account",
+		Summary:  "Delete bank account",
+		Request:  bank_account_request.BankAccountActionRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/merchant/bank-account",
+		Tag:      "bank_account",
+		Summary:  "Create bank account",
+		Request:  bank_account_request.BankAccountRequest{},
+		Status:   http.StatusCreated,
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/merchant/bank-account",
+		Tag:      "bank_account",
+		Summary:  "Update bank account",
+		Request:  bank_account_request.UpdateBankAccountRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/merchant/bank-account/default",
+		Tag:      "bank_account",
+		Summary:  "Set default bank account",
+		Request:  bank_account_request.BankAccountActionRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/merchant/bank-accounts",
+		Tag:      "bank_account",
+		Summary:  "Get bank accounts",
+		Query:    []string{"merchantId"},
+		Response: bank_account_response.GetBankAccountsResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/merchant/shipping-rates",
+		Tag:      "shipping",
+		Summary:  "Get the shipping rate table of a merchant",
+		Query:    []string{"merchantId"},
+		Response: shipping_response.GetShippingRatesResponse{},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/merchant/shipping-rates",
+		Tag:      "shipping",
+		Summary:  "Replace the shipping rate table of a merchant",
+		Request:  shipping_request.UpdateShippingRatesRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/merchant/analytics/conversion",
+		Tag:      "analytics",
+		Summary:  "Get conversion",
+		Request:  analytics_request.AnalyticsRequest{},
+		Response: analytics_response.GetConversionResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/merchant/analytics/sales",
+		Tag:      "analytics",
+		Summary:  "Get sales report",
+		Request:  analytics_request.AnalyticsRequest{},
+		Response: analytics_response.GetSalesReportResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/merchant/analytics/top-products",
+		Tag:      "analytics",
+		Summary:  "Get top products",
+		Request:  analytics_request.AnalyticsRequest{},
+		Response: analytics_response.GetTopProductsResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/admin/audits",
+		Tag:      "backoffice",
+		Summary:  "Get the audit log",
+		Admin:    true,
+		Request:  backoffice_request.AuditSearchRequest{},
+		Response: backoffice_response.GetAuditsResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/admin/kpis",
+		Tag:      "backoffice",
+		Summary:  "Get platform KPIs",
+		Admin:    true,
+		Response: backoffice_response.GetPlatformKpisResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/admin/merchant/balance",
+		Tag:      "backoffice",
+		Summary:  "Adjust merchant balance",
+		Admin:    true,
+		Request:  backoffice_request.AdjustBalanceRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/admin/merchants",
+		Tag:      "backoffice",
+		Summary:  "Search merchants",
+		Admin:    true,
+		Request:  backoffice_request.MerchantSearchRequest{},
+		Response: backoffice_response.SearchMerchantsResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/admin/transaction",
+		Tag:      "backoffice",
+		Summary:  "Get transaction detail",
+		Admin:    true,
+		Query:    []string{"transactionId"},
+		Response: backoffice_response.GetTransactionDetailResponse{},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/admin/transaction/status",
+		Tag:      "backoffice",
+		Summary:  "Force the status of a transaction",
+		Admin:    true,
+		Request:  transaction_request.ForceTransactionStatusRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/admin/transactions",
+		Tag:      "backoffice",
+		Summary:  "Search transactions",
+		Admin:    true,
+		Request:  backoffice_request.TransactionSearchRequest{},
+		Response: backoffice_response.SearchTransactionsResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/admin/user/ban",
+		Tag:      "backoffice",
+		Summary:  "Ban user",
+		Admin:    true,
+		Request:  backoffice_request.BanUserRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/admin/user/unban",
+		Tag:      "backoffice",
+		Summary:  "Unban user",
+		Admin:    true,
+		Request:  backoffice_request.BanUserRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/admin/user/unlock",
+		Tag:      "backoffice",
+		Summary:  "Unlock user",
+		Admin:    true,
+		Request:  backoffice_request.BanUserRequest{},
+		Response: base.BaseResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/admin/users",
+		Tag:      "backoffice",
+		Summary:  "Search users",
+		Admin:    true,
+		Request:  backoffice_request.UserSearchRequest{},
+		Response: backoffice_response.SearchUsersResponse{},
+	},
+}
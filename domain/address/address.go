@@ -0,0 +1,50 @@
+package address
+
+import (
+	"context"
+
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/dto/request/address"
+)
+
+// Address is an entry of the address book of a customer. A checkout ships to the
+// default address unless the customer picks another one.
+type Address struct {
+	domain.Base
+	CustomerId    string `json:"customer_id"`
+	Label         string `json:"label"`
+	RecipientName string `json:"recipient_name"`
+	Phone         string `json:"phone"`
+	Street        string `json:"street"`
+	City          string `json:"city"`
+	Province      string `json:"province"`
+	PostalCode    string `json:"postal_code"`
+	IsDefault     bool   `json:"is_default"`
+}
+
+type AddressRepository interface {
+	CreateAddress(ctx context.Context, address Address) error
+	UpdateAddress(ctx context.Context, addressId string, address Address) error
+	DeleteAddress(ctx context.Context, addressId string) error
+	GetAddressById(ctx context.Context, addressId string) (*Address, error)
+	GetAddressesByCustomer(ctx context.Context, customerId string) ([]Address, error)
+	SetDefaultAddress(ctx context.Context, customerId string, addressId string) error
+}
+
+type AddressUsecase interface {
+	GetAddresses(ctx context.Context, customerId string) ([]Address, error)
+	CreateAddress(ctx context.Context, request address.AddressRequest) error
+	UpdateAddress(ctx context.Context, request address.UpdateAddressRequest) error
+	DeleteAddress(ctx context.Context, request address.AddressActionRequest) error
+	SetDefaultAddress(ctx context.Context, request address.AddressActionRequest) error
+	GetShippingAddress(ctx context.Context, customerId string, addressId string) (*Address, error)
+}
+
+type AddressController interface {
+	GetAddresses(echo.Context) error
+	CreateAddress(echo.Context) error
+	UpdateAddress(echo.Context) error
+	DeleteAddress(echo.Context) error
+	SetDefaultAddress(echo.Context) error
+}
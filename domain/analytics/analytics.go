@@ -0,0 +1,74 @@
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/dto/request/analytics"
+)
+
+// AnalyticsQuery selects the transactions of a merchant created in [From, To)
+type AnalyticsQuery struct {
+	MerchantId string
+	Bucket     string
+	From       time.Time
+	To         time.Time
+}
+
+// SalesBucket sums the accepted transactions created within one day, week or month
+type SalesBucket struct {
+	BucketStart       time.Time `json:"bucket_start"`
+	Revenue           int       `json:"revenue"`
+	Orders            int       `json:"orders"`
+	AverageOrderValue int       `json:"average_order_value"`
+}
+
+type SalesReport struct {
+	Bucket            string        `json:"bucket"`
+	Revenue           int           `json:"revenue"`
+	Orders            int           `json:"orders"`
+	AverageOrderValue int           `json:"average_order_value"`
+	Buckets           []SalesBucket `json:"buckets"`
+}
+
+type ProductSales struct {
+	ProductId string `json:"product_id"`
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity"`
+	Revenue   int    `json:"revenue"`
+}
+
+// Conversion counts the checked out transactions by how far they got. Paid counts the
+// transactions with a payment, Refunded the paid ones that were declined afterwards.
+type Conversion struct {
+	CheckedOut     int     `json:"checked_out"`
+	Paid           int     `json:"paid"`
+	Accepted       int     `json:"accepted"`
+	Refunded       int     `json:"refunded"`
+	ConversionRate float64 `json:"conversion_rate"`
+	RefundRate     float64 `json:"refund_rate"`
+}
+
+// SalesRollup caches one sales bucket of a merchant, see AnalyticsUsecase.RefreshRollups
+type SalesRollup struct {
+	MerchantId  string    `json:"merchant_id" gorm:"primary_key"`
+	Bucket      string    `json:"bucket" gorm:"primary_key"`
+	BucketStart time.Time `json:"bucket_start" gorm:"primary_key"`
+	Revenue     int       `json:"revenue"`
+	Orders      int       `json:"orders"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type AnalyticsUsecase interface {
+	GetSalesReport(ctx context.Context, request analytics.AnalyticsRequest) (*SalesReport, error)
+	GetTopProducts(ctx context.Context, request analytics.AnalyticsRequest) ([]ProductSales, error)
+	GetConversion(ctx context.Context, request analytics.AnalyticsRequest) (*Conversion, error)
+	RefreshRollups(ctx context.Context) error
+}
+
+type AnalyticsController interface {
+	GetSalesReport(echo echo.Context) error
+	GetTopProducts(echo echo.Context) error
+	GetConversion(echo echo.Context) error
+}
@@ -0,0 +1,104 @@
+package apperror
+
+import (
+	"errors"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Kind tells what went wrong, the controllers map every kind to one status code
+type Kind int
+
+const (
+	INTERNAL Kind = iota
+	VALIDATION
+	UNAUTHENTICATED
+	FORBIDDEN
+	NOT_FOUND
+	CONFLICT
+	INVALID_TRANSITION
+	INSUFFICIENT_BALANCE
+	TOO_MANY_REQUESTS
+)
+
+// KindList holds the error code of every kind, errors without a code of their own use it
+var KindList = []string{
+	"internal",
+	"validation_failed",
+	"unauthenticated",
+	"forbidden",
+	"not_found",
+	"conflict",
+	"invalid_transition",
+	"insufficient_balance",
+	"too_many_requests",
+}
+
+func ToString(k Kind) string {
+	if k < INTERNAL || k > TOO_MANY_REQUESTS {
+		return ""
+	}
+	return KindList[k]
+}
+
+// Error is an error usecases and repositories return to tell the client what failed.
+// Code is machine readable, Message is meant for people.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// New creates an error with a code of its own, for errors clients handle specifically
+func New(kind Kind, code string, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func Validation(message string) *Error {
+	return New(VALIDATION, ToString(VALIDATION), message)
+}
+
+func Unauthenticated(message string) *Error {
+	return New(UNAUTHENTICATED, ToString(UNAUTHENTICATED), message)
+}
+
+func Forbidden(message string) *Error {
+	return New(FORBIDDEN, ToString(FORBIDDEN), message)
+}
+
+func NotFound(message string) *Error {
+	return New(NOT_FOUND, ToString(NOT_FOUND), message)
+}
+
+func Conflict(message string) *Error {
+	return New(CONFLICT, ToString(CONFLICT), message)
+}
+
+func InvalidTransition(message string) *Error {
+	return New(INVALID_TRANSITION, ToString(INVALID_TRANSITION), message)
+}
+
+func InsufficientBalance(message string) *Error {
+	return New(INSUFFICIENT_BALANCE, ToString(INSUFFICIENT_BALANCE), message)
+}
+
+func TooManyRequests(message string) *Error {
+	return New(TOO_MANY_REQUESTS, ToString(TOO_MANY_REQUESTS), message)
+}
+
+// From returns err as Error. Missing records are not found, any other untyped error is
+// internal and its message is not shown to the client.
+func From(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	if gorm.IsRecordNotFoundError(err) {
+		return NotFound("record not found")
+	}
+	return New(INTERNAL, ToString(INTERNAL), "internal server error")
+}
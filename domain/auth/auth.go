@@ -1,14 +1,69 @@
 package auth
 
 import (
+	"context"
+	"time"
+
 	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	"github.com/williamchang80/sea-apd/dto/request/auth"
 )
 
+var (
+	ErrTooManyAttempts = apperror.TooManyRequests("too many attempts, try again later")
+	ErrAccountLocked   = apperror.New(apperror.TOO_MANY_REQUESTS, "account_locked", "account is locked after too many failed logins, try again later")
+)
+
+// LoginAttempts are the failed logins of an account or an ip address within the window
+// of the last failure
+type LoginAttempts struct {
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+// LoginAttemptStore keeps track of failed logins by key
+type LoginAttemptStore interface {
+	GetLoginAttempts(key string) (LoginAttempts, error)
+	// AddLoginFailure counts a failure, failures older than window are forgotten first
+	AddLoginFailure(key string, at time.Time, window time.Duration) (LoginAttempts, error)
+	LockLogin(key string, until time.Time) error
+	ResetLoginAttempts(key string) error
+}
+
+// LoginResult carries the access token, or a challenge token when the user still has to
+// enter a two-factor code
+type LoginResult struct {
+	Token          string
+	ChallengeToken string
+}
+
+// TwoFactorEnrolment is the secret an authenticator app is set up with
+type TwoFactorEnrolment struct {
+	Secret string `json:"secret"`
+	Uri    string `json:"uri"`
+}
+
 type AuthController interface {
 	Login(echo.Context) error
+	VerifyTwoFactorLogin(echo.Context) error
+	ForgotPassword(echo.Context) error
+	ResetPassword(echo.Context) error
+	EnrollTwoFactor(echo.Context) error
+	ConfirmTwoFactor(echo.Context) error
+	DisableTwoFactor(echo.Context) error
+	RegenerateRecoveryCodes(echo.Context) error
 }
 
 type AuthUsecase interface {
-	Login(request auth.LoginRequest) (string, error)
+	Login(ctx context.Context, request auth.LoginRequest) (*LoginResult, error)
+	VerifyTwoFactorLogin(ctx context.Context, request auth.TwoFactorLoginRequest) (string, error)
+	ForgotPassword(ctx context.Context, request auth.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, request auth.ResetPasswordRequest) error
+	ValidateSession(ctx context.Context, userId string, issuedAt time.Time) error
+	UnlockAccount(ctx context.Context, email string) error
+	EnrollTwoFactor(ctx context.Context, request auth.LoginRequest) (*TwoFactorEnrolment, error)
+	ConfirmTwoFactor(ctx context.Context, request auth.TwoFactorRequest) ([]string, error)
+	DisableTwoFactor(ctx context.Context, request auth.TwoFactorRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, request auth.TwoFactorRequest) ([]string, error)
 }
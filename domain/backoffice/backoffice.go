@@ -0,0 +1,120 @@
+package backoffice
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/merchant"
+	"github.com/williamchang80/sea-apd/domain/transaction"
+	"github.com/williamchang80/sea-apd/domain/user"
+	"github.com/williamchang80/sea-apd/dto/request/backoffice"
+	request "github.com/williamchang80/sea-apd/dto/request/transaction"
+)
+
+// AdminAudit records one use of the back-office by an admin
+type AdminAudit struct {
+	domain.Base
+	AdminId    string `json:"admin_id"`
+	Action     string `json:"action"`
+	TargetType string `json:"target_type"`
+	TargetId   string `json:"target_id"`
+	Reason     string `json:"reason"`
+	Detail     string `json:"detail"`
+}
+
+// BalanceAdjustment is a manual change of a merchant balance, Memo explains it
+type BalanceAdjustment struct {
+	domain.Base
+	MerchantId string `json:"merchant_id"`
+	AdminId    string `json:"admin_id"`
+	Amount     int    `json:"amount"`
+	Memo       string `json:"memo"`
+}
+
+type UserQuery struct {
+	Keyword string
+	Role    string
+	Banned  *bool
+	Limit   int
+	Offset  int
+}
+
+type MerchantQuery struct {
+	Keyword string
+	Status  string
+	Limit   int
+	Offset  int
+}
+
+type TransactionQuery struct {
+	MerchantId string
+	CustomerId string
+	Status     string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+type AuditQuery struct {
+	AdminId  string
+	TargetId string
+	Limit    int
+	Offset   int
+}
+
+type TransactionDetail struct {
+	Transaction   transaction.Transaction               `json:"transaction"`
+	StatusChanges []transaction.TransactionStatusChange `json:"status_changes"`
+}
+
+// PlatformKpis summarises the whole platform, amounts are in the smallest currency unit
+type PlatformKpis struct {
+	Users                 int            `json:"users"`
+	BannedUsers           int            `json:"banned_users"`
+	MerchantsByStatus     map[string]int `json:"merchants_by_status"`
+	TransactionsByStatus  map[string]int `json:"transactions_by_status"`
+	GrossMerchandiseValue int            `json:"gross_merchandise_value"`
+	MerchantBalances      int            `json:"merchant_balances"`
+}
+
+type BackofficeRepository interface {
+	SearchUsers(ctx context.Context, query UserQuery) ([]user.User, error)
+	SearchMerchants(ctx context.Context, query MerchantQuery) ([]merchant.Merchant, error)
+	SearchTransactions(ctx context.Context, query TransactionQuery) ([]transaction.Transaction, error)
+	SetUserBan(ctx context.Context, userId string, bannedAt *time.Time, reason string, audit AdminAudit) error
+	AdjustMerchantBalance(ctx context.Context, adjustment BalanceAdjustment, audit AdminAudit) error
+	CreateAudit(ctx context.Context, audit AdminAudit) error
+	GetAudits(ctx context.Context, query AuditQuery) ([]AdminAudit, error)
+	GetPlatformKpis(ctx context.Context) (*PlatformKpis, error)
+}
+
+type BackofficeUsecase interface {
+	SearchUsers(ctx context.Context, request backoffice.UserSearchRequest) ([]user.User, error)
+	SearchMerchants(ctx context.Context, request backoffice.MerchantSearchRequest) ([]merchant.Merchant, error)
+	SearchTransactions(ctx context.Context, request backoffice.TransactionSearchRequest) ([]transaction.Transaction, error)
+	GetTransactionDetail(ctx context.Context, adminId string, transactionId string) (*TransactionDetail, error)
+	ForceTransactionStatus(ctx context.Context, request request.ForceTransactionStatusRequest) error
+	BanUser(ctx context.Context, request backoffice.BanUserRequest) error
+	UnbanUser(ctx context.Context, request backoffice.BanUserRequest) error
+	UnlockUser(ctx context.Context, request backoffice.BanUserRequest) error
+	AdjustMerchantBalance(ctx context.Context, request backoffice.AdjustBalanceRequest) error
+	GetPlatformKpis(ctx context.Context, adminId string) (*PlatformKpis, error)
+	GetAudits(ctx context.Context, request backoffice.AuditSearchRequest) ([]AdminAudit, error)
+}
+
+type BackofficeController interface {
+	SearchUsers(echo echo.Context) error
+	SearchMerchants(echo echo.Context) error
+	SearchTransactions(echo echo.Context) error
+	GetTransactionDetail(echo echo.Context) error
+	ForceTransactionStatus(echo echo.Context) error
+	BanUser(echo echo.Context) error
+	UnbanUser(echo echo.Context) error
+	UnlockUser(echo echo.Context) error
+	AdjustMerchantBalance(echo echo.Context) error
+	GetPlatformKpis(echo echo.Context) error
+	GetAudits(echo echo.Context) error
+}
@@ -0,0 +1,52 @@
+package bank_account
+
+import (
+	"context"
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/common/bank"
+	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/dto/request/bank_account"
+)
+
+// BankAccount is a payout destination of a merchant. Withdrawals can only go to
+// accounts an admin has verified, changing the account sends it back to pending.
+type BankAccount struct {
+	domain.Base
+	MerchantId    string `json:"merchant_id"`
+	BankCode      string `json:"bank_code"`
+	AccountNumber string `json:"account_number"`
+	HolderName    string `json:"holder_name"`
+	Status        string `json:"status"`
+	IsDefault     bool   `json:"is_default"`
+}
+
+type BankAccountRepository interface {
+	CreateBankAccount(ctx context.Context, account BankAccount) error
+	UpdateBankAccount(ctx context.Context, accountId string, account BankAccount) error
+	DeleteBankAccount(ctx context.Context, accountId string) error
+	GetBankAccountById(ctx context.Context, accountId string) (*BankAccount, error)
+	GetBankAccountsByMerchant(ctx context.Context, merchantId string) ([]BankAccount, error)
+	SetDefaultBankAccount(ctx context.Context, merchantId string, accountId string) error
+	UpdateBankAccountStatus(ctx context.Context, accountId string, status string) error
+}
+
+type BankAccountUsecase interface {
+	GetSupportedBanks(ctx context.Context) []bank.Bank
+	GetBankAccounts(ctx context.Context, merchantId string) ([]BankAccount, error)
+	CreateBankAccount(ctx context.Context, request bank_account.BankAccountRequest) error
+	UpdateBankAccount(ctx context.Context, request bank_account.UpdateBankAccountRequest) error
+	DeleteBankAccount(ctx context.Context, request bank_account.BankAccountActionRequest) error
+	SetDefaultBankAccount(ctx context.Context, request bank_account.BankAccountActionRequest) error
+	VerifyBankAccount(ctx context.Context, request bank_account.VerifyBankAccountRequest) error
+	GetPayoutAccount(ctx context.Context, merchantId string, accountId string) (*BankAccount, error)
+}
+
+type BankAccountController interface {
+	GetSupportedBanks(echo.Context) error
+	GetBankAccounts(echo.Context) error
+	CreateBankAccount(echo.Context) error
+	UpdateBankAccount(echo.Context) error
+	DeleteBankAccount(echo.Context) error
+	SetDefaultBankAccount(echo.Context) error
+	VerifyBankAccount(echo.Context) error
+}
@@ -0,0 +1,44 @@
+package category
+
+import (
+	"context"
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/product"
+	"github.com/williamchang80/sea-apd/dto/request/category"
+)
+
+// Category is a node of the category tree, its slug is unique among its siblings
+type Category struct {
+	domain.Base
+	Name         string     `json:"name"`
+	Slug         string     `gorm:"unique_index:idx_categories_parent_slug;not null;" json:"slug"`
+	ParentId     *string    `gorm:"unique_index:idx_categories_parent_slug" json:"parent_id"`
+	ProductCount int        `gorm:"-" json:"product_count"`
+	Children     []Category `gorm:"-" json:"children"`
+}
+
+type CategoryRepository interface {
+	CreateCategory(context.Context, Category) error
+	UpdateCategory(ctx context.Context, categoryId string, category Category) error
+	DeleteCategory(ctx context.Context, category Category) error
+	GetCategoryById(ctx context.Context, categoryId string) (*Category, error)
+	GetCategories(ctx context.Context) ([]Category, error)
+	GetProductCountByCategory(ctx context.Context) (map[string]int, error)
+}
+
+type CategoryUsecase interface {
+	CreateCategory(ctx context.Context, request category.CategoryRequest) error
+	UpdateCategory(ctx context.Context, request category.UpdateCategoryRequest) error
+	DeleteCategory(ctx context.Context, categoryId string) error
+	GetCategoryTree(ctx context.Context) ([]Category, error)
+	GetProductsByCategory(ctx context.Context, categoryId string) ([]product.Product, error)
+}
+
+type CategoryController interface {
+	CreateCategory(echo.Context) error
+	UpdateCategory(echo.Context) error
+	DeleteCategory(echo.Context) error
+	GetCategoryTree(echo.Context) error
+	GetProductsByCategory(echo.Context) error
+}
@@ -1,39 +1,107 @@
 package merchant
 
 import (
+	"context"
+	"time"
+
 	"github.com/labstack/echo"
 	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	"github.com/williamchang80/sea-apd/dto/request/merchant"
 )
 
 type Merchant struct {
 	domain.Base
-	Name     string `json:"name"`
-	Balance  int    `json:"balance"`
-	UserId   string `json:"user_id"`
-	Brand    string `json:"brand"`
-	Address  string `json:"address"`
-	Approval string `json:"approval"`
+	Name string `json:"name"`
+	// Balance can be withdrawn, PendingBalance holds the amount of the paid orders until
+	// the customer confirms the delivery or the order completes by itself
+	Balance        int    `json:"balance"`
+	PendingBalance int    `json:"pending_balance"`
+	UserId         string `json:"user_id"`
+	Brand          string `json:"brand"`
+	Address        string `json:"address"`
+	Approval       string `json:"approval"`
+	// DeclineReason tells the merchant why the last review declined the application
+	DeclineReason string `json:"decline_reason"`
+	// SuspensionReason and SuspendedUntil are set while the merchant is suspended, the
+	// suspension is lifted on the first use of the merchant after SuspendedUntil
+	SuspensionReason string     `json:"suspension_reason"`
+	SuspendedUntil   *time.Time `json:"suspended_until"`
+}
+
+// MerchantDocument is a verification document kept in private storage, it is only
+// readable through the admin document endpoint
+type MerchantDocument struct {
+	domain.Base
+	MerchantId  string `json:"merchant_id"`
+	Type        string `json:"type"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Key         string `json:"-"`
 }
 
+// MerchantReview is the audit record of one change of the merchant approval status
+type MerchantReview struct {
+	domain.Base
+	MerchantId string `json:"merchant_id"`
+	ActorId    string `json:"actor_id"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Reason     string `json:"reason"`
+}
+
+// MerchantApplication is a merchant waiting for review together with its documents
+type MerchantApplication struct {
+	Merchant  Merchant           `json:"merchant"`
+	Documents []MerchantDocument `json:"documents"`
+}
+
+var (
+	ErrInvalidStatusTransition = apperror.InvalidTransition("merchant approval status has changed")
+	ErrMerchantSuspended       = apperror.New(apperror.FORBIDDEN, "merchant_suspended", "merchant is suspended")
+	ErrMerchantClosed          = apperror.New(apperror.FORBIDDEN, "merchant_closed", "merchant is closed")
+	ErrPendingBalanceTooLow    = apperror.InsufficientBalance("pending balance of the merchant is less than the amount")
+)
+
 type MerchantRepository interface {
-	UpdateMerchantBalance(amount int, merchantId string) error
-	GetMerchantBalance(merchantId string) (int, error)
-	RegisterMerchant(merchant Merchant) (*Merchant, error)
-	GetMerchants() ([]Merchant, error)
-	GetMerchantById(merchantId string) (*Merchant, error)
-	UpdateMerchantApprovalStatus(merchantId string, status string) error
-	UpdateMerchant(merchantId string, merchant Merchant) error
+	UpdateMerchantBalance(ctx context.Context, amount int, merchantId string) error
+	GetMerchantBalance(ctx context.Context, merchantId string) (int, error)
+	UpdateMerchantPendingBalance(ctx context.Context, amount int, merchantId string) error
+	RegisterMerchant(ctx context.Context, merchant Merchant) (*Merchant, error)
+	GetMerchants(ctx context.Context) ([]Merchant, error)
+	GetMerchantById(ctx context.Context, merchantId string) (*Merchant, error)
+	UpdateMerchantApprovalStatus(ctx context.Context, merchantId string, status string) error
+	UpdateMerchant(ctx context.Context, merchantId string, merchant Merchant) error
+	GetMerchantsByStatus(ctx context.Context, status string) ([]Merchant, error)
+	GetMerchantsByUser(ctx context.Context, userId string) ([]Merchant, error)
+	ChangeMerchantStatus(ctx context.Context, review MerchantReview, changes map[string]interface{}) error
+	GetMerchantReviews(ctx context.Context, merchantId string) ([]MerchantReview, error)
+	CreateMerchantDocument(ctx context.Context, document MerchantDocument) error
+	DeleteMerchantDocument(ctx context.Context, documentId string) error
+	GetMerchantDocumentById(ctx context.Context, documentId string) (*MerchantDocument, error)
+	GetMerchantDocuments(ctx context.Context, merchantIds []string) ([]MerchantDocument, error)
 }
 
 type MerchantUsecase interface {
-	UpdateMerchantBalance(request merchant.UpdateMerchantBalanceRequest) error
-	GetMerchantBalance(merchantId string) (int, error)
-	RegisterMerchant(request merchant.MerchantRequest) error
-	GetMerchants() ([]Merchant, error)
-	GetMerchantById(merchantId string) (*Merchant, error)
-	UpdateMerchantApprovalStatus(request merchant.UpdateMerchantApprovalStatusRequest) error
-	UpdateMerchant(request merchant.UpdateMerchantRequest) error
+	UpdateMerchantBalance(ctx context.Context, request merchant.UpdateMerchantBalanceRequest) error
+	GetMerchantBalance(ctx context.Context, merchantId string) (int, error)
+	GetMerchantPendingBalance(ctx context.Context, merchantId string) (int, error)
+	UpdateMerchantPendingBalance(ctx context.Context, request merchant.UpdateMerchantBalanceRequest) error
+	RegisterMerchant(ctx context.Context, request merchant.MerchantRequest) error
+	GetMerchants(ctx context.Context) ([]Merchant, error)
+	GetMerchantById(ctx context.Context, merchantId string) (*Merchant, error)
+	UpdateMerchantApprovalStatus(ctx context.Context, request merchant.UpdateMerchantApprovalStatusRequest) error
+	UpdateMerchant(ctx context.Context, request merchant.UpdateMerchantRequest) error
+	UploadMerchantDocument(ctx context.Context, request merchant.MerchantDocumentRequest) error
+	GetMerchantDocuments(ctx context.Context, merchantId string) ([]MerchantDocument, error)
+	ReadMerchantDocument(ctx context.Context, documentId string) (*MerchantDocument, []byte, error)
+	GetReviewQueue(ctx context.Context) ([]MerchantApplication, error)
+	ResubmitMerchant(ctx context.Context, request merchant.ResubmitMerchantRequest) error
+	GetMerchantReviews(ctx context.Context, merchantId string) ([]MerchantReview, error)
+	SuspendMerchant(ctx context.Context, request merchant.SuspendMerchantRequest) error
+	ReactivateMerchant(ctx context.Context, request merchant.ReactivateMerchantRequest) error
+	CloseMerchant(ctx context.Context, request merchant.CloseMerchantRequest) error
+	ValidateMerchantActive(ctx context.Context, merchantId string) error
 }
 type MerchantController interface {
 	GetMerchantBalance(echo echo.Context) error
@@ -42,4 +110,13 @@ type MerchantController interface {
 	RegisterMerchant(echo echo.Context) error
 	UpdateMerchantApprovalStatus(echo echo.Context) error
 	UpdateMerchant(echo echo.Context) error
+	UploadMerchantDocument(echo echo.Context) error
+	GetMerchantDocuments(echo echo.Context) error
+	ReadMerchantDocument(echo echo.Context) error
+	GetReviewQueue(echo echo.Context) error
+	ResubmitMerchant(echo echo.Context) error
+	GetMerchantReviews(echo echo.Context) error
+	SuspendMerchant(echo echo.Context) error
+	ReactivateMerchant(echo echo.Context) error
+	CloseMerchant(echo echo.Context) error
 }
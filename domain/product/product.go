@@ -1,39 +1,151 @@
 package product
 
 import (
+	"context"
+	"io"
+
 	"github.com/labstack/echo"
+	"github.com/lib/pq"
 	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	"github.com/williamchang80/sea-apd/domain/transaction"
 	"github.com/williamchang80/sea-apd/dto/request/product"
 )
 
 type Product struct {
 	domain.Base
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Price       int    `json:"price"`
-	Image       string `json:"image"`
-	Stock       int    `json:"stock"`
-	MerchantId  string `json:"merchant_id"`
+	Sku         string           `json:"sku"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Price       int              `json:"price"`
+	Image       string           `json:"image"`
+	Thumbnail   string           `json:"thumbnail"`
+	ImageKey    string           `json:"-"`
+	Stock       int              `json:"stock"`
+	WeightGrams int              `json:"weight_grams"`
+	LengthCm    int              `json:"length_cm"`
+	WidthCm     int              `json:"width_cm"`
+	HeightCm    int              `json:"height_cm"`
+	MerchantId  string           `json:"merchant_id"`
+	CategoryId  *string          `json:"category_id"`
+	Tags        []Tag            `gorm:"many2many:product_tags;" json:"tags"`
+	Options     []ProductOption  `gorm:"foreignkey:ProductId" json:"options"`
+	Variants    []ProductVariant `gorm:"foreignkey:ProductId" json:"variants"`
+}
+
+// ProductOption is a dimension a product varies in, e.g. "Size" with values S, M and L
+type ProductOption struct {
+	domain.Base
+	ProductId string         `json:"product_id"`
+	Name      string         `json:"name"`
+	Position  int            `json:"position"`
+	Values    pq.StringArray `gorm:"type:text[]" json:"values"`
+}
+
+// ProductVariant is a sellable combination of option values. OptionValues are ordered
+// by the Position of the product options. A nil Price falls back to the product price.
+type ProductVariant struct {
+	domain.Base
+	ProductId    string         `json:"product_id"`
+	Sku          string         `gorm:"unique;not null;" json:"sku"`
+	OptionValues pq.StringArray `gorm:"type:text[]" json:"option_values"`
+	Price        *int           `json:"price"`
+	Stock        int            `json:"stock"`
+}
+
+var ErrInsufficientStock = apperror.New(apperror.CONFLICT, "insufficient_stock", "insufficient stock")
+
+type Tag struct {
+	domain.Base
+	Name string `gorm:"unique;not null;" json:"name"`
+}
+
+// ImportJob tracks a catalogue import of one merchant. Dry runs are never stored,
+// their job only carries the validation report.
+type ImportJob struct {
+	domain.Base
+	MerchantId    string           `json:"merchant_id"`
+	Format        string           `json:"format"`
+	DryRun        bool             `json:"dry_run"`
+	Status        string           `json:"status"`
+	TotalRows     int              `json:"total_rows"`
+	ProcessedRows int              `json:"processed_rows"`
+	CreatedRows   int              `json:"created_rows"`
+	UpdatedRows   int              `json:"updated_rows"`
+	FailedRows    int              `json:"failed_rows"`
+	Errors        []ImportRowError `gorm:"foreignkey:JobId" json:"errors"`
+}
+
+// ImportRowError reports why a row of the imported file was rejected. Row 1 is the
+// first data row, the csv header is not counted.
+type ImportRowError struct {
+	domain.Base
+	JobId   string `json:"-"`
+	Row     int    `gorm:"column:row_number" json:"row"`
+	Sku     string `json:"sku"`
+	Message string `json:"message"`
+}
+
+type ProductSearchQuery struct {
+	Keyword    string
+	MinPrice   int
+	MaxPrice   int
+	InStock    bool
+	MerchantId string
+	Sort       string
+	Limit      int
+	Offset     int
+}
+
+// ProductSearcher runs full-text search over the product catalogue
+type ProductSearcher interface {
+	SearchProducts(ctx context.Context, query ProductSearchQuery) ([]Product, error)
 }
 
 type ProductUsecase interface {
-	GetProducts() ([]Product, error)
-	GetProductById(productId string) (*Product, error)
-	CreateProduct(productRequest product.ProductRequest) error
-	UpdateProduct(productId string, productRequest product.ProductRequest) error
-	DeleteProduct(productId string) error
-	GetProductsByMerchant(merchantId string) ([]Product, error)
-	GetProductPriceTotal(transaction transaction.Transaction) (int, error)
+	GetProducts(ctx context.Context) ([]Product, error)
+	GetProductById(ctx context.Context, productId string) (*Product, error)
+	CreateProduct(ctx context.Context, productRequest product.ProductRequest) error
+	UpdateProduct(ctx context.Context, productId string, productRequest product.ProductRequest) error
+	DeleteProduct(ctx context.Context, productId string) error
+	GetProductsByMerchant(ctx context.Context, merchantId string) ([]Product, error)
+	GetProductPriceTotal(ctx context.Context, transaction transaction.Transaction) (int, error)
+	SearchProducts(ctx context.Context, request product.ProductSearchRequest) ([]Product, error)
+	GetProductsByCategories(ctx context.Context, categoryIds []string) ([]Product, error)
+	GetProductsByTag(ctx context.Context, tag string) ([]Product, error)
+	SetProductOptions(ctx context.Context, request product.ProductOptionsRequest) error
+	CreateVariant(ctx context.Context, request product.VariantRequest) error
+	UpdateVariant(ctx context.Context, request product.UpdateVariantRequest) error
+	DeleteVariant(ctx context.Context, variantId string) error
+	GetUnitPrice(ctx context.Context, productId string, variantId *string) (int, error)
+	ReserveStock(ctx context.Context, details []transaction.ProductTransaction) error
+	ReleaseStock(ctx context.Context, details []transaction.ProductTransaction) error
+	ImportProducts(ctx context.Context, request product.ImportProductsRequest) (*ImportJob, error)
+	GetImportJob(ctx context.Context, jobId string) (*ImportJob, error)
+	ExportProducts(ctx context.Context, merchantId string, format string, w io.Writer) error
 }
 
 type ProductRepository interface {
-	GetProducts() ([]Product, error)
-	GetProductById(productId string) (*Product, error)
-	CreateProduct(Product) error
-	UpdateProduct(productId string, product Product) error
-	DeleteProduct(productId string) error
-	GetProductsByMerchant(merchantId string) ([]Product, error)
+	GetProducts(ctx context.Context) ([]Product, error)
+	GetProductById(ctx context.Context, productId string) (*Product, error)
+	CreateProduct(context.Context, Product) error
+	UpdateProduct(ctx context.Context, productId string, product Product) error
+	DeleteProduct(ctx context.Context, productId string) error
+	GetProductsByMerchant(ctx context.Context, merchantId string) ([]Product, error)
+	GetProductsByCategories(ctx context.Context, categoryIds []string) ([]Product, error)
+	GetProductsByTag(ctx context.Context, tag string) ([]Product, error)
+	GetOrCreateTags(ctx context.Context, names []string) ([]Tag, error)
+	ReplaceOptions(ctx context.Context, productId string, options []ProductOption) error
+	CreateVariant(context.Context, ProductVariant) error
+	UpdateVariant(ctx context.Context, variantId string, variant ProductVariant) error
+	DeleteVariant(ctx context.Context, variantId string) error
+	GetVariantById(ctx context.Context, variantId string) (*ProductVariant, error)
+	ReserveStock(ctx context.Context, details []transaction.ProductTransaction) error
+	ReleaseStock(ctx context.Context, details []transaction.ProductTransaction) error
+	UpsertProduct(ctx context.Context, product Product) (bool, error)
+	CreateImportJob(ctx context.Context, job *ImportJob) error
+	UpdateImportJob(ctx context.Context, job *ImportJob) error
+	GetImportJobById(ctx context.Context, jobId string) (*ImportJob, error)
 }
 
 type ProductController interface {
@@ -43,4 +155,13 @@ type ProductController interface {
 	UpdateProduct(echo.Context) error
 	DeleteProduct(echo.Context) error
 	GetProductsByMerchant(echo.Context) error
+	SearchProducts(echo.Context) error
+	GetProductsByTag(echo.Context) error
+	SetProductOptions(echo.Context) error
+	CreateVariant(echo.Context) error
+	UpdateVariant(echo.Context) error
+	DeleteVariant(echo.Context) error
+	ImportProducts(echo.Context) error
+	GetImportJob(echo.Context) error
+	ExportProducts(echo.Context) error
 }
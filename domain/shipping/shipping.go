@@ -0,0 +1,67 @@
+package shipping
+
+import (
+	"context"
+
+	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/dto/request/shipping"
+)
+
+// AnyRegion is the region of the table rates used for destinations without rates of their own
+const AnyRegion = "*"
+
+// ShippingRate is a row of the rate table of a merchant. A parcel to the region costs the
+// Cost of the lightest row whose MaxWeightGrams it does not exceed.
+type ShippingRate struct {
+	domain.Base
+	MerchantId     string `json:"merchant_id"`
+	Region         string `json:"region"`
+	MaxWeightGrams int    `json:"max_weight_grams"`
+	Cost           int    `json:"cost"`
+}
+
+// Parcel is what a rate is quoted for, WeightGrams and VolumeCm3 are the totals of every
+// item of the transaction
+type Parcel struct {
+	MerchantId  string `json:"merchant_id"`
+	Province    string `json:"province"`
+	City        string `json:"city"`
+	PostalCode  string `json:"postal_code"`
+	WeightGrams int    `json:"weight_grams"`
+	VolumeCm3   int    `json:"volume_cm3"`
+}
+
+type Quote struct {
+	Provider string `json:"provider"`
+	Service  string `json:"service"`
+	Cost     int    `json:"cost"`
+}
+
+var (
+	ErrNoShippingRate  = apperror.New(apperror.VALIDATION, "no_shipping_rate", "merchant does not ship the parcel to the region")
+	ErrRateUnavailable = apperror.New(apperror.INTERNAL, "shipping_rate_unavailable", "shipping rates are not available right now")
+)
+
+// ShippingRateProvider quotes the cost of sending a parcel
+type ShippingRateProvider interface {
+	Name() string
+	Quote(ctx context.Context, parcel Parcel) (*Quote, error)
+}
+
+type ShippingRepository interface {
+	GetShippingRates(ctx context.Context, merchantId string) ([]ShippingRate, error)
+	ReplaceShippingRates(ctx context.Context, merchantId string, rates []ShippingRate) error
+}
+
+type ShippingUsecase interface {
+	GetShippingRates(ctx context.Context, merchantId string) ([]ShippingRate, error)
+	UpdateShippingRates(ctx context.Context, request shipping.UpdateShippingRatesRequest) error
+	QuoteShipping(ctx context.Context, parcel Parcel) (*Quote, error)
+}
+
+type ShippingController interface {
+	GetShippingRates(echo.Context) error
+	UpdateShippingRates(echo.Context) error
+}
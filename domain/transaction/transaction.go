@@ -1,39 +1,91 @@
 package transaction
 
 import (
+	"context"
+	"time"
+
 	"github.com/labstack/echo"
 	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/analytics"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/shipping"
 	"github.com/williamchang80/sea-apd/dto/request/transaction"
-	"time"
 )
 
 type Transaction struct {
 	domain.Base
-	BankNumber string `json:"bank_number"`
-	BankName   string `json:"bank_name"`
-	Amount     int    `json:"amount"`
-	CustomerId string `json:"customer_id"`
-	Status     string `json:"status"`
-	MerchantId string `json:"merchant_id"`
-	ProductDetails []ProductTransaction `json:"product_details" gorm:"many2many:product_transactions;
-								      AssociationForeignKey:TransactionId"`
+	BankNumber     string               `json:"bank_number"`
+	BankName       string               `json:"bank_name"`
+	Amount         int                  `json:"amount"`
+	CustomerId     string               `json:"customer_id"`
+	Status         string               `json:"status"`
+	MerchantId     string               `json:"merchant_id"`
+	ProductDetails []ProductTransaction `json:"product_details" gorm:"foreignkey:TransactionId"`
+	// ShippingCost is quoted by ShippingProvider at checkout and included in Amount
+	ShippingCost     int    `json:"shipping_cost"`
+	ShippingProvider string `json:"shipping_provider"`
+	ShippingService  string `json:"shipping_service"`
+	// ShippingAddress is copied from the address book at checkout, so editing the address
+	// book does not move an order that was already placed
+	ShippingAddress ShippingAddress `json:"shipping_address" gorm:"embedded;embedded_prefix:shipping_"`
+	Shipment        Shipment        `json:"shipment" gorm:"embedded;embedded_prefix:shipment_"`
+}
+
+type ShippingAddress struct {
+	RecipientName string `json:"recipient_name"`
+	Phone         string `json:"phone"`
+	Street        string `json:"street"`
+	City          string `json:"city"`
+	Province      string `json:"province"`
+	PostalCode    string `json:"postal_code"`
+}
+
+// Shipment is entered by the merchant when the goods are handed to the courier
+type Shipment struct {
+	Courier        string     `json:"courier"`
+	TrackingNumber string     `json:"tracking_number"`
+	ShippedAt      *time.Time `json:"shipped_at"`
 }
 
 type ProductTransaction struct {
 	ProductId     string    `json:"product_id"`
+	VariantId     *string   `json:"variant_id"`
 	TransactionId string    `json:"transaction_id"`
 	Quantity      int       `json:"quantity"`
+	UnitPrice     int       `json:"unit_price"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
 }
 
+// TransactionStatusChange is one entry of the status history of a transaction. ActorId
+// and Reason are only known for changes forced by an admin.
+type TransactionStatusChange struct {
+	domain.Base
+	TransactionId string `json:"transaction_id"`
+	FromStatus    string `json:"from_status"`
+	ToStatus      string `json:"to_status"`
+	ActorId       string `json:"actor_id"`
+	Reason        string `json:"reason"`
+}
+
+var ErrInvalidStatusTransition = apperror.InvalidTransition("transaction status has changed")
+
 type TransactionUsecase interface {
-	CreateTransaction(transaction.TransactionRequest) error
-	GetTransactionById(id string) (*Transaction, error)
-	UpdateTransactionStatus(transaction.UpdateTransactionRequest) error
-	GetTransactionHistory(userId string) ([]Transaction, error)
-	GetMerchantRequestItem(merchantId string) ([]Transaction, error)
-	PayTransaction(request transaction.PaymentRequest) error
+	CreateTransaction(context.Context, transaction.TransactionRequest) error
+	GetTransactionById(ctx context.Context, id string) (*Transaction, error)
+	UpdateTransactionStatus(context.Context, transaction.UpdateTransactionRequest) error
+	GetTransactionHistory(ctx context.Context, userId string) ([]Transaction, error)
+	GetMerchantRequestItem(ctx context.Context, merchantId string) ([]Transaction, error)
+	PayTransaction(ctx context.Context, request transaction.PaymentRequest) error
+	AddToCart(ctx context.Context, request transaction.CartItemRequest) error
+	GetCart(ctx context.Context, customerId string) ([]Transaction, error)
+	Checkout(ctx context.Context, request transaction.CheckoutRequest) error
+	QuoteShipping(ctx context.Context, request transaction.CheckoutRequest) (*shipping.Quote, error)
+	ForceTransactionStatus(ctx context.Context, request transaction.ForceTransactionStatusRequest) (*TransactionStatusChange, error)
+	GetStatusChanges(ctx context.Context, transactionId string) ([]TransactionStatusChange, error)
+	ShipTransaction(ctx context.Context, request transaction.ShipmentRequest) error
+	ConfirmDelivery(ctx context.Context, request transaction.ConfirmDeliveryRequest) error
+	CompleteShippedTransactions(ctx context.Context, shippedBefore time.Time) (int, error)
 }
 
 type TransactionController interface {
@@ -43,13 +95,33 @@ type TransactionController interface {
 	GetTransactionHistory(echo.Context) error
 	GetMerchantRequestItem(echo.Context) error
 	PayTransaction(echo.Context) error
+	AddToCart(echo.Context) error
+	GetCart(echo.Context) error
+	Checkout(echo.Context) error
+	QuoteShipping(echo.Context) error
+	ShipTransaction(echo.Context) error
+	ConfirmDelivery(echo.Context) error
 }
 
 type TransactionRepository interface {
-	CreateTransaction(Transaction) error
-	GetTransactionById(string) (*Transaction, error)
-	UpdateTransactionStatus(status string, id string) (*Transaction, error)
-	GetTransactionByRequiredStatus(requiredStatus []string, userId string) ([]Transaction, error)
-	GetMerchantRequestItem(merchantId string) ([]Transaction, error)
-	UpdateTransaction(transaction Transaction) error
+	CreateTransaction(context.Context, Transaction) error
+	GetTransactionById(context.Context, string) (*Transaction, error)
+	UpdateTransactionStatus(ctx context.Context, status string, id string) (*Transaction, error)
+	GetTransactionByRequiredStatus(ctx context.Context, requiredStatus []string, userId string) ([]Transaction, error)
+	GetMerchantRequestItem(ctx context.Context, merchantId string) ([]Transaction, error)
+	UpdateTransaction(ctx context.Context, transaction Transaction) error
+	GetCart(ctx context.Context, customerId string, merchantId string) (*Transaction, error)
+	GetCartsByCustomer(ctx context.Context, customerId string) ([]Transaction, error)
+	AddCartItem(ctx context.Context, item ProductTransaction) error
+	CheckoutTransaction(ctx context.Context, transaction Transaction) error
+	GetSalesBuckets(ctx context.Context, query analytics.AnalyticsQuery) ([]analytics.SalesBucket, error)
+	GetSalesRollups(ctx context.Context, query analytics.AnalyticsQuery) ([]analytics.SalesBucket, error)
+	GetTopProducts(ctx context.Context, query analytics.AnalyticsQuery, limit int) ([]analytics.ProductSales, error)
+	GetConversion(ctx context.Context, query analytics.AnalyticsQuery) (*analytics.Conversion, error)
+	RefreshSalesRollups(ctx context.Context, since time.Time) error
+	ChangeTransactionStatus(ctx context.Context, change TransactionStatusChange) (*Transaction, error)
+	CompleteTransaction(ctx context.Context, change TransactionStatusChange) (*Transaction, error)
+	GetStatusChanges(ctx context.Context, transactionId string) ([]TransactionStatusChange, error)
+	ShipTransaction(ctx context.Context, change TransactionStatusChange, shipment Shipment) (*Transaction, error)
+	GetShippedTransactions(ctx context.Context, shippedBefore time.Time) ([]Transaction, error)
 }
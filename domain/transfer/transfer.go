@@ -1,17 +1,21 @@
 package transfer
 
 import (
+	"context"
 	"github.com/labstack/echo"
 	"github.com/williamchang80/sea-apd/domain"
 	"github.com/williamchang80/sea-apd/dto/request/transfer"
 )
 
+// Transfer keeps a copy of the bank details so the history stays correct after the
+// bank account is changed or removed
 type Transfer struct {
 	domain.Base
-	Amount     int    `json:"amount"`
-	BankName   string `json:"bank_name"`
-	BankNumber string `json:"bank_number"`
-	MerchantId string `json:"merchant_id"`
+	Amount        int    `json:"amount"`
+	BankAccountId string `json:"bank_account_id"`
+	BankName      string `json:"bank_name"`
+	BankNumber    string `json:"bank_number"`
+	MerchantId    string `json:"merchant_id"`
 }
 
 type TransferController interface {
@@ -20,11 +24,11 @@ type TransferController interface {
 }
 
 type TransferUsecase interface {
-	GetTransferHistory(merchantId string) ([]Transfer, error)
-	CreateTransferHistory(request transfer.CreateTransferHistoryRequest) error
+	GetTransferHistory(ctx context.Context, merchantId string) ([]Transfer, error)
+	CreateTransferHistory(ctx context.Context, request transfer.CreateTransferHistoryRequest) error
 }
 
 type TransferRepository interface {
-	GetTransferHistory(merchantId string) ([]Transfer, error)
-	CreateTransferHistory(Transfer) error
+	GetTransferHistory(ctx context.Context, merchantId string) ([]Transfer, error)
+	CreateTransferHistory(context.Context, Transfer) error
 }
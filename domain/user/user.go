@@ -1,8 +1,12 @@
 package user
 
 import (
+	"context"
+	"time"
+
 	"github.com/labstack/echo"
 	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	"github.com/williamchang80/sea-apd/dto/request/admin"
 	"github.com/williamchang80/sea-apd/dto/request/auth"
 	"github.com/williamchang80/sea-apd/dto/request/user"
@@ -15,35 +19,145 @@ type User struct {
 	Email    string `gorm:"unique;unique;size:100;not null;" json:"email"`
 	Password string `gorm:"not null;" json:"password"`
 	Role     string `gorm:"not null;" json:"role"`
+	// BannedAt is set while an admin has banned the user, banned users cannot log in
+	BannedAt  *time.Time `json:"banned_at"`
+	BanReason string     `json:"ban_reason"`
+	// EmailVerifiedAt stays empty until the user follows the link mailed on registration
+	EmailVerifiedAt    *time.Time `json:"email_verified_at"`
+	VerificationSentAt *time.Time `json:"-"`
+	// SessionsRevokedAt invalidates the tokens issued before it, e.g. after a password reset
+	SessionsRevokedAt *time.Time `json:"-"`
+	// TotpSecret is set on enrolment and only asked for at login once TotpEnabledAt is set.
+	// TotpLastStep is the time step of the last accepted code, codes cannot be replayed.
+	TotpSecret    string     `json:"-"`
+	TotpEnabledAt *time.Time `json:"totp_enabled_at"`
+	TotpLastStep  int64      `json:"-"`
+}
+
+// RecoveryCode lets a user log in once without the authenticator, only its hash is stored
+type RecoveryCode struct {
+	domain.Base
+	UserId   string     `gorm:"not null;index;" json:"user_id"`
+	CodeHash string     `gorm:"not null;" json:"-"`
+	UsedAt   *time.Time `json:"used_at"`
+}
+
+// TwoFactorPolicy tells whether users of the role have to enable two-factor authentication
+// before they can log in
+type TwoFactorPolicy struct {
+	Role      string    `gorm:"primary_key;" json:"role"`
+	Required  bool      `json:"required"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+var (
+	ErrTwoFactorCodeNotValid = apperror.New(apperror.UNAUTHENTICATED, "invalid_two_factor_code", "two-factor code is not valid")
+	ErrTwoFactorNotPending   = apperror.New(apperror.CONFLICT, "two_factor_enabled", "two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled   = apperror.New(apperror.CONFLICT, "two_factor_not_enabled", "two-factor authentication is not enabled")
+	ErrTwoFactorRequired     = apperror.New(apperror.FORBIDDEN, "two_factor_required", "two-factor authentication has to be enabled for this account")
+)
+
+var (
+	ErrUserBanned               = apperror.New(apperror.FORBIDDEN, "user_banned", "user is banned")
+	ErrEmailNotVerified         = apperror.New(apperror.FORBIDDEN, "email_not_verified", "email is not verified")
+	ErrVerificationRecentlySent = apperror.TooManyRequests("verification email was sent recently, try again later")
+	ErrEmailTaken               = apperror.New(apperror.CONFLICT, "email_taken", "email must be unique")
+)
+
+// AdminInvitation lets the invited email become an admin once. Only the hash of the
+// token sent by mail is stored.
+type AdminInvitation struct {
+	domain.Base
+	Email      string     `gorm:"size:100;not null;" json:"email"`
+	TokenHash  string     `gorm:"unique;not null;" json:"-"`
+	InvitedBy  string     `gorm:"not null;" json:"invited_by"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+	AcceptedBy string     `json:"accepted_by"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// PasswordReset is a single use token mailed to a user who forgot the password, only its
+// hash is stored
+type PasswordReset struct {
+	domain.Base
+	UserId    string     `gorm:"not null;" json:"user_id"`
+	TokenHash string     `gorm:"unique;not null;" json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
 }
 
+var (
+	ErrResetTokenNotValid = apperror.New(apperror.VALIDATION, "invalid_reset_token", "password reset token is not valid or expired")
+	ErrSessionRevoked     = apperror.New(apperror.UNAUTHENTICATED, "session_revoked", "session was revoked")
+)
+
+var (
+	ErrInvitationNotValid   = apperror.New(apperror.VALIDATION, "invalid_invitation", "invitation is not valid")
+	ErrInvitationNotPending = apperror.New(apperror.CONFLICT, "invitation_not_pending", "invitation was already accepted or revoked")
+)
+
 // UserRepository ...
 type UserRepository interface {
-	CreateUser(User) error
-	GetUserByEmail(email string) (*User, error)
-	UpdateUserRole(role string, userId string) error
-	GetUserById(userId string) (*User, error)
-	UpdateUser(User) error
+	CreateUser(context.Context, User) error
+	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	UpdateUserRole(ctx context.Context, role string, userId string) error
+	GetUserById(ctx context.Context, userId string) (*User, error)
+	UpdateUser(context.Context, User) error
+	CountUsersByRole(ctx context.Context, role string) (int, error)
+	CreateAdminInvitation(ctx context.Context, invitation AdminInvitation) error
+	GetAdminInvitations(ctx context.Context) ([]AdminInvitation, error)
+	RevokeAdminInvitation(ctx context.Context, invitationId string, revokedAt time.Time) error
+	AcceptAdminInvitation(ctx context.Context, tokenHash string, u User, acceptedAt time.Time) error
+	SetEmailVerified(ctx context.Context, email string, verifiedAt *time.Time) error
+	MarkVerificationSent(ctx context.Context, email string, sentAt time.Time, sentBefore time.Time) error
+	CreatePasswordReset(ctx context.Context, reset PasswordReset) error
+	ResetPassword(ctx context.Context, tokenHash string, email string, passwordHash string, resetAt time.Time) error
+	SetTotpSecret(ctx context.Context, userId string, secret string) error
+	EnableTotp(ctx context.Context, userId string, enabledAt time.Time, step int64, codeHashes []string) error
+	DisableTotp(ctx context.Context, userId string) error
+	UseTotpStep(ctx context.Context, userId string, step int64) error
+	ReplaceRecoveryCodes(ctx context.Context, userId string, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userId string, codeHash string, usedAt time.Time) error
+	GetTwoFactorPolicies(ctx context.Context) ([]TwoFactorPolicy, error)
+	SetTwoFactorPolicy(ctx context.Context, policy TwoFactorPolicy) error
 }
 
 // AdminUsecase ...
 type AdminUsecase interface {
-	RegisterAdmin(admin.Admin) error
+	AcceptAdminInvitation(context.Context, admin.Admin) error
+	InviteAdmin(context.Context, admin.InviteAdminRequest) (*AdminInvitation, error)
+	GetAdminInvitations(ctx context.Context) ([]AdminInvitation, error)
+	RevokeAdminInvitation(context.Context, admin.RevokeInvitationRequest) error
+	BootstrapAdmin(context.Context, admin.BootstrapAdminRequest) error
+	GetTwoFactorPolicies(ctx context.Context) ([]TwoFactorPolicy, error)
+	SetTwoFactorPolicy(context.Context, admin.TwoFactorPolicyRequest) error
 }
 
 type UserUsecase interface {
-	CreateUser(request auth.RegisterUserRequest) error
-	UpdateUserRole(request user.UpdateUserRoleRequest) error
-	GetUserById(userId string) (*User, error)
-	UpdateUser(request user.UpdateUserRequest) error
+	CreateUser(ctx context.Context, request auth.RegisterUserRequest) error
+	UpdateUserRole(ctx context.Context, request user.UpdateUserRoleRequest) error
+	GetUserById(ctx context.Context, userId string) (*User, error)
+	UpdateUser(ctx context.Context, request user.UpdateUserRequest) error
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, request user.ResendVerificationRequest) error
+	ValidateUserVerified(ctx context.Context, userId string) error
 }
 
 // AdminController ...
 type AdminController interface {
-	RegisterAdmin(echo.Context) error
+	AcceptAdminInvitation(echo.Context) error
+	InviteAdmin(echo.Context) error
+	GetAdminInvitations(echo.Context) error
+	RevokeAdminInvitation(echo.Context) error
+	GetTwoFactorPolicies(echo.Context) error
+	SetTwoFactorPolicy(echo.Context) error
 }
 
 type UserController interface {
 	CreateUser(echo.Context) error
 	UpdateUser(echo.Context) error
+	VerifyEmail(echo.Context) error
+	ResendVerification(echo.Context) error
 }
@@ -0,0 +1,7 @@
+package domain
+
+import "github.com/williamchang80/sea-apd/domain/address"
+
+type AddressListDto struct {
+	Addresses []address.Address `json:"addresses"`
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"github.com/williamchang80/sea-apd/common/bank"
+	"github.com/williamchang80/sea-apd/domain/bank_account"
+)
+
+type BankAccountListDto struct {
+	BankAccounts []bank_account.BankAccount `json:"bank_accounts"`
+}
+
+type BankListDto struct {
+	Banks []bank.Bank `json:"banks"`
+}
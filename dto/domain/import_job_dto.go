@@ -0,0 +1,7 @@
+package domain
+
+import "github.com/williamchang80/sea-apd/domain/product"
+
+type ImportJobDto struct {
+	Job *product.ImportJob `json:"job"`
+}
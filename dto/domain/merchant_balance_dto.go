@@ -1,5 +1,6 @@
 package domain
 
 type MerchantBalanceDto struct {
-	Balance int `json:"balance"`
+	Balance        int `json:"balance"`
+	PendingBalance int `json:"pending_balance"`
 }
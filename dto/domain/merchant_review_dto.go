@@ -0,0 +1,15 @@
+package domain
+
+import "github.com/williamchang80/sea-apd/domain/merchant"
+
+type MerchantDocumentListDto struct {
+	Documents []merchant.MerchantDocument `json:"documents"`
+}
+
+type MerchantApplicationListDto struct {
+	Applications []merchant.MerchantApplication `json:"applications"`
+}
+
+type MerchantReviewListDto struct {
+	Reviews []merchant.MerchantReview `json:"reviews"`
+}
@@ -0,0 +1,7 @@
+package domain
+
+import "github.com/williamchang80/sea-apd/domain/shipping"
+
+type ShippingRateListDto struct {
+	Rates []shipping.ShippingRate `json:"rates"`
+}
@@ -0,0 +1,31 @@
+package address
+
+// AddressRequest and the other address requests act on the address book of the customer
+// of the token, their CustomerId is never read from the body
+type AddressRequest struct {
+	CustomerId    string `json:"-"`
+	Label         string `json:"label" validate:"max=50"`
+	RecipientName string `json:"recipient_name" validate:"required,max=100"`
+	Phone         string `json:"phone" validate:"required,max=20"`
+	Street        string `json:"street" validate:"required,max=255"`
+	City          string `json:"city" validate:"required,max=100"`
+	Province      string `json:"province" validate:"required,max=100"`
+	PostalCode    string `json:"postal_code" validate:"required,numeric,len=5"`
+}
+
+type UpdateAddressRequest struct {
+	AddressId     string `json:"address_id" validate:"required,uuid"`
+	CustomerId    string `json:"-"`
+	Label         string `json:"label" validate:"max=50"`
+	RecipientName string `json:"recipient_name" validate:"required,max=100"`
+	Phone         string `json:"phone" validate:"required,max=20"`
+	Street        string `json:"street" validate:"required,max=255"`
+	City          string `json:"city" validate:"required,max=100"`
+	Province      string `json:"province" validate:"required,max=100"`
+	PostalCode    string `json:"postal_code" validate:"required,numeric,len=5"`
+}
+
+type AddressActionRequest struct {
+	AddressId  string `json:"address_id" validate:"required,uuid"`
+	CustomerId string `json:"-"`
+}
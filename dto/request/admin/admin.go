@@ -1,8 +1,32 @@
 package admin
 
-// Admin ...
+// Admin accepts an admin invitation, Token is the invitation token sent to Email
 type Admin struct {
-	Token    string `json:"token"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Token    string `json:"token" validate:"required"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type InviteAdminRequest struct {
+	AdminId string `json:"-"`
+	Email   string `json:"email" validate:"required,email"`
+}
+
+type RevokeInvitationRequest struct {
+	AdminId      string `json:"-"`
+	InvitationId string `json:"invitation_id"`
+}
+
+// BootstrapAdminRequest creates the first admin, or promotes the user with the email
+// when it is already registered
+type BootstrapAdminRequest struct {
+	Name     string
+	Email    string
+	Password string
+}
+
+type TwoFactorPolicyRequest struct {
+	AdminId  string `json:"-"`
+	Role     string `json:"role" validate:"required,enum=user_role"`
+	Required bool   `json:"required"`
 }
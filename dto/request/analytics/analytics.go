@@ -0,0 +1,11 @@
+package analytics
+
+// AnalyticsRequest selects the merchant and period of a report. From and To are dates
+// formatted as 2006-01-02, To is inclusive.
+type AnalyticsRequest struct {
+	MerchantId string `json:"merchant_id" query:"merchantId" validate:"required,uuid"`
+	Bucket     string `json:"bucket" query:"bucket" validate:"omitempty,enum=time_bucket"`
+	From       string `json:"from" query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To         string `json:"to" query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Limit      int    `json:"limit" query:"limit" validate:"min=0"`
+}
@@ -1,13 +1,46 @@
 package auth
 
+// LoginRequest and TwoFactorRequest count failed logins by Email and by Ip, the address
+// the request came from
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+	Ip       string `json:"-"`
 }
 
 type RegisterUserRequest struct {
-	Email                string `json:"email"`
-	Password             string `json:"password"`
-	Name                 string `json:"name"`
-	PasswordConfirmation string `json:"password_confirmation"`
+	Email                string `json:"email" validate:"required,email"`
+	Password             string `json:"password" validate:"required"`
+	Name                 string `json:"name" validate:"required,max=100"`
+	PasswordConfirmation string `json:"password_confirmation" validate:"required,eqfield=Password"`
+}
+
+// ForgotPasswordRequest and ResetPasswordRequest are throttled by Email and by Ip, the
+// address the request came from
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Ip    string `json:"-"`
+}
+
+type ResetPasswordRequest struct {
+	Email                string `json:"email" validate:"required,email"`
+	Token                string `json:"token" validate:"required"`
+	Password             string `json:"password" validate:"required"`
+	PasswordConfirmation string `json:"password_confirmation" validate:"required,eqfield=Password"`
+	Ip                   string `json:"-"`
+}
+
+// TwoFactorLoginRequest is the second login step, Code is a code of the authenticator app
+// or a recovery code
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+}
+
+// TwoFactorRequest manages the two-factor authentication of the user with the password
+type TwoFactorRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+	Ip       string `json:"-"`
 }
@@ -0,0 +1,54 @@
+package backoffice
+
+// The AdminId of every request is taken from the token of the admin, not from the body
+
+type UserSearchRequest struct {
+	AdminId string `json:"-"`
+	Query   string `query:"q"`
+	Role    string `query:"role" validate:"omitempty,enum=user_role"`
+	Banned  string `query:"banned" validate:"omitempty,oneof=true false"`
+	Limit   int    `query:"limit" validate:"min=0"`
+	Offset  int    `query:"offset" validate:"min=0"`
+}
+
+type MerchantSearchRequest struct {
+	AdminId string `json:"-"`
+	Query   string `query:"q"`
+	Status  string `query:"status" validate:"omitempty,enum=merchant_status"`
+	Limit   int    `query:"limit" validate:"min=0"`
+	Offset  int    `query:"offset" validate:"min=0"`
+}
+
+// TransactionSearchRequest filters by creation date, From and To are formatted as
+// 2006-01-02 and To is inclusive
+type TransactionSearchRequest struct {
+	AdminId    string `json:"-"`
+	MerchantId string `query:"merchant_id" validate:"omitempty,uuid"`
+	CustomerId string `query:"customer_id" validate:"omitempty,uuid"`
+	Status     string `query:"status" validate:"omitempty,enum=transaction_status"`
+	From       string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To         string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Limit      int    `query:"limit" validate:"min=0"`
+	Offset     int    `query:"offset" validate:"min=0"`
+}
+
+type BanUserRequest struct {
+	AdminId string `json:"-"`
+	UserId  string `json:"user_id" validate:"required,uuid"`
+	Reason  string `json:"reason" validate:"max=500"`
+}
+
+type AdjustBalanceRequest struct {
+	AdminId    string `json:"-"`
+	MerchantId string `json:"merchant_id" validate:"required,uuid"`
+	Amount     int    `json:"amount" validate:"required"`
+	Memo       string `json:"memo" validate:"required,max=500"`
+}
+
+type AuditSearchRequest struct {
+	AdminId  string `json:"-"`
+	ActorId  string `query:"admin_id" validate:"omitempty,uuid"`
+	TargetId string `query:"target_id"`
+	Limit    int    `query:"limit" validate:"min=0"`
+	Offset   int    `query:"offset" validate:"min=0"`
+}
@@ -0,0 +1,31 @@
+package bank_account
+
+import "github.com/williamchang80/sea-apd/common/constants/bank_account_status"
+
+type BankAccountRequest struct {
+	MerchantId    string `json:"merchant_id" validate:"required,uuid"`
+	BankCode      string `json:"bank_code" validate:"required"`
+	AccountNumber string `json:"account_number" validate:"required,numeric,max=34"`
+	HolderName    string `json:"holder_name" validate:"required,max=100"`
+	Password      string `json:"password" validate:"required"`
+}
+
+type UpdateBankAccountRequest struct {
+	AccountId     string `json:"account_id" validate:"required,uuid"`
+	MerchantId    string `json:"merchant_id" validate:"required,uuid"`
+	BankCode      string `json:"bank_code" validate:"required"`
+	AccountNumber string `json:"account_number" validate:"required,numeric,max=34"`
+	HolderName    string `json:"holder_name" validate:"required,max=100"`
+	Password      string `json:"password" validate:"required"`
+}
+
+type BankAccountActionRequest struct {
+	AccountId  string `json:"account_id" validate:"required,uuid"`
+	MerchantId string `json:"merchant_id" validate:"required,uuid"`
+	Password   string `json:"password" validate:"required"`
+}
+
+type VerifyBankAccountRequest struct {
+	AccountId string                                `json:"account_id" validate:"required,uuid"`
+	Status    bank_account_status.BankAccountStatus `json:"status" validate:"enum=bank_account_status"`
+}
@@ -0,0 +1,12 @@
+package category
+
+type CategoryRequest struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	ParentId string `json:"parent_id" validate:"omitempty,uuid"`
+}
+
+type UpdateCategoryRequest struct {
+	CategoryId string `json:"category_id" validate:"required,uuid"`
+	Name       string `json:"name" validate:"required,max=100"`
+	ParentId   string `json:"parent_id" validate:"omitempty,uuid"`
+}
@@ -1,30 +1,68 @@
 package merchant
 
 import (
+	"io"
+	"time"
+
 	"github.com/williamchang80/sea-apd/common/constants/merchant_status"
 )
 
 type UpdateMerchantBalanceRequest struct {
-	Amount     int    `json:"amount"`
-	MerchantId string `json:"merchant_id"`
+	Amount     int    `json:"amount" validate:"required"`
+	MerchantId string `json:"merchant_id" validate:"required,uuid"`
 }
 
 type MerchantRequest struct {
-	Name    string `json:"name"`
-	UserId  string `json:"user_id"`
-	Brand   string `json:"brand"`
-	Address string `json:"address"`
+	Name    string `json:"name" validate:"required,max=100"`
+	UserId  string `json:"user_id" validate:"required,uuid"`
+	Brand   string `json:"brand" validate:"required,max=100"`
+	Address string `json:"address" validate:"required,max=255"`
 }
 
+// UpdateMerchantApprovalStatusRequest is sent by an admin, AdminId is taken from the token
 type UpdateMerchantApprovalStatusRequest struct {
-	Status     merchant_status.MerchantApprovalStatus `json:"status"`
-	MerchantId string                                 `json:"merchant_id"`
+	Status     merchant_status.MerchantApprovalStatus `json:"status" validate:"enum=merchant_status"`
+	MerchantId string                                 `json:"merchant_id" validate:"required,uuid"`
+	AdminId    string                                 `json:"-"`
+	Reason     string                                 `json:"reason" validate:"max=500"`
 }
 
 type UpdateMerchantRequest struct {
-	MerchantId string `json:"merchant_id"`
-	Name       string `json:"name"`
-	Brand      string `json:"brand"`
-	Address    string `json:"address"`
+	MerchantId string `json:"merchant_id" validate:"required,uuid"`
+	Name       string `json:"name" validate:"max=100"`
+	Brand      string `json:"brand" validate:"max=100"`
+	Address    string `json:"address" validate:"max=255"`
+}
+
+type MerchantDocumentRequest struct {
+	MerchantId  string    `json:"merchant_id" form:"merchant_id" validate:"required,uuid"`
+	Type        string    `json:"type" form:"type" validate:"required,enum=document_type"`
+	FileName    string    `json:"-"`
+	ContentType string    `json:"-"`
+	File        io.Reader `json:"-"`
+}
+
+type ResubmitMerchantRequest struct {
+	MerchantId string `json:"merchant_id" validate:"required,uuid"`
+	UserId     string `json:"user_id" validate:"required,uuid"`
 }
 
+// SuspendMerchantRequest and ReactivateMerchantRequest are sent by an admin, AdminId is
+// taken from the token
+type SuspendMerchantRequest struct {
+	MerchantId string    `json:"merchant_id" validate:"required,uuid"`
+	AdminId    string    `json:"-"`
+	Reason     string    `json:"reason" validate:"required,max=500"`
+	Until      time.Time `json:"until"`
+}
+
+type ReactivateMerchantRequest struct {
+	MerchantId string `json:"merchant_id" validate:"required,uuid"`
+	AdminId    string `json:"-"`
+}
+
+type CloseMerchantRequest struct {
+	MerchantId string `json:"merchant_id" validate:"required,uuid"`
+	UserId     string `json:"user_id" validate:"required,uuid"`
+	Reason     string `json:"reason" validate:"max=500"`
+}
@@ -1,14 +1,65 @@
 package product
 
 import (
+	"io"
 	"mime/multipart"
 )
 
 type ProductRequest struct {
-	Name        string         `json:"name"`
-	Stock       int            `json:"stock"`
-	Description string         `json:"description"`
-	Price       int            `json:"price"`
-	MerchantId  string         `json:"merchant_id"`
+	Sku         string         `json:"sku" form:"sku" validate:"max=64"`
+	Name        string         `json:"name" form:"name" validate:"required,max=255"`
+	Stock       int            `json:"stock" form:"stock" validate:"min=0"`
+	Description string         `json:"description" form:"description"`
+	Price       int            `json:"price" form:"price" validate:"min=0"`
+	WeightGrams int            `json:"weight_grams" form:"weight_grams" validate:"min=0"`
+	LengthCm    int            `json:"length_cm" form:"length_cm" validate:"min=0"`
+	WidthCm     int            `json:"width_cm" form:"width_cm" validate:"min=0"`
+	HeightCm    int            `json:"height_cm" form:"height_cm" validate:"min=0"`
+	MerchantId  string         `json:"merchant_id" form:"merchant_id" validate:"required,uuid"`
+	CategoryId  string         `json:"category_id" form:"category_id" validate:"omitempty,uuid"`
+	Tags        []string       `json:"tags" form:"tags" validate:"max=20,dive,required,max=50"`
 	Image       multipart.File `json:"image"`
 }
+
+type ProductSearchRequest struct {
+	Query      string `query:"q"`
+	MinPrice   int    `query:"min_price" validate:"min=0"`
+	MaxPrice   int    `query:"max_price" validate:"min=0"`
+	InStock    bool   `query:"in_stock"`
+	MerchantId string `query:"merchant_id" validate:"omitempty,uuid"`
+	Sort       string `query:"sort" validate:"omitempty,enum=product_sort"`
+	Limit      int    `query:"limit" validate:"min=0"`
+	Offset     int    `query:"offset" validate:"min=0"`
+}
+
+type ProductOptionRequest struct {
+	Name   string   `json:"name" validate:"required,max=50"`
+	Values []string `json:"values" validate:"required,dive,required,max=50"`
+}
+
+type ProductOptionsRequest struct {
+	ProductId string                 `json:"product_id" validate:"required,uuid"`
+	Options   []ProductOptionRequest `json:"options" validate:"dive"`
+}
+
+type VariantRequest struct {
+	ProductId    string   `json:"product_id" validate:"required,uuid"`
+	Sku          string   `json:"sku" validate:"max=64"`
+	OptionValues []string `json:"option_values"`
+	Price        *int     `json:"price" validate:"omitempty,min=0"`
+	Stock        int      `json:"stock" validate:"min=0"`
+}
+
+type UpdateVariantRequest struct {
+	VariantId string `json:"variant_id" validate:"required,uuid"`
+	Sku       string `json:"sku" validate:"max=64"`
+	Price     *int   `json:"price" validate:"omitempty,min=0"`
+	Stock     int    `json:"stock" validate:"min=0"`
+}
+
+type ImportProductsRequest struct {
+	MerchantId string    `json:"merchant_id" form:"merchant_id" validate:"required,uuid"`
+	Format     string    `json:"format" form:"format" validate:"omitempty,enum=catalogue_format"`
+	DryRun     bool      `json:"dry_run" form:"dry_run"`
+	File       io.Reader `json:"-"`
+}
@@ -0,0 +1,13 @@
+package shipping
+
+type ShippingRateRequest struct {
+	// Region is a province, or "*" for every destination without rates of its own
+	Region         string `json:"region" validate:"required,max=100"`
+	MaxWeightGrams int    `json:"max_weight_grams" validate:"min=1"`
+	Cost           int    `json:"cost" validate:"min=0"`
+}
+
+type UpdateShippingRatesRequest struct {
+	MerchantId string                `json:"merchant_id" validate:"required,uuid"`
+	Rates      []ShippingRateRequest `json:"rates" validate:"max=200,dive"`
+}
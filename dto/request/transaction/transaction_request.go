@@ -5,21 +5,56 @@ import (
 )
 
 type TransactionRequest struct {
-	BankNumber string `json:"bank_number"`
-	BankName   string `json:"bank_name"`
-	Amount     int    `json:"amount"`
-	CustomerId string `json:"customer_id"`
-	MerchantId string `json:"merchant_id"`
+	BankNumber string `json:"bank_number" validate:"required"`
+	BankName   string `json:"bank_name" validate:"required"`
+	Amount     int    `json:"amount" validate:"min=1"`
+	CustomerId string `json:"customer_id" validate:"required,uuid"`
+	MerchantId string `json:"merchant_id" validate:"required,uuid"`
 }
 
 type UpdateTransactionRequest struct {
-	TransactionId string                               `json:"transaction_id"`
-	Status        transaction_status.TransactionStatus `json:"status"`
+	TransactionId string                               `json:"transaction_id" validate:"required,uuid"`
+	Status        transaction_status.TransactionStatus `json:"status" validate:"enum=transaction_status"`
 }
 
 type PaymentRequest struct {
-	CustomerId string `json:"customer_id"`
-	BankNumber string `json:"bank_number"`
-	BankName   string `json:"bank_name"`
-	TransactionId string `json:"transaction_id"`
+	CustomerId    string `json:"customer_id" validate:"required,uuid"`
+	BankNumber    string `json:"bank_number" validate:"required"`
+	BankName      string `json:"bank_name" validate:"required"`
+	TransactionId string `json:"transaction_id" validate:"required,uuid"`
+}
+
+type CartItemRequest struct {
+	CustomerId string  `json:"customer_id" validate:"required,uuid"`
+	MerchantId string  `json:"merchant_id" validate:"required,uuid"`
+	ProductId  string  `json:"product_id" validate:"required,uuid"`
+	VariantId  *string `json:"variant_id" validate:"omitempty,uuid"`
+	Quantity   int     `json:"quantity" validate:"min=1"`
+}
+
+type CheckoutRequest struct {
+	CustomerId    string `json:"customer_id" validate:"required,uuid"`
+	TransactionId string `json:"transaction_id" validate:"required,uuid"`
+	AddressId     string `json:"address_id" validate:"omitempty,uuid"`
+}
+
+type ForceTransactionStatusRequest struct {
+	TransactionId string                               `json:"transaction_id" validate:"required,uuid"`
+	Status        transaction_status.TransactionStatus `json:"status" validate:"enum=transaction_status"`
+	AdminId       string                               `json:"-"`
+	Reason        string                               `json:"reason" validate:"required,max=500"`
+}
+
+type ShipmentRequest struct {
+	TransactionId  string `json:"transaction_id" validate:"required,uuid"`
+	MerchantId     string `json:"merchant_id" validate:"required,uuid"`
+	Courier        string `json:"courier" validate:"required,max=50"`
+	TrackingNumber string `json:"tracking_number" validate:"required,max=50"`
+}
+
+// ConfirmDeliveryRequest is sent by the customer of the transaction, CustomerId is taken
+// from the token
+type ConfirmDeliveryRequest struct {
+	TransactionId string `json:"transaction_id" validate:"required,uuid"`
+	CustomerId    string `json:"-"`
 }
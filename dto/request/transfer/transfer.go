@@ -1,8 +1,8 @@
 package transfer
 
 type CreateTransferHistoryRequest struct {
-	BankNumber string `json:"bank_number"`
-	BankName   string `json:"bank_name"`
-	Amount     int    `json:"amount"`
-	MerchantId string `json:"merchant_id"`
+	// BankAccountId is the verified payout account, the default account when empty
+	BankAccountId string `json:"bank_account_id" validate:"omitempty,uuid"`
+	Amount        int    `json:"amount" validate:"min=1"`
+	MerchantId    string `json:"merchant_id" validate:"required,uuid"`
 }
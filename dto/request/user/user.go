@@ -8,9 +8,13 @@ type UpdateUserRoleRequest struct {
 }
 
 type UpdateUserRequest struct {
-	OldPassword string `json:"old_password"`
+	OldPassword string `json:"old_password" validate:"required"`
 	NewPassword string `json:"new_password"`
-	NewEmail    string `json:"new_email"`
-	OldEmail    string `json:"old_email"`
-	UserId      string `json:"user_id"`
+	NewEmail    string `json:"new_email" validate:"omitempty,email"`
+	OldEmail    string `json:"old_email" validate:"required,email"`
+	UserId      string `json:"user_id" validate:"required,uuid"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
 }
@@ -0,0 +1,11 @@
+package address
+
+import (
+	"github.com/williamchang80/sea-apd/dto/domain"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+)
+
+type GetAddressesResponse struct {
+	base.BaseResponse
+	Data domain.AddressListDto `json:"data"`
+}
@@ -0,0 +1,21 @@
+package admin
+
+import (
+	"github.com/williamchang80/sea-apd/domain/user"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+)
+
+type InviteAdminResponse struct {
+	base.BaseResponse
+	Data user.AdminInvitation `json:"data"`
+}
+
+type GetAdminInvitationsResponse struct {
+	base.BaseResponse
+	Data []user.AdminInvitation `json:"data"`
+}
+
+type GetTwoFactorPoliciesResponse struct {
+	base.BaseResponse
+	Data []user.TwoFactorPolicy `json:"data"`
+}
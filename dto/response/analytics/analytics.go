@@ -0,0 +1,21 @@
+package analytics
+
+import (
+	"github.com/williamchang80/sea-apd/domain/analytics"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+)
+
+type GetSalesReportResponse struct {
+	base.BaseResponse
+	Data analytics.SalesReport `json:"data"`
+}
+
+type GetTopProductsResponse struct {
+	base.BaseResponse
+	Data []analytics.ProductSales `json:"data"`
+}
+
+type GetConversionResponse struct {
+	base.BaseResponse
+	Data analytics.Conversion `json:"data"`
+}
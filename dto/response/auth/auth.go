@@ -1,8 +1,25 @@
 package auth
 
-import "github.com/williamchang80/sea-apd/dto/response/base"
+import (
+	"github.com/williamchang80/sea-apd/domain/auth"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+)
 
+// LoginResponse has either the Token or, when a two-factor code is needed, the
+// ChallengeToken to send along with the code
 type LoginResponse struct {
 	base.BaseResponse
-	Token string `json:"token"`
+	Token          string `json:"token,omitempty"`
+	ChallengeToken string `json:"challenge_token,omitempty"`
+}
+
+type EnrollTwoFactorResponse struct {
+	base.BaseResponse
+	Data auth.TwoFactorEnrolment `json:"data"`
+}
+
+// RecoveryCodesResponse shows the recovery codes once, only their hashes are kept
+type RecoveryCodesResponse struct {
+	base.BaseResponse
+	Data []string `json:"data"`
 }
@@ -0,0 +1,39 @@
+package backoffice
+
+import (
+	"github.com/williamchang80/sea-apd/domain/backoffice"
+	"github.com/williamchang80/sea-apd/domain/merchant"
+	"github.com/williamchang80/sea-apd/domain/transaction"
+	"github.com/williamchang80/sea-apd/domain/user"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+)
+
+type SearchUsersResponse struct {
+	base.BaseResponse
+	Data []user.User `json:"data"`
+}
+
+type SearchMerchantsResponse struct {
+	base.BaseResponse
+	Data []merchant.Merchant `json:"data"`
+}
+
+type SearchTransactionsResponse struct {
+	base.BaseResponse
+	Data []transaction.Transaction `json:"data"`
+}
+
+type GetTransactionDetailResponse struct {
+	base.BaseResponse
+	Data backoffice.TransactionDetail `json:"data"`
+}
+
+type GetPlatformKpisResponse struct {
+	base.BaseResponse
+	Data backoffice.PlatformKpis `json:"data"`
+}
+
+type GetAuditsResponse struct {
+	base.BaseResponse
+	Data []backoffice.AdminAudit `json:"data"`
+}
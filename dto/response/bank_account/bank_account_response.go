@@ -0,0 +1,16 @@
+package bank_account
+
+import (
+	"github.com/williamchang80/sea-apd/dto/domain"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+)
+
+type GetBankAccountsResponse struct {
+	base.BaseResponse
+	Data domain.BankAccountListDto `json:"data"`
+}
+
+type GetSupportedBanksResponse struct {
+	base.BaseResponse
+	Data domain.BankListDto `json:"data"`
+}
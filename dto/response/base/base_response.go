@@ -1,6 +1,17 @@
 package base
 
+import "github.com/williamchang80/sea-apd/common/validation"
+
+// BaseResponse is the envelope of every response, failed requests also carry the machine
+// readable error code
 type BaseResponse struct {
-	Code    int      `json:"code"`
-	Message string   `json:"message"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ValidationErrorResponse lists every field of the request that failed validation
+type ValidationErrorResponse struct {
+	BaseResponse
+	Errors validation.Errors `json:"errors"`
 }
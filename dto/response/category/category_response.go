@@ -0,0 +1,11 @@
+package category
+
+import (
+	"github.com/williamchang80/sea-apd/domain/category"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+)
+
+type GetCategoryTreeResponse struct {
+	base.BaseResponse
+	Data []category.Category `json:"data"`
+}
@@ -19,3 +19,18 @@ type GetMerchantsResponse struct {
 	base.BaseResponse
 	Data domain.MerchantListDto `json:"data"`
 }
+
+type GetMerchantDocumentsResponse struct {
+	base.BaseResponse
+	Data domain.MerchantDocumentListDto `json:"data"`
+}
+
+type GetReviewQueueResponse struct {
+	base.BaseResponse
+	Data domain.MerchantApplicationListDto `json:"data"`
+}
+
+type GetMerchantReviewsResponse struct {
+	base.BaseResponse
+	Data domain.MerchantReviewListDto `json:"data"`
+}
@@ -14,3 +14,8 @@ type GetProductByIdResponse struct {
 	base.BaseResponse
 	Data domain.ProductDto `json:"data"`
 }
+
+type GetImportJobResponse struct {
+	base.BaseResponse
+	Data domain.ImportJobDto `json:"data"`
+}
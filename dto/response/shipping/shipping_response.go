@@ -0,0 +1,11 @@
+package shipping
+
+import (
+	"github.com/williamchang80/sea-apd/dto/domain"
+	"github.com/williamchang80/sea-apd/dto/response/base"
+)
+
+type GetShippingRatesResponse struct {
+	base.BaseResponse
+	Data domain.ShippingRateListDto `json:"data"`
+}
@@ -1,6 +1,7 @@
 package transaction
 
 import (
+	"github.com/williamchang80/sea-apd/domain/shipping"
 	"github.com/williamchang80/sea-apd/domain/transaction"
 	"github.com/williamchang80/sea-apd/dto/domain"
 	"github.com/williamchang80/sea-apd/dto/response/base"
@@ -15,3 +16,8 @@ type GetTransactionByIdResponse struct {
 	base.BaseResponse
 	Data domain.TransactionDto `json:"data"`
 }
+
+type GetShippingQuoteResponse struct {
+	base.BaseResponse
+	Data shipping.Quote `json:"data"`
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/postgres" // inject postgres
 	"github.com/joho/godotenv"
+	"github.com/williamchang80/sea-apd/common/metrics"
+	"github.com/williamchang80/sea-apd/common/tracing"
 )
 
 const driver = "postgres"
@@ -30,6 +32,8 @@ func Postgres() *gorm.DB {
 		if err != nil {
 			panic(err)
 		}
+		metrics.InstrumentGorm(db)
+		tracing.InstrumentGorm(db)
 	}
 	return db
 }
@@ -0,0 +1,79 @@
+package shipping
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/williamchang80/sea-apd/common/logger"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/shipping"
+)
+
+const httpRequestTimeout = 10 * time.Second
+
+// HTTPRateProvider asks a rate api for the quote. The parcel is posted to the url as
+// json and the api answers with the service and cost, e.g. {"service": "REG", "cost": 9000}.
+type HTTPRateProvider struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+type httpQuote struct {
+	Service string `json:"service"`
+	Cost    *int   `json:"cost"`
+}
+
+func NewHTTPRateProvider(url string, apiKey string) *HTTPRateProvider {
+	client := tracing.NewClient()
+	client.Timeout = httpRequestTimeout
+	return &HTTPRateProvider{url: url, apiKey: apiKey, client: client}
+}
+
+func (h *HTTPRateProvider) Name() string {
+	return HTTPProvider
+}
+
+// Quote fails with shipping.ErrRateUnavailable when the api cannot be reached or answers
+// with anything but a quote, the cause is logged
+func (h *HTTPRateProvider) Quote(ctx context.Context, parcel shipping.Parcel) (*shipping.Quote, error) {
+	body, err := json.Marshal(parcel)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.unavailable(ctx, err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	}
+	res, err := h.client.Do(req)
+	if err != nil {
+		return nil, h.unavailable(ctx, err.Error())
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusUnprocessableEntity {
+		return nil, shipping.ErrNoShippingRate
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, h.unavailable(ctx, "rate api answered "+res.Status)
+	}
+	var quote httpQuote
+	if err := json.NewDecoder(res.Body).Decode(&quote); err != nil {
+		return nil, h.unavailable(ctx, err.Error())
+	}
+	if quote.Cost == nil || *quote.Cost < 0 {
+		return nil, h.unavailable(ctx, "rate api answered without a cost")
+	}
+	return &shipping.Quote{Provider: h.Name(), Service: quote.Service, Cost: *quote.Cost}, nil
+}
+
+func (h *HTTPRateProvider) unavailable(ctx context.Context, cause string) error {
+	logger.Error(ctx, "shipping rate api failed", logger.Fields{"cause": cause})
+	return shipping.ErrRateUnavailable
+}
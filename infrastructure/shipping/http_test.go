@@ -0,0 +1,105 @@
+package shipping
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/williamchang80/sea-apd/domain/shipping"
+)
+
+// newRateStub serves a rate api charging 10 per gram to Bali, answering 422 for Papua and
+// failing for anything else. Requests without the api key are refused.
+func newRateStub() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer mock-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var parcel shipping.Parcel
+		if err := json.NewDecoder(r.Body).Decode(&parcel); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch parcel.Province {
+		case "Bali":
+			json.NewEncoder(w).Encode(map[string]interface{}{"service": "REG", "cost": parcel.WeightGrams * 10})
+		case "Papua":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		case "Aceh":
+			json.NewEncoder(w).Encode(map[string]interface{}{"service": "REG"})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+}
+
+func TestHTTPRateProvider_Quote(t *testing.T) {
+	server := newRateStub()
+	defer server.Close()
+	tests := []struct {
+		name    string
+		url     string
+		apiKey  string
+		parcel  shipping.Parcel
+		want    int
+		wantErr error
+	}{
+		{
+			name:   "success",
+			url:    server.URL,
+			apiKey: "mock-key",
+			parcel: shipping.Parcel{MerchantId: "1", Province: "Bali", WeightGrams: 1500},
+			want:   15000,
+		},
+		{
+			name:    "failed with region the api does not ship to",
+			url:     server.URL,
+			apiKey:  "mock-key",
+			parcel:  shipping.Parcel{MerchantId: "1", Province: "Papua", WeightGrams: 1500},
+			wantErr: shipping.ErrNoShippingRate,
+		},
+		{
+			name:    "failed with answer without cost",
+			url:     server.URL,
+			apiKey:  "mock-key",
+			parcel:  shipping.Parcel{MerchantId: "1", Province: "Aceh", WeightGrams: 1500},
+			wantErr: shipping.ErrRateUnavailable,
+		},
+		{
+			name:    "failed with failing api",
+			url:     server.URL,
+			apiKey:  "mock-key",
+			parcel:  shipping.Parcel{MerchantId: "1", Province: "Jawa Barat", WeightGrams: 1500},
+			wantErr: shipping.ErrRateUnavailable,
+		},
+		{
+			name:    "failed with wrong api key",
+			url:     server.URL,
+			apiKey:  "wrong",
+			parcel:  shipping.Parcel{MerchantId: "1", Province: "Bali", WeightGrams: 1500},
+			wantErr: shipping.ErrRateUnavailable,
+		},
+		{
+			name:    "failed with unreachable api",
+			url:     "http://127.0.0.1:1",
+			apiKey:  "mock-key",
+			parcel:  shipping.Parcel{MerchantId: "1", Province: "Bali", WeightGrams: 1500},
+			wantErr: shipping.ErrRateUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewHTTPRateProvider(tt.url, tt.apiKey)
+			got, err := p.Quote(context.Background(), tt.parcel)
+			if err != tt.wantErr {
+				t.Fatalf("Quote() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (got.Cost != tt.want || got.Service != "REG" || got.Provider != HTTPProvider) {
+				t.Errorf("Quote() = %+v, want cost %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package shipping
+
+import (
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/williamchang80/sea-apd/domain/shipping"
+)
+
+const (
+	TableProvider = "table"
+	HTTPProvider  = "http"
+)
+
+// NewProvider returns the ShippingRateProvider configured by SHIPPING_RATE_PROVIDER,
+// defaulting to the rate tables of the merchants
+func NewProvider(rates shipping.ShippingRepository) shipping.ShippingRateProvider {
+	godotenv.Load()
+	switch os.Getenv("SHIPPING_RATE_PROVIDER") {
+	case HTTPProvider:
+		return NewHTTPRateProvider(os.Getenv("SHIPPING_RATE_URL"), os.Getenv("SHIPPING_RATE_API_KEY"))
+	}
+	return NewTableRateProvider(rates)
+}
@@ -0,0 +1,75 @@
+package shipping
+
+import (
+	"context"
+	"strings"
+
+	"github.com/williamchang80/sea-apd/domain/shipping"
+)
+
+const (
+	// volumetricDivisor turns cubic centimetres into grams, couriers charge 1 kg for
+	// every 6000 cm3 of a light but bulky parcel
+	volumetricDivisor = 6
+	tableService      = "standard"
+)
+
+// TableRateProvider quotes from the rate table of the merchant. Rates of the destination
+// province win over the rates of shipping.AnyRegion. A merchant without any rate ships
+// for free, so checkouts keep working until the merchant sets up its table.
+type TableRateProvider struct {
+	rates shipping.ShippingRepository
+}
+
+func NewTableRateProvider(rates shipping.ShippingRepository) *TableRateProvider {
+	return &TableRateProvider{rates: rates}
+}
+
+// ChargeableWeight is the weight a parcel is charged for, its volumetric weight when
+// that is more than its actual weight
+func ChargeableWeight(parcel shipping.Parcel) int {
+	if volumetric := parcel.VolumeCm3 / volumetricDivisor; volumetric > parcel.WeightGrams {
+		return volumetric
+	}
+	return parcel.WeightGrams
+}
+
+// NormalizeRegion collapses the whitespace of a region, regions are compared ignoring case
+func NormalizeRegion(region string) string {
+	return strings.Join(strings.Fields(region), " ")
+}
+
+func (t *TableRateProvider) Name() string {
+	return TableProvider
+}
+
+func (t *TableRateProvider) Quote(ctx context.Context, parcel shipping.Parcel) (*shipping.Quote, error) {
+	rates, err := t.rates.GetShippingRates(ctx, parcel.MerchantId)
+	if err != nil {
+		return nil, err
+	}
+	if len(rates) == 0 {
+		return &shipping.Quote{Provider: t.Name(), Service: tableService}, nil
+	}
+	weight := ChargeableWeight(parcel)
+	for _, region := range []string{NormalizeRegion(parcel.Province), shipping.AnyRegion} {
+		if rate := lightestRate(rates, region, weight); rate != nil {
+			return &shipping.Quote{Provider: t.Name(), Service: tableService, Cost: rate.Cost}, nil
+		}
+	}
+	return nil, shipping.ErrNoShippingRate
+}
+
+// lightestRate returns the rate of the region with the lowest weight limit the weight fits in
+func lightestRate(rates []shipping.ShippingRate, region string, weight int) *shipping.ShippingRate {
+	var lightest *shipping.ShippingRate
+	for i, rate := range rates {
+		if !strings.EqualFold(rate.Region, region) || rate.MaxWeightGrams < weight {
+			continue
+		}
+		if lightest == nil || rate.MaxWeightGrams < lightest.MaxWeightGrams {
+			lightest = &rates[i]
+		}
+	}
+	return lightest
+}
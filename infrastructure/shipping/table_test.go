@@ -0,0 +1,70 @@
+package shipping
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/domain/shipping"
+	shipping2 "github.com/williamchang80/sea-apd/mocks/repository/shipping"
+)
+
+func TestTableRateProvider_Quote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		parcel  shipping.Parcel
+		want    int
+		wantErr error
+	}{
+		{
+			name:   "success with rate of the province",
+			parcel: shipping.Parcel{MerchantId: shipping2.MockMerchantId, Province: "DKI Jakarta", WeightGrams: 800},
+			want:   9000,
+		},
+		{
+			name:   "success with province of other case and spacing",
+			parcel: shipping.Parcel{MerchantId: shipping2.MockMerchantId, Province: " dki  jakarta", WeightGrams: 1200},
+			want:   20000,
+		},
+		{
+			name:   "success with rate of any region",
+			parcel: shipping.Parcel{MerchantId: shipping2.MockMerchantId, Province: "Bali", WeightGrams: 1000},
+			want:   15000,
+		},
+		{
+			name:   "success with parcel too heavy for the province rates",
+			parcel: shipping.Parcel{MerchantId: shipping2.MockMerchantId, Province: "DKI Jakarta", WeightGrams: 7000},
+			want:   45000,
+		},
+		{
+			name: "success with volumetric weight of bulky parcel",
+			parcel: shipping.Parcel{MerchantId: shipping2.MockMerchantId, Province: "DKI Jakarta", WeightGrams: 500,
+				VolumeCm3: 30 * 30 * 30},
+			want: 20000,
+		},
+		{
+			name:   "success with merchant without rates",
+			parcel: shipping.Parcel{MerchantId: shipping2.MockFreeMerchantId, Province: "Bali", WeightGrams: 50000},
+			want:   0,
+		},
+		{
+			name:    "failed with parcel too heavy for every rate",
+			parcel:  shipping.Parcel{MerchantId: shipping2.MockMerchantId, Province: "Bali", WeightGrams: 20000},
+			wantErr: shipping.ErrNoShippingRate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewTableRateProvider(shipping2.NewMockRepository(ctrl))
+			got, err := p.Quote(context.Background(), tt.parcel)
+			if err != tt.wantErr {
+				t.Fatalf("Quote() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (got.Cost != tt.want || got.Provider != TableProvider) {
+				t.Errorf("Quote() = %+v, want cost %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type LocalStore struct {
+	root      string
+	urlPrefix string
+}
+
+func NewLocalStore(root string, urlPrefix string) *LocalStore {
+	return &LocalStore{root: root, urlPrefix: strings.TrimSuffix(urlPrefix, "/")}
+}
+
+func (l *LocalStore) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if cleaned == "/" {
+		return "", errors.New("blob key cannot be empty")
+	}
+	return filepath.Join(l.root, cleaned), nil
+}
+
+func (l *LocalStore) Put(key string, contentType string, data []byte) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+func (l *LocalStore) Get(key string) ([]byte, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(path)
+}
+
+func (l *LocalStore) Delete(key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (l *LocalStore) URL(key string) string {
+	return l.urlPrefix + "/" + strings.TrimPrefix(key, "/")
+}
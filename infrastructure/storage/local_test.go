@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestLocalStore_PutGetDelete(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "local-store")
+	defer os.RemoveAll(dir)
+	store := NewLocalStore(dir, "/uploads/")
+	tests := []struct {
+		name    string
+		key     string
+		data    []byte
+		wantURL string
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			key:     "products/1/full",
+			data:    []byte("mock image"),
+			wantURL: "/uploads/products/1/full",
+			wantErr: false,
+		},
+		{
+			name:    "success with traversal kept inside root",
+			key:     "../../etc/full",
+			data:    []byte("mock image"),
+			wantURL: "/uploads/../../etc/full",
+			wantErr: false,
+		},
+		{
+			name:    "failed with empty key",
+			key:     "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := store.Put(tt.key, "image/jpeg", tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LocalStore.Put() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := store.Get(tt.key)
+			if err != nil || !reflect.DeepEqual(got, tt.data) {
+				t.Errorf("LocalStore.Get() = %s, %v, want %s", got, err, tt.data)
+			}
+			if url := store.URL(tt.key); url != tt.wantURL {
+				t.Errorf("LocalStore.URL() = %v, want %v", url, tt.wantURL)
+			}
+			if err := store.Delete(tt.key); err != nil {
+				t.Errorf("LocalStore.Delete() error = %v", err)
+			}
+			if _, err := store.Get(tt.key); err == nil {
+				t.Errorf("LocalStore.Get() after delete should fail")
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newS3StandIn serves a minimal path-style S3 api backed by a map, rejecting unsigned requests
+func newS3StandIn() (*httptest.Server, map[string][]byte) {
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), s3Algorithm+" Credential=mock-access/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := ioutil.ReadAll(r.Body)
+			objects[r.URL.Path] = body
+		case http.MethodGet:
+			body, exist := objects[r.URL.Path]
+			if !exist {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(body)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	return server, objects
+}
+
+func TestS3Store_PutGetDelete(t *testing.T) {
+	server, objects := newS3StandIn()
+	defer server.Close()
+	tests := []struct {
+		name    string
+		config  S3Config
+		key     string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			config: S3Config{
+				Endpoint:  server.URL,
+				Bucket:    "mock-bucket",
+				AccessKey: "mock-access",
+				SecretKey: "mock-secret",
+				PublicURL: "https://cdn.mock.com",
+			},
+			key:     "products/1/full",
+			wantErr: false,
+		},
+		{
+			name: "failed with invalid credential",
+			config: S3Config{
+				Endpoint:  server.URL,
+				Bucket:    "mock-bucket",
+				AccessKey: "other-access",
+			},
+			key:     "products/1/full",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewS3Store(tt.config)
+			data := []byte("mock image")
+			err := store.Put(tt.key, "image/jpeg", data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("S3Store.Put() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if _, exist := objects["/mock-bucket/"+tt.key]; !exist {
+				t.Errorf("S3Store.Put() did not store object under bucket path")
+			}
+			got, err := store.Get(tt.key)
+			if err != nil || !reflect.DeepEqual(got, data) {
+				t.Errorf("S3Store.Get() = %s, %v, want %s", got, err, data)
+			}
+			if url := store.URL(tt.key); url != "https://cdn.mock.com/"+tt.key {
+				t.Errorf("S3Store.URL() = %v", url)
+			}
+			if err := store.Delete(tt.key); err != nil {
+				t.Errorf("S3Store.Delete() error = %v", err)
+			}
+			if _, err := store.Get(tt.key); err == nil {
+				t.Errorf("S3Store.Get() after delete should fail")
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"os"
+
+	"github.com/joho/godotenv"
+)
+
+const (
+	LocalDriver = "local"
+	S3Driver    = "s3"
+
+	defaultLocalRoot        = "./uploads"
+	defaultLocalPrefix      = "/uploads"
+	defaultPrivateLocalRoot = "./private"
+)
+
+// BlobStore stores binary objects under a key and knows the public url they are served from
+type BlobStore interface {
+	Put(key string, contentType string, data []byte) error
+	Get(key string) ([]byte, error)
+	Delete(key string) error
+	URL(key string) string
+}
+
+var (
+	store        BlobStore
+	privateStore BlobStore
+)
+
+func getenv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func newStore(bucket string, localRoot string) BlobStore {
+	switch os.Getenv("STORAGE_DRIVER") {
+	case S3Driver:
+		return NewS3Store(S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    os.Getenv("S3_REGION"),
+			Bucket:    bucket,
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			PublicURL: os.Getenv("STORAGE_BASE_URL"),
+		})
+	}
+	return NewLocalStore(localRoot, os.Getenv("STORAGE_BASE_URL")+defaultLocalPrefix)
+}
+
+// Storage returns the BlobStore configured by STORAGE_DRIVER, defaulting to the local filesystem
+func Storage() BlobStore {
+	if store == nil {
+		godotenv.Load()
+		store = newStore(os.Getenv("S3_BUCKET"), getenv("STORAGE_LOCAL_PATH", defaultLocalRoot))
+	}
+	return store
+}
+
+// PrivateStorage returns a BlobStore for objects that must never be served publicly, such
+// as merchant verification documents. Its URLs are not reachable, read objects through Get.
+// The s3 driver needs a S3_PRIVATE_BUCKET of its own, the app does not start without it.
+func PrivateStorage() BlobStore {
+	if privateStore == nil {
+		godotenv.Load()
+		bucket := os.Getenv("S3_PRIVATE_BUCKET")
+		if os.Getenv("STORAGE_DRIVER") == S3Driver && (bucket == "" || bucket == os.Getenv("S3_BUCKET")) {
+			panic("the s3 storage driver needs a S3_PRIVATE_BUCKET other than S3_BUCKET")
+		}
+		privateStore = newStore(bucket, getenv("STORAGE_PRIVATE_PATH", defaultPrivateLocalRoot))
+	}
+	return privateStore
+}
+
+// LocalRoot returns the directory served for the local driver, or an empty string for other drivers
+func LocalRoot() string {
+	if local, ok := Storage().(*LocalStore); ok {
+		return local.root
+	}
+	return ""
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"os"
+	"testing"
+)
+
+func TestPrivateStorage(t *testing.T) {
+	tests := []struct {
+		name          string
+		driver        string
+		bucket        string
+		privateBucket string
+		wantPanic     bool
+	}{
+		{name: "local driver", driver: LocalDriver, wantPanic: false},
+		{name: "s3 driver with private bucket", driver: S3Driver, bucket: "images", privateBucket: "documents",
+			wantPanic: false},
+		{name: "s3 driver without private bucket", driver: S3Driver, bucket: "images", wantPanic: true},
+		{name: "s3 driver with public bucket", driver: S3Driver, bucket: "images", privateBucket: "images",
+			wantPanic: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range map[string]string{
+				"STORAGE_DRIVER":    tt.driver,
+				"S3_BUCKET":         tt.bucket,
+				"S3_PRIVATE_BUCKET": tt.privateBucket,
+			} {
+				previous := os.Getenv(key)
+				os.Setenv(key, value)
+				defer os.Setenv(key, previous)
+			}
+			privateStore = nil
+			defer func() {
+				privateStore = nil
+				if r := recover(); (r != nil) != tt.wantPanic {
+					t.Errorf("PrivateStorage() panic = %v, wantPanic %v", r, tt.wantPanic)
+				}
+			}()
+			PrivateStorage()
+		})
+	}
+}
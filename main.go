@@ -1,20 +1,43 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"net/http"
 	"os"
 
 	"github.com/labstack/echo"
+	"github.com/williamchang80/sea-apd/common/logger"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/docs"
 	"github.com/williamchang80/sea-apd/routes"
 )
 
 func main() {
+	shutdownTracing, err := tracing.Init(context.Background())
+	if err != nil {
+		logger.Error(context.Background(), "tracing failed to start", logger.Fields{"error": err.Error()})
+		os.Exit(1)
+	}
 	e := echo.New()
 	routes.InitMainRoutes(e)
+	if docsPort := os.Getenv("SWAGGER_PORT"); docsPort != "" {
+		go serveDocs(":" + docsPort)
+	}
 	appPort := ":" + os.Getenv("APP_PORT")
 	appHost := fmt.Sprintf("http://%s%v", os.Getenv("APP_HOST"), appPort)
-	fmt.Println("App is running on " + appHost)
-	log.Panic(http.ListenAndServe(appPort, e))
+	logger.Info(context.Background(), "app is running", logger.Fields{"url": appHost})
+	if err := http.ListenAndServe(appPort, e); err != nil {
+		logger.Error(context.Background(), "app stopped", logger.Fields{"error": err.Error()})
+		shutdownTracing(context.Background())
+		os.Exit(1)
+	}
+}
+
+// serveDocs serves swagger ui next to the app, nginx proxies /docs to it
+func serveDocs(port string) {
+	logger.Info(context.Background(), "docs are running", logger.Fields{"port": port})
+	if err := http.ListenAndServe(port, docs.Handler()); err != nil {
+		logger.Error(context.Background(), "docs stopped", logger.Fields{"error": err.Error()})
+	}
 }
@@ -0,0 +1,100 @@
+package address
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/address"
+)
+
+// Addresses of MockCustomerId known to the mock repository, the home address is the default
+const (
+	MockCustomerId      = "customer"
+	MockHomeAddressId   = "home"
+	MockOfficeAddressId = "office"
+)
+
+func mockAddresses() []address.Address {
+	return []address.Address{
+		{
+			Base:          domain.Base{ID: MockHomeAddressId},
+			CustomerId:    MockCustomerId,
+			Label:         "Home",
+			RecipientName: "Mock Recipient",
+			Phone:         "081234567890",
+			Street:        "Jl. Mock No. 1",
+			City:          "Jakarta Selatan",
+			Province:      "DKI Jakarta",
+			PostalCode:    "12345",
+			IsDefault:     true,
+		},
+		{
+			Base:          domain.Base{ID: MockOfficeAddressId},
+			CustomerId:    MockCustomerId,
+			Label:         "Office",
+			RecipientName: "Mock Recipient",
+			Phone:         "081234567890",
+			Street:        "Jl. Mock No. 2",
+			City:          "Bandung",
+			Province:      "Jawa Barat",
+			PostalCode:    "40111",
+		},
+	}
+}
+
+type MockRepository struct {
+	ctrl *gomock.Controller
+}
+
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	return &MockRepository{ctrl: ctrl}
+}
+
+func (m MockRepository) CreateAddress(ctx context.Context, addr address.Address) error {
+	if addr.CustomerId == "" || addr.Street == "" {
+		return errors.New("Cannot Create Address")
+	}
+	return nil
+}
+
+func (m MockRepository) UpdateAddress(ctx context.Context, addressId string, addr address.Address) error {
+	if addressId == "" {
+		return errors.New("Cannot Update Address")
+	}
+	return nil
+}
+
+func (m MockRepository) DeleteAddress(ctx context.Context, addressId string) error {
+	if addressId == "" {
+		return errors.New("Cannot Delete Address")
+	}
+	return nil
+}
+
+func (m MockRepository) GetAddressById(ctx context.Context, addressId string) (*address.Address, error) {
+	for _, addr := range mockAddresses() {
+		if addr.ID == addressId {
+			return &addr, nil
+		}
+	}
+	return nil, errors.New("Cannot Get Address By Id")
+}
+
+func (m MockRepository) GetAddressesByCustomer(ctx context.Context, customerId string) ([]address.Address, error) {
+	if customerId == "" {
+		return nil, errors.New("Cannot Get Addresses By Customer")
+	}
+	if customerId == MockCustomerId {
+		return mockAddresses(), nil
+	}
+	return []address.Address{}, nil
+}
+
+func (m MockRepository) SetDefaultAddress(ctx context.Context, customerId string, addressId string) error {
+	if customerId == "" || addressId == "" {
+		return errors.New("Cannot Set Default Address")
+	}
+	return nil
+}
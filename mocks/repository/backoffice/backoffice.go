@@ -0,0 +1,67 @@
+package backoffice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/domain/backoffice"
+	"github.com/williamchang80/sea-apd/domain/merchant"
+	"github.com/williamchang80/sea-apd/domain/transaction"
+	"github.com/williamchang80/sea-apd/domain/user"
+)
+
+type MockRepository struct {
+	ctrl *gomock.Controller
+}
+
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{
+		ctrl: ctrl,
+	}
+	return mock
+}
+
+func (m MockRepository) SearchUsers(ctx context.Context, query backoffice.UserQuery) ([]user.User, error) {
+	return []user.User{{Name: "Mock User", Password: "hash"}}, nil
+}
+
+func (m MockRepository) SearchMerchants(ctx context.Context, query backoffice.MerchantQuery) ([]merchant.Merchant, error) {
+	return []merchant.Merchant{}, nil
+}
+
+func (m MockRepository) SearchTransactions(ctx context.Context, query backoffice.TransactionQuery) ([]transaction.Transaction, error) {
+	return []transaction.Transaction{}, nil
+}
+
+func (m MockRepository) SetUserBan(ctx context.Context, userId string, bannedAt *time.Time, reason string,
+	audit backoffice.AdminAudit) error {
+	if userId == "" || audit.AdminId == "" {
+		return errors.New("Cannot Set User Ban")
+	}
+	return nil
+}
+
+func (m MockRepository) AdjustMerchantBalance(ctx context.Context, adjustment backoffice.BalanceAdjustment,
+	audit backoffice.AdminAudit) error {
+	if adjustment.MerchantId == "" || audit.AdminId == "" {
+		return errors.New("Cannot Adjust Merchant Balance")
+	}
+	return nil
+}
+
+func (m MockRepository) CreateAudit(ctx context.Context, audit backoffice.AdminAudit) error {
+	if audit.AdminId == "" || audit.Action == "" {
+		return errors.New("Cannot Create Audit")
+	}
+	return nil
+}
+
+func (m MockRepository) GetAudits(ctx context.Context, query backoffice.AuditQuery) ([]backoffice.AdminAudit, error) {
+	return []backoffice.AdminAudit{}, nil
+}
+
+func (m MockRepository) GetPlatformKpis(ctx context.Context) (*backoffice.PlatformKpis, error) {
+	return &backoffice.PlatformKpis{}, nil
+}
@@ -0,0 +1,103 @@
+package bank_account
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/common/constants/bank_account_status"
+	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/bank_account"
+)
+
+// Accounts of MockMerchantId known to the mock repository, the verified one is the default
+const (
+	MockMerchantId        = "waiting"
+	MockVerifiedAccountId = "verified"
+	MockPendingAccountId  = "pending"
+	MockAccountNumber     = "1234567890"
+)
+
+func mockAccounts() []bank_account.BankAccount {
+	return []bank_account.BankAccount{
+		{
+			Base:          domain.Base{ID: MockVerifiedAccountId},
+			MerchantId:    MockMerchantId,
+			BankCode:      "014",
+			AccountNumber: MockAccountNumber,
+			HolderName:    "Mock Holder",
+			Status:        bank_account_status.ToString(bank_account_status.VERIFIED),
+			IsDefault:     true,
+		},
+		{
+			Base:          domain.Base{ID: MockPendingAccountId},
+			MerchantId:    MockMerchantId,
+			BankCode:      "009",
+			AccountNumber: "9876543210",
+			HolderName:    "Mock Holder",
+			Status:        bank_account_status.ToString(bank_account_status.PENDING),
+		},
+	}
+}
+
+type MockRepository struct {
+	ctrl *gomock.Controller
+}
+
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	return &MockRepository{ctrl: ctrl}
+}
+
+func (m MockRepository) CreateBankAccount(ctx context.Context, account bank_account.BankAccount) error {
+	if account.MerchantId == "" || account.AccountNumber == "" {
+		return errors.New("Cannot Create Bank Account")
+	}
+	return nil
+}
+
+func (m MockRepository) UpdateBankAccount(ctx context.Context, accountId string, account bank_account.BankAccount) error {
+	if accountId == "" {
+		return errors.New("Cannot Update Bank Account")
+	}
+	return nil
+}
+
+func (m MockRepository) DeleteBankAccount(ctx context.Context, accountId string) error {
+	if accountId == "" {
+		return errors.New("Cannot Delete Bank Account")
+	}
+	return nil
+}
+
+func (m MockRepository) GetBankAccountById(ctx context.Context, accountId string) (*bank_account.BankAccount, error) {
+	for _, account := range mockAccounts() {
+		if account.ID == accountId {
+			return &account, nil
+		}
+	}
+	return nil, errors.New("Cannot Get Bank Account By Id")
+}
+
+func (m MockRepository) GetBankAccountsByMerchant(ctx context.Context, merchantId string) ([]bank_account.BankAccount, error) {
+	if merchantId == "" {
+		return nil, errors.New("Cannot Get Bank Accounts By Merchant")
+	}
+	if merchantId == MockMerchantId {
+		return mockAccounts(), nil
+	}
+	return []bank_account.BankAccount{}, nil
+}
+
+func (m MockRepository) SetDefaultBankAccount(ctx context.Context, merchantId string, accountId string) error {
+	if merchantId == "" || accountId == "" {
+		return errors.New("Cannot Set Default Bank Account")
+	}
+	return nil
+}
+
+func (m MockRepository) UpdateBankAccountStatus(ctx context.Context, accountId string, status string) error {
+	if accountId == "" || status == "" {
+		return errors.New("Cannot Update Bank Account Status")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package category
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/category"
+)
+
+var (
+	fashionId      = "1"
+	shoesId        = "2"
+	mockCategories = []category.Category{
+		{Base: domain.Base{ID: "1"}, Name: "Fashion", Slug: "fashion"},
+		{Base: domain.Base{ID: "2"}, Name: "Shoes", Slug: "shoes", ParentId: &fashionId},
+		{Base: domain.Base{ID: "3"}, Name: "Sneakers", Slug: "sneakers", ParentId: &shoesId},
+		{Base: domain.Base{ID: "4"}, Name: "Books", Slug: "books"},
+	}
+	mockProductCounts = map[string]int{"2": 3, "3": 2, "4": 1}
+)
+
+type MockRepository struct {
+	ctrl *gomock.Controller
+}
+
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	return &MockRepository{ctrl: ctrl}
+}
+
+func (m MockRepository) CreateCategory(ctx context.Context, c category.Category) error {
+	if c.Slug == "" {
+		return errors.New("Cannot Create Category")
+	}
+	return nil
+}
+
+func (m MockRepository) UpdateCategory(ctx context.Context, categoryId string, c category.Category) error {
+	if categoryId == "" {
+		return errors.New("Cannot Update Category")
+	}
+	return nil
+}
+
+func (m MockRepository) DeleteCategory(ctx context.Context, c category.Category) error {
+	if c.ID == "" {
+		return errors.New("Cannot Delete Category")
+	}
+	return nil
+}
+
+func (m MockRepository) GetCategoryById(ctx context.Context, categoryId string) (*category.Category, error) {
+	for _, c := range mockCategories {
+		if c.ID == categoryId {
+			return &c, nil
+		}
+	}
+	return nil, errors.New("Cannot Get Category By Id")
+}
+
+func (m MockRepository) GetCategories(ctx context.Context) ([]category.Category, error) {
+	return mockCategories, nil
+}
+
+func (m MockRepository) GetProductCountByCategory(ctx context.Context) (map[string]int, error) {
+	return mockProductCounts, nil
+}
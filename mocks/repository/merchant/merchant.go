@@ -1,13 +1,42 @@
 package merchant
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/common/constants/document_type"
+	"github.com/williamchang80/sea-apd/common/constants/merchant_status"
+	"github.com/williamchang80/sea-apd/domain"
 	"github.com/williamchang80/sea-apd/domain/merchant"
 	merch "github.com/williamchang80/sea-apd/domain/merchant"
 )
 
+// Merchants the mock repository knows about. The waiting and declined merchants have
+// uploaded every required document, the incomplete merchant has none. The accepted
+// merchant still has a balance, the pending merchant only has orders waiting to complete
+// and the suspension of the expired merchant has run out.
+const (
+	MockWaitingMerchantId    = "waiting"
+	MockDeclinedMerchantId   = "declined"
+	MockIncompleteMerchantId = "incomplete"
+	MockAcceptedMerchantId   = "accepted"
+	MockPendingMerchantId    = "pending"
+	MockSuspendedMerchantId  = "suspended"
+	MockExpiredMerchantId    = "expired"
+	MockClosedMerchantId     = "closed"
+	MockMerchantUserId       = "1"
+)
+
+func mockMerchant(merchantId string, status merchant_status.MerchantApprovalStatus) *merch.Merchant {
+	return &merch.Merchant{
+		Base:     domain.Base{ID: merchantId},
+		UserId:   MockMerchantUserId,
+		Approval: merchant_status.ToString(status),
+	}
+}
+
 type MockRepository struct {
 	ctrl *gomock.Controller
 }
@@ -19,51 +48,142 @@ func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
 	return mock
 }
 
-func (m MockRepository) UpdateMerchantBalance(amount int, merchantId string) error {
+func (m MockRepository) UpdateMerchantBalance(ctx context.Context, amount int, merchantId string) error {
 	if len(merchantId) == 0 || amount == 0 {
 		return errors.New("Id and amount cannot be empty")
 	}
 	return nil
 }
 
-func (m MockRepository) GetMerchantBalance(merchantId string) (int, error) {
+func (m MockRepository) GetMerchantBalance(ctx context.Context, merchantId string) (int, error) {
 	if len(merchantId) == 0 {
 		return 0, errors.New("Id cannot be empty")
 	}
 	return 100, nil
 }
 
-func (m MockRepository) RegisterMerchant(merchant merchant.Merchant) (*merch.Merchant, error) {
+func (m MockRepository) UpdateMerchantPendingBalance(ctx context.Context, amount int, merchantId string) error {
+	if len(merchantId) == 0 || amount == 0 {
+		return errors.New("Id and amount cannot be empty")
+	}
+	return nil
+}
+
+func (m MockRepository) RegisterMerchant(ctx context.Context, merchant merchant.Merchant) (*merch.Merchant, error) {
 	var mh = merch.Merchant{}
 	if merchant == mh {
 		return nil,errors.New("Cannot Register Merchant")
 	}
-	return &merch.Merchant{},nil
+	return &merchant,nil
 }
 
-func (m MockRepository) GetMerchants() ([]merchant.Merchant, error) {
+func (m MockRepository) GetMerchants(ctx context.Context) ([]merchant.Merchant, error) {
 	m.ctrl.T.Helper()
 	return []merchant.Merchant{}, nil
 }
 
-func (m MockRepository) GetMerchantById(merchantId string) (*merchant.Merchant, error) {
-	if merchantId != "" {
-		return &merchant.Merchant{}, nil
+func (m MockRepository) GetMerchantById(ctx context.Context, merchantId string) (*merchant.Merchant, error) {
+	switch merchantId {
+	case "":
+		return nil, errors.New("Cannot Get Merchant By Id")
+	case MockWaitingMerchantId, MockIncompleteMerchantId:
+		return mockMerchant(merchantId, merchant_status.WAITING), nil
+	case MockDeclinedMerchantId:
+		return mockMerchant(merchantId, merchant_status.DECLINED), nil
+	case MockAcceptedMerchantId:
+		merch := mockMerchant(merchantId, merchant_status.ACCEPTED)
+		merch.Balance = 100
+		return merch, nil
+	case MockPendingMerchantId:
+		merch := mockMerchant(merchantId, merchant_status.ACCEPTED)
+		merch.PendingBalance = 250
+		return merch, nil
+	case MockSuspendedMerchantId, MockExpiredMerchantId:
+		merch := mockMerchant(merchantId, merchant_status.SUSPENDED)
+		until := time.Now().Add(24 * time.Hour)
+		if merchantId == MockExpiredMerchantId {
+			until = time.Now().Add(-time.Hour)
+		}
+		merch.SuspensionReason = "mock reason"
+		merch.SuspendedUntil = &until
+		return merch, nil
+	case MockClosedMerchantId:
+		return mockMerchant(merchantId, merchant_status.CLOSED), nil
 	}
-	return nil, errors.New("Cannot Get Merchant By Id")
+	return &merchant.Merchant{}, nil
 }
 
-func (m MockRepository) GetMerchantsByUser(userId string) ([]merchant.Merchant, error) {
+func (m MockRepository) GetMerchantsByUser(ctx context.Context, userId string) ([]merchant.Merchant, error) {
 	if userId != "" {
 		return []merchant.Merchant{}, nil
 	}
 	return nil, errors.New("Cannot Get Merchants By User")
 }
 
-func (m MockRepository) UpdateMerchantApprovalStatus(merchantId string, status string) error {
+func (m MockRepository) UpdateMerchantApprovalStatus(ctx context.Context, merchantId string, status string) error {
 	panic("implement me")
 }
 
-func (m MockRepository) UpdateMerchant(merchantId string, merchant merch.Merchant) error {
+func (m MockRepository) UpdateMerchant(ctx context.Context, merchantId string, merchant merch.Merchant) error {
 	panic("implement me")
 }
+
+func (m MockRepository) GetMerchantsByStatus(ctx context.Context, status string) ([]merch.Merchant, error) {
+	switch merchant_status.ParseToEnum(status) {
+	case merchant_status.WAITING:
+		return []merch.Merchant{
+			*mockMerchant(MockWaitingMerchantId, merchant_status.WAITING),
+			*mockMerchant(MockIncompleteMerchantId, merchant_status.WAITING),
+		}, nil
+	case merchant_status.OTHER:
+		return nil, errors.New("Cannot Get Merchants By Status")
+	}
+	return []merch.Merchant{}, nil
+}
+
+func (m MockRepository) ChangeMerchantStatus(ctx context.Context, review merch.MerchantReview, changes map[string]interface{}) error {
+	if review.MerchantId == "" || review.ActorId == "" {
+		return errors.New("Cannot Change Merchant Status")
+	}
+	return nil
+}
+
+func (m MockRepository) GetMerchantReviews(ctx context.Context, merchantId string) ([]merch.MerchantReview, error) {
+	return []merch.MerchantReview{}, nil
+}
+
+func (m MockRepository) CreateMerchantDocument(ctx context.Context, document merch.MerchantDocument) error {
+	if document.MerchantId == "" || document.Key == "" {
+		return errors.New("Cannot Create Merchant Document")
+	}
+	return nil
+}
+
+func (m MockRepository) DeleteMerchantDocument(ctx context.Context, documentId string) error {
+	return nil
+}
+
+func (m MockRepository) GetMerchantDocumentById(ctx context.Context, documentId string) (*merch.MerchantDocument, error) {
+	if documentId == "" {
+		return nil, errors.New("Cannot Get Merchant Document By Id")
+	}
+	return &merch.MerchantDocument{Base: domain.Base{ID: documentId}, Key: documentId}, nil
+}
+
+func (m MockRepository) GetMerchantDocuments(ctx context.Context, merchantIds []string) ([]merch.MerchantDocument, error) {
+	documents := []merch.MerchantDocument{}
+	for _, id := range merchantIds {
+		if id != MockWaitingMerchantId && id != MockDeclinedMerchantId {
+			continue
+		}
+		for _, t := range document_type.GetRequiredDocumentTypes() {
+			documents = append(documents, merch.MerchantDocument{
+				Base:       domain.Base{ID: id + t},
+				MerchantId: id,
+				Type:       t,
+				Key:        id + t,
+			})
+		}
+	}
+	return documents, nil
+}
@@ -1,21 +1,28 @@
 package product
 
 import (
+	"context"
 	"errors"
+	"reflect"
+
 	"github.com/golang/mock/gomock"
+	domain2 "github.com/williamchang80/sea-apd/domain"
 	domain "github.com/williamchang80/sea-apd/domain/product"
+	"github.com/williamchang80/sea-apd/domain/transaction"
 )
 
+var mockVariantPrice = 30
+
 type MockRepository struct {
 	ctrl *gomock.Controller
 }
 
-func (m MockRepository) GetProducts() ([]domain.Product, error) {
+func (m MockRepository) GetProducts(ctx context.Context) ([]domain.Product, error) {
 	m.ctrl.T.Helper()
 	return []domain.Product{}, nil
 }
 
-func (m MockRepository) GetProductById(id string) (*domain.Product, error) {
+func (m MockRepository) GetProductById(ctx context.Context, id string) (*domain.Product, error) {
 	if id != "" {
 		return &domain.Product{
 			Name:        "Mock Name",
@@ -28,23 +35,23 @@ func (m MockRepository) GetProductById(id string) (*domain.Product, error) {
 	return nil, errors.New("Cannot Get Product By Id")
 }
 
-func (m MockRepository) CreateProduct(product domain.Product) error {
+func (m MockRepository) CreateProduct(ctx context.Context, product domain.Product) error {
 	var p = domain.Product{}
-	if product == p {
+	if reflect.DeepEqual(product, p) {
 		return errors.New("Cannot Create Product")
 	}
 	return nil
 }
 
-func (m MockRepository) UpdateProduct(s string, product domain.Product) error {
+func (m MockRepository) UpdateProduct(ctx context.Context, s string, product domain.Product) error {
 	var p = domain.Product{}
-	if s != "" || product == p {
+	if s != "" || reflect.DeepEqual(product, p) {
 		return nil
 	}
 	return errors.New("Cannot Update Product")
 }
 
-func (m MockRepository) DeleteProduct(s string) error {
+func (m MockRepository) DeleteProduct(ctx context.Context, s string) error {
 	if s != "" {
 		return nil
 	}
@@ -58,9 +65,117 @@ func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
 	return mock
 }
 
-func (m MockRepository) GetProductsByMerchant(merchantId string) ([]domain.Product, error) {
+func (m MockRepository) GetProductsByMerchant(ctx context.Context, merchantId string) ([]domain.Product, error) {
 	if merchantId != "" {
 		return []domain.Product{}, nil
 	}
 	return nil, errors.New("Cannot Delete Product")
 }
+
+func (m MockRepository) GetProductsByCategories(ctx context.Context, categoryIds []string) ([]domain.Product, error) {
+	if len(categoryIds) == 0 {
+		return nil, errors.New("Cannot Get Products By Categories")
+	}
+	return []domain.Product{}, nil
+}
+
+func (m MockRepository) GetProductsByTag(ctx context.Context, tag string) ([]domain.Product, error) {
+	if tag == "" {
+		return nil, errors.New("Cannot Get Products By Tag")
+	}
+	return []domain.Product{}, nil
+}
+
+func (m MockRepository) GetOrCreateTags(ctx context.Context, names []string) ([]domain.Tag, error) {
+	tags := []domain.Tag{}
+	for _, name := range names {
+		tags = append(tags, domain.Tag{Name: name})
+	}
+	return tags, nil
+}
+
+func (m MockRepository) ReplaceOptions(ctx context.Context, productId string, options []domain.ProductOption) error {
+	if productId == "" {
+		return errors.New("Cannot Replace Options")
+	}
+	return nil
+}
+
+func (m MockRepository) CreateVariant(ctx context.Context, variant domain.ProductVariant) error {
+	if variant.Sku == "" {
+		return errors.New("Cannot Create Variant")
+	}
+	return nil
+}
+
+func (m MockRepository) UpdateVariant(ctx context.Context, variantId string, variant domain.ProductVariant) error {
+	if variantId == "" {
+		return errors.New("Cannot Update Variant")
+	}
+	return nil
+}
+
+func (m MockRepository) DeleteVariant(ctx context.Context, variantId string) error {
+	if variantId == "" {
+		return errors.New("Cannot Delete Variant")
+	}
+	return nil
+}
+
+func (m MockRepository) GetVariantById(ctx context.Context, variantId string) (*domain.ProductVariant, error) {
+	if variantId == "" {
+		return nil, errors.New("Cannot Get Variant By Id")
+	}
+	return &domain.ProductVariant{
+		Base:      domain2.Base{ID: variantId},
+		ProductId: "1",
+		Sku:       "MOCK-SKU",
+		Price:     &mockVariantPrice,
+		Stock:     5,
+	}, nil
+}
+
+func (m MockRepository) ReserveStock(ctx context.Context, details []transaction.ProductTransaction) error {
+	for _, d := range details {
+		if d.Quantity > 5 {
+			return domain.ErrInsufficientStock
+		}
+	}
+	return nil
+}
+
+func (m MockRepository) ReleaseStock(ctx context.Context, details []transaction.ProductTransaction) error {
+	return nil
+}
+
+// MockExistingSku is the sku the mock repository already has a product for
+const MockExistingSku = "EXISTING"
+
+func (m MockRepository) UpsertProduct(ctx context.Context, product domain.Product) (bool, error) {
+	if product.Sku == "" || product.MerchantId == "" {
+		return false, errors.New("Cannot Upsert Product")
+	}
+	return product.Sku != MockExistingSku, nil
+}
+
+func (m MockRepository) CreateImportJob(ctx context.Context, job *domain.ImportJob) error {
+	if job.MerchantId == "" {
+		return errors.New("Cannot Create Import Job")
+	}
+	job.ID = "job"
+	return nil
+}
+
+func (m MockRepository) UpdateImportJob(ctx context.Context, job *domain.ImportJob) error {
+	if job.ID == "" {
+		return errors.New("Cannot Update Import Job")
+	}
+	return nil
+}
+
+func (m MockRepository) GetImportJobById(ctx context.Context, jobId string) (*domain.ImportJob, error) {
+	if jobId == "" {
+		return nil, errors.New("Cannot Get Import Job By Id")
+	}
+	return &domain.ImportJob{Base: domain2.Base{ID: jobId}}, nil
+}
@@ -0,0 +1,50 @@
+package shipping
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/domain/shipping"
+)
+
+// MockMerchantId has rate tables for DKI Jakarta and every other region, MockFreeMerchantId
+// has no rates at all
+const (
+	MockMerchantId     = "rated"
+	MockFreeMerchantId = "free"
+)
+
+func mockRates() []shipping.ShippingRate {
+	return []shipping.ShippingRate{
+		{MerchantId: MockMerchantId, Region: "DKI Jakarta", MaxWeightGrams: 1000, Cost: 9000},
+		{MerchantId: MockMerchantId, Region: "DKI Jakarta", MaxWeightGrams: 5000, Cost: 20000},
+		{MerchantId: MockMerchantId, Region: shipping.AnyRegion, MaxWeightGrams: 1000, Cost: 15000},
+		{MerchantId: MockMerchantId, Region: shipping.AnyRegion, MaxWeightGrams: 10000, Cost: 45000},
+	}
+}
+
+type MockRepository struct {
+	ctrl *gomock.Controller
+}
+
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	return &MockRepository{ctrl: ctrl}
+}
+
+func (m MockRepository) GetShippingRates(ctx context.Context, merchantId string) ([]shipping.ShippingRate, error) {
+	if merchantId == "" {
+		return nil, errors.New("Cannot Get Shipping Rates")
+	}
+	if merchantId == MockMerchantId {
+		return mockRates(), nil
+	}
+	return []shipping.ShippingRate{}, nil
+}
+
+func (m MockRepository) ReplaceShippingRates(ctx context.Context, merchantId string, rates []shipping.ShippingRate) error {
+	if merchantId == "" {
+		return errors.New("Cannot Replace Shipping Rates")
+	}
+	return nil
+}
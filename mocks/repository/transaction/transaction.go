@@ -1,11 +1,16 @@
 package transaction
 
 import (
+	"context"
 	"errors"
 	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
 	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/analytics"
+	"github.com/williamchang80/sea-apd/domain/merchant"
 	"github.com/williamchang80/sea-apd/domain/transaction"
 	"reflect"
+	"time"
 )
 
 var (
@@ -18,8 +23,41 @@ var (
 		CustomerId: "",
 		MerchantId: "",
 	}
+	MockCartId = "cart"
+	// MockWaitingPaymentId is a checked out transaction of customer "1" waiting for its payment
+	MockWaitingPaymentId = "waiting-payment"
+	// MockWaitingConfirmationId is a transaction of merchant "1" whose payment waits for
+	// its confirmation
+	MockWaitingConfirmationId = "waiting-confirmation"
+	// MockWaitingDeliveryId is a paid transaction of merchant "1" waiting for its shipment
+	MockWaitingDeliveryId = "waiting-delivery"
+	// MockShippedId is an accepted transaction of customer "1" waiting for its delivery
+	MockShippedId = "shipped"
+	// MockUnfundedShippedId is shipped like MockShippedId but the pending balance of its
+	// merchant does not hold its amount
+	MockUnfundedShippedId = "unfunded-shipped"
 )
 
+func mockCart() *transaction.Transaction {
+	return &transaction.Transaction{
+		Base:       domain.Base{ID: MockCartId},
+		Status:     transaction_status.ToString(transaction_status.ON_CARTS),
+		CustomerId: "1",
+		MerchantId: "1",
+		ProductDetails: []transaction.ProductTransaction{
+			{ProductId: "1", TransactionId: MockCartId, Quantity: 2},
+		},
+	}
+}
+
+func mockShipped() *transaction.Transaction {
+	tran := mockCart()
+	tran.ID = MockShippedId
+	tran.Status = transaction_status.ToString(transaction_status.ACCEPTED)
+	tran.Amount = 1000
+	return tran
+}
+
 type MockRepository struct {
 	ctrl *gomock.Controller
 }
@@ -30,38 +68,196 @@ func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
 	return mock
 }
 
-func (m MockRepository) CreateTransaction(transaction transaction.Transaction) error {
+func (m MockRepository) CreateTransaction(ctx context.Context, transaction transaction.Transaction) error {
 	if reflect.DeepEqual(transaction, emptyTransaction) {
 		return errors.New("Transaction cannot be empty")
 	}
 	return nil
 }
 
-func (m MockRepository) GetTransactionById(id string) (*transaction.Transaction, error) {
+func (m MockRepository) GetTransactionById(ctx context.Context, id string) (*transaction.Transaction, error) {
 	if len(id) == 0 {
 		return nil, errors.New("Id cannot be empty")
 	}
+	if id == MockCartId {
+		return mockCart(), nil
+	}
+	if id == MockWaitingPaymentId {
+		tran := mockCart()
+		tran.ID = MockWaitingPaymentId
+		tran.Status = transaction_status.ToString(transaction_status.WAITING_PAYMENT)
+		return tran, nil
+	}
+	if id == MockWaitingConfirmationId {
+		tran := mockCart()
+		tran.ID = MockWaitingConfirmationId
+		tran.Status = transaction_status.ToString(transaction_status.WAITING_CONFIRMATION)
+		return tran, nil
+	}
+	if id == MockWaitingDeliveryId {
+		tran := mockCart()
+		tran.ID = MockWaitingDeliveryId
+		tran.Status = transaction_status.ToString(transaction_status.WAITING_DELIVERY)
+		return tran, nil
+	}
+	if id == MockShippedId {
+		return mockShipped(), nil
+	}
+	if id == MockUnfundedShippedId {
+		tran := mockShipped()
+		tran.ID = MockUnfundedShippedId
+		return tran, nil
+	}
 	return &emptyTransaction, nil
 }
 
-func (m MockRepository) UpdateTransactionStatus(status string, id string) (*transaction.Transaction, error) {
+func (m MockRepository) UpdateTransactionStatus(ctx context.Context, status string, id string) (*transaction.Transaction, error) {
 	if len(status) == 0 || len(id) == 0 {
 		return nil, errors.New("Cannot Update with empty object")
 	}
 	return &emptyTransaction, nil
 }
 
-func (m MockRepository) GetTransactionByRequiredStatus(requiredStatus []string, userId string) ([]transaction.Transaction, error) {
+func (m MockRepository) GetTransactionByRequiredStatus(ctx context.Context, requiredStatus []string, userId string) ([]transaction.Transaction, error) {
 	if len(userId) == 0 || len(requiredStatus) == 0 {
 		return nil, errors.New("Cannot Get Required status with empty user id")
 	}
 	return []transaction.Transaction{}, nil
 }
 
-func (m MockRepository) GetMerchantRequestItem(merchantId string) ([]transaction.Transaction, error) {
+func (m MockRepository) GetMerchantRequestItem(ctx context.Context, merchantId string) ([]transaction.Transaction, error) {
 	panic("implement me")
 }
 
-func (m MockRepository) UpdateTransaction(transaction transaction.Transaction) error {
-	panic("implement me")
+func (m MockRepository) UpdateTransaction(ctx context.Context, transaction transaction.Transaction) error {
+	if len(transaction.ID) == 0 {
+		return errors.New("Id cannot be empty")
+	}
+	return nil
+}
+
+func (m MockRepository) GetCart(ctx context.Context, customerId string, merchantId string) (*transaction.Transaction, error) {
+	if len(customerId) == 0 || len(merchantId) == 0 {
+		return nil, errors.New("Cannot Get Cart")
+	}
+	return mockCart(), nil
+}
+
+func (m MockRepository) GetCartsByCustomer(ctx context.Context, customerId string) ([]transaction.Transaction, error) {
+	if len(customerId) == 0 {
+		return nil, errors.New("Cannot Get Carts")
+	}
+	return []transaction.Transaction{*mockCart()}, nil
+}
+
+func (m MockRepository) AddCartItem(ctx context.Context, item transaction.ProductTransaction) error {
+	if len(item.TransactionId) == 0 {
+		return errors.New("Cannot Add Cart Item")
+	}
+	return nil
+}
+
+func (m MockRepository) CheckoutTransaction(ctx context.Context, transaction transaction.Transaction) error {
+	if len(transaction.ID) == 0 {
+		return errors.New("Cannot Checkout Transaction")
+	}
+	return nil
+}
+
+// MockSalesBuckets are the sales of merchant "1" known to the mock repository
+var MockSalesBuckets = []analytics.SalesBucket{
+	{BucketStart: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Revenue: 3000, Orders: 2},
+	{BucketStart: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), Revenue: 1000, Orders: 1},
+}
+
+func (m MockRepository) GetSalesBuckets(ctx context.Context, query analytics.AnalyticsQuery) ([]analytics.SalesBucket, error) {
+	if query.MerchantId == "" || query.Bucket == "" {
+		return nil, errors.New("Cannot Get Sales Buckets")
+	}
+	if query.MerchantId != "1" {
+		return []analytics.SalesBucket{}, nil
+	}
+	return append([]analytics.SalesBucket{}, MockSalesBuckets...), nil
+}
+
+func (m MockRepository) GetSalesRollups(ctx context.Context, query analytics.AnalyticsQuery) ([]analytics.SalesBucket, error) {
+	if query.MerchantId == "" || query.Bucket == "" {
+		return nil, errors.New("Cannot Get Sales Rollups")
+	}
+	return append([]analytics.SalesBucket{}, MockSalesBuckets[:1]...), nil
+}
+
+func (m MockRepository) GetTopProducts(ctx context.Context, query analytics.AnalyticsQuery, limit int) ([]analytics.ProductSales, error) {
+	if query.MerchantId == "" || limit <= 0 {
+		return nil, errors.New("Cannot Get Top Products")
+	}
+	return []analytics.ProductSales{{ProductId: "1", Name: "Mock Product", Quantity: 4, Revenue: 4000}}, nil
+}
+
+func (m MockRepository) GetConversion(ctx context.Context, query analytics.AnalyticsQuery) (*analytics.Conversion, error) {
+	if query.MerchantId == "" {
+		return nil, errors.New("Cannot Get Conversion")
+	}
+	if query.MerchantId != "1" {
+		return &analytics.Conversion{}, nil
+	}
+	return &analytics.Conversion{CheckedOut: 10, Paid: 8, Accepted: 6, Refunded: 2}, nil
+}
+
+func (m MockRepository) RefreshSalesRollups(ctx context.Context, since time.Time) error {
+	return nil
+}
+
+func (m MockRepository) ChangeTransactionStatus(ctx context.Context, change transaction.TransactionStatusChange) (*transaction.Transaction, error) {
+	if change.TransactionId == "" || change.ToStatus == "" {
+		return nil, errors.New("Cannot Change Transaction Status")
+	}
+	tran := emptyTransaction
+	if change.TransactionId == MockCartId {
+		tran = *mockCart()
+	}
+	if change.TransactionId == MockShippedId {
+		tran = *mockShipped()
+	}
+	if change.TransactionId == MockWaitingPaymentId || change.TransactionId == MockWaitingConfirmationId ||
+		change.TransactionId == MockWaitingDeliveryId {
+		tran = *mockCart()
+		tran.ID = change.TransactionId
+	}
+	tran.Status = change.ToStatus
+	tran.Amount = 1000
+	return &tran, nil
+}
+
+func (m MockRepository) CompleteTransaction(ctx context.Context, change transaction.TransactionStatusChange) (*transaction.Transaction, error) {
+	if change.TransactionId == MockUnfundedShippedId {
+		return nil, merchant.ErrPendingBalanceTooLow
+	}
+	return m.ChangeTransactionStatus(ctx, change)
+}
+
+func (m MockRepository) GetStatusChanges(ctx context.Context, transactionId string) ([]transaction.TransactionStatusChange, error) {
+	if transactionId == "" {
+		return nil, errors.New("Cannot Get Status Changes")
+	}
+	return []transaction.TransactionStatusChange{}, nil
+}
+
+func (m MockRepository) ShipTransaction(ctx context.Context, change transaction.TransactionStatusChange,
+	shipment transaction.Shipment) (*transaction.Transaction, error) {
+	if change.TransactionId == "" || shipment.Courier == "" || shipment.TrackingNumber == "" {
+		return nil, errors.New("Cannot Ship Transaction")
+	}
+	tran := *mockCart()
+	tran.ID = change.TransactionId
+	tran.Status = change.ToStatus
+	tran.Shipment = shipment
+	return &tran, nil
+}
+
+func (m MockRepository) GetShippedTransactions(ctx context.Context, shippedBefore time.Time) ([]transaction.Transaction, error) {
+	if shippedBefore.IsZero() {
+		return nil, errors.New("Cannot Get Shipped Transactions")
+	}
+	return []transaction.Transaction{*mockShipped()}, nil
 }
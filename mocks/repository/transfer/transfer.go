@@ -1,6 +1,7 @@
 package transfer
 
 import (
+	"context"
 	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/williamchang80/sea-apd/domain/transfer"
@@ -20,14 +21,14 @@ func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
 	return mock
 }
 
-func (m MockRepository) GetTransferHistory(merchantId string) ([]transfer.Transfer, error) {
+func (m MockRepository) GetTransferHistory(ctx context.Context, merchantId string) ([]transfer.Transfer, error) {
 	if len(merchantId) == 0 {
 		return nil, errors.New("Merchant id cannot be empty")
 	}
 	return []transfer.Transfer{}, nil
 }
 
-func (m MockRepository) CreateTransferHistory(transfer transfer.Transfer) error {
+func (m MockRepository) CreateTransferHistory(ctx context.Context, transfer transfer.Transfer) error {
 	if transfer == emptyCreateTransferDomain {
 		return errors.New("Transfer request cannot be empty")
 	}
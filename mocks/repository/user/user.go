@@ -1,15 +1,59 @@
 package user
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/golang/mock/gomock"
-	domain "github.com/williamchang80/sea-apd/domain/user"
+	"github.com/jinzhu/gorm"
+	"github.com/williamchang80/sea-apd/common/auth"
+	"github.com/williamchang80/sea-apd/common/constants/user_role"
+	"github.com/williamchang80/sea-apd/domain"
+	user "github.com/williamchang80/sea-apd/domain/user"
+)
+
+const (
+	// MockPassword is the password of every user returned by the mock repository
+	MockPassword = "password"
+	// MockAdminEmail belongs to an admin, MockUnverifiedEmail to a customer that did not
+	// verify the email yet and MockUnknownEmail to nobody
+	MockAdminEmail      = "admin@mock.com"
+	MockUnverifiedEmail = "unverified@mock.com"
+	MockUnknownEmail    = "unknown@mock.com"
+	// MockRecentlySentEmail was sent a verification mail moments ago
+	MockRecentlySentEmail = "recent@mock.com"
+	// MockResetToken is the token of the only pending password reset, it belongs to
+	// the user of every email but MockUnknownEmail
+	MockResetToken = "reset"
+	// MockRevokedUserId revoked the sessions issued before MockSessionsRevokedAt
+	MockRevokedUserId = "revoked"
+	// MockInvitationToken is the token of the only pending invitation and
+	// MockAcceptedInvitationId the id of an invitation that was used already
+	MockInvitationToken      = "invitation"
+	MockAcceptedInvitationId = "accepted"
+	// MockTwoFactorEmail belongs to MockTwoFactorUserId, who enabled two-factor
+	// authentication with MockTotpSecret and still has MockRecoveryCode.
+	// MockEnrollingEmail enrolled MockTotpSecret but did not confirm it yet.
+	MockTwoFactorEmail  = "2fa@mock.com"
+	MockTwoFactorUserId = "2fa"
+	MockEnrollingEmail  = "enrolling@mock.com"
+	MockTotpSecret      = "JBSWY3DPEHPK3PXP"
+	MockRecoveryCode    = "abcd-efgh"
+)
+
+var (
+	mockPasswordHash      = auth.HashPassword(MockPassword)
+	MockSessionsRevokedAt = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
 )
 
 // MockRepository ...
 type MockRepository struct {
 	ctrl *gomock.Controller
+	// Admins is the number of admins CountUsersByRole reports
+	Admins int
+	// TwoFactorRoles are the roles that are required to enable two-factor authentication
+	TwoFactorRoles []string
 }
 
 // NewMockRepository ...
@@ -21,23 +65,192 @@ func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
 }
 
 // CreateUser ...
-func (m MockRepository) CreateUser(user domain.User) error {
-	var u = domain.User{}
-	if user == u {
+func (m MockRepository) CreateUser(ctx context.Context, us user.User) error {
+	if us.Email == "" {
 		return errors.New("Cannot create user")
 	}
+	if us.Email == MockAdminEmail {
+		return user.ErrEmailTaken
+	}
 	return nil
 }
 
 // GetUserByEmail ...
-func (m MockRepository) GetUserByEmail(email string) (*domain.User, error) {
-	if email != "" {
-		return &domain.User{
-			Name:     "name",
-			Email:    "email",
-			Password: "password",
-			Role:     "0",
-		}, nil
-	}
-	return nil, errors.New("Cannot get user by email")
+func (m MockRepository) GetUserByEmail(ctx context.Context, email string) (*user.User, error) {
+	if email == "" {
+		return nil, errors.New("Cannot get user by email")
+	}
+	if email == MockUnknownEmail {
+		return nil, gorm.ErrRecordNotFound
+	}
+	verifiedAt := time.Now()
+	u := &user.User{
+		Base:            domain.Base{ID: "1"},
+		Name:            "name",
+		Email:           email,
+		Password:        mockPasswordHash,
+		Role:            user_role.ToString(user_role.CUSTOMER),
+		EmailVerifiedAt: &verifiedAt,
+	}
+	switch email {
+	case MockAdminEmail:
+		u.Role = user_role.ToString(user_role.ADMIN)
+	case MockUnverifiedEmail, MockRecentlySentEmail:
+		u.EmailVerifiedAt = nil
+	case MockTwoFactorEmail:
+		u.ID = MockTwoFactorUserId
+		u.TotpSecret = MockTotpSecret
+		u.TotpEnabledAt = &verifiedAt
+	case MockEnrollingEmail:
+		u.TotpSecret = MockTotpSecret
+	}
+	return u, nil
+}
+
+func (m MockRepository) UpdateUserRole(ctx context.Context, role string, userId string) error {
+	if role == "" || userId == "" {
+		return errors.New("Cannot update user role")
+	}
+	return nil
+}
+
+func (m MockRepository) GetUserById(ctx context.Context, userId string) (*user.User, error) {
+	if userId == "" {
+		return nil, errors.New("Cannot get user by id")
+	}
+	u := &user.User{Base: domain.Base{ID: userId}, Password: mockPasswordHash}
+	switch userId {
+	case MockRevokedUserId:
+		u.SessionsRevokedAt = &MockSessionsRevokedAt
+	case MockTwoFactorUserId:
+		enabledAt := time.Now()
+		u.Email = MockTwoFactorEmail
+		u.TotpSecret = MockTotpSecret
+		u.TotpEnabledAt = &enabledAt
+	}
+	return u, nil
+}
+
+func (m MockRepository) UpdateUser(ctx context.Context, user user.User) error {
+	return nil
+}
+
+func (m MockRepository) CountUsersByRole(ctx context.Context, role string) (int, error) {
+	if role == user_role.ToString(user_role.ADMIN) {
+		return m.Admins, nil
+	}
+	return 0, nil
+}
+
+func (m MockRepository) CreateAdminInvitation(ctx context.Context, invitation user.AdminInvitation) error {
+	if invitation.Email == "" || invitation.TokenHash == "" {
+		return errors.New("Cannot create admin invitation")
+	}
+	return nil
+}
+
+func (m MockRepository) GetAdminInvitations(ctx context.Context) ([]user.AdminInvitation, error) {
+	return []user.AdminInvitation{}, nil
+}
+
+func (m MockRepository) RevokeAdminInvitation(ctx context.Context, invitationId string, revokedAt time.Time) error {
+	if invitationId == MockAcceptedInvitationId {
+		return user.ErrInvitationNotPending
+	}
+	return nil
+}
+
+func (m MockRepository) AcceptAdminInvitation(ctx context.Context, tokenHash string, u user.User, acceptedAt time.Time) error {
+	if tokenHash != auth.HashSecureToken(MockInvitationToken) {
+		return user.ErrInvitationNotValid
+	}
+	return nil
+}
+
+func (m MockRepository) SetEmailVerified(ctx context.Context, email string, verifiedAt *time.Time) error {
+	if email == "" || email == MockUnknownEmail {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (m MockRepository) MarkVerificationSent(ctx context.Context, email string, sentAt time.Time, sentBefore time.Time) error {
+	if email == MockRecentlySentEmail {
+		return user.ErrVerificationRecentlySent
+	}
+	return nil
+}
+
+func (m MockRepository) CreatePasswordReset(ctx context.Context, reset user.PasswordReset) error {
+	if reset.UserId == "" || reset.TokenHash == "" {
+		return errors.New("Cannot create password reset")
+	}
+	return nil
+}
+
+func (m MockRepository) ResetPassword(ctx context.Context, tokenHash string, email string, passwordHash string, resetAt time.Time) error {
+	if tokenHash != auth.HashSecureToken(MockResetToken) || email == MockUnknownEmail {
+		return user.ErrResetTokenNotValid
+	}
+	return nil
+}
+
+func (m MockRepository) SetTotpSecret(ctx context.Context, userId string, secret string) error {
+	if userId == MockTwoFactorUserId {
+		return user.ErrTwoFactorNotPending
+	}
+	if secret == "" {
+		return errors.New("Cannot set totp secret")
+	}
+	return nil
+}
+
+func (m MockRepository) EnableTotp(ctx context.Context, userId string, enabledAt time.Time, step int64, codeHashes []string) error {
+	if userId == MockTwoFactorUserId {
+		return user.ErrTwoFactorNotPending
+	}
+	if len(codeHashes) == 0 {
+		return errors.New("Cannot enable totp")
+	}
+	return nil
+}
+
+func (m MockRepository) DisableTotp(ctx context.Context, userId string) error {
+	return nil
+}
+
+func (m MockRepository) UseTotpStep(ctx context.Context, userId string, step int64) error {
+	if step <= 0 {
+		return user.ErrTwoFactorCodeNotValid
+	}
+	return nil
+}
+
+func (m MockRepository) ReplaceRecoveryCodes(ctx context.Context, userId string, codeHashes []string) error {
+	if len(codeHashes) == 0 {
+		return errors.New("Cannot replace recovery codes")
+	}
+	return nil
+}
+
+func (m MockRepository) UseRecoveryCode(ctx context.Context, userId string, codeHash string, usedAt time.Time) error {
+	if codeHash != auth.HashRecoveryCode(MockRecoveryCode) {
+		return user.ErrTwoFactorCodeNotValid
+	}
+	return nil
+}
+
+func (m MockRepository) GetTwoFactorPolicies(ctx context.Context) ([]user.TwoFactorPolicy, error) {
+	policies := []user.TwoFactorPolicy{}
+	for _, role := range m.TwoFactorRoles {
+		policies = append(policies, user.TwoFactorPolicy{Role: role, Required: true})
+	}
+	return policies, nil
+}
+
+func (m MockRepository) SetTwoFactorPolicy(ctx context.Context, policy user.TwoFactorPolicy) error {
+	if policy.Role == "" || policy.UpdatedBy == "" {
+		return errors.New("Cannot set two-factor policy")
+	}
+	return nil
 }
@@ -0,0 +1,74 @@
+package address
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/address"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	request "github.com/williamchang80/sea-apd/dto/request/address"
+)
+
+// MockUnknownAddressId is the address GetShippingAddress does not find
+const MockUnknownAddressId = "unknown"
+
+type MockUsecase struct {
+	ctrl *gomock.Controller
+}
+
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	return &MockUsecase{ctrl: ctrl}
+}
+
+func (m MockUsecase) GetAddresses(ctx context.Context, customerId string) ([]address.Address, error) {
+	if customerId == "" {
+		return nil, apperror.Validation("Cannot Get Addresses")
+	}
+	return []address.Address{}, nil
+}
+
+func (m MockUsecase) CreateAddress(ctx context.Context, request request.AddressRequest) error {
+	if request.CustomerId == "" {
+		return apperror.Validation("Cannot Create Address")
+	}
+	return nil
+}
+
+func (m MockUsecase) UpdateAddress(ctx context.Context, request request.UpdateAddressRequest) error {
+	if request.AddressId == "" || request.CustomerId == "" {
+		return apperror.Validation("Cannot Update Address")
+	}
+	return nil
+}
+
+func (m MockUsecase) DeleteAddress(ctx context.Context, request request.AddressActionRequest) error {
+	if request.AddressId == "" || request.CustomerId == "" {
+		return apperror.Validation("Cannot Delete Address")
+	}
+	return nil
+}
+
+func (m MockUsecase) SetDefaultAddress(ctx context.Context, request request.AddressActionRequest) error {
+	if request.AddressId == "" || request.CustomerId == "" {
+		return apperror.Validation("Cannot Set Default Address")
+	}
+	return nil
+}
+
+func (m MockUsecase) GetShippingAddress(ctx context.Context, customerId string, addressId string) (*address.Address, error) {
+	if customerId == "" || addressId == MockUnknownAddressId {
+		return nil, apperror.NotFound("Cannot Get Shipping Address")
+	}
+	return &address.Address{
+		Base:          domain.Base{ID: "1"},
+		CustomerId:    customerId,
+		RecipientName: "Mock Recipient",
+		Phone:         "081234567890",
+		Street:        "Jl. Mock No. 1",
+		City:          "Jakarta Selatan",
+		Province:      "DKI Jakarta",
+		PostalCode:    "12345",
+		IsDefault:     addressId == "",
+	}, nil
+}
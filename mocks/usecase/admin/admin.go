@@ -1,9 +1,9 @@
 package admin
 
 import (
-	"errors"
-
+	"context"
 	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	"github.com/williamchang80/sea-apd/domain/user"
 	"github.com/williamchang80/sea-apd/dto/request/admin"
 )
@@ -16,10 +16,46 @@ type MockUsecase struct {
 	ctrl *gomock.Controller
 }
 
-// RegisterAdmin ...
-func (m MockUsecase) RegisterAdmin(req admin.Admin) error {
+// AcceptAdminInvitation ...
+func (m MockUsecase) AcceptAdminInvitation(ctx context.Context, req admin.Admin) error {
 	if req == emptyAdminRequest {
-		return errors.New("Cannot register admin")
+		return apperror.Validation("Cannot accept admin invitation")
+	}
+	return nil
+}
+
+func (m MockUsecase) InviteAdmin(ctx context.Context, req admin.InviteAdminRequest) (*user.AdminInvitation, error) {
+	if req.AdminId == "" || req.Email == "" {
+		return nil, apperror.Validation("Cannot invite admin")
+	}
+	return &user.AdminInvitation{Email: req.Email, InvitedBy: req.AdminId}, nil
+}
+
+func (m MockUsecase) GetAdminInvitations(ctx context.Context) ([]user.AdminInvitation, error) {
+	return []user.AdminInvitation{}, nil
+}
+
+func (m MockUsecase) RevokeAdminInvitation(ctx context.Context, req admin.RevokeInvitationRequest) error {
+	if req.AdminId == "" || req.InvitationId == "" {
+		return apperror.Validation("Cannot revoke admin invitation")
+	}
+	return nil
+}
+
+func (m MockUsecase) BootstrapAdmin(ctx context.Context, req admin.BootstrapAdminRequest) error {
+	if req.Email == "" || req.Password == "" {
+		return apperror.Validation("Cannot bootstrap admin")
+	}
+	return nil
+}
+
+func (m MockUsecase) GetTwoFactorPolicies(ctx context.Context) ([]user.TwoFactorPolicy, error) {
+	return []user.TwoFactorPolicy{}, nil
+}
+
+func (m MockUsecase) SetTwoFactorPolicy(ctx context.Context, req admin.TwoFactorPolicyRequest) error {
+	if req.AdminId == "" || req.Role == "" {
+		return apperror.Validation("Cannot set two-factor policy")
 	}
 	return nil
 }
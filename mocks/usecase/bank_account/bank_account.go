@@ -0,0 +1,84 @@
+package bank_account
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/common/bank"
+	"github.com/williamchang80/sea-apd/common/constants/bank_account_status"
+	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/bank_account"
+	request "github.com/williamchang80/sea-apd/dto/request/bank_account"
+)
+
+// MockUnverifiedAccountId is the account GetPayoutAccount refuses to pay out to
+const MockUnverifiedAccountId = "unverified"
+
+type MockUsecase struct {
+	ctrl *gomock.Controller
+}
+
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	return &MockUsecase{ctrl: ctrl}
+}
+
+func (m MockUsecase) GetSupportedBanks(ctx context.Context) []bank.Bank {
+	return bank.SupportedBanks
+}
+
+func (m MockUsecase) GetBankAccounts(ctx context.Context, merchantId string) ([]bank_account.BankAccount, error) {
+	if merchantId == "" {
+		return nil, apperror.NotFound("Cannot Get Bank Accounts")
+	}
+	return []bank_account.BankAccount{}, nil
+}
+
+func (m MockUsecase) CreateBankAccount(ctx context.Context, request request.BankAccountRequest) error {
+	if request.MerchantId == "" || request.Password == "" {
+		return apperror.Validation("Cannot Create Bank Account")
+	}
+	return nil
+}
+
+func (m MockUsecase) UpdateBankAccount(ctx context.Context, request request.UpdateBankAccountRequest) error {
+	if request.AccountId == "" || request.Password == "" {
+		return apperror.Validation("Cannot Update Bank Account")
+	}
+	return nil
+}
+
+func (m MockUsecase) DeleteBankAccount(ctx context.Context, request request.BankAccountActionRequest) error {
+	if request.AccountId == "" || request.Password == "" {
+		return apperror.Validation("Cannot Delete Bank Account")
+	}
+	return nil
+}
+
+func (m MockUsecase) SetDefaultBankAccount(ctx context.Context, request request.BankAccountActionRequest) error {
+	if request.AccountId == "" || request.Password == "" {
+		return apperror.Validation("Cannot Set Default Bank Account")
+	}
+	return nil
+}
+
+func (m MockUsecase) VerifyBankAccount(ctx context.Context, request request.VerifyBankAccountRequest) error {
+	if request.AccountId == "" {
+		return apperror.Validation("Cannot Verify Bank Account")
+	}
+	return nil
+}
+
+func (m MockUsecase) GetPayoutAccount(ctx context.Context, merchantId string, accountId string) (*bank_account.BankAccount, error) {
+	if merchantId == "" || accountId == MockUnverifiedAccountId {
+		return nil, apperror.NotFound("Cannot Get Payout Account")
+	}
+	return &bank_account.BankAccount{
+		Base:          domain.Base{ID: "1"},
+		MerchantId:    merchantId,
+		BankCode:      "014",
+		AccountNumber: "1234567890",
+		HolderName:    "Mock Holder",
+		Status:        bank_account_status.ToString(bank_account_status.VERIFIED),
+		IsDefault:     true,
+	}, nil
+}
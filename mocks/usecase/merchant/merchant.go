@@ -1,9 +1,9 @@
 package merchant
 
 import (
-	"errors"
-
+	"context"
 	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	domain "github.com/williamchang80/sea-apd/domain/merchant"
 	"github.com/williamchang80/sea-apd/dto/request/merchant"
 )
@@ -24,49 +24,136 @@ func NewMockUsecase(repo *gomock.Controller) *MockUsecase {
 	}
 }
 
-func (m MockUsecase) UpdateMerchantBalance(request merchant.UpdateMerchantBalanceRequest) error {
+func (m MockUsecase) UpdateMerchantBalance(ctx context.Context, request merchant.UpdateMerchantBalanceRequest) error {
 	if request == emptyUpdateMerchantBalanceRequest {
-		return errors.New("Request cannot be empty")
+		return apperror.Validation("Request cannot be empty")
 	}
 	return nil
 }
 
-func (m MockUsecase) GetMerchantBalance(merchantId string) (int, error) {
+func (m MockUsecase) GetMerchantBalance(ctx context.Context, merchantId string) (int, error) {
 	if len(merchantId) == 0 {
-		return 0, errors.New("Merchant id cannot be empty")
+		return 0, apperror.Validation("Merchant id cannot be empty")
 	}
 	return 1000, nil
 }
 
-func (m MockUsecase) RegisterMerchant(request merchant.MerchantRequest) error {
+func (m MockUsecase) GetMerchantPendingBalance(ctx context.Context, merchantId string) (int, error) {
+	if len(merchantId) == 0 {
+		return 0, apperror.Validation("Merchant id cannot be empty")
+	}
+	return 500, nil
+}
+
+func (m MockUsecase) UpdateMerchantPendingBalance(ctx context.Context, request merchant.UpdateMerchantBalanceRequest) error {
+	if request == emptyUpdateMerchantBalanceRequest {
+		return apperror.Validation("Request cannot be empty")
+	}
+	return nil
+}
+
+func (m MockUsecase) RegisterMerchant(ctx context.Context, request merchant.MerchantRequest) error {
 	if request == emptyMerchantRequest {
-		return errors.New("Cannot Create Merchant")
+		return apperror.Validation("Cannot Create Merchant")
 	}
 	return nil
 }
 
-func (m MockUsecase) GetMerchants() ([]domain.Merchant, error) {
+func (m MockUsecase) GetMerchants(ctx context.Context) ([]domain.Merchant, error) {
 	return []domain.Merchant{}, nil
 }
 
-func (m MockUsecase) GetMerchantById(merchantId string) (*domain.Merchant, error) {
+func (m MockUsecase) GetMerchantById(ctx context.Context, merchantId string) (*domain.Merchant, error) {
 	if merchantId != "" {
 		return &emptyMerchant, nil
 	}
-	return nil, errors.New("Cannot Get Merchant By Id")
+	return nil, apperror.NotFound("Cannot Get Merchant By Id")
 }
 
 func (m MockUsecase) GetMerchantsByUser(userId string) ([]domain.Merchant, error) {
 	if len(userId) == 0 {
-		return nil, errors.New("Cannot Get Merchants by User")
+		return nil, apperror.NotFound("Cannot Get Merchants by User")
 	}
 	return []domain.Merchant{}, nil
 }
 
-func (m MockUsecase) UpdateMerchantApprovalStatus(request merchant.UpdateMerchantApprovalStatusRequest) error {
+func (m MockUsecase) UpdateMerchantApprovalStatus(ctx context.Context, request merchant.UpdateMerchantApprovalStatusRequest) error {
 	panic("implement me")
 }
 
-func (m MockUsecase) UpdateMerchant(request merchant.UpdateMerchantRequest) error {
+func (m MockUsecase) UpdateMerchant(ctx context.Context, request merchant.UpdateMerchantRequest) error {
 	panic("implement me")
-}
\ No newline at end of file
+}
+func (m MockUsecase) UploadMerchantDocument(ctx context.Context, request merchant.MerchantDocumentRequest) error {
+	if request.MerchantId == "" || request.File == nil {
+		return apperror.Validation("Cannot Upload Merchant Document")
+	}
+	return nil
+}
+
+func (m MockUsecase) GetMerchantDocuments(ctx context.Context, merchantId string) ([]domain.MerchantDocument, error) {
+	if merchantId == "" {
+		return nil, apperror.NotFound("Cannot Get Merchant Documents")
+	}
+	return []domain.MerchantDocument{}, nil
+}
+
+func (m MockUsecase) ReadMerchantDocument(ctx context.Context, documentId string) (*domain.MerchantDocument, []byte, error) {
+	if documentId == "" {
+		return nil, nil, apperror.NotFound("Cannot Read Merchant Document")
+	}
+	return &domain.MerchantDocument{FileName: "document.pdf", ContentType: "application/pdf"},
+		[]byte("%PDF-"), nil
+}
+
+func (m MockUsecase) GetReviewQueue(ctx context.Context) ([]domain.MerchantApplication, error) {
+	return []domain.MerchantApplication{}, nil
+}
+
+func (m MockUsecase) ResubmitMerchant(ctx context.Context, request merchant.ResubmitMerchantRequest) error {
+	if request.MerchantId == "" || request.UserId == "" {
+		return apperror.Validation("Cannot Resubmit Merchant")
+	}
+	return nil
+}
+
+func (m MockUsecase) GetMerchantReviews(ctx context.Context, merchantId string) ([]domain.MerchantReview, error) {
+	if merchantId == "" {
+		return nil, apperror.NotFound("Cannot Get Merchant Reviews")
+	}
+	return []domain.MerchantReview{}, nil
+}
+
+// MockSuspendedMerchantId is the merchant the mock usecase treats as suspended
+const MockSuspendedMerchantId = "suspended"
+
+func (m MockUsecase) SuspendMerchant(ctx context.Context, request merchant.SuspendMerchantRequest) error {
+	if request.MerchantId == "" || request.AdminId == "" {
+		return apperror.Validation("Cannot Suspend Merchant")
+	}
+	return nil
+}
+
+func (m MockUsecase) ReactivateMerchant(ctx context.Context, request merchant.ReactivateMerchantRequest) error {
+	if request.MerchantId == "" || request.AdminId == "" {
+		return apperror.Validation("Cannot Reactivate Merchant")
+	}
+	return nil
+}
+
+func (m MockUsecase) CloseMerchant(ctx context.Context, request merchant.CloseMerchantRequest) error {
+	if request.MerchantId == "" || request.UserId == "" {
+		return apperror.Validation("Cannot Close Merchant")
+	}
+	return nil
+}
+
+func (m MockUsecase) ValidateMerchantActive(ctx context.Context, merchantId string) error {
+	if merchantId == "" {
+		return apperror.Validation("Cannot Validate Merchant")
+	}
+	if merchantId == MockSuspendedMerchantId {
+		return domain.ErrMerchantSuspended
+	}
+	return nil
+}
@@ -1,8 +1,14 @@
 package product
 
 import (
-	"errors"
+	"context"
+	"io"
+	"reflect"
+
 	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/common/constants/import_status"
+	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	"github.com/williamchang80/sea-apd/domain/product"
 	"github.com/williamchang80/sea-apd/domain/transaction"
 	product2 "github.com/williamchang80/sea-apd/dto/request/product"
@@ -11,41 +17,43 @@ import (
 var emptyProduct = product.Product{}
 var emptyProductRequest = product2.ProductRequest{}
 
+const mockUnitPrice = 10
+
 type MockUsecase struct {
 	ctrl *gomock.Controller
 }
 
 
-func (m MockUsecase) GetProducts() ([]product.Product, error) {
+func (m MockUsecase) GetProducts(ctx context.Context) ([]product.Product, error) {
 	return []product.Product{}, nil
 }
 
-func (m MockUsecase) GetProductById(id string) (*product.Product, error) {
+func (m MockUsecase) GetProductById(ctx context.Context, id string) (*product.Product, error) {
 	if id != "" {
 		return &emptyProduct, nil
 	}
-	return nil, errors.New("Cannot Get Product By Id")
+	return nil, apperror.NotFound("Cannot Get Product By Id")
 }
 
-func (m MockUsecase) CreateProduct(request product2.ProductRequest) error {
-	if request == emptyProductRequest {
-		return errors.New("Cannot Create Product")
+func (m MockUsecase) CreateProduct(ctx context.Context, request product2.ProductRequest) error {
+	if reflect.DeepEqual(request, emptyProductRequest) {
+		return apperror.Validation("Cannot Create Product")
 	}
 	return nil
 }
 
-func (m MockUsecase) UpdateProduct(id string, request product2.ProductRequest) error {
-	if request == emptyProductRequest {
-		return nil
+func (m MockUsecase) UpdateProduct(ctx context.Context, id string, request product2.ProductRequest) error {
+	if reflect.DeepEqual(request, emptyProductRequest) {
+		return apperror.Validation("Cannot Update Product")
 	}
-	return errors.New("Cannot Update Product")
+	return nil
 }
 
-func (m MockUsecase) DeleteProduct(id string) error {
+func (m MockUsecase) DeleteProduct(ctx context.Context, id string) error {
 	if len(id) != 0 {
 		return nil
 	}
-	return errors.New("Cannot Delete Product")
+	return apperror.Validation("Cannot Delete Product")
 }
 
 func NewMockUsecase(repo *gomock.Controller) *MockUsecase {
@@ -53,13 +61,113 @@ func NewMockUsecase(repo *gomock.Controller) *MockUsecase {
 		ctrl: repo,
 	}
 }
-func (m MockUsecase) GetProductsByMerchant(merchantId string) ([]product.Product, error) {
+func (m MockUsecase) GetProductsByMerchant(ctx context.Context, merchantId string) ([]product.Product, error) {
 	if len(merchantId) == 0 {
-		return nil, errors.New("Cannot Get Products by Merchant")
+		return nil, apperror.NotFound("Cannot Get Products by Merchant")
+	}
+	return []product.Product{}, nil
+}
+
+func (m MockUsecase) GetProductPriceTotal(ctx context.Context, transaction transaction.Transaction) (int, error) {
+	total := 0
+	for _, d := range transaction.ProductDetails {
+		total += mockUnitPrice * d.Quantity
+	}
+	return total, nil
+}
+
+func (m MockUsecase) SearchProducts(ctx context.Context, request product2.ProductSearchRequest) ([]product.Product, error) {
+	if request.MaxPrice > 0 && request.MinPrice > request.MaxPrice {
+		return nil, apperror.Validation("Cannot Search Products")
+	}
+	return []product.Product{}, nil
+}
+
+func (m MockUsecase) GetProductsByCategories(ctx context.Context, categoryIds []string) ([]product.Product, error) {
+	return []product.Product{}, nil
+}
+
+func (m MockUsecase) GetProductsByTag(ctx context.Context, tag string) ([]product.Product, error) {
+	if len(tag) == 0 {
+		return nil, apperror.NotFound("Cannot Get Products by Tag")
 	}
 	return []product.Product{}, nil
 }
 
-func (m MockUsecase) GetProductPriceTotal(transaction transaction.Transaction) (int, error) {
-	panic("implement me")
+func (m MockUsecase) SetProductOptions(ctx context.Context, request product2.ProductOptionsRequest) error {
+	if len(request.ProductId) == 0 {
+		return apperror.Validation("Cannot Set Product Options")
+	}
+	return nil
+}
+
+func (m MockUsecase) CreateVariant(ctx context.Context, request product2.VariantRequest) error {
+	if len(request.Sku) == 0 {
+		return apperror.Validation("Cannot Create Variant")
+	}
+	return nil
+}
+
+func (m MockUsecase) UpdateVariant(ctx context.Context, request product2.UpdateVariantRequest) error {
+	if len(request.VariantId) == 0 {
+		return apperror.Validation("Cannot Update Variant")
+	}
+	return nil
+}
+
+func (m MockUsecase) DeleteVariant(ctx context.Context, variantId string) error {
+	if len(variantId) == 0 {
+		return apperror.Validation("Cannot Delete Variant")
+	}
+	return nil
+}
+
+func (m MockUsecase) GetUnitPrice(ctx context.Context, productId string, variantId *string) (int, error) {
+	if len(productId) == 0 {
+		return 0, apperror.NotFound("Cannot Get Unit Price")
+	}
+	return mockUnitPrice, nil
+}
+
+func (m MockUsecase) ReserveStock(ctx context.Context, details []transaction.ProductTransaction) error {
+	for _, d := range details {
+		if d.Quantity > 5 {
+			return product.ErrInsufficientStock
+		}
+	}
+	return nil
+}
+
+func (m MockUsecase) ReleaseStock(ctx context.Context, details []transaction.ProductTransaction) error {
+	return nil
+}
+
+func (m MockUsecase) ImportProducts(ctx context.Context, request product2.ImportProductsRequest) (*product.ImportJob, error) {
+	if request.MerchantId == "" || request.File == nil {
+		return nil, apperror.Validation("Cannot Import Products")
+	}
+	return &product.ImportJob{
+		MerchantId: request.MerchantId,
+		Format:     request.Format,
+		DryRun:     request.DryRun,
+		Status:     import_status.ToString(import_status.COMPLETED),
+	}, nil
+}
+
+func (m MockUsecase) GetImportJob(ctx context.Context, jobId string) (*product.ImportJob, error) {
+	if jobId == "" {
+		return nil, apperror.NotFound("Cannot Get Import Job")
+	}
+	return &product.ImportJob{
+		Base:   domain.Base{ID: jobId},
+		Status: import_status.ToString(import_status.PENDING),
+	}, nil
+}
+
+func (m MockUsecase) ExportProducts(ctx context.Context, merchantId string, format string, w io.Writer) error {
+	if merchantId == "" {
+		return apperror.Validation("Cannot Export Products")
+	}
+	_, err := io.WriteString(w, "sku,name,description,price,stock,category_id,tags\n")
+	return err
 }
@@ -0,0 +1,42 @@
+package shipping
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/shipping"
+	request "github.com/williamchang80/sea-apd/dto/request/shipping"
+)
+
+// MockShippingCost is the cost of every parcel QuoteShipping quotes
+const MockShippingCost = 9000
+
+type MockUsecase struct {
+	ctrl *gomock.Controller
+}
+
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	return &MockUsecase{ctrl: ctrl}
+}
+
+func (m MockUsecase) GetShippingRates(ctx context.Context, merchantId string) ([]shipping.ShippingRate, error) {
+	if merchantId == "" {
+		return nil, apperror.Validation("Cannot Get Shipping Rates")
+	}
+	return []shipping.ShippingRate{}, nil
+}
+
+func (m MockUsecase) UpdateShippingRates(ctx context.Context, request request.UpdateShippingRatesRequest) error {
+	if request.MerchantId == "" {
+		return apperror.Validation("Cannot Update Shipping Rates")
+	}
+	return nil
+}
+
+func (m MockUsecase) QuoteShipping(ctx context.Context, parcel shipping.Parcel) (*shipping.Quote, error) {
+	if parcel.MerchantId == "" {
+		return nil, apperror.Validation("Cannot Quote Shipping")
+	}
+	return &shipping.Quote{Provider: "table", Service: "standard", Cost: MockShippingCost}, nil
+}
@@ -1,10 +1,13 @@
 package transaction
 
 import (
-	"errors"
+	"context"
 	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/shipping"
 	domain "github.com/williamchang80/sea-apd/domain/transaction"
 	"github.com/williamchang80/sea-apd/dto/request/transaction"
+	"time"
 )
 
 var emptyTransactionRequest = transaction.TransactionRequest{}
@@ -22,38 +25,99 @@ func NewMockUsecase(repo *gomock.Controller) *MockUsecase {
 	}
 }
 
-func (m MockUsecase) CreateTransaction(request transaction.TransactionRequest) error {
+func (m MockUsecase) CreateTransaction(ctx context.Context, request transaction.TransactionRequest) error {
 	if request == emptyTransactionRequest {
-		return errors.New("Request cannot be empty")
+		return apperror.Validation("Request cannot be empty")
 	}
 	return nil
 }
 
-func (m MockUsecase) UpdateTransactionStatus(request transaction.UpdateTransactionRequest) error {
+func (m MockUsecase) UpdateTransactionStatus(ctx context.Context, request transaction.UpdateTransactionRequest) error {
 	if request == emptyUpdateTransactionStatusRequest {
-		return errors.New("Request cannot be empty")
+		return apperror.Validation("Request cannot be empty")
 	}
 	return nil
 }
 
-func (m MockUsecase) GetTransactionById(id string) (*domain.Transaction, error) {
+func (m MockUsecase) GetTransactionById(ctx context.Context, id string) (*domain.Transaction, error) {
 	if len(id) == 0 {
-		return nil, errors.New("Id cannot be empty")
+		return nil, apperror.Validation("Id cannot be empty")
 	}
 	return &domain.Transaction{}, nil
 }
 
-func (m MockUsecase) GetTransactionHistory(userId string) ([]domain.Transaction, error) {
+func (m MockUsecase) GetTransactionHistory(ctx context.Context, userId string) ([]domain.Transaction, error) {
 	if len(userId) != 0 {
 		return []domain.Transaction{}, nil
 	}
-	return nil, errors.New("User Id cannot be empty")
+	return nil, apperror.Validation("User Id cannot be empty")
 }
 
-func (m MockUsecase) GetMerchantRequestItem(merchantId string) ([]domain.Transaction, error) {
+func (m MockUsecase) GetMerchantRequestItem(ctx context.Context, merchantId string) ([]domain.Transaction, error) {
 	panic("implement me")
 }
 
-func (m MockUsecase) PayTransaction(request transaction.PaymentRequest) error {
+func (m MockUsecase) PayTransaction(ctx context.Context, request transaction.PaymentRequest) error {
 	panic("implement me")
-}
\ No newline at end of file
+}
+
+func (m MockUsecase) AddToCart(ctx context.Context, request transaction.CartItemRequest) error {
+	if request.Quantity <= 0 {
+		return apperror.Validation("Quantity must be more than zero")
+	}
+	return nil
+}
+
+func (m MockUsecase) GetCart(ctx context.Context, customerId string) ([]domain.Transaction, error) {
+	if len(customerId) == 0 {
+		return nil, apperror.Validation("Customer Id cannot be empty")
+	}
+	return []domain.Transaction{}, nil
+}
+
+func (m MockUsecase) Checkout(ctx context.Context, request transaction.CheckoutRequest) error {
+	if request == (transaction.CheckoutRequest{}) {
+		return apperror.Validation("Request cannot be empty")
+	}
+	return nil
+}
+
+func (m MockUsecase) QuoteShipping(ctx context.Context, request transaction.CheckoutRequest) (*shipping.Quote, error) {
+	if request.CustomerId == "" || request.TransactionId == "" {
+		return nil, apperror.Validation("Cannot Quote Shipping")
+	}
+	return &shipping.Quote{Provider: "table", Service: "standard", Cost: 9000}, nil
+}
+
+func (m MockUsecase) ForceTransactionStatus(ctx context.Context, request transaction.ForceTransactionStatusRequest) (*domain.TransactionStatusChange, error) {
+	if request.TransactionId == "" || request.AdminId == "" || request.Reason == "" {
+		return nil, apperror.Validation("Cannot Force Transaction Status")
+	}
+	return &domain.TransactionStatusChange{TransactionId: request.TransactionId, ActorId: request.AdminId,
+		Reason: request.Reason}, nil
+}
+
+func (m MockUsecase) GetStatusChanges(ctx context.Context, transactionId string) ([]domain.TransactionStatusChange, error) {
+	if transactionId == "" {
+		return nil, apperror.NotFound("Cannot Get Status Changes")
+	}
+	return []domain.TransactionStatusChange{}, nil
+}
+
+func (m MockUsecase) ShipTransaction(ctx context.Context, request transaction.ShipmentRequest) error {
+	if request.TransactionId == "" || request.MerchantId == "" {
+		return apperror.Validation("Cannot Ship Transaction")
+	}
+	return nil
+}
+
+func (m MockUsecase) ConfirmDelivery(ctx context.Context, request transaction.ConfirmDeliveryRequest) error {
+	if request.TransactionId == "" || request.CustomerId == "" {
+		return apperror.Validation("Cannot Confirm Delivery")
+	}
+	return nil
+}
+
+func (m MockUsecase) CompleteShippedTransactions(ctx context.Context, shippedBefore time.Time) (int, error) {
+	return 0, nil
+}
@@ -1,8 +1,9 @@
 package transfer
 
 import (
-	"errors"
+	"context"
 	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	"github.com/williamchang80/sea-apd/domain/transfer"
 	request "github.com/williamchang80/sea-apd/dto/request/transfer"
 )
@@ -21,16 +22,16 @@ func NewMockUsecase(repo *gomock.Controller) *MockUsecase {
 	}
 }
 
-func (m MockUsecase) GetTransferHistory(merchantId string) ([]transfer.Transfer, error) {
+func (m MockUsecase) GetTransferHistory(ctx context.Context, merchantId string) ([]transfer.Transfer, error) {
 	if len(merchantId) == 0 {
-		return nil, errors.New("mechantId cannot be empty")
+		return nil, apperror.Validation("mechantId cannot be empty")
 	}
 	return []transfer.Transfer{}, nil
 }
 
-func (m MockUsecase) CreateTransferHistory(request request.CreateTransferHistoryRequest) error {
+func (m MockUsecase) CreateTransferHistory(ctx context.Context, request request.CreateTransferHistoryRequest) error {
 	if request == emptyCreateTransferHistoryRequest{
-		return errors.New("request cannot be empty")
+		return apperror.Validation("request cannot be empty")
 	}
 	return nil
 }
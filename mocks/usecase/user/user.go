@@ -1,7 +1,14 @@
 package user
 
 import (
+	"context"
+	"time"
+
 	"github.com/golang/mock/gomock"
+	auth2 "github.com/williamchang80/sea-apd/common/auth"
+	"github.com/williamchang80/sea-apd/common/constants/user_role"
+	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	"github.com/williamchang80/sea-apd/domain/user"
 	"github.com/williamchang80/sea-apd/dto/request/auth"
 	user2 "github.com/williamchang80/sea-apd/dto/request/user"
@@ -17,18 +24,79 @@ func NewMockUsecase(repo *gomock.Controller) *MockUsecase {
 	}
 }
 
-func (m MockUsecase) CreateUser(request auth.RegisterUserRequest) error {
+func (m MockUsecase) CreateUser(ctx context.Context, request auth.RegisterUserRequest) error {
 	panic("implement me")
 }
 
-func (m MockUsecase) UpdateUserRole(request user2.UpdateUserRoleRequest) error {
-	panic("implement me")
+func (m MockUsecase) UpdateUserRole(ctx context.Context, request user2.UpdateUserRoleRequest) error {
+	if request.UserId == "" {
+		return apperror.Validation("Cannot Update User Role")
+	}
+	return nil
 }
 
-func (m MockUsecase) GetUserById(userId string) (*user.User, error) {
-	panic("implement me")
+// MockPassword is the password of every user returned by the mock usecase
+const MockPassword = "password"
+
+// MockAdminUserId, MockBannedUserId and MockUnverifiedUserId return an admin, a banned
+// customer and a customer that did not verify the email yet
+const (
+	MockAdminUserId      = "admin"
+	MockBannedUserId     = "banned"
+	MockUnverifiedUserId = "unverified"
+)
+
+var mockPasswordHash = auth2.HashPassword(MockPassword)
+
+func (m MockUsecase) GetUserById(ctx context.Context, userId string) (*user.User, error) {
+	if userId == "" {
+		return nil, apperror.NotFound("Cannot Get User By Id")
+	}
+	verifiedAt := time.Now()
+	u := &user.User{
+		Base:            domain.Base{ID: userId},
+		Name:            "Mock User",
+		Email:           "mock@mock.com",
+		Password:        mockPasswordHash,
+		Role:            user_role.ToString(user_role.CUSTOMER),
+		EmailVerifiedAt: &verifiedAt,
+	}
+	switch userId {
+	case MockUnverifiedUserId:
+		u.EmailVerifiedAt = nil
+	case MockAdminUserId:
+		u.Role = user_role.ToString(user_role.ADMIN)
+	case MockBannedUserId:
+		bannedAt := time.Now()
+		u.BannedAt = &bannedAt
+		u.BanReason = "fraud"
+	}
+	return u, nil
 }
 
-func (m MockUsecase) UpdateUser(request user2.UpdateUserRequest) error {
+func (m MockUsecase) UpdateUser(ctx context.Context, request user2.UpdateUserRequest) error {
 	panic("implement me")
-}
\ No newline at end of file
+}
+func (m MockUsecase) VerifyEmail(ctx context.Context, token string) error {
+	if token == "" {
+		return apperror.Validation("Cannot Verify Email")
+	}
+	return nil
+}
+
+func (m MockUsecase) ResendVerification(ctx context.Context, request user2.ResendVerificationRequest) error {
+	if request.Email == "" {
+		return apperror.Validation("Cannot Resend Verification")
+	}
+	return nil
+}
+
+func (m MockUsecase) ValidateUserVerified(ctx context.Context, userId string) error {
+	if userId == "" {
+		return apperror.Validation("Cannot Validate User Verified")
+	}
+	if userId == MockUnverifiedUserId {
+		return user.ErrEmailNotVerified
+	}
+	return nil
+}
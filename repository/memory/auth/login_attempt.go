@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/williamchang80/sea-apd/domain/auth"
+)
+
+// sweepInterval is how often attempts that expired are dropped
+const sweepInterval = time.Minute
+
+// LoginAttemptStore is an in-memory LoginAttemptStore for tests and single instance
+// deployments, every instance of the app counts its own failures
+type LoginAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[string]*entry
+	swept    time.Time
+}
+
+type entry struct {
+	attempts  auth.LoginAttempts
+	expiresAt time.Time
+}
+
+func NewLoginAttemptStore() auth.LoginAttemptStore {
+	return &LoginAttemptStore{attempts: map[string]*entry{}}
+}
+
+func (s *LoginAttemptStore) GetLoginAttempts(key string) (auth.LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, exist := s.attempts[key]; exist {
+		return e.attempts, nil
+	}
+	return auth.LoginAttempts{}, nil
+}
+
+func (s *LoginAttemptStore) AddLoginFailure(key string, at time.Time, window time.Duration) (auth.LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(at)
+	e, exist := s.attempts[key]
+	if !exist {
+		e = &entry{}
+		s.attempts[key] = e
+	}
+	if at.Sub(e.attempts.LastFailureAt) >= window {
+		e.attempts.Failures = 0
+	}
+	e.attempts.Failures++
+	e.attempts.LastFailureAt = at
+	if expiresAt := at.Add(window); expiresAt.After(e.expiresAt) {
+		e.expiresAt = expiresAt
+	}
+	return e.attempts, nil
+}
+
+func (s *LoginAttemptStore) LockLogin(key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, exist := s.attempts[key]
+	if !exist {
+		e = &entry{}
+		s.attempts[key] = e
+	}
+	e.attempts.LockedUntil = &until
+	if until.After(e.expiresAt) {
+		e.expiresAt = until
+	}
+	return nil
+}
+
+func (s *LoginAttemptStore) ResetLoginAttempts(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.attempts, key)
+	return nil
+}
+
+// sweep drops the attempts that neither count nor lock anymore, so idle keys do not
+// pile up
+func (s *LoginAttemptStore) sweep(now time.Time) {
+	if now.Sub(s.swept) < sweepInterval {
+		return
+	}
+	for key, e := range s.attempts {
+		if !now.Before(e.expiresAt) {
+			delete(s.attempts, key)
+		}
+	}
+	s.swept = now
+}
@@ -0,0 +1,174 @@
+package product
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/williamchang80/sea-apd/common/constants/product_sort"
+	"github.com/williamchang80/sea-apd/domain/product"
+)
+
+const (
+	nameWeight        = 2
+	descriptionWeight = 1
+	// terms shorter than this are only prefix matched, fuzzy matching them is too noisy
+	fuzzyMinimumLength = 4
+)
+
+// ProductSearcher is an in-memory ProductSearcher that mirrors the postgres
+// ranking closely enough to be used in tests and single-node setups
+type ProductSearcher struct {
+	products []product.Product
+}
+
+func NewProductSearcher(products []product.Product) product.ProductSearcher {
+	return &ProductSearcher{products: products}
+}
+
+type scoredProduct struct {
+	product product.Product
+	score   float64
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func levenshtein(a []rune, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// matchTerm scores a single search term against a word, 1 for a prefix match,
+// 0.5 for a prefix within one edit and 0 otherwise
+func matchTerm(term string, word string) float64 {
+	if strings.HasPrefix(word, term) {
+		return 1
+	}
+	t := []rune(term)
+	if len(t) < fuzzyMinimumLength {
+		return 0
+	}
+	w := []rune(word)
+	if len(w) > len(t) {
+		w = w[:len(t)]
+	}
+	if levenshtein(t, w) <= 1 {
+		return 0.5
+	}
+	return 0
+}
+
+func scoreField(terms []string, words []string) (float64, int) {
+	var score float64
+	matched := 0
+	for _, term := range terms {
+		best := 0.0
+		for _, word := range words {
+			if s := matchTerm(term, word); s > best {
+				best = s
+			}
+		}
+		if best > 0 {
+			matched++
+		}
+		score += best
+	}
+	return score, matched
+}
+
+func (s *ProductSearcher) score(terms []string, p product.Product) (float64, bool) {
+	if len(terms) == 0 {
+		return 0, true
+	}
+	nameScore, nameMatched := scoreField(terms, tokenize(p.Name))
+	descriptionScore, descriptionMatched := scoreField(terms, tokenize(p.Description))
+	if nameMatched+descriptionMatched == 0 {
+		return 0, false
+	}
+	return nameScore*nameWeight + descriptionScore*descriptionWeight, true
+}
+
+func isFiltered(query product.ProductSearchQuery, p product.Product) bool {
+	if query.MinPrice > 0 && p.Price < query.MinPrice {
+		return true
+	}
+	if query.MaxPrice > 0 && p.Price > query.MaxPrice {
+		return true
+	}
+	if query.InStock && p.Stock <= 0 {
+		return true
+	}
+	if query.MerchantId != "" && p.MerchantId != query.MerchantId {
+		return true
+	}
+	return false
+}
+
+func (s *ProductSearcher) SearchProducts(ctx context.Context, query product.ProductSearchQuery) ([]product.Product, error) {
+	terms := tokenize(query.Keyword)
+	var results []scoredProduct
+	for _, p := range s.products {
+		if p.DeletedAt != nil || isFiltered(query, p) {
+			continue
+		}
+		score, ok := s.score(terms, p)
+		if !ok {
+			continue
+		}
+		results = append(results, scoredProduct{product: p, score: score})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		switch product_sort.ParseToEnum(query.Sort) {
+		case product_sort.PRICE_ASC:
+			return results[i].product.Price < results[j].product.Price
+		case product_sort.PRICE_DESC:
+			return results[i].product.Price > results[j].product.Price
+		}
+		return results[i].score > results[j].score
+	})
+
+	if query.Offset > len(results) {
+		results = nil
+	} else {
+		results = results[query.Offset:]
+	}
+	if query.Limit > 0 && query.Limit < len(results) {
+		results = results[:query.Limit]
+	}
+	products := []product.Product{}
+	for _, r := range results {
+		products = append(products, r.product)
+	}
+	return products, nil
+}
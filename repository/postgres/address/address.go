@@ -0,0 +1,83 @@
+package address
+
+import (
+	"context"
+	"github.com/jinzhu/gorm"
+	"github.com/williamchang80/sea-apd/common/logger"
+	"github.com/williamchang80/sea-apd/domain/address"
+)
+
+type AddressRepository struct {
+	db *gorm.DB
+}
+
+func NewAddressRepository(db *gorm.DB) address.AddressRepository {
+	return &AddressRepository{db: db}
+}
+
+func (a *AddressRepository) conn(ctx context.Context) *gorm.DB {
+	return logger.Gorm(ctx, a.db)
+}
+
+func (a *AddressRepository) CreateAddress(ctx context.Context, addr address.Address) error {
+	if err := a.conn(ctx).Create(&addr).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *AddressRepository) UpdateAddress(ctx context.Context, addressId string, addr address.Address) error {
+	if err := a.conn(ctx).Model(&address.Address{}).Where("id = ?", addressId).
+		Updates(map[string]interface{}{
+			"label":          addr.Label,
+			"recipient_name": addr.RecipientName,
+			"phone":          addr.Phone,
+			"street":         addr.Street,
+			"city":           addr.City,
+			"province":       addr.Province,
+			"postal_code":    addr.PostalCode,
+		}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *AddressRepository) DeleteAddress(ctx context.Context, addressId string) error {
+	if err := a.conn(ctx).Where("id = ?", addressId).Delete(&address.Address{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *AddressRepository) GetAddressById(ctx context.Context, addressId string) (*address.Address, error) {
+	var addr address.Address
+	if err := a.conn(ctx).Where("id = ?", addressId).First(&addr).Error; err != nil {
+		return nil, err
+	}
+	return &addr, nil
+}
+
+func (a *AddressRepository) GetAddressesByCustomer(ctx context.Context, customerId string) ([]address.Address, error) {
+	var addresses []address.Address
+	err := a.conn(ctx).Where("customer_id = ?", customerId).Order("created_at asc").Find(&addresses).Error
+	if err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// SetDefaultAddress makes the address the only default address of the customer
+func (a *AddressRepository) SetDefaultAddress(ctx context.Context, customerId string, addressId string) error {
+	tx := a.conn(ctx).Begin()
+	if err := tx.Model(&address.Address{}).Where("customer_id = ?", customerId).
+		Update("is_default", false).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Model(&address.Address{}).Where("id = ? AND customer_id = ?", addressId, customerId).
+		Update("is_default", true).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
@@ -0,0 +1,230 @@
+package backoffice
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
+	"github.com/williamchang80/sea-apd/common/logger"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/backoffice"
+	"github.com/williamchang80/sea-apd/domain/merchant"
+	"github.com/williamchang80/sea-apd/domain/transaction"
+	"github.com/williamchang80/sea-apd/domain/user"
+)
+
+var ErrBalanceNotAdjusted = apperror.InsufficientBalance("merchant not found or balance would become negative")
+
+type BackofficeRepository struct {
+	db *gorm.DB
+}
+
+func NewBackofficeRepository(db *gorm.DB) backoffice.BackofficeRepository {
+	return &BackofficeRepository{db: db}
+}
+
+func (b BackofficeRepository) conn(ctx context.Context) *gorm.DB {
+	return logger.Gorm(ctx, b.db)
+}
+
+func paginate(db *gorm.DB, limit int, offset int) *gorm.DB {
+	return db.Limit(limit).Offset(offset)
+}
+
+func (b BackofficeRepository) SearchUsers(ctx context.Context, query backoffice.UserQuery) ([]user.User, error) {
+	var users []user.User
+	db := b.conn(ctx).Model(&user.User{})
+	if query.Keyword != "" {
+		keyword := "%" + query.Keyword + "%"
+		db = db.Where("name ILIKE ? OR email ILIKE ?", keyword, keyword)
+	}
+	if query.Role != "" {
+		db = db.Where("role = ?", query.Role)
+	}
+	if query.Banned != nil && *query.Banned {
+		db = db.Where("banned_at IS NOT NULL")
+	}
+	if query.Banned != nil && !*query.Banned {
+		db = db.Where("banned_at IS NULL")
+	}
+	err := paginate(db, query.Limit, query.Offset).Order("created_at desc").Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (b BackofficeRepository) SearchMerchants(ctx context.Context, query backoffice.MerchantQuery) ([]merchant.Merchant, error) {
+	var merchants []merchant.Merchant
+	db := b.conn(ctx).Model(&merchant.Merchant{})
+	if query.Keyword != "" {
+		keyword := "%" + query.Keyword + "%"
+		db = db.Where("name ILIKE ? OR brand ILIKE ?", keyword, keyword)
+	}
+	if query.Status != "" {
+		db = db.Where("approval = ?", query.Status)
+	}
+	err := paginate(db, query.Limit, query.Offset).Order("created_at desc").Find(&merchants).Error
+	if err != nil {
+		return nil, err
+	}
+	return merchants, nil
+}
+
+func (b BackofficeRepository) SearchTransactions(ctx context.Context, query backoffice.TransactionQuery) ([]transaction.Transaction, error) {
+	var transactions []transaction.Transaction
+	db := b.conn(ctx).Model(&transaction.Transaction{})
+	if query.MerchantId != "" {
+		db = db.Where("merchant_id = ?", query.MerchantId)
+	}
+	if query.CustomerId != "" {
+		db = db.Where("customer_id = ?", query.CustomerId)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	if query.From != nil {
+		db = db.Where("created_at >= ?", *query.From)
+	}
+	if query.To != nil {
+		db = db.Where("created_at < ?", *query.To)
+	}
+	err := paginate(db, query.Limit, query.Offset).Order("created_at desc").Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// SetUserBan bans the user when bannedAt is set and lifts the ban otherwise, the audit is
+// stored in the same transaction
+func (b BackofficeRepository) SetUserBan(ctx context.Context, userId string, bannedAt *time.Time, reason string,
+	audit backoffice.AdminAudit) error {
+	tx := b.conn(ctx).Begin()
+	result := tx.Model(&user.User{}).Where("id = ?", userId).Updates(map[string]interface{}{
+		"banned_at":  bannedAt,
+		"ban_reason": reason,
+	})
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return gorm.ErrRecordNotFound
+	}
+	if err := tx.Create(&audit).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// AdjustMerchantBalance adds the amount to the balance together with the adjustment and
+// audit records. The balance never becomes negative.
+func (b BackofficeRepository) AdjustMerchantBalance(ctx context.Context, adjustment backoffice.BalanceAdjustment,
+	audit backoffice.AdminAudit) error {
+	tx := b.conn(ctx).Begin()
+	result := tx.Model(&merchant.Merchant{}).
+		Where("id = ? AND balance + ? >= 0", adjustment.MerchantId, adjustment.Amount).
+		UpdateColumn("balance", gorm.Expr("balance + ?", adjustment.Amount))
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrBalanceNotAdjusted
+	}
+	if err := tx.Create(&adjustment).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Create(&audit).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (b BackofficeRepository) CreateAudit(ctx context.Context, audit backoffice.AdminAudit) error {
+	if err := b.conn(ctx).Create(&audit).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (b BackofficeRepository) GetAudits(ctx context.Context, query backoffice.AuditQuery) ([]backoffice.AdminAudit, error) {
+	var audits []backoffice.AdminAudit
+	db := b.conn(ctx).Model(&backoffice.AdminAudit{})
+	if query.AdminId != "" {
+		db = db.Where("admin_id = ?", query.AdminId)
+	}
+	if query.TargetId != "" {
+		db = db.Where("target_id = ?", query.TargetId)
+	}
+	err := paginate(db, query.Limit, query.Offset).Order("created_at desc").Find(&audits).Error
+	if err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
+
+type statusCount struct {
+	Status string
+	Count  int
+}
+
+func (b BackofficeRepository) countByStatus(ctx context.Context, model interface{}, column string) (map[string]int, error) {
+	var counts []statusCount
+	err := b.conn(ctx).Model(model).Select(column + " AS status, COUNT(*) AS count").
+		Group(column).Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	byStatus := map[string]int{}
+	for _, c := range counts {
+		byStatus[c.Status] = c.Count
+	}
+	return byStatus, nil
+}
+
+func (b BackofficeRepository) GetPlatformKpis(ctx context.Context) (*backoffice.PlatformKpis, error) {
+	kpis := backoffice.PlatformKpis{}
+	if err := b.conn(ctx).Model(&user.User{}).Count(&kpis.Users).Error; err != nil {
+		return nil, err
+	}
+	if err := b.conn(ctx).Model(&user.User{}).Where("banned_at IS NOT NULL").
+		Count(&kpis.BannedUsers).Error; err != nil {
+		return nil, err
+	}
+	merchants, err := b.countByStatus(ctx, &merchant.Merchant{}, "approval")
+	if err != nil {
+		return nil, err
+	}
+	kpis.MerchantsByStatus = merchants
+	transactions, err := b.countByStatus(ctx, &transaction.Transaction{}, "status")
+	if err != nil {
+		return nil, err
+	}
+	kpis.TransactionsByStatus = transactions
+
+	var sums []int
+	if err := b.conn(ctx).Model(&transaction.Transaction{}).
+		Where("status IN (?)", transaction_status.GetStatusListForSales()).
+		Pluck("COALESCE(SUM(amount), 0)", &sums).Error; err != nil {
+		return nil, err
+	}
+	if len(sums) > 0 {
+		kpis.GrossMerchandiseValue = sums[0]
+	}
+	sums = nil
+	if err := b.conn(ctx).Model(&merchant.Merchant{}).Pluck("COALESCE(SUM(balance + pending_balance), 0)", &sums).Error; err != nil {
+		return nil, err
+	}
+	if len(sums) > 0 {
+		kpis.MerchantBalances = sums[0]
+	}
+	return &kpis, nil
+}
@@ -0,0 +1,88 @@
+package bank_account
+
+import (
+	"context"
+	"github.com/jinzhu/gorm"
+	"github.com/williamchang80/sea-apd/common/logger"
+	"github.com/williamchang80/sea-apd/domain/bank_account"
+)
+
+type BankAccountRepository struct {
+	db *gorm.DB
+}
+
+func NewBankAccountRepository(db *gorm.DB) bank_account.BankAccountRepository {
+	return &BankAccountRepository{db: db}
+}
+
+func (b *BankAccountRepository) conn(ctx context.Context) *gorm.DB {
+	return logger.Gorm(ctx, b.db)
+}
+
+func (b *BankAccountRepository) CreateBankAccount(ctx context.Context, account bank_account.BankAccount) error {
+	if err := b.conn(ctx).Create(&account).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (b *BankAccountRepository) UpdateBankAccount(ctx context.Context, accountId string, account bank_account.BankAccount) error {
+	if err := b.conn(ctx).Model(&bank_account.BankAccount{}).Where("id = ?", accountId).
+		Updates(map[string]interface{}{
+			"bank_code":      account.BankCode,
+			"account_number": account.AccountNumber,
+			"holder_name":    account.HolderName,
+			"status":         account.Status,
+		}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (b *BankAccountRepository) DeleteBankAccount(ctx context.Context, accountId string) error {
+	if err := b.conn(ctx).Where("id = ?", accountId).Delete(&bank_account.BankAccount{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (b *BankAccountRepository) GetBankAccountById(ctx context.Context, accountId string) (*bank_account.BankAccount, error) {
+	var account bank_account.BankAccount
+	if err := b.conn(ctx).Where("id = ?", accountId).First(&account).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (b *BankAccountRepository) GetBankAccountsByMerchant(ctx context.Context, merchantId string) ([]bank_account.BankAccount, error) {
+	var accounts []bank_account.BankAccount
+	err := b.conn(ctx).Where("merchant_id = ?", merchantId).Order("created_at asc").Find(&accounts).Error
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// SetDefaultBankAccount makes the account the only default account of the merchant
+func (b *BankAccountRepository) SetDefaultBankAccount(ctx context.Context, merchantId string, accountId string) error {
+	tx := b.conn(ctx).Begin()
+	if err := tx.Model(&bank_account.BankAccount{}).Where("merchant_id = ?", merchantId).
+		Update("is_default", false).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Model(&bank_account.BankAccount{}).Where("id = ? AND merchant_id = ?", accountId, merchantId).
+		Update("is_default", true).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (b *BankAccountRepository) UpdateBankAccountStatus(ctx context.Context, accountId string, status string) error {
+	if err := b.conn(ctx).Model(&bank_account.BankAccount{}).Where("id = ?", accountId).
+		Update("status", status).Error; err != nil {
+		return err
+	}
+	return nil
+}
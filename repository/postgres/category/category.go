@@ -0,0 +1,95 @@
+package category
+
+import (
+	"context"
+	"github.com/jinzhu/gorm"
+	"github.com/williamchang80/sea-apd/common/logger"
+	"github.com/williamchang80/sea-apd/domain/category"
+)
+
+type CategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) category.CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+func (c *CategoryRepository) conn(ctx context.Context) *gorm.DB {
+	return logger.Gorm(ctx, c.db)
+}
+
+func (c *CategoryRepository) CreateCategory(ctx context.Context, category category.Category) error {
+	if err := c.conn(ctx).Create(&category).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *CategoryRepository) UpdateCategory(ctx context.Context, categoryId string, cat category.Category) error {
+	if err := c.conn(ctx).Model(&category.Category{}).Where("id = ?", categoryId).
+		Updates(map[string]interface{}{
+			"name":      cat.Name,
+			"slug":      cat.Slug,
+			"parent_id": cat.ParentId,
+		}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteCategory moves the children and products of the category up to its parent before deleting it
+func (c *CategoryRepository) DeleteCategory(ctx context.Context, cat category.Category) error {
+	tx := c.conn(ctx).Begin()
+	if err := tx.Model(&category.Category{}).Where("parent_id = ?", cat.ID).
+		Update("parent_id", cat.ParentId).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Table("products").Where("category_id = ?", cat.ID).
+		Update("category_id", cat.ParentId).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Unscoped().Delete(&cat).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (c *CategoryRepository) GetCategoryById(ctx context.Context, categoryId string) (*category.Category, error) {
+	var cat category.Category
+	if err := c.conn(ctx).Where("id = ?", categoryId).First(&cat).Error; err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+func (c *CategoryRepository) GetCategories(ctx context.Context) ([]category.Category, error) {
+	var categories []category.Category
+	if err := c.conn(ctx).Order("name asc").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (c *CategoryRepository) GetProductCountByCategory(ctx context.Context) (map[string]int, error) {
+	rows, err := c.conn(ctx).Table("products").Select("category_id, count(*)").
+		Where("deleted_at IS NULL AND category_id IS NOT NULL").
+		Group("category_id").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	counts := map[string]int{}
+	for rows.Next() {
+		var categoryId string
+		var count int
+		if err := rows.Scan(&categoryId, &count); err != nil {
+			return nil, err
+		}
+		counts[categoryId] = count
+	}
+	return counts, rows.Err()
+}
@@ -1,7 +1,9 @@
 package merchant
 
 import (
+	"context"
 	"github.com/jinzhu/gorm"
+	"github.com/williamchang80/sea-apd/common/logger"
 	"github.com/williamchang80/sea-apd/domain/merchant"
 )
 
@@ -13,35 +15,47 @@ func NewMerchantRepository(db *gorm.DB) merchant.MerchantRepository {
 	return &MerchantRepository{db: db}
 }
 
-func (m MerchantRepository) UpdateMerchantBalance(amount int, merchantId string) error {
+func (m MerchantRepository) conn(ctx context.Context) *gorm.DB {
+	return logger.Gorm(ctx, m.db)
+}
+
+func (m MerchantRepository) UpdateMerchantBalance(ctx context.Context, amount int, merchantId string) error {
 	var merchant merchant.Merchant
-	if err := m.db.Model(&merchant).Where("id = ?", merchantId).Find(&merchant).Update("balance", gorm.Expr("balance + ?",
+	if err := m.conn(ctx).Model(&merchant).Where("id = ?", merchantId).Find(&merchant).Update("balance", gorm.Expr("balance + ?",
 		amount)).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (m MerchantRepository) GetMerchantBalance(merchantId string) (int, error) {
+func (m MerchantRepository) GetMerchantBalance(ctx context.Context, merchantId string) (int, error) {
 	var merchant merchant.Merchant
-	if err := m.db.Where("id = ?", merchantId).Find(&merchant).Error; err != nil {
+	if err := m.conn(ctx).Where("id = ?", merchantId).Find(&merchant).Error; err != nil {
 		return 0, err
 	}
 	return merchant.Balance, nil
 }
 
-func (m MerchantRepository) GetMerchants() ([]merchant.Merchant, error) {
+func (m MerchantRepository) UpdateMerchantPendingBalance(ctx context.Context, amount int, merchantId string) error {
+	if err := m.conn(ctx).Model(&merchant.Merchant{}).Where("id = ?", merchantId).
+		UpdateColumn("pending_balance", gorm.Expr("pending_balance + ?", amount)).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m MerchantRepository) GetMerchants(ctx context.Context) ([]merchant.Merchant, error) {
 	var merchants []merchant.Merchant
-	err := m.db.Find(&merchants).Error
+	err := m.conn(ctx).Find(&merchants).Error
 	if err != nil {
 		return nil, err
 	}
 	return merchants, nil
 }
 
-func (m MerchantRepository) GetMerchantById(merchantId string) (*merchant.Merchant, error) {
+func (m MerchantRepository) GetMerchantById(ctx context.Context, merchantId string) (*merchant.Merchant, error) {
 	var merchant merchant.Merchant
-	err := m.db.Where("id = ?", merchantId).Find(&merchant).Limit(1).Error
+	err := m.conn(ctx).Where("id = ?", merchantId).Find(&merchant).Limit(1).Error
 	if err != nil {
 		return nil, err
 	}
@@ -57,25 +71,111 @@ func (m MerchantRepository) GetMerchantByUserId(userId string) (*merchant.Mercha
 	return &merchant, nil
 }
 
-func (m MerchantRepository) RegisterMerchant(merchant merchant.Merchant) (*merchant.Merchant, error) {
-	if err := m.db.Create(&merchant).Error; err != nil {
+func (m MerchantRepository) RegisterMerchant(ctx context.Context, merchant merchant.Merchant) (*merchant.Merchant, error) {
+	if err := m.conn(ctx).Create(&merchant).Error; err != nil {
 		return nil, err
 	}
 	return &merchant, nil
 }
 
-func (m MerchantRepository) UpdateMerchantApprovalStatus(merchantId string, status string) error {
-	if err := m.db.Model(&merchant.Merchant{}).Where("id = ?", merchantId).Update(
+func (m MerchantRepository) UpdateMerchantApprovalStatus(ctx context.Context, merchantId string, status string) error {
+	if err := m.conn(ctx).Model(&merchant.Merchant{}).Where("id = ?", merchantId).Update(
 		merchant.Merchant{Approval: status}).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (m MerchantRepository) UpdateMerchant(merchantId string, merch merchant.Merchant) error {
-	if err := m.db.Model(&merch).Where("id = ?",merchantId).
+func (m MerchantRepository) UpdateMerchant(ctx context.Context, merchantId string, merch merchant.Merchant) error {
+	if err := m.conn(ctx).Model(&merch).Where("id = ?",merchantId).
 		Updates(&merch).Error; err != nil {
 		return err
 	}
 	return nil
-}
\ No newline at end of file
+}
+func (m MerchantRepository) GetMerchantsByStatus(ctx context.Context, status string) ([]merchant.Merchant, error) {
+	var merchants []merchant.Merchant
+	err := m.conn(ctx).Where("approval = ?", status).Order("updated_at asc").Find(&merchants).Error
+	if err != nil {
+		return nil, err
+	}
+	return merchants, nil
+}
+
+func (m MerchantRepository) GetMerchantsByUser(ctx context.Context, userId string) ([]merchant.Merchant, error) {
+	var merchants []merchant.Merchant
+	err := m.conn(ctx).Where("user_id = ?", userId).Find(&merchants).Error
+	if err != nil {
+		return nil, err
+	}
+	return merchants, nil
+}
+
+// ChangeMerchantStatus moves the merchant from review.FromStatus to review.ToStatus,
+// writes the other changed columns and stores the review as audit record. It fails with
+// ErrInvalidStatusTransition when the merchant is no longer in review.FromStatus, e.g.
+// when two admins decide at once.
+func (m MerchantRepository) ChangeMerchantStatus(ctx context.Context, review merchant.MerchantReview, changes map[string]interface{}) error {
+	columns := map[string]interface{}{"approval": review.ToStatus}
+	for column, value := range changes {
+		columns[column] = value
+	}
+	tx := m.conn(ctx).Begin()
+	result := tx.Model(&merchant.Merchant{}).
+		Where("id = ? AND approval = ?", review.MerchantId, review.FromStatus).
+		Updates(columns)
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return merchant.ErrInvalidStatusTransition
+	}
+	if err := tx.Create(&review).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (m MerchantRepository) GetMerchantReviews(ctx context.Context, merchantId string) ([]merchant.MerchantReview, error) {
+	var reviews []merchant.MerchantReview
+	err := m.conn(ctx).Where("merchant_id = ?", merchantId).Order("created_at desc").Find(&reviews).Error
+	if err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (m MerchantRepository) CreateMerchantDocument(ctx context.Context, document merchant.MerchantDocument) error {
+	if err := m.conn(ctx).Create(&document).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m MerchantRepository) DeleteMerchantDocument(ctx context.Context, documentId string) error {
+	if err := m.conn(ctx).Unscoped().Where("id = ?", documentId).
+		Delete(&merchant.MerchantDocument{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m MerchantRepository) GetMerchantDocumentById(ctx context.Context, documentId string) (*merchant.MerchantDocument, error) {
+	var document merchant.MerchantDocument
+	if err := m.conn(ctx).Where("id = ?", documentId).First(&document).Error; err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+func (m MerchantRepository) GetMerchantDocuments(ctx context.Context, merchantIds []string) ([]merchant.MerchantDocument, error) {
+	var documents []merchant.MerchantDocument
+	err := m.conn(ctx).Where("merchant_id IN (?)", merchantIds).Order("created_at asc").Find(&documents).Error
+	if err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
@@ -1,6 +1,7 @@
 package merchant
 
 import (
+	"context"
 	"github.com/jinzhu/gorm"
 	domain "github.com/williamchang80/sea-apd/domain/merchant"
 	mock_psql "github.com/williamchang80/sea-apd/mocks/postgres"
@@ -76,7 +77,7 @@ func TestMerchantRepository_GetMerchantBalance(t *testing.T) {
 			pr := MerchantRepository{
 				db: tt.initMock(),
 			}
-			balance, err := pr.GetMerchantBalance(tt.args.merchantId)
+			balance, err := pr.GetMerchantBalance(context.Background(), tt.args.merchantId)
 			if err != nil && !tt.wantErr {
 				t.Errorf("MerchantRepository.GetMerchantBalance() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -131,7 +132,7 @@ func TestMerchantRepository_UpdateMerchantBalance(t *testing.T) {
 			pr := MerchantRepository{
 				db: tt.initMock(),
 			}
-			err := pr.UpdateMerchantBalance(tt.args.amount, tt.args.merchantId)
+			err := pr.UpdateMerchantBalance(context.Background(), tt.args.amount, tt.args.merchantId)
 			if err != nil && !tt.wantErr {
 				t.Errorf("MerchantRepository.UpdateMerchantBalance() error = %v, wantErr %v", err, tt.wantErr)
 				return
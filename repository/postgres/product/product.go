@@ -1,8 +1,14 @@
 package product
 
 import (
+	"context"
+	"time"
+
 	"github.com/jinzhu/gorm"
+	"github.com/williamchang80/sea-apd/common/constants/merchant_status"
+	"github.com/williamchang80/sea-apd/common/logger"
 	"github.com/williamchang80/sea-apd/domain/product"
+	"github.com/williamchang80/sea-apd/domain/transaction"
 )
 
 type ProductRepository struct {
@@ -13,50 +19,279 @@ func NewProductRepository(db *gorm.DB) product.ProductRepository {
 	return &ProductRepository{db: db}
 }
 
-func (p *ProductRepository) GetProducts() ([]product.Product, error) {
+func (p *ProductRepository) conn(ctx context.Context) *gorm.DB {
+	return logger.Gorm(ctx, p.db)
+}
+
+// MigrateSkuIndex makes skus unique per merchant, products without a sku are left out
+func MigrateSkuIndex(db *gorm.DB) error {
+	return db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_products_merchant_sku ON products " +
+		"(merchant_id, sku) WHERE sku <> '' AND deleted_at IS NULL").Error
+}
+
+// visibleProducts hides the products of closed merchants and of merchants whose
+// suspension has not run out yet
+func visibleProducts(db *gorm.DB) *gorm.DB {
+	return db.Where("products.merchant_id NOT IN (SELECT id FROM merchants WHERE approval = ? "+
+		"OR (approval = ? AND suspended_until > ?))",
+		merchant_status.ToString(merchant_status.CLOSED),
+		merchant_status.ToString(merchant_status.SUSPENDED), time.Now())
+}
+
+func (p *ProductRepository) GetProducts(ctx context.Context) ([]product.Product, error) {
 	var products []product.Product
-	err := p.db.Find(&products).Error
+	err := p.conn(ctx).Scopes(visibleProducts).Find(&products).Error
 	if err != nil {
 		return nil, err
 	}
 	return products, nil
 }
 
-func (p *ProductRepository) GetProductById(productId string) (*product.Product, error) {
+func (p *ProductRepository) GetProductById(ctx context.Context, productId string) (*product.Product, error) {
 	var product product.Product
-	err := p.db.Where("id = ?", productId).Find(&product).Limit(1).Error
+	err := p.conn(ctx).Where("id = ?", productId).Preload("Tags").
+		Preload("Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position asc")
+		}).Preload("Variants").Find(&product).Limit(1).Error
 	if err != nil {
 		return nil, err
 	}
 	return &product, nil
 }
 
-func (p *ProductRepository) CreateProduct(product product.Product) error {
-	if err := p.db.Create(&product).Error; err != nil {
+func (p *ProductRepository) CreateProduct(ctx context.Context, product product.Product) error {
+	if err := p.conn(ctx).Create(&product).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (p *ProductRepository) UpdateProduct(productId string, product product.Product) error {
-	if err := p.db.Model(&product).Where("id = " + productId).Update(&product).Error; err != nil {
+func (p *ProductRepository) UpdateProduct(ctx context.Context, productId string, product product.Product) error {
+	if err := p.conn(ctx).Model(&product).Where("id = " + productId).Update(&product).Error; err != nil {
 		return err
 	}
+	if product.Tags != nil {
+		product.ID = productId
+		if err := p.conn(ctx).Model(&product).Association("Tags").Replace(product.Tags).Error; err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (p *ProductRepository) DeleteProduct(productId string) error {
-	if err := p.db.Delete(&product.Product{}, productId).Error; err != nil {
+func (p *ProductRepository) DeleteProduct(ctx context.Context, productId string) error {
+	if err := p.conn(ctx).Delete(&product.Product{}, productId).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (p *ProductRepository) GetProductsByMerchant(merchantId string) ([]product.Product, error) {
+func (p *ProductRepository) GetProductsByMerchant(ctx context.Context, merchantId string) ([]product.Product, error) {
+	var products []product.Product
+	err := p.conn(ctx).Where("merchant_id = ?", merchantId).Preload("Tags").Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (p *ProductRepository) GetProductsByCategories(ctx context.Context, categoryIds []string) ([]product.Product, error) {
 	var products []product.Product
-	err := p.db.Where("merchant_id = ?", merchantId).Find(&products).Error
+	err := p.conn(ctx).Scopes(visibleProducts).Where("category_id IN (?)", categoryIds).
+		Preload("Tags").Find(&products).Error
 	if err != nil {
 		return nil, err
 	}
 	return products, nil
 }
+
+func (p *ProductRepository) GetProductsByTag(ctx context.Context, tag string) ([]product.Product, error) {
+	var products []product.Product
+	err := p.conn(ctx).Scopes(visibleProducts).Joins("JOIN product_tags ON product_tags.product_id = products.id").
+		Joins("JOIN tags ON tags.id = product_tags.tag_id").
+		Where("tags.name = ?", tag).Preload("Tags").Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (p *ProductRepository) GetOrCreateTags(ctx context.Context, names []string) ([]product.Tag, error) {
+	tags := []product.Tag{}
+	for _, name := range names {
+		var tag product.Tag
+		if err := p.conn(ctx).Where(product.Tag{Name: name}).FirstOrCreate(&tag).Error; err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+func (p *ProductRepository) ReplaceOptions(ctx context.Context, productId string, options []product.ProductOption) error {
+	tx := p.conn(ctx).Begin()
+	if err := tx.Unscoped().Where("product_id = ?", productId).
+		Delete(&product.ProductOption{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, option := range options {
+		option.ProductId = productId
+		if err := tx.Create(&option).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+func (p *ProductRepository) CreateVariant(ctx context.Context, variant product.ProductVariant) error {
+	if err := p.conn(ctx).Create(&variant).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *ProductRepository) UpdateVariant(ctx context.Context, variantId string, variant product.ProductVariant) error {
+	if err := p.conn(ctx).Model(&product.ProductVariant{}).Where("id = ?", variantId).
+		Updates(map[string]interface{}{
+			"sku":   variant.Sku,
+			"price": variant.Price,
+			"stock": variant.Stock,
+		}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *ProductRepository) DeleteVariant(ctx context.Context, variantId string) error {
+	if err := p.conn(ctx).Where("id = ?", variantId).Delete(&product.ProductVariant{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *ProductRepository) GetVariantById(ctx context.Context, variantId string) (*product.ProductVariant, error) {
+	var variant product.ProductVariant
+	if err := p.conn(ctx).Where("id = ?", variantId).First(&variant).Error; err != nil {
+		return nil, err
+	}
+	return &variant, nil
+}
+
+func (p *ProductRepository) ReserveStock(ctx context.Context, details []transaction.ProductTransaction) error {
+	return p.adjustStock(ctx, details, -1)
+}
+
+func (p *ProductRepository) ReleaseStock(ctx context.Context, details []transaction.ProductTransaction) error {
+	return p.adjustStock(ctx, details, 1)
+}
+
+// adjustStock moves the stock of every line in one database transaction. Lines with a
+// variant adjust the variant stock, the rest adjust the product stock. Reserving fails
+// as a whole when any line does not have enough stock left.
+func (p *ProductRepository) adjustStock(ctx context.Context, details []transaction.ProductTransaction, sign int) error {
+	tx := p.conn(ctx).Begin()
+	for _, d := range details {
+		table, id := "products", d.ProductId
+		if d.VariantId != nil {
+			table, id = "product_variants", *d.VariantId
+		}
+		query := tx.Table(table).Where("id = ? AND deleted_at IS NULL", id)
+		if sign < 0 {
+			query = query.Where("stock >= ?", d.Quantity)
+		}
+		result := query.UpdateColumn("stock", gorm.Expr("stock + ?", sign*d.Quantity))
+		if result.Error != nil {
+			tx.Rollback()
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			tx.Rollback()
+			return product.ErrInsufficientStock
+		}
+	}
+	return tx.Commit().Error
+}
+
+// UpsertProduct creates the product or, when the merchant already has a product with
+// the same sku, overwrites it. It reports whether a new product was created.
+func (p *ProductRepository) UpsertProduct(ctx context.Context, prod product.Product) (bool, error) {
+	tx := p.conn(ctx).Begin()
+	var existing product.Product
+	err := tx.Where("merchant_id = ? AND sku = ?", prod.MerchantId, prod.Sku).First(&existing).Error
+	if gorm.IsRecordNotFoundError(err) {
+		if err := tx.Create(&prod).Error; err != nil {
+			tx.Rollback()
+			return false, err
+		}
+		return true, tx.Commit().Error
+	}
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if err := tx.Model(&existing).Updates(map[string]interface{}{
+		"name":        prod.Name,
+		"description": prod.Description,
+		"price":       prod.Price,
+		"stock":       prod.Stock,
+		"category_id": prod.CategoryId,
+	}).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if prod.Tags != nil {
+		if err := tx.Model(&existing).Association("Tags").Replace(prod.Tags).Error; err != nil {
+			tx.Rollback()
+			return false, err
+		}
+	}
+	return false, tx.Commit().Error
+}
+
+func (p *ProductRepository) CreateImportJob(ctx context.Context, job *product.ImportJob) error {
+	if err := p.conn(ctx).Create(job).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateImportJob saves the progress counters of the job and stores the row errors
+// that were added since the last update
+func (p *ProductRepository) UpdateImportJob(ctx context.Context, job *product.ImportJob) error {
+	tx := p.conn(ctx).Begin()
+	if err := tx.Model(&product.ImportJob{}).Where("id = ?", job.ID).
+		Updates(map[string]interface{}{
+			"status":         job.Status,
+			"processed_rows": job.ProcessedRows,
+			"created_rows":   job.CreatedRows,
+			"updated_rows":   job.UpdatedRows,
+			"failed_rows":    job.FailedRows,
+		}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for i := range job.Errors {
+		if job.Errors[i].ID != "" {
+			continue
+		}
+		job.Errors[i].JobId = job.ID
+		if err := tx.Create(&job.Errors[i]).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+func (p *ProductRepository) GetImportJobById(ctx context.Context, jobId string) (*product.ImportJob, error) {
+	var job product.ImportJob
+	err := p.conn(ctx).Where("id = ?", jobId).Preload("Errors", func(db *gorm.DB) *gorm.DB {
+		return db.Order("row_number asc")
+	}).First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
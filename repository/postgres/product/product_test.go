@@ -1,6 +1,7 @@
 package product
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"regexp"
@@ -103,8 +104,10 @@ func TestProductRepository_GetProducts(t *testing.T) {
 					FROM
 						"products"
 					WHERE
-						"products"."deleted_at" IS NULL
-				`)).WillReturnRows(sqlmock.NewRows([]string{
+						"products"."deleted_at" IS NULL AND
+						((products.merchant_id NOT IN (SELECT id FROM merchants WHERE approval = $1
+						OR (approval = $2 AND suspended_until > $3))))
+				`)).WithArgs("closed", "suspended", sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{
 					"name",
 					"description",
 					"price",
@@ -120,7 +123,7 @@ func TestProductRepository_GetProducts(t *testing.T) {
 			pr := ProductRepository{
 				db: tt.initMock(),
 			}
-			products, err := pr.GetProducts()
+			products, err := pr.GetProducts(context.Background())
 			if err != nil && !tt.wantErr {
 				t.Errorf("ProductRepository.GetProducts() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -171,7 +174,7 @@ func TestProductRepository_GetProductById(t *testing.T) {
 			pr := ProductRepository{
 				db: tt.initMock(),
 			}
-			products, err := pr.GetProductById(tt.args.productId)
+			products, err := pr.GetProductById(context.Background(), tt.args.productId)
 			if err != nil && !tt.wantErr {
 				t.Errorf("ProductRepository.GetProductById() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -244,7 +247,7 @@ func TestProductRepository_CreateProduct(t *testing.T) {
 			pr := ProductRepository{
 				db: tt.initMock(),
 			}
-			err := pr.CreateProduct(tt.args.product)
+			err := pr.CreateProduct(context.Background(), tt.args.product)
 			if err != nil && !tt.wantErr {
 				t.Errorf("ProductRepository.CreateProduct() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -291,7 +294,7 @@ func TestProductRepository_DeleteProduct(t *testing.T) {
 			pr := ProductRepository{
 				db: tt.initMock(),
 			}
-			err := pr.DeleteProduct(tt.args.productId)
+			err := pr.DeleteProduct(context.Background(), tt.args.productId)
 			if err != nil && !tt.wantErr {
 				t.Errorf("ProductRepository.DeleteProduct() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -352,7 +355,7 @@ func TestProductRepository_UpdateProduct(t *testing.T) {
 			pr := ProductRepository{
 				db: tt.initMock(),
 			}
-			err := pr.UpdateProduct(tt.args.productId, tt.args.product)
+			err := pr.UpdateProduct(context.Background(), tt.args.productId, tt.args.product)
 			if err != nil && !tt.wantErr {
 				t.Errorf("ProductRepository.UpdateProduct() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -393,6 +396,10 @@ func TestProductRepository_GetProductsByMerchant(t *testing.T) {
 					"image",
 					"stock",
 				}))
+				mocks.ExpectQuery(regexp.QuoteMeta(`
+					SELECT * FROM "tags"
+					INNER JOIN "product_tags" ON "product_tags"."tag_id" = "tags"."id"
+				`)).WillReturnRows(sqlmock.NewRows([]string{"name"}))
 				return db
 			},
 		},
@@ -403,7 +410,7 @@ func TestProductRepository_GetProductsByMerchant(t *testing.T) {
 			pr := ProductRepository{
 				db: tt.initMock(),
 			}
-			prod, err := pr.GetProductsByMerchant(tt.args.merchantId)
+			prod, err := pr.GetProductsByMerchant(context.Background(), tt.args.merchantId)
 			if err != nil && !tt.wantErr {
 				t.Errorf("ProductRepository.GetProductsByMerchant() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,99 @@
+package product
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/jinzhu/gorm"
+	"github.com/williamchang80/sea-apd/common/constants/product_sort"
+	"github.com/williamchang80/sea-apd/common/logger"
+	"github.com/williamchang80/sea-apd/domain/product"
+)
+
+const (
+	// searchVector must stay identical to the indexed expression in
+	// MigrateSearchIndex, otherwise postgres will not use the index
+	searchVector      = "to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(description, ''))"
+	similarityMinimum = 0.3
+)
+
+type ProductSearchRepository struct {
+	db *gorm.DB
+}
+
+func (p *ProductSearchRepository) conn(ctx context.Context) *gorm.DB {
+	return logger.Gorm(ctx, p.db)
+}
+
+func NewProductSearcher(db *gorm.DB) product.ProductSearcher {
+	return &ProductSearchRepository{db: db}
+}
+
+// MigrateSearchIndex creates the full-text and trigram indexes used by SearchProducts
+func MigrateSearchIndex(db *gorm.DB) error {
+	statements := []string{
+		"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+		"CREATE INDEX IF NOT EXISTS idx_products_search ON products USING GIN (" + searchVector + ")",
+		"CREATE INDEX IF NOT EXISTS idx_products_name_trgm ON products USING GIN (name gin_trgm_ops)",
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ToPrefixTsQuery turns free text into a tsquery where every term is prefix matched,
+// e.g. "red sho" becomes "red:* & sho:*"
+func ToPrefixTsQuery(keyword string) string {
+	terms := strings.FieldsFunc(strings.ToLower(keyword), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+func (p *ProductSearchRepository) SearchProducts(ctx context.Context, query product.ProductSearchQuery) ([]product.Product, error) {
+	var products []product.Product
+	db := p.conn(ctx).Model(&product.Product{}).Scopes(visibleProducts)
+	tsQuery := ToPrefixTsQuery(query.Keyword)
+	if tsQuery != "" {
+		db = db.Where("("+searchVector+" @@ to_tsquery('simple', ?) OR word_similarity(?, name) > ?)",
+			tsQuery, query.Keyword, similarityMinimum)
+	}
+	if query.MinPrice > 0 {
+		db = db.Where("price >= ?", query.MinPrice)
+	}
+	if query.MaxPrice > 0 {
+		db = db.Where("price <= ?", query.MaxPrice)
+	}
+	if query.InStock {
+		db = db.Where("stock > 0")
+	}
+	if query.MerchantId != "" {
+		db = db.Where("merchant_id = ?", query.MerchantId)
+	}
+	switch product_sort.ParseToEnum(query.Sort) {
+	case product_sort.PRICE_ASC:
+		db = db.Order("price asc")
+	case product_sort.PRICE_DESC:
+		db = db.Order("price desc")
+	default:
+		if tsQuery != "" {
+			db = db.Order(gorm.Expr("ts_rank("+searchVector+", to_tsquery('simple', ?)) + "+
+				"word_similarity(?, name) desc", tsQuery, query.Keyword))
+		}
+	}
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit).Offset(query.Offset)
+	}
+	err := db.Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
@@ -0,0 +1,46 @@
+package shipping
+
+import (
+	"context"
+	"github.com/jinzhu/gorm"
+	"github.com/williamchang80/sea-apd/common/logger"
+	"github.com/williamchang80/sea-apd/domain/shipping"
+)
+
+type ShippingRepository struct {
+	db *gorm.DB
+}
+
+func NewShippingRepository(db *gorm.DB) shipping.ShippingRepository {
+	return &ShippingRepository{db: db}
+}
+
+func (s *ShippingRepository) conn(ctx context.Context) *gorm.DB {
+	return logger.Gorm(ctx, s.db)
+}
+
+func (s *ShippingRepository) GetShippingRates(ctx context.Context, merchantId string) ([]shipping.ShippingRate, error) {
+	var rates []shipping.ShippingRate
+	err := s.conn(ctx).Where("merchant_id = ?", merchantId).Order("region asc, max_weight_grams asc").
+		Find(&rates).Error
+	if err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// ReplaceShippingRates swaps the whole rate table of the merchant for the rates
+func (s *ShippingRepository) ReplaceShippingRates(ctx context.Context, merchantId string, rates []shipping.ShippingRate) error {
+	tx := s.conn(ctx).Begin()
+	if err := tx.Where("merchant_id = ?", merchantId).Delete(&shipping.ShippingRate{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, rate := range rates {
+		if err := tx.Create(&rate).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
@@ -0,0 +1,113 @@
+package transaction
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/williamchang80/sea-apd/common/constants/time_bucket"
+	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
+	"github.com/williamchang80/sea-apd/domain/analytics"
+	"github.com/williamchang80/sea-apd/domain/transaction"
+)
+
+// salesQuery selects the accepted and completed transactions of the query, only those
+// transactions count as sales
+func salesQuery(db *gorm.DB, query analytics.AnalyticsQuery) *gorm.DB {
+	return db.Where("transactions.merchant_id = ? AND transactions.status IN (?)", query.MerchantId,
+		transaction_status.GetStatusListForSales()).
+		Where("transactions.created_at >= ? AND transactions.created_at < ?", query.From, query.To)
+}
+
+func (t TransactionRepository) GetSalesBuckets(ctx context.Context, query analytics.AnalyticsQuery) ([]analytics.SalesBucket, error) {
+	var buckets []analytics.SalesBucket
+	err := salesQuery(t.conn(ctx).Model(&transaction.Transaction{}), query).
+		Select("date_trunc(?, transactions.created_at) AS bucket_start, "+
+			"COALESCE(SUM(transactions.amount), 0) AS revenue, COUNT(*) AS orders", query.Bucket).
+		Group("bucket_start").Order("bucket_start asc").Scan(&buckets).Error
+	if err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+func (t TransactionRepository) GetSalesRollups(ctx context.Context, query analytics.AnalyticsQuery) ([]analytics.SalesBucket, error) {
+	var buckets []analytics.SalesBucket
+	err := t.conn(ctx).Model(&analytics.SalesRollup{}).Select("bucket_start, revenue, orders").
+		Where("merchant_id = ? AND bucket = ?", query.MerchantId, query.Bucket).
+		Where("bucket_start >= date_trunc(?, ?::timestamptz) AND bucket_start < ?",
+			query.Bucket, query.From, query.To).
+		Order("bucket_start asc").Scan(&buckets).Error
+	if err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+func (t TransactionRepository) GetTopProducts(ctx context.Context, query analytics.AnalyticsQuery, limit int) ([]analytics.ProductSales, error) {
+	var products []analytics.ProductSales
+	err := salesQuery(t.conn(ctx).Table("product_transactions"), query).
+		Select("product_transactions.product_id, products.name, " +
+			"SUM(product_transactions.quantity) AS quantity, " +
+			"SUM(product_transactions.quantity * product_transactions.unit_price) AS revenue").
+		Joins("JOIN transactions ON transactions.id = product_transactions.transaction_id").
+		Joins("JOIN products ON products.id = product_transactions.product_id").
+		Where("transactions.deleted_at IS NULL").
+		Group("product_transactions.product_id, products.name").
+		Order("revenue desc, quantity desc").Limit(limit).Scan(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// GetConversion counts the checked out transactions of the query. A declined transaction
+// carrying bank details was paid before, so it counts as refunded.
+func (t TransactionRepository) GetConversion(ctx context.Context, query analytics.AnalyticsQuery) (*analytics.Conversion, error) {
+	var conversion analytics.Conversion
+	paid := []string{
+		transaction_status.ToString(transaction_status.WAITING_CONFIRMATION),
+		transaction_status.ToString(transaction_status.WAITING_DELIVERY),
+		transaction_status.ToString(transaction_status.ACCEPTED),
+		transaction_status.ToString(transaction_status.COMPLETED),
+	}
+	declined := transaction_status.ToString(transaction_status.DECLINED)
+	err := t.conn(ctx).Model(&transaction.Transaction{}).
+		Select("COUNT(*) AS checked_out, "+
+			"COUNT(*) FILTER (WHERE status IN (?) OR (status = ? AND bank_number <> '')) AS paid, "+
+			"COUNT(*) FILTER (WHERE status IN (?)) AS accepted, "+
+			"COUNT(*) FILTER (WHERE status = ? AND bank_number <> '') AS refunded",
+			paid, declined, transaction_status.GetStatusListForSales(), declined).
+		Where("merchant_id = ? AND status <> ?", query.MerchantId,
+			transaction_status.ToString(transaction_status.ON_CARTS)).
+		Where("created_at >= ? AND created_at < ?", query.From, query.To).
+		Scan(&conversion).Error
+	if err != nil {
+		return nil, err
+	}
+	return &conversion, nil
+}
+
+// RefreshSalesRollups rebuilds every rollup of the merchants with transactions changed
+// since the given time. Whole merchants are rebuilt because a transaction accepted now
+// may belong to a bucket of long ago.
+func (t TransactionRepository) RefreshSalesRollups(ctx context.Context, since time.Time) error {
+	changed := t.conn(ctx).Model(&transaction.Transaction{}).Select("DISTINCT merchant_id").
+		Where("updated_at >= ?", since).SubQuery()
+	tx := t.conn(ctx).Begin()
+	if err := tx.Where("merchant_id IN ?", changed).Delete(&analytics.SalesRollup{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, bucket := range time_bucket.GetRollupBuckets() {
+		if err := tx.Exec("INSERT INTO sales_rollups (merchant_id, bucket, bucket_start, revenue, orders, updated_at) "+
+			"SELECT merchant_id, ?, date_trunc(?, created_at), SUM(amount), COUNT(*), now() "+
+			"FROM transactions WHERE deleted_at IS NULL AND status IN (?) AND merchant_id IN ? "+
+			"GROUP BY merchant_id, 3", bucket, bucket,
+			transaction_status.GetStatusListForSales(), changed).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
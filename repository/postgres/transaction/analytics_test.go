@@ -0,0 +1,72 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jinzhu/gorm"
+	"github.com/williamchang80/sea-apd/domain/analytics"
+	mock_psql "github.com/williamchang80/sea-apd/mocks/postgres"
+)
+
+func TestTransactionRepository_GetSalesBuckets(t *testing.T) {
+	db, mocks := mock_psql.Connection()
+	defer db.Close()
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	query := analytics.AnalyticsQuery{MerchantId: "1", Bucket: "day", From: from, To: from.AddDate(0, 0, 2)}
+	salesQuery := regexp.QuoteMeta(`
+		SELECT date_trunc($1, transactions.created_at) AS bucket_start,
+		COALESCE(SUM(transactions.amount), 0) AS revenue, COUNT(*) AS orders
+		FROM "transactions"
+		WHERE "transactions"."deleted_at" IS NULL
+		AND ((transactions.merchant_id = $2 AND transactions.status IN ($3,$4))
+		AND (transactions.created_at >= $5 AND transactions.created_at < $6))
+		GROUP BY bucket_start ORDER BY bucket_start asc
+	`)
+	tests := []struct {
+		name     string
+		want     []analytics.SalesBucket
+		wantErr  bool
+		initMock func() *gorm.DB
+	}{
+		{
+			name:    "success",
+			want:    []analytics.SalesBucket{{BucketStart: from, Revenue: 3000, Orders: 2}},
+			wantErr: false,
+			initMock: func() *gorm.DB {
+				mocks.ExpectQuery(salesQuery).
+					WithArgs("day", "1", "accepted", "completed", query.From, query.To).
+					WillReturnRows(sqlmock.NewRows([]string{"bucket_start", "revenue", "orders"}).
+						AddRow(from, 3000, 2))
+				return db
+			},
+		},
+		{
+			name:    "failed",
+			want:    nil,
+			wantErr: true,
+			initMock: func() *gorm.DB {
+				mocks.ExpectQuery(salesQuery).WillReturnError(errors.New("query failed"))
+				return db
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := TransactionRepository{db: tt.initMock()}
+			got, err := tr.GetSalesBuckets(context.Background(), query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TransactionRepository.GetSalesBuckets() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TransactionRepository.GetSalesBuckets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,14 @@
 package transaction
 
 import (
+	"context"
+	"time"
+
 	"github.com/jinzhu/gorm"
 	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
+	"github.com/williamchang80/sea-apd/common/logger"
+	"github.com/williamchang80/sea-apd/common/metrics"
+	"github.com/williamchang80/sea-apd/domain/merchant"
 	"github.com/williamchang80/sea-apd/domain/transaction"
 )
 
@@ -14,29 +20,171 @@ func NewTransactionRepository(db *gorm.DB) transaction.TransactionRepository {
 	return &TransactionRepository{db: db}
 }
 
-func (t TransactionRepository) CreateTransaction(tr transaction.Transaction) error {
-	err := t.db.Create(&tr).Error
-	return err
+func (t TransactionRepository) conn(ctx context.Context) *gorm.DB {
+	return logger.Gorm(ctx, t.db)
+}
+
+// commitStatusChange commits tx and counts the status change once it is stored
+func commitStatusChange(tx *gorm.DB, change transaction.TransactionStatusChange) error {
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	metrics.TransactionTransitions.WithLabelValues(metrics.Status(change.FromStatus), change.ToStatus).Inc()
+	return nil
 }
 
-func (t TransactionRepository) UpdateTransactionStatus(status string, id string) (*transaction.Transaction, error) {
+func (t TransactionRepository) CreateTransaction(ctx context.Context, tr transaction.Transaction) error {
+	tx := t.conn(ctx).Begin()
+	if err := tx.Create(&tr).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	change := transaction.TransactionStatusChange{TransactionId: tr.ID, ToStatus: tr.Status}
+	if err := tx.Create(&change).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return commitStatusChange(tx, change)
+}
+
+func (t TransactionRepository) UpdateTransactionStatus(ctx context.Context, status string, id string) (*transaction.Transaction, error) {
 	var tran transaction.Transaction
-	err := t.db.Where("id = ?", id).Find(&tran).Update("status", status).Error
-	return &tran, err
+	tx := t.conn(ctx).Begin()
+	if err := tx.Where("id = ?", id).Find(&tran).Error; err != nil {
+		tx.Rollback()
+		return &tran, err
+	}
+	change := transaction.TransactionStatusChange{TransactionId: tran.ID, FromStatus: tran.Status,
+		ToStatus: status}
+	if err := tx.Model(&tran).Update("status", status).Error; err != nil {
+		tx.Rollback()
+		return &tran, err
+	}
+	if err := tx.Create(&change).Error; err != nil {
+		tx.Rollback()
+		return &tran, err
+	}
+	return &tran, commitStatusChange(tx, change)
+}
+
+// ChangeTransactionStatus moves the transaction from change.FromStatus to change.ToStatus
+// and records the change in the status history. It fails with ErrInvalidStatusTransition
+// when the transaction is no longer in change.FromStatus.
+func (t TransactionRepository) ChangeTransactionStatus(ctx context.Context, change transaction.TransactionStatusChange) (
+	*transaction.Transaction, error) {
+	return t.changeStatus(ctx, change, map[string]interface{}{}, nil)
+}
+
+// CompleteTransaction changes the status like ChangeTransactionStatus and moves the amount
+// of the transaction from the pending to the available balance of the merchant in the same
+// database transaction. Nothing is stored when the pending balance of the merchant does not
+// hold the amount, it then fails with ErrPendingBalanceTooLow.
+func (t TransactionRepository) CompleteTransaction(ctx context.Context, change transaction.TransactionStatusChange) (
+	*transaction.Transaction, error) {
+	return t.changeStatus(ctx, change, map[string]interface{}{}, releasePendingBalance)
 }
 
-func (t TransactionRepository) GetTransactionById(id string) (*transaction.Transaction, error) {
+// releasePendingBalance moves the amount of the transaction to the available balance of its merchant
+func releasePendingBalance(tx *gorm.DB, tran transaction.Transaction) error {
+	if tran.Amount <= 0 {
+		return nil
+	}
+	result := tx.Model(&merchant.Merchant{}).Where("id = ? AND pending_balance >= ?", tran.MerchantId, tran.Amount).
+		UpdateColumns(map[string]interface{}{
+			"balance":         gorm.Expr("balance + ?", tran.Amount),
+			"pending_balance": gorm.Expr("pending_balance - ?", tran.Amount),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return merchant.ErrPendingBalanceTooLow
+	}
+	return nil
+}
+
+// ShipTransaction stores the shipment together with the status change, it fails like
+// ChangeTransactionStatus when the transaction is no longer in change.FromStatus
+func (t TransactionRepository) ShipTransaction(ctx context.Context, change transaction.TransactionStatusChange,
+	shipment transaction.Shipment) (*transaction.Transaction, error) {
+	return t.changeStatus(ctx, change, map[string]interface{}{
+		"shipment_courier":         shipment.Courier,
+		"shipment_tracking_number": shipment.TrackingNumber,
+		"shipment_shipped_at":      shipment.ShippedAt,
+	}, nil)
+}
+
+// changeStatus updates the columns along with the status of the transaction, settle runs
+// on the changed transaction before the commit when it is given
+func (t TransactionRepository) changeStatus(ctx context.Context, change transaction.TransactionStatusChange,
+	columns map[string]interface{}, settle func(tx *gorm.DB, tran transaction.Transaction) error) (
+	*transaction.Transaction, error) {
+	columns["status"] = change.ToStatus
+	tx := t.conn(ctx).Begin()
+	result := tx.Model(&transaction.Transaction{}).
+		Where("id = ? AND status = ?", change.TransactionId, change.FromStatus).
+		Updates(columns)
+	if result.Error != nil {
+		tx.Rollback()
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return nil, transaction.ErrInvalidStatusTransition
+	}
+	if err := tx.Create(&change).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	var tran transaction.Transaction
-	err := t.db.First(&tran, id).Error
+	if err := tx.Where("id = ?", change.TransactionId).Preload("ProductDetails").First(&tran).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if settle != nil {
+		if err := settle(tx, tran); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	return &tran, commitStatusChange(tx, change)
+}
+
+// GetShippedTransactions returns the accepted transactions shipped before the given time,
+// transactions accepted without a shipment count from their last update
+func (t TransactionRepository) GetShippedTransactions(ctx context.Context, shippedBefore time.Time) (
+	[]transaction.Transaction, error) {
+	var transactions []transaction.Transaction
+	err := t.conn(ctx).Where("status = ?", transaction_status.ToString(transaction_status.ACCEPTED)).
+		Where("COALESCE(shipment_shipped_at, updated_at) < ?", shippedBefore).
+		Order("created_at asc").Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func (t TransactionRepository) GetStatusChanges(ctx context.Context, transactionId string) ([]transaction.TransactionStatusChange, error) {
+	var changes []transaction.TransactionStatusChange
+	err := t.conn(ctx).Where("transaction_id = ?", transactionId).Order("created_at asc").Find(&changes).Error
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (t TransactionRepository) GetTransactionById(ctx context.Context, id string) (*transaction.Transaction, error) {
+	var tran transaction.Transaction
+	err := t.conn(ctx).Where("id = ?", id).Preload("ProductDetails").First(&tran).Error
 	if err != nil {
 		return nil, err
 	}
 	return &tran, nil
 }
 
-func (t TransactionRepository) GetTransactionByRequiredStatus(requiredStatus []string, userId string) ([]transaction.Transaction, error) {
+func (t TransactionRepository) GetTransactionByRequiredStatus(ctx context.Context, requiredStatus []string, userId string) ([]transaction.Transaction, error) {
 	var transactions []transaction.Transaction
-	err := t.db.Where("status IN (?)", requiredStatus).Where(
+	err := t.conn(ctx).Where("status IN (?)", requiredStatus).Where(
 		"customer_id = ?", userId).Find(&transactions).Error
 	if err != nil {
 		return nil, err
@@ -44,10 +192,10 @@ func (t TransactionRepository) GetTransactionByRequiredStatus(requiredStatus []s
 	return transactions, nil
 }
 
-func (t TransactionRepository) GetMerchantRequestItem(merchantId string) ([]transaction.Transaction, error) {
+func (t TransactionRepository) GetMerchantRequestItem(ctx context.Context, merchantId string) ([]transaction.Transaction, error) {
 	var transactions []transaction.Transaction
 	onRequestMerchantStatus := transaction_status.ToString(transaction_status.WAITING_DELIVERY)
-	err := t.db.Model(&transactions).Where("status = ?", onRequestMerchantStatus).
+	err := t.conn(ctx).Model(&transactions).Where("status = ?", onRequestMerchantStatus).
 		Where("merchant_id = ?", merchantId).
 		Preload("ProductDetails").Find(&transactions).Error
 	if err != nil {
@@ -56,10 +204,107 @@ func (t TransactionRepository) GetMerchantRequestItem(merchantId string) ([]tran
 	return transactions, nil
 }
 
-func (t TransactionRepository) UpdateTransaction(transaction transaction.Transaction) error {
-	if err := t.db.Model(&transaction).Where("id = ?", transaction.ID).
-		Update("bank_name", "bank_number", "amount").Error; err != nil {
+func (t TransactionRepository) UpdateTransaction(ctx context.Context, transaction transaction.Transaction) error {
+	if err := t.conn(ctx).Model(&transaction).Where("id = ?", transaction.ID).
+		Updates(map[string]interface{}{
+			"bank_name":   transaction.BankName,
+			"bank_number": transaction.BankNumber,
+			"amount":      transaction.Amount,
+		}).Error; err != nil {
 		return err
 	}
 	return nil
 }
+
+// GetCart returns the open cart of the customer at the merchant, or nil when there is none
+func (t TransactionRepository) GetCart(ctx context.Context, customerId string, merchantId string) (*transaction.Transaction, error) {
+	var tran transaction.Transaction
+	err := t.conn(ctx).Where("status = ?", transaction_status.ToString(transaction_status.ON_CARTS)).
+		Where("customer_id = ? AND merchant_id = ?", customerId, merchantId).
+		Preload("ProductDetails").First(&tran).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tran, nil
+}
+
+func (t TransactionRepository) GetCartsByCustomer(ctx context.Context, customerId string) ([]transaction.Transaction, error) {
+	var transactions []transaction.Transaction
+	err := t.conn(ctx).Where("status = ?", transaction_status.ToString(transaction_status.ON_CARTS)).
+		Where("customer_id = ?", customerId).
+		Preload("ProductDetails").Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func whereProductLine(db *gorm.DB, item transaction.ProductTransaction) *gorm.DB {
+	db = db.Where("transaction_id = ? AND product_id = ?", item.TransactionId, item.ProductId)
+	if item.VariantId == nil {
+		return db.Where("variant_id IS NULL")
+	}
+	return db.Where("variant_id = ?", *item.VariantId)
+}
+
+// AddCartItem adds the quantity to the matching cart line, creating the line when needed
+func (t TransactionRepository) AddCartItem(ctx context.Context, item transaction.ProductTransaction) error {
+	var existing transaction.ProductTransaction
+	err := whereProductLine(t.conn(ctx), item).First(&existing).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return t.conn(ctx).Create(&item).Error
+	}
+	if err != nil {
+		return err
+	}
+	return whereProductLine(t.conn(ctx).Model(&transaction.ProductTransaction{}), item).
+		UpdateColumn("quantity", gorm.Expr("quantity + ?", item.Quantity)).Error
+}
+
+// CheckoutTransaction stores the captured unit prices, amount, status, shipping cost and
+// shipping address of a checked out cart. It returns ErrInvalidStatusTransition when the
+// cart was checked out in the meantime.
+func (t TransactionRepository) CheckoutTransaction(ctx context.Context, tr transaction.Transaction) error {
+	tx := t.conn(ctx).Begin()
+	for _, item := range tr.ProductDetails {
+		if err := whereProductLine(tx.Model(&transaction.ProductTransaction{}), item).
+			UpdateColumn("unit_price", item.UnitPrice).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	result := tx.Model(&transaction.Transaction{}).
+		Where("id = ? AND status = ?", tr.ID, transaction_status.ToString(transaction_status.ON_CARTS)).
+		Updates(map[string]interface{}{
+			"amount":                  tr.Amount,
+			"status":                  tr.Status,
+			"shipping_cost":           tr.ShippingCost,
+			"shipping_provider":       tr.ShippingProvider,
+			"shipping_service":        tr.ShippingService,
+			"shipping_recipient_name": tr.ShippingAddress.RecipientName,
+			"shipping_phone":          tr.ShippingAddress.Phone,
+			"shipping_street":         tr.ShippingAddress.Street,
+			"shipping_city":           tr.ShippingAddress.City,
+			"shipping_province":       tr.ShippingAddress.Province,
+			"shipping_postal_code":    tr.ShippingAddress.PostalCode,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return transaction.ErrInvalidStatusTransition
+	}
+	change := transaction.TransactionStatusChange{TransactionId: tr.ID,
+		FromStatus: transaction_status.ToString(transaction_status.ON_CARTS),
+		ToStatus:   tr.Status}
+	if err := tx.Create(&change).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return commitStatusChange(tx, change)
+}
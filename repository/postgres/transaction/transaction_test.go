@@ -1,10 +1,12 @@
 package transaction
 
 import (
+	"context"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jinzhu/gorm"
 	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
+	"github.com/williamchang80/sea-apd/domain/merchant"
 	domain "github.com/williamchang80/sea-apd/domain/transaction"
 	request "github.com/williamchang80/sea-apd/dto/request/transaction"
 	mock_psql "github.com/williamchang80/sea-apd/mocks/postgres"
@@ -115,7 +117,7 @@ func TestTransactionRepository_CreateTransaction(t *testing.T) {
 			pr := TransactionRepository{
 				db: tt.initMock(),
 			}
-			err := pr.CreateTransaction(tt.args.transaction)
+			err := pr.CreateTransaction(context.Background(), tt.args.transaction)
 			if err != nil && !tt.wantErr {
 				t.Errorf("ProductRepository.CreateProduct() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -164,7 +166,7 @@ func TestTransactionRepository_GetTransactionById(t *testing.T) {
 			pr := TransactionRepository{
 				db: tt.initMock(),
 			}
-			products, err := pr.GetTransactionById(tt.args.transactionId)
+			products, err := pr.GetTransactionById(context.Background(), tt.args.transactionId)
 			if err != nil && !tt.wantErr {
 				t.Errorf("TransactionRepository.GetTransactionById() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -214,7 +216,7 @@ func TestTransactionRepository_UpdateTransactionStatus(t *testing.T) {
 			pr := TransactionRepository{
 				db: tt.initMock(),
 			}
-			_, err := pr.UpdateTransactionStatus(tt.args.productId, tt.args.status)
+			_, err := pr.UpdateTransactionStatus(context.Background(), tt.args.productId, tt.args.status)
 			if err != nil && !tt.wantErr {
 				t.Errorf("TransactionRepository.UpdateTransactionStatus() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -223,6 +225,22 @@ func TestTransactionRepository_UpdateTransactionStatus(t *testing.T) {
 	}
 }
 
+func TestTransactionRepository_CheckoutTransaction(t *testing.T) {
+	db, mocks := mock_psql.Connection()
+	defer db.Close()
+	mocks.ExpectBegin()
+	mocks.ExpectExec(regexp.QuoteMeta(`UPDATE "transactions"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mocks.ExpectRollback()
+	pr := TransactionRepository{db: db}
+	tran := mockTransactionEntity
+	tran.ID = mockTransactionId
+	if err := pr.CheckoutTransaction(context.Background(), tran); err != domain.ErrInvalidStatusTransition {
+		t.Errorf("TransactionRepository.CheckoutTransaction() error = %v, want %v", err,
+			domain.ErrInvalidStatusTransition)
+	}
+}
+
 func TestTransactionRepository_GetTransactionByRequiredStatus(t *testing.T) {
 	db, mocks := mock_psql.Connection()
 	defer db.Close()
@@ -263,7 +281,7 @@ func TestTransactionRepository_GetTransactionByRequiredStatus(t *testing.T) {
 			pr := TransactionRepository{
 				db: tt.initMock(),
 			}
-			_, err := pr.GetTransactionByRequiredStatus(tt.args.requiredStatus, tt.args.productId)
+			_, err := pr.GetTransactionByRequiredStatus(context.Background(), tt.args.requiredStatus, tt.args.productId)
 			if err != nil && !tt.wantErr {
 				t.Errorf("TransactionRepository.GetTransactionByRequiredStatus() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -271,3 +289,77 @@ func TestTransactionRepository_GetTransactionByRequiredStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionRepository_CompleteTransaction(t *testing.T) {
+	db, mocks := mock_psql.Connection()
+	defer db.Close()
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+	}{
+		{
+			name:         "success",
+			rowsAffected: 1,
+			wantErr:      nil,
+		},
+		{
+			name:         "failed with pending balance less than the amount",
+			rowsAffected: 0,
+			wantErr:      merchant.ErrPendingBalanceTooLow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks.ExpectBegin()
+			mocks.ExpectExec(regexp.QuoteMeta(`UPDATE "transactions"`)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mocks.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transaction_status_changes"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+			mocks.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "status", "amount", "merchant_id"}).
+					AddRow(mockTransactionId, transaction_status.ToString(transaction_status.COMPLETED), 1000, "1"))
+			mocks.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_transactions"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"transaction_id"}))
+			mocks.ExpectExec(regexp.QuoteMeta(`UPDATE "merchants"`)).
+				WithArgs(1000, 1000, "1", 1000).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+			if tt.wantErr == nil {
+				mocks.ExpectCommit()
+			} else {
+				mocks.ExpectRollback()
+			}
+			pr := TransactionRepository{db: db}
+			_, err := pr.CompleteTransaction(context.Background(), domain.TransactionStatusChange{
+				TransactionId: mockTransactionId,
+				FromStatus:    transaction_status.ToString(transaction_status.ACCEPTED),
+				ToStatus:      transaction_status.ToString(transaction_status.COMPLETED),
+			})
+			if err != tt.wantErr {
+				t.Errorf("TransactionRepository.CompleteTransaction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mocks.ExpectationsWereMet(); err != nil {
+				t.Errorf("TransactionRepository.CompleteTransaction() %v", err)
+			}
+		})
+	}
+}
+
+func TestTransactionRepository_UpdateTransaction(t *testing.T) {
+	db, mocks := mock_psql.Connection()
+	defer db.Close()
+	mocks.ExpectBegin()
+	mocks.ExpectExec(regexp.QuoteMeta(`UPDATE "transactions" SET "amount" = $1, "bank_name" = $2, "bank_number" = $3`)).
+		WithArgs(10000, "Mock Bank", "123456789", sqlmock.AnyArg(), mockTransactionId, mockTransactionId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mocks.ExpectCommit()
+	pr := TransactionRepository{db: db}
+	tran := mockTransactionEntity
+	tran.ID = mockTransactionId
+	if err := pr.UpdateTransaction(context.Background(), tran); err != nil {
+		t.Errorf("TransactionRepository.UpdateTransaction() error = %v", err)
+	}
+	if err := mocks.ExpectationsWereMet(); err != nil {
+		t.Errorf("TransactionRepository.UpdateTransaction() %v", err)
+	}
+}
@@ -1,7 +1,9 @@
 package transfer
 
 import (
+	"context"
 	"github.com/jinzhu/gorm"
+	"github.com/williamchang80/sea-apd/common/logger"
 	"github.com/williamchang80/sea-apd/domain/transfer"
 )
 
@@ -13,17 +15,21 @@ func NewTransferRepository(db *gorm.DB) transfer.TransferRepository {
 	return &TransferRepository{db: db}
 }
 
-func (t TransferRepository) GetTransferHistory(userId string) ([]transfer.Transfer, error) {
+func (t TransferRepository) conn(ctx context.Context) *gorm.DB {
+	return logger.Gorm(ctx, t.db)
+}
+
+func (t TransferRepository) GetTransferHistory(ctx context.Context, userId string) ([]transfer.Transfer, error) {
 	var transfers []transfer.Transfer
-	err := t.db.Where("user_id = ?", userId).Find(&transfers).Error
+	err := t.conn(ctx).Where("user_id = ?", userId).Find(&transfers).Error
 	if err != nil {
 		return nil, err
 	}
 	return transfers,nil
 }
 
-func (t TransferRepository) CreateTransferHistory(transfer transfer.Transfer) error {
-	if err := t.db.Create(&transfer).Error; err != nil {
+func (t TransferRepository) CreateTransferHistory(ctx context.Context, transfer transfer.Transfer) error {
+	if err := t.conn(ctx).Create(&transfer).Error; err != nil {
 		return err
 	}
 	return nil
@@ -1,6 +1,7 @@
 package transfer
 
 import (
+	"context"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jinzhu/gorm"
 	domain "github.com/williamchang80/sea-apd/domain/transfer"
@@ -89,7 +90,7 @@ func TestTransferRepository_GetTransferHistory(t *testing.T) {
 			pr := TransferRepository{
 				db: tt.initMock(),
 			}
-			_, err := pr.GetTransferHistory(tt.args.merchantId)
+			_, err := pr.GetTransferHistory(context.Background(), tt.args.merchantId)
 			if err != nil && !tt.wantErr {
 				t.Errorf("TransferRepository.GetTransferHistory() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -154,7 +155,7 @@ func TestTransferRepository_CreateTransferHistory(t *testing.T) {
 			pr := TransferRepository{
 				db: tt.initMock(),
 			}
-			err := pr.CreateTransferHistory(tt.args.transfer)
+			err := pr.CreateTransferHistory(context.Background(), tt.args.transfer)
 			if err != nil && !tt.wantErr {
 				t.Errorf("TransferRepository.CreateTransferHistory() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1,7 +1,14 @@
 package user
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	"github.com/williamchang80/sea-apd/common/constants/user_role"
+	"github.com/williamchang80/sea-apd/common/logger"
 	"github.com/williamchang80/sea-apd/domain/user"
 )
 
@@ -15,9 +22,20 @@ func NewUserRepository(db *gorm.DB) user.UserRepository {
 	return &UserRepository{db: db}
 }
 
-// CreateUser ...
-func (u UserRepository) CreateUser(user user.User) error {
-	if err := u.db.Create(&user).Error; err != nil {
+func (u UserRepository) conn(ctx context.Context) *gorm.DB {
+	return logger.Gorm(ctx, u.db)
+}
+
+// uniqueViolation is the postgres error code of an insert that breaks a unique constraint
+const uniqueViolation = "23505"
+
+// CreateUser returns ErrEmailTaken when another user has the email
+func (u UserRepository) CreateUser(ctx context.Context, us user.User) error {
+	if err := u.conn(ctx).Create(&us).Error; err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return user.ErrEmailTaken
+		}
 		return err
 	}
 
@@ -25,28 +43,280 @@ func (u UserRepository) CreateUser(user user.User) error {
 }
 
 // GetUserByEmail ...
-func (u UserRepository) GetUserByEmail(email string) (*user.User, error) {
+func (u UserRepository) GetUserByEmail(ctx context.Context, email string) (*user.User, error) {
 	var user user.User
-	err := u.db.Where("email = ?", email).First(&user).Error
+	err := u.conn(ctx).Where("email = ?", email).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (u UserRepository) UpdateUserRole(role string, userId string) error {
-	err := u.db.Model(&user.User{}).Where("id = ?", userId).Updates(user.User{Role: role}).Error
+func (u UserRepository) UpdateUserRole(ctx context.Context, role string, userId string) error {
+	err := u.conn(ctx).Model(&user.User{}).Where("id = ?", userId).Updates(user.User{Role: role}).Error
 	return err
 }
 
-func (u UserRepository) GetUserById(userId string) (*user.User, error) {
+func (u UserRepository) GetUserById(ctx context.Context, userId string) (*user.User, error) {
 	var user user.User
-	if err := u.db.Where("id = ?", userId).Find(&user).Error; err != nil {
+	if err := u.conn(ctx).Where("id = ?", userId).Find(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (u UserRepository) UpdateUser(user user.User) error {
-	return u.db.Model(&user).Updates(&user).Error
+func (u UserRepository) UpdateUser(ctx context.Context, user user.User) error {
+	return u.conn(ctx).Model(&user).Updates(&user).Error
+}
+
+func (u UserRepository) CountUsersByRole(ctx context.Context, role string) (int, error) {
+	count := 0
+	if err := u.conn(ctx).Model(&user.User{}).Where("role = ?", role).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (u UserRepository) CreateAdminInvitation(ctx context.Context, invitation user.AdminInvitation) error {
+	return u.conn(ctx).Create(&invitation).Error
+}
+
+func (u UserRepository) GetAdminInvitations(ctx context.Context) ([]user.AdminInvitation, error) {
+	var invitations []user.AdminInvitation
+	if err := u.conn(ctx).Order("created_at desc").Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// RevokeAdminInvitation revokes the invitation unless it was accepted or revoked before
+func (u UserRepository) RevokeAdminInvitation(ctx context.Context, invitationId string, revokedAt time.Time) error {
+	result := u.conn(ctx).Model(&user.AdminInvitation{}).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", invitationId).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return user.ErrInvitationNotPending
+	}
+	return nil
+}
+
+// AcceptAdminInvitation uses up the pending invitation of the user's email with the
+// token hash and makes the user an admin. The conditional update keeps the invitation
+// single use when it is accepted twice at the same time.
+func (u UserRepository) AcceptAdminInvitation(ctx context.Context, tokenHash string, accepting user.User, acceptedAt time.Time) error {
+	tx := u.conn(ctx).Begin()
+	result := tx.Model(&user.AdminInvitation{}).
+		Where("token_hash = ? AND email = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?",
+			tokenHash, accepting.Email, acceptedAt).
+		Updates(map[string]interface{}{
+			"accepted_at": acceptedAt,
+			"accepted_by": accepting.ID,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return user.ErrInvitationNotValid
+	}
+	if err := tx.Model(&user.User{}).Where("id = ?", accepting.ID).
+		Update("role", user_role.ToString(user_role.ADMIN)).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// SetEmailVerified marks the email of the user as verified at verifiedAt, or as not
+// verified when verifiedAt is nil
+func (u UserRepository) SetEmailVerified(ctx context.Context, email string, verifiedAt *time.Time) error {
+	result := u.conn(ctx).Model(&user.User{}).Where("email = ?", email).Update("email_verified_at", verifiedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// MarkVerificationSent records that a verification mail goes out unless the previous one
+// was sent at or after sentBefore
+func (u UserRepository) MarkVerificationSent(ctx context.Context, email string, sentAt time.Time, sentBefore time.Time) error {
+	result := u.conn(ctx).Model(&user.User{}).
+		Where("email = ? AND (verification_sent_at IS NULL OR verification_sent_at < ?)", email, sentBefore).
+		Update("verification_sent_at", sentAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return user.ErrVerificationRecentlySent
+	}
+	return nil
+}
+
+func (u UserRepository) CreatePasswordReset(ctx context.Context, reset user.PasswordReset) error {
+	return u.conn(ctx).Create(&reset).Error
+}
+
+// ResetPassword sets the password of the user with the email when the token hash belongs
+// to a pending reset of the user. Every pending reset of the user is used up and the
+// sessions issued before resetAt are revoked.
+func (u UserRepository) ResetPassword(ctx context.Context, tokenHash string, email string, passwordHash string, resetAt time.Time) error {
+	tx := u.conn(ctx).Begin()
+	var reset user.PasswordReset
+	if err := tx.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, resetAt).
+		First(&reset).Error; err != nil {
+		tx.Rollback()
+		if gorm.IsRecordNotFoundError(err) {
+			return user.ErrResetTokenNotValid
+		}
+		return err
+	}
+	var us user.User
+	if err := tx.Where("id = ? AND email = ?", reset.UserId, email).First(&us).Error; err != nil {
+		tx.Rollback()
+		if gorm.IsRecordNotFoundError(err) {
+			return user.ErrResetTokenNotValid
+		}
+		return err
+	}
+	result := tx.Model(&user.PasswordReset{}).
+		Where("user_id = ? AND used_at IS NULL", reset.UserId).
+		Update("used_at", resetAt)
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return user.ErrResetTokenNotValid
+	}
+	if err := tx.Model(&user.User{}).Where("id = ?", us.ID).Updates(map[string]interface{}{
+		"password":            passwordHash,
+		"sessions_revoked_at": resetAt,
+	}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// SetTotpSecret stores the secret of an enrolment that is not confirmed yet
+func (u UserRepository) SetTotpSecret(ctx context.Context, userId string, secret string) error {
+	result := u.conn(ctx).Model(&user.User{}).Where("id = ? AND totp_enabled_at IS NULL", userId).
+		Update("totp_secret", secret)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return user.ErrTwoFactorNotPending
+	}
+	return nil
+}
+
+func createRecoveryCodes(tx *gorm.DB, userId string, codeHashes []string) error {
+	if err := tx.Unscoped().Where("user_id = ?", userId).Delete(&user.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	for _, hash := range codeHashes {
+		if err := tx.Create(&user.RecoveryCode{UserId: userId, CodeHash: hash}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EnableTotp confirms the enrolment with the step of the first code and replaces the
+// recovery codes of the user
+func (u UserRepository) EnableTotp(ctx context.Context, userId string, enabledAt time.Time, step int64, codeHashes []string) error {
+	tx := u.conn(ctx).Begin()
+	result := tx.Model(&user.User{}).
+		Where("id = ? AND totp_enabled_at IS NULL AND totp_secret <> ''", userId).
+		Updates(map[string]interface{}{
+			"totp_enabled_at": enabledAt,
+			"totp_last_step":  step,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return user.ErrTwoFactorNotPending
+	}
+	if err := createRecoveryCodes(tx, userId, codeHashes); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (u UserRepository) DisableTotp(ctx context.Context, userId string) error {
+	tx := u.conn(ctx).Begin()
+	if err := tx.Model(&user.User{}).Where("id = ?", userId).Updates(map[string]interface{}{
+		"totp_secret":     "",
+		"totp_enabled_at": nil,
+		"totp_last_step":  0,
+	}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Unscoped().Where("user_id = ?", userId).Delete(&user.RecoveryCode{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// UseTotpStep records the step of an accepted code, a step that is not newer than the
+// last accepted one is refused
+func (u UserRepository) UseTotpStep(ctx context.Context, userId string, step int64) error {
+	result := u.conn(ctx).Model(&user.User{}).Where("id = ? AND totp_last_step < ?", userId, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return user.ErrTwoFactorCodeNotValid
+	}
+	return nil
+}
+
+func (u UserRepository) ReplaceRecoveryCodes(ctx context.Context, userId string, codeHashes []string) error {
+	tx := u.conn(ctx).Begin()
+	if err := createRecoveryCodes(tx, userId, codeHashes); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (u UserRepository) UseRecoveryCode(ctx context.Context, userId string, codeHash string, usedAt time.Time) error {
+	result := u.conn(ctx).Model(&user.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userId, codeHash).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return user.ErrTwoFactorCodeNotValid
+	}
+	return nil
+}
+
+func (u UserRepository) GetTwoFactorPolicies(ctx context.Context) ([]user.TwoFactorPolicy, error) {
+	var policies []user.TwoFactorPolicy
+	if err := u.conn(ctx).Find(&policies).Error; err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (u UserRepository) SetTwoFactorPolicy(ctx context.Context, policy user.TwoFactorPolicy) error {
+	return u.conn(ctx).Save(&policy).Error
 }
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"reflect"
 	"regexp"
 	"testing"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
 	domain "github.com/williamchang80/sea-apd/domain/user"
 
 	mock_psql "github.com/williamchang80/sea-apd/mocks/postgres"
@@ -111,7 +113,7 @@ func TestUserRepository_CreateUser(t *testing.T) {
 			ur := UserRepository{
 				db: tt.initMock(),
 			}
-			err := ur.CreateUser(tt.args.user)
+			err := ur.CreateUser(context.Background(), tt.args.user)
 			if err != nil && !tt.wantErr {
 				t.Errorf("UserRepository.CreateUser() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -120,6 +122,20 @@ func TestUserRepository_CreateUser(t *testing.T) {
 	}
 }
 
+func TestUserRepository_CreateUserWithTakenEmail(t *testing.T) {
+	db, mocks := mock_psql.Connection()
+	defer db.Close()
+	mocks.ExpectBegin()
+	mocks.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
+		WillReturnError(&pq.Error{Code: uniqueViolation})
+	mocks.ExpectRollback()
+	ur := UserRepository{db: db}
+	err := ur.CreateUser(context.Background(), domain.User{Name: "name", Email: "email@email.com"})
+	if err != domain.ErrEmailTaken {
+		t.Errorf("UserRepository.CreateUser() error = %v, want %v", err, domain.ErrEmailTaken)
+	}
+}
+
 func TestUserRepository_GetUseByEmail(t *testing.T) {
 	db, mocks := mock_psql.Connection()
 	defer db.Close()
@@ -156,7 +172,7 @@ func TestUserRepository_GetUseByEmail(t *testing.T) {
 			ur := UserRepository{
 				db: tt.initMock(),
 			}
-			user, err := ur.GetUserByEmail(tt.args.email)
+			user, err := ur.GetUserByEmail(context.Background(), tt.args.email)
 			if err != nil && !tt.wantErr {
 				t.Errorf("UserRepository.GetUserByEmail() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"github.com/labstack/echo"
+	controller "github.com/williamchang80/sea-apd/controller/http/address"
+	domain "github.com/williamchang80/sea-apd/domain/address"
+	"github.com/williamchang80/sea-apd/infrastructure/db"
+	repository "github.com/williamchang80/sea-apd/repository/postgres/address"
+	usecase "github.com/williamchang80/sea-apd/usecase/address"
+)
+
+type AddressRoute struct {
+	controller domain.AddressController
+	Usecase    domain.AddressUsecase
+	repository domain.AddressRepository
+}
+
+func NewAddressRoute(e *echo.Echo) AddressRoute {
+	db := db.Postgres()
+	if db != nil {
+		d := db.AutoMigrate(&domain.Address{})
+		d.AddForeignKey("customer_id", "users(id)", "CASCADE", "CASCADE")
+	}
+	repo := repository.NewAddressRepository(db)
+	u := usecase.NewAddressUsecase(repo)
+	c := controller.NewAddressController(e, u)
+	return AddressRoute{
+		controller: c,
+		Usecase:    u,
+		repository: repo,
+	}
+}
@@ -23,7 +23,9 @@ func NewAdminRoutes(e *echo.Echo) AdminRoute {
 	usecase := use_case.NewAdminUseCase(repo, authRoute.usecase)
 	controller := user.NewAdminController(e, usecase)
 	if db != nil {
-		db.AutoMigrate(&domain.User{})
+		d := MigrateUsers(db).AutoMigrate(&domain.AdminInvitation{})
+		d.Model(&domain.AdminInvitation{}).AddForeignKey("invited_by", "users(id)",
+			"CASCADE", "CASCADE")
 	}
 
 	return AdminRoute{
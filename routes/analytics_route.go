@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"os"
+	"time"
+
+	"github.com/labstack/echo"
+	controller "github.com/williamchang80/sea-apd/controller/http/analytics"
+	domain "github.com/williamchang80/sea-apd/domain/analytics"
+	"github.com/williamchang80/sea-apd/infrastructure/db"
+	"github.com/williamchang80/sea-apd/repository/postgres/transaction"
+	usecase "github.com/williamchang80/sea-apd/usecase/analytics"
+)
+
+type AnalyticsRoute struct {
+	controller domain.AnalyticsController
+	usecase    domain.AnalyticsUsecase
+}
+
+// NewAnalyticsRoute serves the sales report from rollups refreshed in the background
+// when ANALYTICS_ROLLUP_INTERVAL is set, e.g. to 15m
+func NewAnalyticsRoute(e *echo.Echo) AnalyticsRoute {
+	db := db.Postgres()
+	interval, err := time.ParseDuration(os.Getenv("ANALYTICS_ROLLUP_INTERVAL"))
+	useRollups := db != nil && err == nil && interval > 0
+	if db != nil {
+		db.AutoMigrate(&domain.SalesRollup{})
+	}
+	repo := transaction.NewTransactionRepository(db)
+	u := usecase.NewAnalyticsUsecase(repo, useRollups)
+	if useRollups {
+		usecase.StartRollupJob(u, interval)
+	}
+	c := controller.NewAnalyticsController(e, u)
+	return AnalyticsRoute{
+		controller: c,
+		usecase:    u,
+	}
+}
@@ -4,11 +4,16 @@ import (
 	"github.com/labstack/echo"
 	controller "github.com/williamchang80/sea-apd/controller/http/auth"
 	domain "github.com/williamchang80/sea-apd/domain/auth"
+	domain2 "github.com/williamchang80/sea-apd/domain/user"
 	"github.com/williamchang80/sea-apd/infrastructure/db"
+	memory "github.com/williamchang80/sea-apd/repository/memory/auth"
 	user2 "github.com/williamchang80/sea-apd/repository/postgres/user"
 	"github.com/williamchang80/sea-apd/usecase/auth"
 )
 
+// loginAttempts is shared by every auth usecase, so a lockout holds for all routes
+var loginAttempts = memory.NewLoginAttemptStore()
+
 type AuthRoute struct {
 	controller domain.AuthController
 	usecase    domain.AuthUsecase
@@ -17,7 +22,15 @@ type AuthRoute struct {
 func NewAuthRoute(e *echo.Echo) AuthRoute {
 	db := db.Postgres()
 	repo :=  user2.NewUserRepository(db)
-	usecase := auth.NewAuthUsecase(repo)
+	usecase := auth.NewAuthUsecase(repo, loginAttempts)
+	if db != nil {
+		d := MigrateUsers(db).AutoMigrate(&domain2.PasswordReset{}, &domain2.RecoveryCode{},
+			&domain2.TwoFactorPolicy{})
+		d.Model(&domain2.PasswordReset{}).AddForeignKey("user_id", "users(id)",
+			"CASCADE", "CASCADE")
+		d.Model(&domain2.RecoveryCode{}).AddForeignKey("user_id", "users(id)",
+			"CASCADE", "CASCADE")
+	}
 	c := controller.NewAuthController(e, usecase)
 	return AuthRoute{
 		controller: c,
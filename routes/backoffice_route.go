@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"github.com/labstack/echo"
+	controller "github.com/williamchang80/sea-apd/controller/http/backoffice"
+	domain "github.com/williamchang80/sea-apd/domain/backoffice"
+	transaction "github.com/williamchang80/sea-apd/domain/transaction"
+	"github.com/williamchang80/sea-apd/infrastructure/db"
+	"github.com/williamchang80/sea-apd/repository/postgres/backoffice"
+	usecase "github.com/williamchang80/sea-apd/usecase/backoffice"
+)
+
+type BackofficeRoute struct {
+	controller domain.BackofficeController
+	usecase    domain.BackofficeUsecase
+	repository domain.BackofficeRepository
+}
+
+func NewBackofficeRoute(e *echo.Echo) BackofficeRoute {
+	userRoute := NewUserRoute(e)
+	transactionRoute := NewTransactionRoute(e)
+	authRoute := NewAuthRoute(e)
+	db := db.Postgres()
+	if db != nil {
+		d := db.AutoMigrate(&domain.AdminAudit{}, &domain.BalanceAdjustment{})
+		d.Model(&domain.AdminAudit{}).AddForeignKey("admin_id", "users(id)",
+			"CASCADE", "CASCADE")
+		d.Model(&domain.BalanceAdjustment{}).AddForeignKey("admin_id", "users(id)",
+			"CASCADE", "CASCADE")
+		d.Model(&domain.BalanceAdjustment{}).AddForeignKey("merchant_id", "merchants(id)",
+			"CASCADE", "CASCADE")
+	}
+	repo := backoffice.NewBackofficeRepository(db)
+	u := usecase.NewBackofficeUsecase(repo, userRoute.usecase,
+		transactionRoute.Usecase.(transaction.TransactionUsecase), authRoute.usecase)
+	c := controller.NewBackofficeController(e, u)
+	return BackofficeRoute{
+		controller: c,
+		usecase:    u,
+		repository: repo,
+	}
+}
@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"github.com/labstack/echo"
+	controller "github.com/williamchang80/sea-apd/controller/http/bank_account"
+	domain "github.com/williamchang80/sea-apd/domain/bank_account"
+	"github.com/williamchang80/sea-apd/infrastructure/db"
+	repository "github.com/williamchang80/sea-apd/repository/postgres/bank_account"
+	usecase "github.com/williamchang80/sea-apd/usecase/bank_account"
+)
+
+type BankAccountRoute struct {
+	controller domain.BankAccountController
+	Usecase    domain.BankAccountUsecase
+	repository domain.BankAccountRepository
+}
+
+func NewBankAccountRoute(e *echo.Echo) BankAccountRoute {
+	db := db.Postgres()
+	merchantRoute := NewMerchantRoute(e)
+	userRoute := NewUserRoute(e)
+	if db != nil {
+		d := db.AutoMigrate(&domain.BankAccount{})
+		d.AddForeignKey("merchant_id", "merchants(id)", "CASCADE", "CASCADE")
+	}
+	repo := repository.NewBankAccountRepository(db)
+	u := usecase.NewBankAccountUsecase(repo, merchantRoute.Usecase, userRoute.usecase)
+	c := controller.NewBankAccountController(e, u)
+	return BankAccountRoute{
+		controller: c,
+		Usecase:    u,
+		repository: repo,
+	}
+}
@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"github.com/labstack/echo"
+	controller "github.com/williamchang80/sea-apd/controller/http/category"
+	domain "github.com/williamchang80/sea-apd/domain/category"
+	"github.com/williamchang80/sea-apd/domain/product"
+	"github.com/williamchang80/sea-apd/infrastructure/db"
+	"github.com/williamchang80/sea-apd/repository/postgres/category"
+	usecase "github.com/williamchang80/sea-apd/usecase/category"
+)
+
+type CategoryRoute struct {
+	controller domain.CategoryController
+	usecase    domain.CategoryUsecase
+	repository domain.CategoryRepository
+}
+
+func NewCategoryRoute(e *echo.Echo) CategoryRoute {
+	productRoute := NewProductRoutes(e)
+	db := db.Postgres()
+	if db != nil {
+		d := db.AutoMigrate(&domain.Category{})
+		d.AddForeignKey("parent_id", "categories(id)", "SET NULL", "CASCADE")
+		// slugs used to be unique across the tree, postgres does not compare the null
+		// parent_id of root categories so their slugs get an index of their own
+		db.Exec("ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_slug_key")
+		db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_root_slug ON categories (slug) " +
+			"WHERE parent_id IS NULL")
+		db.Model(&product.Product{}).AddForeignKey("category_id", "categories(id)",
+			"SET NULL", "CASCADE")
+	}
+	repo := category.NewCategoryRepository(db)
+	u := usecase.NewCategoryUsecase(repo, productRoute.Usecase)
+	c := controller.NewCategoryController(e, u)
+	return CategoryRoute{
+		controller: c,
+		usecase:    u,
+		repository: repo,
+	}
+}
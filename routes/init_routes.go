@@ -2,12 +2,12 @@ package routes
 
 import (
 	"github.com/labstack/echo"
-	middleware2 "github.com/labstack/echo/middleware"
-	"github.com/williamchang80/sea-apd/common/auth"
-	message "github.com/williamchang80/sea-apd/common/constants/response"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/williamchang80/sea-apd/common/mailer"
-	"github.com/williamchang80/sea-apd/dto/response/base"
-	"net/http"
+	"github.com/williamchang80/sea-apd/common/throttle"
+	"github.com/williamchang80/sea-apd/common/validation"
+	"github.com/williamchang80/sea-apd/controller/middleware"
+	"net"
 	"os"
 	"strings"
 )
@@ -19,37 +19,113 @@ type Routes struct {
 }
 
 func InitMainRoutes(echo *echo.Echo) {
+	InitValidation(echo)
+	InitLogging(echo)
+	InitTracing(echo)
+	InitMetrics(echo)
+	InitRateLimit(echo)
 	NewUserRoute(echo)
 	NewMerchantRoute(echo)
 	NewProductRoutes(echo)
+	NewCategoryRoute(echo)
 	NewAdminRoutes(echo)
-	NewTransactionRoute(echo)
+	NewAddressRoute(echo)
+	NewShippingRoute(echo)
+	transactionRoute := NewTransactionRoute(echo)
+	NewAnalyticsRoute(echo)
+	NewBankAccountRoute(echo)
 	NewTransferRoute(echo)
-	NewAuthRoute(echo)
+	NewBackofficeRoute(echo)
+	authRoute := NewAuthRoute(echo)
 
+	middleware.InitSessions(authRoute.usecase)
 	mailer.InitMail()
-	//InitMiddleware(echo)
+	InitAutoComplete(transactionRoute)
+	InitMiddleware(echo)
 }
 
+// InitValidation validates every bound request and renders the errors of handlers
+func InitValidation(e *echo.Echo) {
+	validator := validation.NewValidator()
+	e.Validator = validator
+	e.Binder = validation.NewBinder(validator)
+	e.HTTPErrorHandler = middleware.ErrorHandler
+}
+
+// InitLogging gives every request an id and writes its access log line
+func InitLogging(e *echo.Echo) {
+	e.Use(middleware.RequestId, middleware.AccessLog)
+}
+
+// InitTracing opens a span for every request, see tracing.Init for the exporters
+func InitTracing(e *echo.Echo) {
+	e.Use(middleware.Tracing)
+}
+
+// InitMetrics counts every request and serves the metrics to prometheus on /metrics
+func InitMetrics(e *echo.Echo) {
+	e.Use(middleware.Metrics)
+	e.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+}
+
+const (
+	// defaultRateLimits keep clients from spamming the routes that create records
+	defaultRateLimits = "*=300/m; POST /api/transaction=10/m; POST /api/auth/register=5/h; " +
+		"POST /api/auth/login=20/m; POST /api/cart/checkout=10/m"
+	// defaultTrustedProxies are the networks nginx reaches the app from
+	defaultTrustedProxies = "127.0.0.0/8,::1/128,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16"
+)
+
+// InitRateLimit limits the requests of every client with the policies of RATE_LIMITS, like
+// "*=300/m; POST /api/transaction=10/m", they replace the default policies of the same
+// routes only. X-Forwarded-For is only read from the networks of TRUSTED_PROXIES, by the
+// rate limits and by middleware.ClientIp. The buckets are kept in memory,
+// RATE_LIMIT_STORE names the store.
+func InitRateLimit(e *echo.Echo) {
+	policies, err := throttle.ParsePolicies(defaultRateLimits + ";" + os.Getenv("RATE_LIMITS"))
+	if err != nil {
+		panic(err)
+	}
+	var trustedProxies []*net.IPNet
+	for _, cidr := range strings.Split(getenv("TRUSTED_PROXIES", defaultTrustedProxies), ",") {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			panic(err)
+		}
+		trustedProxies = append(trustedProxies, network)
+	}
+	var store throttle.Store
+	switch getenv("RATE_LIMIT_STORE", "memory") {
+	case "memory":
+		store = throttle.NewMemoryStore()
+	default:
+		panic("unknown RATE_LIMIT_STORE " + os.Getenv("RATE_LIMIT_STORE"))
+	}
+	middleware.InitTrustedProxies(trustedProxies)
+	e.Use(middleware.RateLimit(middleware.RateLimitConfig{
+		Store:          store,
+		Policies:       policies,
+		TrustedProxies: trustedProxies,
+	}))
+}
+
+func getenv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// InitMiddleware checks the session of every request that carries a valid token, the auth
+// routes are skipped so clients holding a stale token can still log in
 func InitMiddleware(e *echo.Echo) {
-	key := os.Getenv("SECRET_AUTH_KEY")
-	e.Use(middleware2.JWTWithConfig(middleware2.JWTConfig{
-		SigningKey:  []byte(key),
-		TokenLookup: "header:Authorization",
-		AuthScheme:  "Bearer",
-		Skipper: func(context echo.Context) bool {
-			if strings.HasPrefix(context.Request().URL.Path, "/api/auth") {
-				return true
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		sessions := middleware.Sessions(next)
+		return func(c echo.Context) error {
+			if strings.HasPrefix(c.Request().URL.Path, "/api/auth") {
+				return next(c)
 			}
-			return false
-		},
-		BeforeFunc: func(e echo.Context) {
-			if !auth.IsValidTokenLifetime(e.Request().Header.Get("Authorization")) {
-				e.JSON(http.StatusUnauthorized, base.BaseResponse{
-					Code:    http.StatusUnauthorized,
-					Message: message.UNAUTHORIZED,
-				})
-			}
-		},
-	}))
+			return sessions(c)
+		}
+	})
 }
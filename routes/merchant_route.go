@@ -5,6 +5,7 @@ import (
 	controller "github.com/williamchang80/sea-apd/controller/http/merchant"
 	domain "github.com/williamchang80/sea-apd/domain/merchant"
 	"github.com/williamchang80/sea-apd/infrastructure/db"
+	"github.com/williamchang80/sea-apd/infrastructure/storage"
 	"github.com/williamchang80/sea-apd/repository/postgres/merchant"
 	use_case "github.com/williamchang80/sea-apd/usecase/merchant"
 )
@@ -21,9 +22,13 @@ func NewMerchantRoute(e *echo.Echo) MerchantRoute {
 	if db != nil {
 		d := db.AutoMigrate(&domain.Merchant{})
 		d.AddForeignKey("user_id", "users(id)", "CASCADE", "CASCADE")
+		db.AutoMigrate(&domain.MerchantDocument{}).AddForeignKey("merchant_id", "merchants(id)",
+			"CASCADE", "CASCADE")
+		db.AutoMigrate(&domain.MerchantReview{}).AddForeignKey("merchant_id", "merchants(id)",
+			"CASCADE", "CASCADE")
 	}
 	repo := merchant.NewMerchantRepository(db)
-	u := use_case.NewMerchantUsecase(repo, userRoute.usecase)
+	u := use_case.NewMerchantUsecase(repo, userRoute.usecase, storage.PrivateStorage())
 	c := controller.NewMerchantController(e, u)
 	return MerchantRoute{
 		controller: c,
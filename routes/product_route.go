@@ -5,6 +5,7 @@ import (
 	"github.com/williamchang80/sea-apd/controller/http/product"
 	domain "github.com/williamchang80/sea-apd/domain/product"
 	"github.com/williamchang80/sea-apd/infrastructure/db"
+	"github.com/williamchang80/sea-apd/infrastructure/storage"
 	product2 "github.com/williamchang80/sea-apd/repository/postgres/product"
 	use_case "github.com/williamchang80/sea-apd/usecase/product"
 )
@@ -18,11 +19,27 @@ type ProductRoute struct {
 func NewProductRoutes(e *echo.Echo) ProductRoute {
 	db := db.Postgres()
 	if db != nil {
+		db.AutoMigrate(&domain.Tag{})
 		d := db.AutoMigrate(&domain.Product{})
 		d.AddForeignKey("merchant_id", "merchants(id)", "CASCADE", "CASCADE")
+		db.AutoMigrate(&domain.ProductOption{}).AddForeignKey("product_id", "products(id)",
+			"CASCADE", "CASCADE")
+		db.AutoMigrate(&domain.ProductVariant{}).AddForeignKey("product_id", "products(id)",
+			"CASCADE", "CASCADE")
+		product2.MigrateSearchIndex(db)
+		product2.MigrateSkuIndex(db)
+		db.AutoMigrate(&domain.ImportJob{}).AddForeignKey("merchant_id", "merchants(id)",
+			"CASCADE", "CASCADE")
+		db.AutoMigrate(&domain.ImportRowError{}).AddForeignKey("job_id", "import_jobs(id)",
+			"CASCADE", "CASCADE")
 	}
 	repo := product2.NewProductRepository(db)
-	usecase := use_case.NewProductUseCase(repo)
+	searcher := product2.NewProductSearcher(db)
+	store := storage.Storage()
+	if root := storage.LocalRoot(); root != "" {
+		e.Static("/uploads", root)
+	}
+	usecase := use_case.NewProductUseCase(repo, searcher, store)
 	controller := product.NewProductController(e, usecase)
 	return ProductRoute{
 		Controller: controller,
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"github.com/labstack/echo"
+	controller "github.com/williamchang80/sea-apd/controller/http/shipping"
+	domain "github.com/williamchang80/sea-apd/domain/shipping"
+	"github.com/williamchang80/sea-apd/infrastructure/db"
+	provider "github.com/williamchang80/sea-apd/infrastructure/shipping"
+	repository "github.com/williamchang80/sea-apd/repository/postgres/shipping"
+	usecase "github.com/williamchang80/sea-apd/usecase/shipping"
+)
+
+type ShippingRoute struct {
+	controller domain.ShippingController
+	Usecase    domain.ShippingUsecase
+	repository domain.ShippingRepository
+}
+
+func NewShippingRoute(e *echo.Echo) ShippingRoute {
+	db := db.Postgres()
+	if db != nil {
+		d := db.AutoMigrate(&domain.ShippingRate{})
+		d.AddForeignKey("merchant_id", "merchants(id)", "CASCADE", "CASCADE")
+	}
+	repo := repository.NewShippingRepository(db)
+	u := usecase.NewShippingUsecase(repo, provider.NewProvider(repo))
+	c := controller.NewShippingController(e, u)
+	return ShippingRoute{
+		controller: c,
+		Usecase:    u,
+		repository: repo,
+	}
+}
@@ -1,6 +1,9 @@
 package routes
 
 import (
+	"os"
+	"time"
+
 	"github.com/labstack/echo"
 	controller "github.com/williamchang80/sea-apd/controller/http/transaction"
 	domain "github.com/williamchang80/sea-apd/domain/transaction"
@@ -9,18 +12,36 @@ import (
 	usecase "github.com/williamchang80/sea-apd/usecase/transaction"
 )
 
+const (
+	defaultAutoCompleteAfter    = 14 * 24 * time.Hour
+	defaultAutoCompleteInterval = time.Hour
+)
+
 type TransactionRoute struct {
 	controller domain.TransactionController
 	usecase    domain.TransactionUsecase
 	repository domain.TransactionRepository
 }
 
+// durationEnv reads a positive duration such as 336h from the environment
+func durationEnv(key string, fallback time.Duration) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || d <= 0 {
+		return fallback
+	}
+	return d
+}
+
 func NewTransactionRoute(e *echo.Echo) Routes {
 	merchantRoute := NewMerchantRoute(e)
 	productRoute := NewProductRoutes(e)
+	userRoute := NewUserRoute(e)
+	addressRoute := NewAddressRoute(e)
+	shippingRoute := NewShippingRoute(e)
 	db := db.Postgres()
 	if db != nil {
-		d := db.AutoMigrate(&domain.Transaction{}, &domain.ProductTransaction{})
+		d := db.AutoMigrate(&domain.Transaction{}, &domain.ProductTransaction{},
+			&domain.TransactionStatusChange{})
 		d.Model(&domain.Transaction{}).AddForeignKey("customer_id", "users(id)",
 			"CASCADE", "CASCADE")
 		d.Model(&domain.Transaction{}).AddForeignKey("merchant_id", "merchants(id)",
@@ -29,9 +50,14 @@ func NewTransactionRoute(e *echo.Echo) Routes {
 			"CASCADE", "CASCADE")
 		d.Model(&domain.ProductTransaction{}).AddForeignKey("transaction_id", "transactions(id)",
 			"CASCADE", "CASCADE")
+		d.Model(&domain.ProductTransaction{}).AddForeignKey("variant_id", "product_variants(id)",
+			"CASCADE", "CASCADE")
+		d.Model(&domain.TransactionStatusChange{}).AddForeignKey("transaction_id", "transactions(id)",
+			"CASCADE", "CASCADE")
 	}
 	repo := transaction.NewTransactionRepository(db)
-	u := usecase.NewTransactionUsecase(repo, merchantRoute.Usecase, productRoute.Usecase)
+	u := usecase.NewTransactionUsecase(repo, merchantRoute.Usecase, productRoute.Usecase,
+		userRoute.usecase, addressRoute.Usecase, shippingRoute.Usecase)
 	controller := controller.NewTransactionController(e, u)
 	return Routes{
 		Controller: controller,
@@ -39,3 +65,17 @@ func NewTransactionRoute(e *echo.Echo) Routes {
 		Repository: repo,
 	}
 }
+
+// InitAutoComplete completes the transactions shipped longer than
+// TRANSACTION_AUTO_COMPLETE_AFTER ago every TRANSACTION_AUTO_COMPLETE_INTERVAL when their
+// customer does not confirm the delivery. It is started once, NewTransactionRoute runs for
+// every route depending on transactions.
+func InitAutoComplete(r Routes) {
+	u, ok := r.Usecase.(domain.TransactionUsecase)
+	if !ok || db.Postgres() == nil {
+		return
+	}
+	usecase.StartAutoCompleteJob(u,
+		durationEnv("TRANSACTION_AUTO_COMPLETE_AFTER", defaultAutoCompleteAfter),
+		durationEnv("TRANSACTION_AUTO_COMPLETE_INTERVAL", defaultAutoCompleteInterval))
+}
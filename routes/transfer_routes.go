@@ -18,12 +18,13 @@ type TransferRoute struct {
 func NewTransferRoute(e *echo.Echo) TransferRoute {
 	db := db.Postgres()
 	merchant := NewMerchantRoute(e)
+	bankAccount := NewBankAccountRoute(e)
 	if db != nil {
 		d := db.AutoMigrate(&domain.Transfer{})
 		d.AddForeignKey("merchant_id", "merchants(id)", "CASCADE", "CASCADE")
 	}
 	repo := repository.NewTransferRepository(db)
-	usecase := transfer.NewTransferUsecase(repo, merchant.Usecase)
+	usecase := transfer.NewTransferUsecase(repo, merchant.Usecase, bankAccount.Usecase)
 	c := controller.NewTransferController(e, usecase)
 	return TransferRoute{
 		controller: c,
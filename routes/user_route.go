@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"github.com/jinzhu/gorm"
 	"github.com/labstack/echo"
 	controller "github.com/williamchang80/sea-apd/controller/http/user"
 	domain "github.com/williamchang80/sea-apd/domain/user"
@@ -22,7 +23,7 @@ func NewUserRoute(e *echo.Echo) UserRoute {
 	u := usecase.NewUserUsecase(repository, authRoute.usecase)
 	controller := controller.NewUserController(e, u)
 	if db != nil {
-		db.AutoMigrate(&domain.User{})
+		MigrateUsers(db)
 	}
 
 	return UserRoute{
@@ -31,3 +32,15 @@ func NewUserRoute(e *echo.Echo) UserRoute {
 		Repository: repository,
 	}
 }
+
+// MigrateUsers migrates the users table. Users that registered before emails were
+// verified are treated as verified since their registration.
+func MigrateUsers(db *gorm.DB) *gorm.DB {
+	verificationAdded := !db.Dialect().HasColumn("users", "email_verified_at")
+	d := db.AutoMigrate(&domain.User{})
+	if verificationAdded {
+		d.Model(&domain.User{}).Where("email_verified_at IS NULL").
+			UpdateColumn("email_verified_at", gorm.Expr("created_at"))
+	}
+	return d
+}
@@ -0,0 +1,174 @@
+package address
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/address"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	request "github.com/williamchang80/sea-apd/dto/request/address"
+)
+
+var (
+	ErrAddressNotFound  = apperror.New(apperror.NOT_FOUND, "address_not_found", "address not found")
+	ErrNoDefaultAddress = apperror.New(apperror.NOT_FOUND, "no_default_address", "customer has no address to ship to")
+	ErrInvalidPhone     = apperror.Validation("phone must have 8 to 15 digits")
+	ErrEmptyAddress     = apperror.Validation("recipient name, street, city and province cannot be empty")
+)
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9]{8,15}$`)
+
+type AddressUsecase struct {
+	repo address.AddressRepository
+}
+
+func NewAddressUsecase(repo address.AddressRepository) address.AddressUsecase {
+	return &AddressUsecase{repo: repo}
+}
+
+// squash trims the value and collapses its inner whitespace
+func squash(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// normalizePhone drops the separators people type in phone numbers
+func normalizePhone(phone string) (string, error) {
+	phone = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "").Replace(phone)
+	if !phonePattern.MatchString(phone) {
+		return "", ErrInvalidPhone
+	}
+	return phone, nil
+}
+
+func convertToDomain(customerId string, label string, recipientName string, phone string, street string,
+	city string, province string, postalCode string) (address.Address, error) {
+	phone, err := normalizePhone(phone)
+	if err != nil {
+		return address.Address{}, err
+	}
+	addr := address.Address{
+		CustomerId:    customerId,
+		Label:         squash(label),
+		RecipientName: squash(recipientName),
+		Phone:         phone,
+		Street:        squash(street),
+		City:          squash(city),
+		Province:      squash(province),
+		PostalCode:    strings.TrimSpace(postalCode),
+	}
+	if addr.RecipientName == "" || addr.Street == "" || addr.City == "" || addr.Province == "" {
+		return address.Address{}, ErrEmptyAddress
+	}
+	return addr, nil
+}
+
+func (a *AddressUsecase) getCustomerAddress(ctx context.Context, customerId string, addressId string) (*address.Address, error) {
+	addr, err := a.repo.GetAddressById(ctx, addressId)
+	if err != nil || addr == nil || addr.CustomerId != customerId {
+		return nil, ErrAddressNotFound
+	}
+	return addr, nil
+}
+
+func (a *AddressUsecase) GetAddresses(ctx context.Context, customerId string) ([]address.Address, error) {
+	ctx, span := tracing.Start(ctx, "AddressUsecase.GetAddresses")
+	defer span.End()
+	if customerId == "" {
+		return nil, apperror.Validation("customer id cannot be empty")
+	}
+	addresses, err := a.repo.GetAddressesByCustomer(ctx, customerId)
+	if err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// CreateAddress adds the address to the address book, the first address of a customer
+// becomes its default
+func (a *AddressUsecase) CreateAddress(ctx context.Context, r request.AddressRequest) error {
+	ctx, span := tracing.Start(ctx, "AddressUsecase.CreateAddress")
+	defer span.End()
+	addr, err := convertToDomain(r.CustomerId, r.Label, r.RecipientName, r.Phone, r.Street, r.City,
+		r.Province, r.PostalCode)
+	if err != nil {
+		return err
+	}
+	addresses, err := a.repo.GetAddressesByCustomer(ctx, r.CustomerId)
+	if err != nil {
+		return err
+	}
+	addr.IsDefault = len(addresses) == 0
+	return a.repo.CreateAddress(ctx, addr)
+}
+
+func (a *AddressUsecase) UpdateAddress(ctx context.Context, r request.UpdateAddressRequest) error {
+	ctx, span := tracing.Start(ctx, "AddressUsecase.UpdateAddress")
+	defer span.End()
+	addr, err := convertToDomain(r.CustomerId, r.Label, r.RecipientName, r.Phone, r.Street, r.City,
+		r.Province, r.PostalCode)
+	if err != nil {
+		return err
+	}
+	if _, err := a.getCustomerAddress(ctx, r.CustomerId, r.AddressId); err != nil {
+		return err
+	}
+	return a.repo.UpdateAddress(ctx, r.AddressId, addr)
+}
+
+// DeleteAddress removes the address, the oldest remaining address takes over when the
+// default address is removed
+func (a *AddressUsecase) DeleteAddress(ctx context.Context, r request.AddressActionRequest) error {
+	ctx, span := tracing.Start(ctx, "AddressUsecase.DeleteAddress")
+	defer span.End()
+	addr, err := a.getCustomerAddress(ctx, r.CustomerId, r.AddressId)
+	if err != nil {
+		return err
+	}
+	if err := a.repo.DeleteAddress(ctx, r.AddressId); err != nil {
+		return err
+	}
+	if !addr.IsDefault {
+		return nil
+	}
+	addresses, err := a.repo.GetAddressesByCustomer(ctx, r.CustomerId)
+	if err != nil {
+		return err
+	}
+	for _, remaining := range addresses {
+		if remaining.ID != r.AddressId {
+			return a.repo.SetDefaultAddress(ctx, r.CustomerId, remaining.ID)
+		}
+	}
+	return nil
+}
+
+func (a *AddressUsecase) SetDefaultAddress(ctx context.Context, r request.AddressActionRequest) error {
+	ctx, span := tracing.Start(ctx, "AddressUsecase.SetDefaultAddress")
+	defer span.End()
+	if _, err := a.getCustomerAddress(ctx, r.CustomerId, r.AddressId); err != nil {
+		return err
+	}
+	return a.repo.SetDefaultAddress(ctx, r.CustomerId, r.AddressId)
+}
+
+// GetShippingAddress returns the address a checkout ships to, the default address of the
+// customer when no address is given
+func (a *AddressUsecase) GetShippingAddress(ctx context.Context, customerId string, addressId string) (*address.Address, error) {
+	ctx, span := tracing.Start(ctx, "AddressUsecase.GetShippingAddress")
+	defer span.End()
+	if addressId != "" {
+		return a.getCustomerAddress(ctx, customerId, addressId)
+	}
+	addresses, err := a.GetAddresses(ctx, customerId)
+	if err != nil {
+		return nil, err
+	}
+	for i := range addresses {
+		if addresses[i].IsDefault {
+			return &addresses[i], nil
+		}
+	}
+	return nil, ErrNoDefaultAddress
+}
@@ -0,0 +1,142 @@
+package address
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	request "github.com/williamchang80/sea-apd/dto/request/address"
+	address2 "github.com/williamchang80/sea-apd/mocks/repository/address"
+)
+
+func TestAddressUsecase_CreateAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.AddressRequest
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: request.AddressRequest{CustomerId: address2.MockCustomerId, RecipientName: " Mock  Recipient ",
+				Phone: "+62 812-3456-7890", Street: "Jl. Mock No. 3", City: "Surabaya", Province: "Jawa Timur",
+				PostalCode: "60111"},
+			wantErr: false,
+		},
+		{
+			name: "failed with phone of too few digits",
+			args: request.AddressRequest{CustomerId: address2.MockCustomerId, RecipientName: "Mock Recipient",
+				Phone: "0812", Street: "Jl. Mock No. 3", City: "Surabaya", Province: "Jawa Timur",
+				PostalCode: "60111"},
+			wantErr: true,
+		},
+		{
+			name: "failed with phone of letters",
+			args: request.AddressRequest{CustomerId: address2.MockCustomerId, RecipientName: "Mock Recipient",
+				Phone: "0812abcdefgh", Street: "Jl. Mock No. 3", City: "Surabaya", Province: "Jawa Timur",
+				PostalCode: "60111"},
+			wantErr: true,
+		},
+		{
+			name: "failed with blank street",
+			args: request.AddressRequest{CustomerId: address2.MockCustomerId, RecipientName: "Mock Recipient",
+				Phone: "081234567890", Street: "   ", City: "Surabaya", Province: "Jawa Timur",
+				PostalCode: "60111"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewAddressUsecase(address2.NewMockRepository(ctrl))
+			if err := u.CreateAddress(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("CreateAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddressUsecase_DeleteAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.AddressActionRequest
+		wantErr bool
+	}{
+		{
+			name:    "success with default address",
+			args:    request.AddressActionRequest{CustomerId: address2.MockCustomerId, AddressId: address2.MockHomeAddressId},
+			wantErr: false,
+		},
+		{
+			name:    "success with other address",
+			args:    request.AddressActionRequest{CustomerId: address2.MockCustomerId, AddressId: address2.MockOfficeAddressId},
+			wantErr: false,
+		},
+		{
+			name:    "failed with address of another customer",
+			args:    request.AddressActionRequest{CustomerId: "other", AddressId: address2.MockHomeAddressId},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewAddressUsecase(address2.NewMockRepository(ctrl))
+			if err := u.DeleteAddress(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("DeleteAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddressUsecase_GetShippingAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	type args struct {
+		customerId string
+		addressId  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "success with default address",
+			args:    args{customerId: address2.MockCustomerId},
+			want:    address2.MockHomeAddressId,
+			wantErr: false,
+		},
+		{
+			name:    "success with chosen address",
+			args:    args{customerId: address2.MockCustomerId, addressId: address2.MockOfficeAddressId},
+			want:    address2.MockOfficeAddressId,
+			wantErr: false,
+		},
+		{
+			name:    "failed without address",
+			args:    args{customerId: "other"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with address of another customer",
+			args:    args{customerId: "other", addressId: address2.MockOfficeAddressId},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewAddressUsecase(address2.NewMockRepository(ctrl))
+			got, err := u.GetShippingAddress(context.Background(), tt.args.customerId, tt.args.addressId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetShippingAddress() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.ID != tt.want {
+				t.Errorf("GetShippingAddress() got = %v, want %v", got.ID, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package analytics
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/williamchang80/sea-apd/common/constants/time_bucket"
+	"github.com/williamchang80/sea-apd/common/logger"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/analytics"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/transaction"
+	request "github.com/williamchang80/sea-apd/dto/request/analytics"
+)
+
+const (
+	dateLayout        = "2006-01-02"
+	defaultPeriodDays = 30
+	maxPeriodDays     = 731
+	defaultTopLimit   = 10
+	maxTopLimit       = 100
+	// rollupOverlap re-reads the transactions changed shortly before the last refresh,
+	// so a transaction committed while the refresh ran is not missed
+	rollupOverlap = time.Minute
+)
+
+type AnalyticsUsecase struct {
+	repo       transaction.TransactionRepository
+	useRollups bool
+	mu         sync.Mutex
+	refreshed  time.Time
+}
+
+// NewAnalyticsUsecase creates the analytics usecase. With useRollups the sales report is
+// read from the rollup table, which is only as fresh as the last RefreshRollups.
+func NewAnalyticsUsecase(repo transaction.TransactionRepository, useRollups bool) analytics.AnalyticsUsecase {
+	return &AnalyticsUsecase{repo: repo, useRollups: useRollups}
+}
+
+// StartRollupJob refreshes the rollups of the usecase now and then every interval
+func StartRollupJob(u analytics.AnalyticsUsecase, interval time.Duration) {
+	go func() {
+		ctx := logger.WithRequestId(context.Background(), "rollup-job")
+		for {
+			if err := u.RefreshRollups(ctx); err != nil {
+				logger.Error(ctx, "refreshing sales rollups failed", logger.Fields{"error": err.Error()})
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// truncate returns the start of the bucket t falls in, weeks start on monday like
+// date_trunc does in postgres
+func truncate(t time.Time, bucket time_bucket.TimeBucket) time.Time {
+	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch bucket {
+	case time_bucket.WEEK:
+		return t.AddDate(0, 0, -(int(t.Weekday())+6)%7)
+	case time_bucket.MONTH:
+		return t.AddDate(0, 0, 1-t.Day())
+	}
+	return t
+}
+
+func next(t time.Time, bucket time_bucket.TimeBucket) time.Time {
+	switch bucket {
+	case time_bucket.WEEK:
+		return t.AddDate(0, 0, 7)
+	case time_bucket.MONTH:
+		return t.AddDate(0, 1, 0)
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+// convertToQuery defaults to daily buckets over the last 30 days
+func convertToQuery(r request.AnalyticsRequest) (analytics.AnalyticsQuery, error) {
+	if r.MerchantId == "" {
+		return analytics.AnalyticsQuery{}, apperror.Validation("merchant id cannot be empty")
+	}
+	bucket := time_bucket.DAY
+	if r.Bucket != "" {
+		bucket = time_bucket.ParseToEnum(strings.ToLower(r.Bucket))
+		if bucket == time_bucket.OTHER {
+			return analytics.AnalyticsQuery{}, apperror.Validation("bucket must be day, week or month")
+		}
+	}
+	to := truncate(time.Now().UTC(), time_bucket.DAY)
+	if r.To != "" {
+		t, err := time.Parse(dateLayout, r.To)
+		if err != nil {
+			return analytics.AnalyticsQuery{}, apperror.Validation("to must be a date like " + dateLayout)
+		}
+		to = t
+	}
+	from := to.AddDate(0, 0, 1-defaultPeriodDays)
+	if r.From != "" {
+		f, err := time.Parse(dateLayout, r.From)
+		if err != nil {
+			return analytics.AnalyticsQuery{}, apperror.Validation("from must be a date like " + dateLayout)
+		}
+		from = f
+	}
+	if from.After(to) {
+		return analytics.AnalyticsQuery{}, apperror.Validation("from cannot be after to")
+	}
+	if to.Sub(from) > maxPeriodDays*24*time.Hour {
+		return analytics.AnalyticsQuery{}, apperror.Validation("period cannot be longer than two years")
+	}
+	return analytics.AnalyticsQuery{
+		MerchantId: r.MerchantId,
+		Bucket:     time_bucket.ToString(bucket),
+		From:       from,
+		To:         to.AddDate(0, 0, 1),
+	}, nil
+}
+
+func averageOrderValue(revenue int, orders int) int {
+	if orders == 0 {
+		return 0
+	}
+	return revenue / orders
+}
+
+func rate(part int, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole)
+}
+
+// GetSalesReport returns one bucket for every day, week or month of the period, buckets
+// without sales included, so the series can be charted as is
+func (a *AnalyticsUsecase) GetSalesReport(ctx context.Context, r request.AnalyticsRequest) (*analytics.SalesReport, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsUsecase.GetSalesReport")
+	defer span.End()
+	query, err := convertToQuery(r)
+	if err != nil {
+		return nil, err
+	}
+	var sales []analytics.SalesBucket
+	if a.useRollups {
+		sales, err = a.repo.GetSalesRollups(ctx, query)
+	} else {
+		sales, err = a.repo.GetSalesBuckets(ctx, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	byStart := map[time.Time]analytics.SalesBucket{}
+	for _, s := range sales {
+		byStart[s.BucketStart.UTC()] = s
+	}
+
+	bucket := time_bucket.ParseToEnum(query.Bucket)
+	report := &analytics.SalesReport{Bucket: query.Bucket, Buckets: []analytics.SalesBucket{}}
+	for start := truncate(query.From, bucket); start.Before(query.To); start = next(start, bucket) {
+		s := byStart[start]
+		report.Buckets = append(report.Buckets, analytics.SalesBucket{
+			BucketStart:       start,
+			Revenue:           s.Revenue,
+			Orders:            s.Orders,
+			AverageOrderValue: averageOrderValue(s.Revenue, s.Orders),
+		})
+		report.Revenue += s.Revenue
+		report.Orders += s.Orders
+	}
+	report.AverageOrderValue = averageOrderValue(report.Revenue, report.Orders)
+	return report, nil
+}
+
+func (a *AnalyticsUsecase) GetTopProducts(ctx context.Context, r request.AnalyticsRequest) ([]analytics.ProductSales, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsUsecase.GetTopProducts")
+	defer span.End()
+	query, err := convertToQuery(r)
+	if err != nil {
+		return nil, err
+	}
+	limit := r.Limit
+	if limit <= 0 {
+		limit = defaultTopLimit
+	}
+	if limit > maxTopLimit {
+		limit = maxTopLimit
+	}
+	products, err := a.repo.GetTopProducts(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// GetConversion returns the share of checked out transactions that got accepted and the
+// share of paid transactions that were refunded
+func (a *AnalyticsUsecase) GetConversion(ctx context.Context, r request.AnalyticsRequest) (*analytics.Conversion, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsUsecase.GetConversion")
+	defer span.End()
+	query, err := convertToQuery(r)
+	if err != nil {
+		return nil, err
+	}
+	conversion, err := a.repo.GetConversion(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	conversion.ConversionRate = rate(conversion.Accepted, conversion.CheckedOut)
+	conversion.RefundRate = rate(conversion.Refunded, conversion.Paid)
+	return conversion, nil
+}
+
+// RefreshRollups rebuilds the rollups of the merchants with transactions changed since
+// the previous refresh, the first refresh rebuilds all of them
+func (a *AnalyticsUsecase) RefreshRollups(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "AnalyticsUsecase.RefreshRollups")
+	defer span.End()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	started := time.Now()
+	since := time.Time{}
+	if !a.refreshed.IsZero() {
+		since = a.refreshed.Add(-rollupOverlap)
+	}
+	if err := a.repo.RefreshSalesRollups(ctx, since); err != nil {
+		return err
+	}
+	a.refreshed = started
+	return nil
+}
@@ -0,0 +1,124 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/common/constants/time_bucket"
+	request "github.com/williamchang80/sea-apd/dto/request/analytics"
+	transaction2 "github.com/williamchang80/sea-apd/mocks/repository/transaction"
+)
+
+func TestAnalyticsUsecase_GetSalesReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name        string
+		args        request.AnalyticsRequest
+		useRollups  bool
+		wantBuckets int
+		wantRevenue int
+		wantAverage int
+		wantErr     bool
+	}{
+		{
+			name:        "success with empty days filled",
+			args:        request.AnalyticsRequest{MerchantId: "1", From: "2020-01-01", To: "2020-01-03"},
+			wantBuckets: 3,
+			wantRevenue: 4000,
+			wantAverage: 1333,
+		},
+		{
+			name: "success with weekly buckets",
+			args: request.AnalyticsRequest{MerchantId: "1", Bucket: "week", From: "2019-12-30",
+				To: "2020-01-12"},
+			wantBuckets: 2,
+			wantRevenue: 0,
+		},
+		{
+			name: "success from rollups",
+			args: request.AnalyticsRequest{MerchantId: "1", Bucket: "DAY", From: "2020-01-01",
+				To: "2020-01-02"},
+			useRollups:  true,
+			wantBuckets: 2,
+			wantRevenue: 3000,
+			wantAverage: 1500,
+		},
+		{
+			name:    "failed with unknown bucket",
+			args:    request.AnalyticsRequest{MerchantId: "1", Bucket: "year"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with from after to",
+			args:    request.AnalyticsRequest{MerchantId: "1", From: "2020-02-01", To: "2020-01-01"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with invalid date",
+			args:    request.AnalyticsRequest{MerchantId: "1", From: "01/01/2020"},
+			wantErr: true,
+		},
+		{
+			name:    "failed without merchant",
+			args:    request.AnalyticsRequest{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAnalyticsUsecase(transaction2.NewMockRepository(ctrl), tt.useRollups)
+			got, err := a.GetSalesReport(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetSalesReport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if len(got.Buckets) != tt.wantBuckets || got.Revenue != tt.wantRevenue ||
+				got.AverageOrderValue != tt.wantAverage {
+				t.Errorf("GetSalesReport() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	day := time.Date(2020, 1, 15, 13, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		bucket time_bucket.TimeBucket
+		want   time.Time
+	}{
+		{name: "day", bucket: time_bucket.DAY, want: time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "week starts on monday", bucket: time_bucket.WEEK, want: time.Date(2020, 1, 13, 0, 0, 0, 0, time.UTC)},
+		{name: "month", bucket: time_bucket.MONTH, want: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncate(day, tt.bucket); !got.Equal(tt.want) {
+				t.Errorf("truncate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyticsUsecase_GetConversion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	a := NewAnalyticsUsecase(transaction2.NewMockRepository(ctrl), false)
+	got, err := a.GetConversion(context.Background(), request.AnalyticsRequest{MerchantId: "1"})
+	if err != nil {
+		t.Fatalf("GetConversion() error = %v", err)
+	}
+	if got.ConversionRate != 0.6 || got.RefundRate != 0.25 {
+		t.Errorf("GetConversion() = %+v", got)
+	}
+	got, err = a.GetConversion(context.Background(), request.AnalyticsRequest{MerchantId: "2"})
+	if err != nil || got.ConversionRate != 0 || got.RefundRate != 0 {
+		t.Errorf("GetConversion() without transactions = %+v, error = %v", got, err)
+	}
+}
@@ -1,29 +1,109 @@
 package auth
 
 import (
-	"errors"
+	"context"
 	"github.com/williamchang80/sea-apd/common/auth"
+	"github.com/williamchang80/sea-apd/common/throttle"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	auth2 "github.com/williamchang80/sea-apd/domain/auth"
 	user "github.com/williamchang80/sea-apd/domain/user"
 	request "github.com/williamchang80/sea-apd/dto/request/auth"
+	"time"
 )
 
 type AuthUsecase struct {
-	repo user.UserRepository
+	repo      user.UserRepository
+	attempts  auth2.LoginAttemptStore
+	limits    passwordLimits
+	twoFactor *throttle.Limiter
 }
 
-func NewAuthUsecase(repository user.UserRepository) auth2.AuthUsecase {
-	return AuthUsecase{repo: repository}
+// passwordLimits throttle the password endpoints per email and per ip address
+type passwordLimits struct {
+	forgotByEmail *throttle.Limiter
+	forgotByIp    *throttle.Limiter
+	resetByEmail  *throttle.Limiter
+	resetByIp     *throttle.Limiter
 }
 
-func (a AuthUsecase) Login(request request.LoginRequest) (string, error) {
-	user, err := a.repo.GetUserByEmail(request.Email)
-	if err != nil || !auth.IsMatchedPassword(user.Password, request.Password) {
-		return "", errors.New("Password and email not matched")
+func NewAuthUsecase(repository user.UserRepository, attempts auth2.LoginAttemptStore) auth2.AuthUsecase {
+	return AuthUsecase{repo: repository, attempts: attempts, limits: passwordLimits{
+		forgotByEmail: throttle.NewLimiter(3, time.Hour),
+		forgotByIp:    throttle.NewLimiter(20, time.Hour),
+		resetByEmail:  throttle.NewLimiter(5, time.Hour),
+		resetByIp:     throttle.NewLimiter(20, time.Hour),
+	}, twoFactor: throttle.NewLimiter(5, 5*time.Minute)}
+}
+
+// authenticate checks the password of the user, failed attempts are counted by email
+// and by ip address
+func (a AuthUsecase) authenticate(ctx context.Context, email string, password string, ip string) (*user.User, error) {
+	now := time.Now()
+	if err := a.checkLoginAttempts(email, ip, now); err != nil {
+		return nil, err
+	}
+	u, err := a.repo.GetUserByEmail(ctx, email)
+	if err != nil || !auth.IsMatchedPassword(u.Password, password) {
+		if err := a.recordLoginFailure(ctx, email, ip, now); err != nil {
+			return nil, err
+		}
+		return nil, apperror.New(apperror.UNAUTHENTICATED, "invalid_credentials", "Password and email not matched")
+	}
+	if err := a.attempts.ResetLoginAttempts(accountKey(email)); err != nil {
+		return nil, err
+	}
+	if u.BannedAt != nil {
+		return nil, user.ErrUserBanned
+	}
+	return u, nil
+}
+
+// Login returns the access token, or a challenge token when the user has enabled
+// two-factor authentication
+func (a AuthUsecase) Login(ctx context.Context, request request.LoginRequest) (*auth2.LoginResult, error) {
+	ctx, span := tracing.Start(ctx, "AuthUsecase.Login")
+	defer span.End()
+	u, err := a.authenticate(ctx, request.Email, request.Password, request.Ip)
+	if err != nil {
+		return nil, err
 	}
-	token, err := auth.GenerateToken(user)
+	if u.TotpEnabledAt != nil {
+		challenge, err := auth.GeneratePurposeToken(auth.LoginChallengePurpose, u.ID,
+			time.Now().Add(ChallengeLifetime))
+		if err != nil {
+			return nil, err
+		}
+		return &auth2.LoginResult{ChallengeToken: challenge}, nil
+	}
+	required, err := a.isTwoFactorRequired(ctx, u.Role)
 	if err != nil {
-		return "", errors.New("Password and email not matched")
+		return nil, err
+	}
+	if required {
+		return nil, user.ErrTwoFactorRequired
+	}
+	token, err := auth.GenerateToken(u)
+	if err != nil {
+		return nil, apperror.New(apperror.UNAUTHENTICATED, "invalid_credentials", "Password and email not matched")
+	}
+	return &auth2.LoginResult{Token: token}, nil
+}
+
+// ValidateSession fails for tokens of banned users and tokens issued before the sessions
+// of the user were revoked
+func (a AuthUsecase) ValidateSession(ctx context.Context, userId string, issuedAt time.Time) error {
+	ctx, span := tracing.Start(ctx, "AuthUsecase.ValidateSession")
+	defer span.End()
+	u, err := a.repo.GetUserById(ctx, userId)
+	if err != nil || u == nil {
+		return apperror.NotFound("user not found")
+	}
+	if u.BannedAt != nil {
+		return user.ErrUserBanned
+	}
+	if u.SessionsRevokedAt != nil && issuedAt.Unix() < u.SessionsRevokedAt.Unix() {
+		return user.ErrSessionRevoked
 	}
-	return token, nil
+	return nil
 }
@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/williamchang80/sea-apd/common/constants/mailer_type"
+	"github.com/williamchang80/sea-apd/common/mailer"
+	"github.com/williamchang80/sea-apd/common/mailer/factory"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	auth2 "github.com/williamchang80/sea-apd/domain/auth"
+)
+
+const (
+	// FailureWindow is how long a failed login counts, LockoutDuration how long an account
+	// or ip address stays locked once it failed too often within the window
+	FailureWindow   = 15 * time.Minute
+	LockoutDuration = 15 * time.Minute
+	// AccountLockoutThreshold failures lock the account and mail its owner,
+	// IpLockoutThreshold failures lock the ip address
+	AccountLockoutThreshold = 10
+	IpLockoutThreshold      = 50
+	// delayAfterFailures failures in a row make the next login wait one second, every
+	// further failure doubles the wait up to maxLoginDelay
+	delayAfterFailures = 3
+	maxLoginDelay      = 30 * time.Second
+)
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+// LoginDelay is how long after the last of the failures the next login is allowed
+func LoginDelay(failures int) time.Duration {
+	if failures < delayAfterFailures {
+		return 0
+	}
+	delay := time.Second
+	for i := delayAfterFailures; i < failures && delay < maxLoginDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxLoginDelay {
+		return maxLoginDelay
+	}
+	return delay
+}
+
+func (a AuthUsecase) loginKeys(email string, ip string) []string {
+	keys := []string{accountKey(email)}
+	if ip != "" {
+		keys = append(keys, ipKey(ip))
+	}
+	return keys
+}
+
+// checkLoginAttempts refuses logins of locked accounts and ip addresses and logins that
+// come before the delay of the recent failures is over
+func (a AuthUsecase) checkLoginAttempts(email string, ip string, now time.Time) error {
+	for _, key := range a.loginKeys(email, ip) {
+		attempts, err := a.attempts.GetLoginAttempts(key)
+		if err != nil {
+			return err
+		}
+		if attempts.LockedUntil != nil && now.Before(*attempts.LockedUntil) {
+			if key == accountKey(email) {
+				return auth2.ErrAccountLocked
+			}
+			return auth2.ErrTooManyAttempts
+		}
+		if now.Sub(attempts.LastFailureAt) < FailureWindow &&
+			now.Before(attempts.LastFailureAt.Add(LoginDelay(attempts.Failures))) {
+			return auth2.ErrTooManyAttempts
+		}
+	}
+	return nil
+}
+
+// recordLoginFailure counts the failure and locks the account or ip address that reached
+// its threshold, the owner of a locked account is told by mail
+func (a AuthUsecase) recordLoginFailure(ctx context.Context, email string, ip string, now time.Time) error {
+	until := now.Add(LockoutDuration)
+	attempts, err := a.attempts.AddLoginFailure(accountKey(email), now, FailureWindow)
+	if err != nil {
+		return err
+	}
+	if attempts.Failures == AccountLockoutThreshold {
+		if err := a.attempts.LockLogin(accountKey(email), until); err != nil {
+			return err
+		}
+		if u, err := a.repo.GetUserByEmail(ctx, strings.TrimSpace(email)); err == nil && u != nil {
+			mails := factory.CreateMailerFactory(mailer_type.ACCOUNT_LOCKED).CreateMail(*u, until)
+			mailer.SendEmail(ctx, mails)
+		}
+	}
+	if ip == "" {
+		return nil
+	}
+	attempts, err = a.attempts.AddLoginFailure(ipKey(ip), now, FailureWindow)
+	if err != nil {
+		return err
+	}
+	if attempts.Failures == IpLockoutThreshold {
+		return a.attempts.LockLogin(ipKey(ip), until)
+	}
+	return nil
+}
+
+// UnlockAccount lifts the lockout of the account and forgets its failed logins
+func (a AuthUsecase) UnlockAccount(ctx context.Context, email string) error {
+	ctx, span := tracing.Start(ctx, "AuthUsecase.UnlockAccount")
+	defer span.End()
+	return a.attempts.ResetLoginAttempts(accountKey(email))
+}
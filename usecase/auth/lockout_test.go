@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	auth2 "github.com/williamchang80/sea-apd/domain/auth"
+	request "github.com/williamchang80/sea-apd/dto/request/auth"
+	"github.com/williamchang80/sea-apd/mocks/repository/user"
+	memory "github.com/williamchang80/sea-apd/repository/memory/auth"
+)
+
+func TestLoginDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		want     time.Duration
+	}{
+		{name: "no delay for first failures", failures: delayAfterFailures - 1, want: 0},
+		{name: "one second after threshold", failures: delayAfterFailures, want: time.Second},
+		{name: "doubles with every failure", failures: delayAfterFailures + 2, want: 4 * time.Second},
+		{name: "capped", failures: 100, want: maxLoginDelay},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LoginDelay(tt.failures); got != tt.want {
+				t.Errorf("LoginDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// seedFailures records failures a minute ago, long enough for the delay to be over
+func seedFailures(t *testing.T, store auth2.LoginAttemptStore, key string, n int) {
+	at := time.Now().Add(-time.Minute)
+	for i := 0; i < n; i++ {
+		if _, err := store.AddLoginFailure(key, at, FailureWindow); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAuthUsecase_LoginDelay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	a := NewAuthUsecase(user.NewMockRepository(ctrl), memory.NewLoginAttemptStore())
+	for i := 0; i < delayAfterFailures; i++ {
+		a.Login(context.Background(), request.LoginRequest{Email: "mock@mock.com", Password: "wrong"})
+	}
+	_, err := a.Login(context.Background(), request.LoginRequest{Email: "mock@mock.com", Password: user.MockPassword})
+	if err != auth2.ErrTooManyAttempts {
+		t.Errorf("AuthUsecase.Login() error = %v, want %v", err, auth2.ErrTooManyAttempts)
+	}
+}
+
+func TestAuthUsecase_AccountLockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := memory.NewLoginAttemptStore()
+	a := NewAuthUsecase(user.NewMockRepository(ctrl), store)
+	seedFailures(t, store, accountKey("mock@mock.com"), AccountLockoutThreshold-1)
+
+	a.Login(context.Background(), request.LoginRequest{Email: "Mock@mock.com", Password: "wrong"})
+	_, err := a.Login(context.Background(), request.LoginRequest{Email: "mock@mock.com", Password: user.MockPassword})
+	if err != auth2.ErrAccountLocked {
+		t.Fatalf("AuthUsecase.Login() error = %v, want %v", err, auth2.ErrAccountLocked)
+	}
+	if err := a.UnlockAccount(context.Background(), "mock@mock.com"); err != nil {
+		t.Fatalf("AuthUsecase.UnlockAccount() error = %v", err)
+	}
+	if _, err := a.Login(context.Background(), request.LoginRequest{Email: "mock@mock.com", Password: user.MockPassword}); err != nil {
+		t.Errorf("AuthUsecase.Login() after unlock error = %v", err)
+	}
+}
+
+func TestAuthUsecase_IpLockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := memory.NewLoginAttemptStore()
+	a := NewAuthUsecase(user.NewMockRepository(ctrl), store)
+	seedFailures(t, store, ipKey("10.0.0.1"), IpLockoutThreshold-1)
+
+	a.Login(context.Background(), request.LoginRequest{Email: "first@mock.com", Password: "wrong", Ip: "10.0.0.1"})
+	_, err := a.Login(context.Background(), request.LoginRequest{Email: "second@mock.com", Password: user.MockPassword, Ip: "10.0.0.1"})
+	if err != auth2.ErrTooManyAttempts {
+		t.Fatalf("AuthUsecase.Login() error = %v, want %v", err, auth2.ErrTooManyAttempts)
+	}
+	_, err = a.Login(context.Background(), request.LoginRequest{Email: "second@mock.com", Password: user.MockPassword, Ip: "10.0.0.2"})
+	if err != nil {
+		t.Errorf("AuthUsecase.Login() from other ip error = %v", err)
+	}
+}
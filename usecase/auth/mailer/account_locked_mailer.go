@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/williamchang80/sea-apd/common/mailer"
+	"github.com/williamchang80/sea-apd/domain/user"
+)
+
+type AccountLockedMailer struct {
+}
+
+func (a AccountLockedMailer) CreateMail(i ...interface{}) []mailer.Mail {
+	u, _ := i[0].(user.User)
+	until, _ := i[1].(time.Time)
+	return CreateAccountLockedMailer(u, until)
+}
+
+func CreateAccountLockedMailer(u user.User, until time.Time) []mailer.Mail {
+	accountLockedMailer := mailer.Mail{
+		Sender:    mailer.MailSender,
+		Subject:   "Your account was locked",
+		Recipient: u.Email,
+		Body: fmt.Sprintf(`Hello %v, there were too many failed logins to your account so it is
+		locked until %v. If it was not you, reset your password once the lock is over or
+		ask an admin to unlock your account`, u.Name, until.Format(time.RFC1123)),
+	}
+	return []mailer.Mail{
+		accountLockedMailer,
+	}
+}
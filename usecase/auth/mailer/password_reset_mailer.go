@@ -0,0 +1,34 @@
+package mailer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/williamchang80/sea-apd/common/mailer"
+	"github.com/williamchang80/sea-apd/domain/user"
+)
+
+type PasswordResetMailer struct {
+}
+
+func (p PasswordResetMailer) CreateMail(i ...interface{}) []mailer.Mail {
+	u, _ := i[0].(user.User)
+	reset, _ := i[1].(user.PasswordReset)
+	token, _ := i[2].(string)
+	return CreatePasswordResetMailer(u, reset, token)
+}
+
+func CreatePasswordResetMailer(u user.User, reset user.PasswordReset, token string) []mailer.Mail {
+	passwordResetMailer := mailer.Mail{
+		Sender:    mailer.MailSender,
+		Subject:   "Reset your password",
+		Recipient: u.Email,
+		Body: fmt.Sprintf(`Hello %v, somebody asked to reset your password. Reset it with the
+		token %v before %v, this logs you out everywhere.
+		If it was not you, you can ignore this mail and keep your password`, u.Name, token,
+			reset.ExpiresAt.Format(time.RFC1123)),
+	}
+	return []mailer.Mail{
+		passwordResetMailer,
+	}
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/williamchang80/sea-apd/common/auth"
+	"github.com/williamchang80/sea-apd/common/constants/mailer_type"
+	"github.com/williamchang80/sea-apd/common/mailer"
+	"github.com/williamchang80/sea-apd/common/mailer/factory"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	auth2 "github.com/williamchang80/sea-apd/domain/auth"
+	"github.com/williamchang80/sea-apd/domain/user"
+	request "github.com/williamchang80/sea-apd/dto/request/auth"
+)
+
+// ResetTokenLifetime is how long a mailed password reset token can be used
+const ResetTokenLifetime = 30 * time.Minute
+
+func (l passwordLimits) allowForgot(email string, ip string) bool {
+	return l.forgotByIp.Allow(ip) && l.forgotByEmail.Allow(strings.ToLower(email))
+}
+
+func (l passwordLimits) allowReset(email string, ip string) bool {
+	return l.resetByIp.Allow(ip) && l.resetByEmail.Allow(strings.ToLower(email))
+}
+
+// ForgotPassword mails a reset token when a user has the email. It succeeds for unknown
+// emails as well so the response does not tell which emails are registered.
+func (a AuthUsecase) ForgotPassword(ctx context.Context, r request.ForgotPasswordRequest) error {
+	ctx, span := tracing.Start(ctx, "AuthUsecase.ForgotPassword")
+	defer span.End()
+	email := strings.TrimSpace(r.Email)
+	if !a.limits.allowForgot(email, r.Ip) {
+		return auth2.ErrTooManyAttempts
+	}
+	u, err := a.repo.GetUserByEmail(ctx, email)
+	if err != nil || u == nil || u.BannedAt != nil {
+		return nil
+	}
+	token, err := auth.GenerateSecureToken()
+	if err != nil {
+		return err
+	}
+	reset := user.PasswordReset{
+		UserId:    u.ID,
+		TokenHash: auth.HashSecureToken(token),
+		ExpiresAt: time.Now().Add(ResetTokenLifetime),
+	}
+	if err := a.repo.CreatePasswordReset(ctx, reset); err != nil {
+		return err
+	}
+	mails := factory.CreateMailerFactory(mailer_type.PASSWORD_RESET).CreateMail(*u, reset, token)
+	// the mail is sent in the background, waiting for it would tell the email is registered
+	go mailer.SendEmail(ctx, mails)
+	return nil
+}
+
+// ResetPassword sets a new password with a mailed token and logs the user out of every
+// session
+func (a AuthUsecase) ResetPassword(ctx context.Context, r request.ResetPasswordRequest) error {
+	ctx, span := tracing.Start(ctx, "AuthUsecase.ResetPassword")
+	defer span.End()
+	email := strings.TrimSpace(r.Email)
+	if !a.limits.allowReset(email, r.Ip) {
+		return auth2.ErrTooManyAttempts
+	}
+	if r.Token == "" {
+		return user.ErrResetTokenNotValid
+	}
+	if r.Password == "" {
+		return apperror.Validation("password cannot be empty")
+	}
+	if r.Password != r.PasswordConfirmation {
+		return apperror.Validation("password and confirmation password must be same")
+	}
+	return a.repo.ResetPassword(ctx, auth.HashSecureToken(r.Token), email, auth.HashPassword(r.Password),
+		time.Now())
+}
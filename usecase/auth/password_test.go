@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	auth2 "github.com/williamchang80/sea-apd/domain/auth"
+	request "github.com/williamchang80/sea-apd/dto/request/auth"
+	"github.com/williamchang80/sea-apd/mocks/repository/user"
+	memory "github.com/williamchang80/sea-apd/repository/memory/auth"
+)
+
+func TestAuthUsecase_ForgotPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.ForgotPasswordRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    request.ForgotPasswordRequest{Email: "mock@mock.com", Ip: "127.0.0.1"},
+			wantErr: false,
+		},
+		{
+			name:    "success with unknown email",
+			args:    request.ForgotPasswordRequest{Email: user.MockUnknownEmail, Ip: "127.0.0.1"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAuthUsecase(user.NewMockRepository(ctrl), memory.NewLoginAttemptStore())
+			if err := a.ForgotPassword(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("AuthUsecase.ForgotPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_ForgotPasswordThrottle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	a := NewAuthUsecase(user.NewMockRepository(ctrl), memory.NewLoginAttemptStore())
+	var err error
+	for i := 0; i < 4; i++ {
+		err = a.ForgotPassword(context.Background(), request.ForgotPasswordRequest{Email: "Mock@mock.com", Ip: "127.0.0.1"})
+	}
+	if err != auth2.ErrTooManyAttempts {
+		t.Errorf("AuthUsecase.ForgotPassword() error = %v, want %v", err, auth2.ErrTooManyAttempts)
+	}
+	err = a.ForgotPassword(context.Background(), request.ForgotPasswordRequest{Email: "other@mock.com", Ip: "127.0.0.1"})
+	if err != nil {
+		t.Errorf("AuthUsecase.ForgotPassword() of other email error = %v", err)
+	}
+}
+
+func TestAuthUsecase_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.ResetPasswordRequest
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: request.ResetPasswordRequest{Email: "mock@mock.com", Token: user.MockResetToken,
+				Password: "new", PasswordConfirmation: "new"},
+			wantErr: false,
+		},
+		{
+			name: "failed with wrong token",
+			args: request.ResetPasswordRequest{Email: "mock@mock.com", Token: "guess",
+				Password: "new", PasswordConfirmation: "new"},
+			wantErr: true,
+		},
+		{
+			name: "failed with token of other user",
+			args: request.ResetPasswordRequest{Email: user.MockUnknownEmail, Token: user.MockResetToken,
+				Password: "new", PasswordConfirmation: "new"},
+			wantErr: true,
+		},
+		{
+			name: "failed with different confirmation",
+			args: request.ResetPasswordRequest{Email: "mock@mock.com", Token: user.MockResetToken,
+				Password: "new", PasswordConfirmation: "other"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAuthUsecase(user.NewMockRepository(ctrl), memory.NewLoginAttemptStore())
+			if err := a.ResetPassword(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("AuthUsecase.ResetPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_ValidateSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name     string
+		userId   string
+		issuedAt time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "success",
+			userId:   "1",
+			issuedAt: time.Now(),
+			wantErr:  false,
+		},
+		{
+			name:     "success with token issued after revoking",
+			userId:   user.MockRevokedUserId,
+			issuedAt: user.MockSessionsRevokedAt.Add(time.Minute),
+			wantErr:  false,
+		},
+		{
+			name:     "failed with token issued before revoking",
+			userId:   user.MockRevokedUserId,
+			issuedAt: user.MockSessionsRevokedAt.Add(-time.Minute),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAuthUsecase(user.NewMockRepository(ctrl), memory.NewLoginAttemptStore())
+			if err := a.ValidateSession(context.Background(), tt.userId, tt.issuedAt); (err != nil) != tt.wantErr {
+				t.Errorf("AuthUsecase.ValidateSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/williamchang80/sea-apd/common/auth"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	auth2 "github.com/williamchang80/sea-apd/domain/auth"
+	"github.com/williamchang80/sea-apd/domain/user"
+	request "github.com/williamchang80/sea-apd/dto/request/auth"
+)
+
+const (
+	// ChallengeLifetime is how long the second login step can be completed
+	ChallengeLifetime = 5 * time.Minute
+	// RecoveryCodeCount is how many recovery codes a user gets at a time
+	RecoveryCodeCount = 10
+	totpIssuer        = "sea-apd"
+)
+
+func (a AuthUsecase) isTwoFactorRequired(ctx context.Context, role string) (bool, error) {
+	policies, err := a.repo.GetTwoFactorPolicies(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range policies {
+		if p.Role == role {
+			return p.Required, nil
+		}
+	}
+	return false, nil
+}
+
+func isTotpCode(code string) bool {
+	if len(code) != auth.TotpDigits {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyCode accepts a code of the authenticator app or an unused recovery code, a code
+// is accepted only once
+func (a AuthUsecase) verifyCode(ctx context.Context, u user.User, code string) error {
+	if !a.twoFactor.Allow(u.ID) {
+		return auth2.ErrTooManyAttempts
+	}
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return user.ErrTwoFactorCodeNotValid
+	}
+	now := time.Now()
+	if isTotpCode(code) {
+		step, ok := auth.ValidateTotp(u.TotpSecret, code, now)
+		if !ok {
+			return user.ErrTwoFactorCodeNotValid
+		}
+		return a.repo.UseTotpStep(ctx, u.ID, step)
+	}
+	return a.repo.UseRecoveryCode(ctx, u.ID, auth.HashRecoveryCode(code), now)
+}
+
+func newRecoveryCodes() ([]string, []string, error) {
+	codes, err := auth.GenerateRecoveryCodes(RecoveryCodeCount)
+	if err != nil {
+		return nil, nil, err
+	}
+	hashes := make([]string, len(codes))
+	for i, c := range codes {
+		hashes[i] = auth.HashRecoveryCode(c)
+	}
+	return codes, hashes, nil
+}
+
+// VerifyTwoFactorLogin completes a login that returned a challenge token
+func (a AuthUsecase) VerifyTwoFactorLogin(ctx context.Context, r request.TwoFactorLoginRequest) (string, error) {
+	ctx, span := tracing.Start(ctx, "AuthUsecase.VerifyTwoFactorLogin")
+	defer span.End()
+	userId, err := auth.ParsePurposeToken(auth.LoginChallengePurpose, r.ChallengeToken)
+	if err != nil {
+		return "", apperror.Unauthenticated("challenge token is not valid")
+	}
+	u, err := a.repo.GetUserById(ctx, userId)
+	if err != nil || u == nil {
+		return "", apperror.NotFound("user not found")
+	}
+	if u.BannedAt != nil {
+		return "", user.ErrUserBanned
+	}
+	if u.TotpEnabledAt == nil {
+		return "", user.ErrTwoFactorNotEnabled
+	}
+	if err := a.verifyCode(ctx, *u, r.Code); err != nil {
+		return "", err
+	}
+	return auth.GenerateToken(u)
+}
+
+// EnrollTwoFactor creates a new secret for the authenticator app, it is only asked for
+// at login after the enrolment is confirmed with a code
+func (a AuthUsecase) EnrollTwoFactor(ctx context.Context, r request.LoginRequest) (*auth2.TwoFactorEnrolment, error) {
+	ctx, span := tracing.Start(ctx, "AuthUsecase.EnrollTwoFactor")
+	defer span.End()
+	u, err := a.authenticate(ctx, r.Email, r.Password, r.Ip)
+	if err != nil {
+		return nil, err
+	}
+	if u.TotpEnabledAt != nil {
+		return nil, user.ErrTwoFactorNotPending
+	}
+	secret, err := auth.GenerateTotpSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := a.repo.SetTotpSecret(ctx, u.ID, secret); err != nil {
+		return nil, err
+	}
+	return &auth2.TwoFactorEnrolment{
+		Secret: secret,
+		Uri:    auth.TotpUri(totpIssuer, u.Email, secret),
+	}, nil
+}
+
+// ConfirmTwoFactor enables two-factor authentication once a code of the enrolled secret
+// is valid and returns the recovery codes
+func (a AuthUsecase) ConfirmTwoFactor(ctx context.Context, r request.TwoFactorRequest) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "AuthUsecase.ConfirmTwoFactor")
+	defer span.End()
+	u, err := a.authenticate(ctx, r.Email, r.Password, r.Ip)
+	if err != nil {
+		return nil, err
+	}
+	if u.TotpEnabledAt != nil {
+		return nil, user.ErrTwoFactorNotPending
+	}
+	if u.TotpSecret == "" {
+		return nil, apperror.Validation("two-factor enrolment has not been started")
+	}
+	if !a.twoFactor.Allow(u.ID) {
+		return nil, auth2.ErrTooManyAttempts
+	}
+	now := time.Now()
+	step, ok := auth.ValidateTotp(u.TotpSecret, strings.TrimSpace(r.Code), now)
+	if !ok {
+		return nil, user.ErrTwoFactorCodeNotValid
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := a.repo.EnableTotp(ctx, u.ID, now, step, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off unless the role of the user
+// requires it
+func (a AuthUsecase) DisableTwoFactor(ctx context.Context, r request.TwoFactorRequest) error {
+	ctx, span := tracing.Start(ctx, "AuthUsecase.DisableTwoFactor")
+	defer span.End()
+	u, err := a.authenticate(ctx, r.Email, r.Password, r.Ip)
+	if err != nil {
+		return err
+	}
+	if u.TotpEnabledAt == nil {
+		return user.ErrTwoFactorNotEnabled
+	}
+	required, err := a.isTwoFactorRequired(ctx, u.Role)
+	if err != nil {
+		return err
+	}
+	if required {
+		return user.ErrTwoFactorRequired
+	}
+	if err := a.verifyCode(ctx, *u, r.Code); err != nil {
+		return err
+	}
+	return a.repo.DisableTotp(ctx, u.ID)
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of the user, used or not
+func (a AuthUsecase) RegenerateRecoveryCodes(ctx context.Context, r request.TwoFactorRequest) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "AuthUsecase.RegenerateRecoveryCodes")
+	defer span.End()
+	u, err := a.authenticate(ctx, r.Email, r.Password, r.Ip)
+	if err != nil {
+		return nil, err
+	}
+	if u.TotpEnabledAt == nil {
+		return nil, user.ErrTwoFactorNotEnabled
+	}
+	if err := a.verifyCode(ctx, *u, r.Code); err != nil {
+		return nil, err
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := a.repo.ReplaceRecoveryCodes(ctx, u.ID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
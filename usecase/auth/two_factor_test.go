@@ -0,0 +1,241 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/common/auth"
+	"github.com/williamchang80/sea-apd/common/constants/user_role"
+	domain "github.com/williamchang80/sea-apd/domain/user"
+	request "github.com/williamchang80/sea-apd/dto/request/auth"
+	"github.com/williamchang80/sea-apd/mocks/repository/user"
+	memory "github.com/williamchang80/sea-apd/repository/memory/auth"
+)
+
+func mockTotpCode(t *testing.T) string {
+	code, err := auth.TotpCode(user.MockTotpSecret, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+func TestAuthUsecase_Login(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name          string
+		args          request.LoginRequest
+		requiredRoles []string
+		wantChallenge bool
+		wantErr       error
+	}{
+		{
+			name: "success",
+			args: request.LoginRequest{Email: "mock@mock.com", Password: user.MockPassword},
+		},
+		{
+			name:          "success with challenge",
+			args:          request.LoginRequest{Email: user.MockTwoFactorEmail, Password: user.MockPassword},
+			wantChallenge: true,
+		},
+		{
+			name:          "success without required two-factor of other role",
+			args:          request.LoginRequest{Email: "mock@mock.com", Password: user.MockPassword},
+			requiredRoles: []string{user_role.ToString(user_role.ADMIN)},
+		},
+		{
+			name:          "failed when role requires two-factor",
+			args:          request.LoginRequest{Email: user.MockAdminEmail, Password: user.MockPassword},
+			requiredRoles: []string{user_role.ToString(user_role.ADMIN)},
+			wantErr:       domain.ErrTwoFactorRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := user.NewMockRepository(ctrl)
+			repo.TwoFactorRoles = tt.requiredRoles
+			got, err := NewAuthUsecase(repo, memory.NewLoginAttemptStore()).Login(context.Background(), tt.args)
+			if err != tt.wantErr {
+				t.Fatalf("AuthUsecase.Login() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if (got.ChallengeToken != "") != tt.wantChallenge || (got.Token != "") == tt.wantChallenge {
+				t.Errorf("AuthUsecase.Login() = %+v, wantChallenge %v", got, tt.wantChallenge)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_VerifyTwoFactorLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	challenge, _ := auth.GeneratePurposeToken(auth.LoginChallengePurpose, user.MockTwoFactorUserId,
+		time.Now().Add(ChallengeLifetime))
+	expired, _ := auth.GeneratePurposeToken(auth.LoginChallengePurpose, user.MockTwoFactorUserId,
+		time.Now().Add(-time.Minute))
+	verification, _ := auth.GeneratePurposeToken(auth.EmailVerificationPurpose, user.MockTwoFactorUserId,
+		time.Now().Add(ChallengeLifetime))
+	tests := []struct {
+		name    string
+		args    request.TwoFactorLoginRequest
+		wantErr bool
+	}{
+		{
+			name:    "success with totp code",
+			args:    request.TwoFactorLoginRequest{ChallengeToken: challenge, Code: mockTotpCode(t)},
+			wantErr: false,
+		},
+		{
+			name:    "success with recovery code",
+			args:    request.TwoFactorLoginRequest{ChallengeToken: challenge, Code: "ABCD EFGH"},
+			wantErr: false,
+		},
+		{
+			name:    "failed with wrong code",
+			args:    request.TwoFactorLoginRequest{ChallengeToken: challenge, Code: "000000x"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with expired challenge",
+			args:    request.TwoFactorLoginRequest{ChallengeToken: expired, Code: mockTotpCode(t)},
+			wantErr: true,
+		},
+		{
+			name:    "failed with token of other purpose",
+			args:    request.TwoFactorLoginRequest{ChallengeToken: verification, Code: mockTotpCode(t)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAuthUsecase(user.NewMockRepository(ctrl), memory.NewLoginAttemptStore())
+			got, err := a.VerifyTwoFactorLogin(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AuthUsecase.VerifyTwoFactorLogin() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got == "" {
+				t.Errorf("AuthUsecase.VerifyTwoFactorLogin() returned no token")
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_EnrollTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.LoginRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    request.LoginRequest{Email: "mock@mock.com", Password: user.MockPassword},
+			wantErr: false,
+		},
+		{
+			name:    "failed with wrong password",
+			args:    request.LoginRequest{Email: "mock@mock.com", Password: "wrong"},
+			wantErr: true,
+		},
+		{
+			name:    "failed when already enabled",
+			args:    request.LoginRequest{Email: user.MockTwoFactorEmail, Password: user.MockPassword},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAuthUsecase(user.NewMockRepository(ctrl), memory.NewLoginAttemptStore()).EnrollTwoFactor(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AuthUsecase.EnrollTwoFactor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (got.Secret == "" || got.Uri == "") {
+				t.Errorf("AuthUsecase.EnrollTwoFactor() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_ConfirmTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.TwoFactorRequest
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: request.TwoFactorRequest{Email: user.MockEnrollingEmail, Password: user.MockPassword,
+				Code: mockTotpCode(t)},
+			wantErr: false,
+		},
+		{
+			name: "failed with wrong code",
+			args: request.TwoFactorRequest{Email: user.MockEnrollingEmail, Password: user.MockPassword,
+				Code: "abcdef"},
+			wantErr: true,
+		},
+		{
+			name:    "failed without enrolment",
+			args:    request.TwoFactorRequest{Email: "mock@mock.com", Password: user.MockPassword, Code: mockTotpCode(t)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAuthUsecase(user.NewMockRepository(ctrl), memory.NewLoginAttemptStore()).ConfirmTwoFactor(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AuthUsecase.ConfirmTwoFactor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(got) != RecoveryCodeCount {
+				t.Errorf("AuthUsecase.ConfirmTwoFactor() returned %d recovery codes", len(got))
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_DisableTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name          string
+		args          request.TwoFactorRequest
+		requiredRoles []string
+		wantErr       bool
+	}{
+		{
+			name: "success",
+			args: request.TwoFactorRequest{Email: user.MockTwoFactorEmail, Password: user.MockPassword,
+				Code: user.MockRecoveryCode},
+			wantErr: false,
+		},
+		{
+			name: "failed when role requires two-factor",
+			args: request.TwoFactorRequest{Email: user.MockTwoFactorEmail, Password: user.MockPassword,
+				Code: user.MockRecoveryCode},
+			requiredRoles: []string{user_role.ToString(user_role.CUSTOMER)},
+			wantErr:       true,
+		},
+		{
+			name:    "failed when not enabled",
+			args:    request.TwoFactorRequest{Email: "mock@mock.com", Password: user.MockPassword, Code: user.MockRecoveryCode},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := user.NewMockRepository(ctrl)
+			repo.TwoFactorRoles = tt.requiredRoles
+			if err := NewAuthUsecase(repo, memory.NewLoginAttemptStore()).DisableTwoFactor(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("AuthUsecase.DisableTwoFactor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package backoffice
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/williamchang80/sea-apd/common/constants/admin_action"
+	"github.com/williamchang80/sea-apd/common/constants/merchant_status"
+	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
+	"github.com/williamchang80/sea-apd/common/constants/user_role"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/auth"
+	"github.com/williamchang80/sea-apd/domain/backoffice"
+	"github.com/williamchang80/sea-apd/domain/merchant"
+	"github.com/williamchang80/sea-apd/domain/transaction"
+	"github.com/williamchang80/sea-apd/domain/user"
+	request "github.com/williamchang80/sea-apd/dto/request/backoffice"
+	transaction2 "github.com/williamchang80/sea-apd/dto/request/transaction"
+)
+
+const (
+	dateLayout   = "2006-01-02"
+	defaultLimit = 20
+	maxLimit     = 100
+
+	targetUser        = "user"
+	targetMerchant    = "merchant"
+	targetTransaction = "transaction"
+)
+
+var ErrEmptyAdminId = apperror.Validation("admin id cannot be empty")
+
+type BackofficeUsecase struct {
+	repo               backoffice.BackofficeRepository
+	userUsecase        user.UserUsecase
+	transactionUsecase transaction.TransactionUsecase
+	authUsecase        auth.AuthUsecase
+}
+
+func NewBackofficeUsecase(repo backoffice.BackofficeRepository, u user.UserUsecase,
+	t transaction.TransactionUsecase, a auth.AuthUsecase) backoffice.BackofficeUsecase {
+	return &BackofficeUsecase{repo: repo, userUsecase: u, transactionUsecase: t, authUsecase: a}
+}
+
+func pagination(limit int, offset int) (int, int) {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+func parseDate(name string, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, apperror.Validation(name + " must be a date like " + dateLayout)
+	}
+	return &t, nil
+}
+
+// audit records a read of the back-office, writes are audited by the repository
+// together with the change
+func (b *BackofficeUsecase) audit(ctx context.Context, adminId string, action admin_action.AdminAction, targetType string,
+	targetId string, detail string) error {
+	return b.repo.CreateAudit(ctx, backoffice.AdminAudit{
+		AdminId:    adminId,
+		Action:     admin_action.ToString(action),
+		TargetType: targetType,
+		TargetId:   targetId,
+		Detail:     detail,
+	})
+}
+
+func (b *BackofficeUsecase) SearchUsers(ctx context.Context, r request.UserSearchRequest) ([]user.User, error) {
+	ctx, span := tracing.Start(ctx, "BackofficeUsecase.SearchUsers")
+	defer span.End()
+	if r.AdminId == "" {
+		return nil, ErrEmptyAdminId
+	}
+	query := backoffice.UserQuery{Keyword: strings.TrimSpace(r.Query)}
+	query.Limit, query.Offset = pagination(r.Limit, r.Offset)
+	if r.Role != "" {
+		role := user_role.ParseToEnum(strings.ToLower(r.Role))
+		if role == user_role.OTHER {
+			return nil, apperror.Validation("role is not valid")
+		}
+		query.Role = user_role.ToString(role)
+	}
+	if r.Banned != "" {
+		banned, err := strconv.ParseBool(r.Banned)
+		if err != nil {
+			return nil, apperror.Validation("banned must be true or false")
+		}
+		query.Banned = &banned
+	}
+	if err := b.audit(ctx, r.AdminId, admin_action.SEARCH_USERS, targetUser, "",
+		fmt.Sprintf("%+v", query)); err != nil {
+		return nil, err
+	}
+	users, err := b.repo.SearchUsers(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		users[i].Password = ""
+	}
+	return users, nil
+}
+
+func (b *BackofficeUsecase) SearchMerchants(ctx context.Context, r request.MerchantSearchRequest) ([]merchant.Merchant, error) {
+	ctx, span := tracing.Start(ctx, "BackofficeUsecase.SearchMerchants")
+	defer span.End()
+	if r.AdminId == "" {
+		return nil, ErrEmptyAdminId
+	}
+	query := backoffice.MerchantQuery{Keyword: strings.TrimSpace(r.Query)}
+	query.Limit, query.Offset = pagination(r.Limit, r.Offset)
+	if r.Status != "" {
+		status := merchant_status.ParseToEnum(strings.ToLower(r.Status))
+		if status == merchant_status.OTHER {
+			return nil, apperror.Validation("status is not valid")
+		}
+		query.Status = merchant_status.ToString(status)
+	}
+	if err := b.audit(ctx, r.AdminId, admin_action.SEARCH_MERCHANTS, targetMerchant, "",
+		fmt.Sprintf("%+v", query)); err != nil {
+		return nil, err
+	}
+	merchants, err := b.repo.SearchMerchants(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return merchants, nil
+}
+
+func (b *BackofficeUsecase) SearchTransactions(ctx context.Context, r request.TransactionSearchRequest) ([]transaction.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "BackofficeUsecase.SearchTransactions")
+	defer span.End()
+	if r.AdminId == "" {
+		return nil, ErrEmptyAdminId
+	}
+	query := backoffice.TransactionQuery{MerchantId: r.MerchantId, CustomerId: r.CustomerId}
+	query.Limit, query.Offset = pagination(r.Limit, r.Offset)
+	if r.Status != "" {
+		status := transaction_status.ParseToEnum(strings.ToLower(r.Status))
+		if status == transaction_status.OTHER {
+			return nil, apperror.Validation("status is not valid")
+		}
+		query.Status = transaction_status.ToString(status)
+	}
+	from, err := parseDate("from", r.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseDate("to", r.To)
+	if err != nil {
+		return nil, err
+	}
+	if to != nil {
+		end := to.AddDate(0, 0, 1)
+		to = &end
+	}
+	query.From, query.To = from, to
+	if err := b.audit(ctx, r.AdminId, admin_action.SEARCH_TRANSACTIONS, targetTransaction, "",
+		fmt.Sprintf("%+v", r)); err != nil {
+		return nil, err
+	}
+	transactions, err := b.repo.SearchTransactions(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func (b *BackofficeUsecase) GetTransactionDetail(ctx context.Context, adminId string, transactionId string) (*backoffice.TransactionDetail, error) {
+	ctx, span := tracing.Start(ctx, "BackofficeUsecase.GetTransactionDetail")
+	defer span.End()
+	if adminId == "" {
+		return nil, ErrEmptyAdminId
+	}
+	tran, err := b.transactionUsecase.GetTransactionById(ctx, transactionId)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := b.transactionUsecase.GetStatusChanges(ctx, transactionId)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.audit(ctx, adminId, admin_action.VIEW_TRANSACTION, targetTransaction, transactionId, ""); err != nil {
+		return nil, err
+	}
+	return &backoffice.TransactionDetail{Transaction: *tran, StatusChanges: changes}, nil
+}
+
+// ForceTransactionStatus moves the transaction to any status, the change and its reason
+// end up in the status history of the transaction and in the audit log
+func (b *BackofficeUsecase) ForceTransactionStatus(ctx context.Context, r transaction2.ForceTransactionStatusRequest) error {
+	ctx, span := tracing.Start(ctx, "BackofficeUsecase.ForceTransactionStatus")
+	defer span.End()
+	if r.AdminId == "" {
+		return ErrEmptyAdminId
+	}
+	change, err := b.transactionUsecase.ForceTransactionStatus(ctx, r)
+	if err != nil {
+		return err
+	}
+	return b.repo.CreateAudit(ctx, backoffice.AdminAudit{
+		AdminId:    r.AdminId,
+		Action:     admin_action.ToString(admin_action.FORCE_TRANSACTION_STATUS),
+		TargetType: targetTransaction,
+		TargetId:   r.TransactionId,
+		Reason:     change.Reason,
+		Detail:     change.FromStatus + " -> " + change.ToStatus,
+	})
+}
+
+// BanUser stops the user from logging in, admins cannot be banned
+func (b *BackofficeUsecase) BanUser(ctx context.Context, r request.BanUserRequest) error {
+	ctx, span := tracing.Start(ctx, "BackofficeUsecase.BanUser")
+	defer span.End()
+	if r.AdminId == "" {
+		return ErrEmptyAdminId
+	}
+	reason := strings.TrimSpace(r.Reason)
+	if reason == "" {
+		return apperror.Validation("ban reason cannot be empty")
+	}
+	u, err := b.userUsecase.GetUserById(ctx, r.UserId)
+	if err != nil || u == nil {
+		return apperror.NotFound("user not found")
+	}
+	if user_role.ParseToEnum(u.Role) == user_role.ADMIN {
+		return apperror.Forbidden("admins cannot be banned")
+	}
+	if u.BannedAt != nil {
+		return apperror.Conflict("user is already banned")
+	}
+	now := time.Now()
+	return b.repo.SetUserBan(ctx, r.UserId, &now, reason, backoffice.AdminAudit{
+		AdminId:    r.AdminId,
+		Action:     admin_action.ToString(admin_action.BAN_USER),
+		TargetType: targetUser,
+		TargetId:   r.UserId,
+		Reason:     reason,
+	})
+}
+
+func (b *BackofficeUsecase) UnbanUser(ctx context.Context, r request.BanUserRequest) error {
+	ctx, span := tracing.Start(ctx, "BackofficeUsecase.UnbanUser")
+	defer span.End()
+	if r.AdminId == "" {
+		return ErrEmptyAdminId
+	}
+	u, err := b.userUsecase.GetUserById(ctx, r.UserId)
+	if err != nil || u == nil {
+		return apperror.NotFound("user not found")
+	}
+	if u.BannedAt == nil {
+		return apperror.Conflict("user is not banned")
+	}
+	return b.repo.SetUserBan(ctx, r.UserId, nil, "", backoffice.AdminAudit{
+		AdminId:    r.AdminId,
+		Action:     admin_action.ToString(admin_action.UNBAN_USER),
+		TargetType: targetUser,
+		TargetId:   r.UserId,
+		Reason:     strings.TrimSpace(r.Reason),
+		Detail:     "ban reason was: " + u.BanReason,
+	})
+}
+
+// UnlockUser lifts the lockout after too many failed logins before it runs out
+func (b *BackofficeUsecase) UnlockUser(ctx context.Context, r request.BanUserRequest) error {
+	ctx, span := tracing.Start(ctx, "BackofficeUsecase.UnlockUser")
+	defer span.End()
+	if r.AdminId == "" {
+		return ErrEmptyAdminId
+	}
+	u, err := b.userUsecase.GetUserById(ctx, r.UserId)
+	if err != nil || u == nil {
+		return apperror.NotFound("user not found")
+	}
+	if err := b.authUsecase.UnlockAccount(ctx, u.Email); err != nil {
+		return err
+	}
+	return b.repo.CreateAudit(ctx, backoffice.AdminAudit{
+		AdminId:    r.AdminId,
+		Action:     admin_action.ToString(admin_action.UNLOCK_USER),
+		TargetType: targetUser,
+		TargetId:   r.UserId,
+		Reason:     strings.TrimSpace(r.Reason),
+	})
+}
+
+// AdjustMerchantBalance credits or debits the merchant by hand, the memo is mandatory
+func (b *BackofficeUsecase) AdjustMerchantBalance(ctx context.Context, r request.AdjustBalanceRequest) error {
+	ctx, span := tracing.Start(ctx, "BackofficeUsecase.AdjustMerchantBalance")
+	defer span.End()
+	if r.AdminId == "" {
+		return ErrEmptyAdminId
+	}
+	if r.MerchantId == "" {
+		return apperror.Validation("merchant id cannot be empty")
+	}
+	if r.Amount == 0 {
+		return apperror.Validation("amount cannot be zero")
+	}
+	memo := strings.TrimSpace(r.Memo)
+	if memo == "" {
+		return apperror.Validation("memo cannot be empty")
+	}
+	return b.repo.AdjustMerchantBalance(ctx, backoffice.BalanceAdjustment{
+		MerchantId: r.MerchantId,
+		AdminId:    r.AdminId,
+		Amount:     r.Amount,
+		Memo:       memo,
+	}, backoffice.AdminAudit{
+		AdminId:    r.AdminId,
+		Action:     admin_action.ToString(admin_action.ADJUST_BALANCE),
+		TargetType: targetMerchant,
+		TargetId:   r.MerchantId,
+		Reason:     memo,
+		Detail:     strconv.Itoa(r.Amount),
+	})
+}
+
+func (b *BackofficeUsecase) GetPlatformKpis(ctx context.Context, adminId string) (*backoffice.PlatformKpis, error) {
+	ctx, span := tracing.Start(ctx, "BackofficeUsecase.GetPlatformKpis")
+	defer span.End()
+	if adminId == "" {
+		return nil, ErrEmptyAdminId
+	}
+	if err := b.audit(ctx, adminId, admin_action.VIEW_KPIS, "", "", ""); err != nil {
+		return nil, err
+	}
+	kpis, err := b.repo.GetPlatformKpis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return kpis, nil
+}
+
+// GetAudits lists the audit log, reading it is not audited itself
+func (b *BackofficeUsecase) GetAudits(ctx context.Context, r request.AuditSearchRequest) ([]backoffice.AdminAudit, error) {
+	ctx, span := tracing.Start(ctx, "BackofficeUsecase.GetAudits")
+	defer span.End()
+	if r.AdminId == "" {
+		return nil, ErrEmptyAdminId
+	}
+	query := backoffice.AuditQuery{AdminId: r.ActorId, TargetId: r.TargetId}
+	query.Limit, query.Offset = pagination(r.Limit, r.Offset)
+	audits, err := b.repo.GetAudits(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
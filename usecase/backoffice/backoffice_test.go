@@ -0,0 +1,290 @@
+package backoffice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
+	"github.com/williamchang80/sea-apd/domain/backoffice"
+	request "github.com/williamchang80/sea-apd/dto/request/backoffice"
+	transaction2 "github.com/williamchang80/sea-apd/dto/request/transaction"
+	backoffice2 "github.com/williamchang80/sea-apd/mocks/repository/backoffice"
+	user2 "github.com/williamchang80/sea-apd/mocks/repository/user"
+	"github.com/williamchang80/sea-apd/mocks/usecase/transaction"
+	"github.com/williamchang80/sea-apd/mocks/usecase/user"
+	memory "github.com/williamchang80/sea-apd/repository/memory/auth"
+	"github.com/williamchang80/sea-apd/usecase/auth"
+)
+
+const mockAdminId = "admin"
+
+func newMockUsecase(ctrl *gomock.Controller) backoffice.BackofficeUsecase {
+	return NewBackofficeUsecase(backoffice2.NewMockRepository(ctrl), user.NewMockUsecase(ctrl),
+		transaction.NewMockUsecase(ctrl), auth.NewAuthUsecase(user2.NewMockRepository(ctrl),
+			memory.NewLoginAttemptStore()))
+}
+
+func TestPagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		limit      int
+		offset     int
+		wantLimit  int
+		wantOffset int
+	}{
+		{name: "default", wantLimit: defaultLimit},
+		{name: "capped", limit: 1000, offset: 40, wantLimit: maxLimit, wantOffset: 40},
+		{name: "negative offset", limit: 5, offset: -1, wantLimit: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, offset := pagination(tt.limit, tt.offset)
+			if limit != tt.wantLimit || offset != tt.wantOffset {
+				t.Errorf("pagination() = %v, %v, want %v, %v", limit, offset, tt.wantLimit, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestBackofficeUsecase_SearchUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.UserSearchRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    request.UserSearchRequest{AdminId: mockAdminId, Query: "mock", Role: "Customer", Banned: "false"},
+			wantErr: false,
+		},
+		{
+			name:    "failed without admin",
+			args:    request.UserSearchRequest{Query: "mock"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with invalid role",
+			args:    request.UserSearchRequest{AdminId: mockAdminId, Role: "owner"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with invalid banned filter",
+			args:    request.UserSearchRequest{AdminId: mockAdminId, Banned: "maybe"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, err := newMockUsecase(ctrl).SearchUsers(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BackofficeUsecase.SearchUsers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for _, u := range users {
+				if u.Password != "" {
+					t.Errorf("BackofficeUsecase.SearchUsers() returned a password hash")
+				}
+			}
+		})
+	}
+}
+
+func TestBackofficeUsecase_SearchTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.TransactionSearchRequest
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: request.TransactionSearchRequest{AdminId: mockAdminId, Status: "accepted",
+				From: "2020-01-01", To: "2020-01-31"},
+			wantErr: false,
+		},
+		{
+			name:    "failed with invalid status",
+			args:    request.TransactionSearchRequest{AdminId: mockAdminId, Status: "lost"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with invalid date",
+			args:    request.TransactionSearchRequest{AdminId: mockAdminId, From: "01/01/2020"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newMockUsecase(ctrl).SearchTransactions(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("BackofficeUsecase.SearchTransactions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBackofficeUsecase_ForceTransactionStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    transaction2.ForceTransactionStatusRequest
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: transaction2.ForceTransactionStatusRequest{TransactionId: "1", AdminId: mockAdminId,
+				Status: transaction_status.ACCEPTED, Reason: "paid offline"},
+			wantErr: false,
+		},
+		{
+			name: "failed without reason",
+			args: transaction2.ForceTransactionStatusRequest{TransactionId: "1", AdminId: mockAdminId,
+				Status: transaction_status.ACCEPTED},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := newMockUsecase(ctrl).ForceTransactionStatus(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("BackofficeUsecase.ForceTransactionStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBackofficeUsecase_BanUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.BanUserRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    request.BanUserRequest{AdminId: mockAdminId, UserId: "1", Reason: "fraud"},
+			wantErr: false,
+		},
+		{
+			name:    "failed without reason",
+			args:    request.BanUserRequest{AdminId: mockAdminId, UserId: "1"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with admin",
+			args:    request.BanUserRequest{AdminId: mockAdminId, UserId: user.MockAdminUserId, Reason: "fraud"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with banned user",
+			args:    request.BanUserRequest{AdminId: mockAdminId, UserId: user.MockBannedUserId, Reason: "fraud"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := newMockUsecase(ctrl).BanUser(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("BackofficeUsecase.BanUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBackofficeUsecase_UnbanUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.BanUserRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    request.BanUserRequest{AdminId: mockAdminId, UserId: user.MockBannedUserId},
+			wantErr: false,
+		},
+		{
+			name:    "failed with user that is not banned",
+			args:    request.BanUserRequest{AdminId: mockAdminId, UserId: "1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := newMockUsecase(ctrl).UnbanUser(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("BackofficeUsecase.UnbanUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBackofficeUsecase_AdjustMerchantBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.AdjustBalanceRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    request.AdjustBalanceRequest{AdminId: mockAdminId, MerchantId: "1", Amount: -500, Memo: "chargeback"},
+			wantErr: false,
+		},
+		{
+			name:    "failed with zero amount",
+			args:    request.AdjustBalanceRequest{AdminId: mockAdminId, MerchantId: "1", Memo: "chargeback"},
+			wantErr: true,
+		},
+		{
+			name:    "failed without memo",
+			args:    request.AdjustBalanceRequest{AdminId: mockAdminId, MerchantId: "1", Amount: 500},
+			wantErr: true,
+		},
+		{
+			name:    "failed without admin",
+			args:    request.AdjustBalanceRequest{MerchantId: "1", Amount: 500, Memo: "goodwill"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := newMockUsecase(ctrl).AdjustMerchantBalance(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("BackofficeUsecase.AdjustMerchantBalance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBackofficeUsecase_UnlockUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.BanUserRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    request.BanUserRequest{AdminId: mockAdminId, UserId: "1"},
+			wantErr: false,
+		},
+		{
+			name:    "failed without admin id",
+			args:    request.BanUserRequest{UserId: "1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newMockUsecase(ctrl)
+			if err := b.UnlockUser(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("BackofficeUsecase.UnlockUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
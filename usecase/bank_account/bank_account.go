@@ -0,0 +1,265 @@
+package bank_account
+
+import (
+	"context"
+	"strings"
+
+	"github.com/williamchang80/sea-apd/common/auth"
+	"github.com/williamchang80/sea-apd/common/bank"
+	"github.com/williamchang80/sea-apd/common/constants/bank_account_status"
+	"github.com/williamchang80/sea-apd/common/constants/mailer_type"
+	"github.com/williamchang80/sea-apd/common/mailer"
+	"github.com/williamchang80/sea-apd/common/mailer/factory"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/bank_account"
+	"github.com/williamchang80/sea-apd/domain/merchant"
+	"github.com/williamchang80/sea-apd/domain/user"
+	request "github.com/williamchang80/sea-apd/dto/request/bank_account"
+)
+
+const (
+	accountAdded   = "added"
+	accountChanged = "changed"
+	accountRemoved = "removed"
+	accountDefault = "made default"
+)
+
+var (
+	ErrWrongPassword     = apperror.New(apperror.FORBIDDEN, "wrong_password", "password is not correct")
+	ErrAccountNotFound   = apperror.New(apperror.NOT_FOUND, "bank_account_not_found", "bank account not found")
+	ErrUnverifiedAccount = apperror.New(apperror.FORBIDDEN, "bank_account_unverified", "withdrawals can only go to verified bank accounts")
+	ErrNoDefaultAccount  = apperror.New(apperror.NOT_FOUND, "no_default_bank_account", "merchant has no default bank account")
+	ErrDuplicateAccount  = apperror.New(apperror.CONFLICT, "bank_account_exists", "bank account is already registered")
+	ErrEmptyHolderName   = apperror.Validation("holder name cannot be empty")
+)
+
+type BankAccountUsecase struct {
+	repo            bank_account.BankAccountRepository
+	merchantUsecase merchant.MerchantUsecase
+	userUsecase     user.UserUsecase
+}
+
+func NewBankAccountUsecase(repo bank_account.BankAccountRepository, m merchant.MerchantUsecase,
+	u user.UserUsecase) bank_account.BankAccountUsecase {
+	return &BankAccountUsecase{repo: repo, merchantUsecase: m, userUsecase: u}
+}
+
+// convertToDomain validates the account against the format of its bank
+func convertToDomain(merchantId string, bankCode string, accountNumber string,
+	holderName string) (bank_account.BankAccount, error) {
+	b, err := bank.GetBank(strings.TrimSpace(bankCode))
+	if err != nil {
+		return bank_account.BankAccount{}, err
+	}
+	number := bank.NormalizeAccountNumber(accountNumber)
+	if err := b.ValidateAccountNumber(number); err != nil {
+		return bank_account.BankAccount{}, err
+	}
+	holderName = strings.Join(strings.Fields(holderName), " ")
+	if holderName == "" {
+		return bank_account.BankAccount{}, ErrEmptyHolderName
+	}
+	return bank_account.BankAccount{
+		MerchantId:    merchantId,
+		BankCode:      b.Code,
+		AccountNumber: number,
+		HolderName:    holderName,
+		Status:        bank_account_status.ToString(bank_account_status.PENDING),
+	}, nil
+}
+
+// reauthenticate checks the password of the user owning the merchant, every change of
+// a payout destination has to be confirmed with it
+func (b *BankAccountUsecase) reauthenticate(ctx context.Context, merchantId string, password string) (*user.User, error) {
+	merch, err := b.merchantUsecase.GetMerchantById(ctx, merchantId)
+	if err != nil || merch == nil {
+		return nil, apperror.NotFound("merchant not found")
+	}
+	u, err := b.userUsecase.GetUserById(ctx, merch.UserId)
+	if err != nil || u == nil {
+		return nil, apperror.NotFound("user not found")
+	}
+	if password == "" || !auth.IsMatchedPassword(u.Password, password) {
+		return nil, ErrWrongPassword
+	}
+	return u, nil
+}
+
+// notify mails the merchant owner about the change, a failing mail does not undo it
+func notify(ctx context.Context, u user.User, account bank_account.BankAccount, action string) {
+	mails := factory.CreateMailerFactory(mailer_type.BANK_ACCOUNT).CreateMail(u, account, action)
+	mailer.SendEmail(ctx, mails)
+}
+
+func (b *BankAccountUsecase) getMerchantAccount(ctx context.Context, merchantId string, accountId string) (*bank_account.BankAccount, error) {
+	account, err := b.repo.GetBankAccountById(ctx, accountId)
+	if err != nil || account == nil || account.MerchantId != merchantId {
+		return nil, ErrAccountNotFound
+	}
+	return account, nil
+}
+
+func (b *BankAccountUsecase) validateUnique(ctx context.Context, account bank_account.BankAccount, accountId string) error {
+	accounts, err := b.repo.GetBankAccountsByMerchant(ctx, account.MerchantId)
+	if err != nil {
+		return err
+	}
+	for _, a := range accounts {
+		if a.ID != accountId && a.BankCode == account.BankCode && a.AccountNumber == account.AccountNumber {
+			return ErrDuplicateAccount
+		}
+	}
+	return nil
+}
+
+func (b *BankAccountUsecase) GetSupportedBanks(ctx context.Context) []bank.Bank {
+	ctx, span := tracing.Start(ctx, "BankAccountUsecase.GetSupportedBanks")
+	defer span.End()
+	return bank.SupportedBanks
+}
+
+func (b *BankAccountUsecase) GetBankAccounts(ctx context.Context, merchantId string) ([]bank_account.BankAccount, error) {
+	ctx, span := tracing.Start(ctx, "BankAccountUsecase.GetBankAccounts")
+	defer span.End()
+	if merchantId == "" {
+		return nil, apperror.Validation("merchant id cannot be empty")
+	}
+	accounts, err := b.repo.GetBankAccountsByMerchant(ctx, merchantId)
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// CreateBankAccount registers a pending account, the first account of a merchant becomes its default
+func (b *BankAccountUsecase) CreateBankAccount(ctx context.Context, r request.BankAccountRequest) error {
+	ctx, span := tracing.Start(ctx, "BankAccountUsecase.CreateBankAccount")
+	defer span.End()
+	account, err := convertToDomain(r.MerchantId, r.BankCode, r.AccountNumber, r.HolderName)
+	if err != nil {
+		return err
+	}
+	u, err := b.reauthenticate(ctx, r.MerchantId, r.Password)
+	if err != nil {
+		return err
+	}
+	if err := b.validateUnique(ctx, account, ""); err != nil {
+		return err
+	}
+	accounts, err := b.repo.GetBankAccountsByMerchant(ctx, r.MerchantId)
+	if err != nil {
+		return err
+	}
+	account.IsDefault = len(accounts) == 0
+	if err := b.repo.CreateBankAccount(ctx, account); err != nil {
+		return err
+	}
+	notify(ctx, *u, account, accountAdded)
+	return nil
+}
+
+// UpdateBankAccount changes the account details, the account needs to be verified again
+func (b *BankAccountUsecase) UpdateBankAccount(ctx context.Context, r request.UpdateBankAccountRequest) error {
+	ctx, span := tracing.Start(ctx, "BankAccountUsecase.UpdateBankAccount")
+	defer span.End()
+	account, err := convertToDomain(r.MerchantId, r.BankCode, r.AccountNumber, r.HolderName)
+	if err != nil {
+		return err
+	}
+	u, err := b.reauthenticate(ctx, r.MerchantId, r.Password)
+	if err != nil {
+		return err
+	}
+	if _, err := b.getMerchantAccount(ctx, r.MerchantId, r.AccountId); err != nil {
+		return err
+	}
+	if err := b.validateUnique(ctx, account, r.AccountId); err != nil {
+		return err
+	}
+	if err := b.repo.UpdateBankAccount(ctx, r.AccountId, account); err != nil {
+		return err
+	}
+	notify(ctx, *u, account, accountChanged)
+	return nil
+}
+
+func (b *BankAccountUsecase) DeleteBankAccount(ctx context.Context, r request.BankAccountActionRequest) error {
+	ctx, span := tracing.Start(ctx, "BankAccountUsecase.DeleteBankAccount")
+	defer span.End()
+	u, err := b.reauthenticate(ctx, r.MerchantId, r.Password)
+	if err != nil {
+		return err
+	}
+	account, err := b.getMerchantAccount(ctx, r.MerchantId, r.AccountId)
+	if err != nil {
+		return err
+	}
+	if err := b.repo.DeleteBankAccount(ctx, r.AccountId); err != nil {
+		return err
+	}
+	notify(ctx, *u, *account, accountRemoved)
+	return nil
+}
+
+func (b *BankAccountUsecase) SetDefaultBankAccount(ctx context.Context, r request.BankAccountActionRequest) error {
+	ctx, span := tracing.Start(ctx, "BankAccountUsecase.SetDefaultBankAccount")
+	defer span.End()
+	u, err := b.reauthenticate(ctx, r.MerchantId, r.Password)
+	if err != nil {
+		return err
+	}
+	account, err := b.getMerchantAccount(ctx, r.MerchantId, r.AccountId)
+	if err != nil {
+		return err
+	}
+	if err := b.repo.SetDefaultBankAccount(ctx, r.MerchantId, r.AccountId); err != nil {
+		return err
+	}
+	notify(ctx, *u, *account, accountDefault)
+	return nil
+}
+
+func (b *BankAccountUsecase) VerifyBankAccount(ctx context.Context, r request.VerifyBankAccountRequest) error {
+	ctx, span := tracing.Start(ctx, "BankAccountUsecase.VerifyBankAccount")
+	defer span.End()
+	if r.Status != bank_account_status.VERIFIED && r.Status != bank_account_status.REJECTED {
+		return apperror.Validation("status must be verified or rejected")
+	}
+	if _, err := b.repo.GetBankAccountById(ctx, r.AccountId); err != nil {
+		return ErrAccountNotFound
+	}
+	return b.repo.UpdateBankAccountStatus(ctx, r.AccountId, bank_account_status.ToString(r.Status))
+}
+
+// GetPayoutAccount returns the verified account a withdrawal goes to, the default
+// account of the merchant when no account is given
+func (b *BankAccountUsecase) GetPayoutAccount(ctx context.Context, merchantId string, accountId string) (*bank_account.BankAccount, error) {
+	ctx, span := tracing.Start(ctx, "BankAccountUsecase.GetPayoutAccount")
+	defer span.End()
+	var account *bank_account.BankAccount
+	if accountId != "" {
+		a, err := b.getMerchantAccount(ctx, merchantId, accountId)
+		if err != nil {
+			return nil, err
+		}
+		account = a
+	} else {
+		accounts, err := b.GetBankAccounts(ctx, merchantId)
+		if err != nil {
+			return nil, err
+		}
+		for i := range accounts {
+			if accounts[i].IsDefault {
+				account = &accounts[i]
+			}
+		}
+		if account == nil {
+			return nil, ErrNoDefaultAccount
+		}
+	}
+	if bank_account_status.ParseToEnum(account.Status) != bank_account_status.VERIFIED {
+		return nil, ErrUnverifiedAccount
+	}
+	return account, nil
+}
@@ -0,0 +1,204 @@
+package bank_account
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/common/constants/bank_account_status"
+	request "github.com/williamchang80/sea-apd/dto/request/bank_account"
+	bank_account2 "github.com/williamchang80/sea-apd/mocks/repository/bank_account"
+	merchant2 "github.com/williamchang80/sea-apd/mocks/repository/merchant"
+	user2 "github.com/williamchang80/sea-apd/mocks/usecase/user"
+	merchantusecase "github.com/williamchang80/sea-apd/usecase/merchant"
+)
+
+func newUsecase(ctrl *gomock.Controller) *BankAccountUsecase {
+	u := user2.NewMockUsecase(ctrl)
+	m := merchantusecase.NewMerchantUsecase(merchant2.NewMockRepository(ctrl), u, nil)
+	return &BankAccountUsecase{repo: bank_account2.NewMockRepository(ctrl), merchantUsecase: m, userUsecase: u}
+}
+
+func TestBankAccountUsecase_CreateBankAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.BankAccountRequest
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: request.BankAccountRequest{MerchantId: bank_account2.MockMerchantId, BankCode: "008",
+				AccountNumber: "123-456-789-0123", HolderName: " Mock  Holder ", Password: user2.MockPassword},
+			wantErr: false,
+		},
+		{
+			name: "failed with wrong password",
+			args: request.BankAccountRequest{MerchantId: bank_account2.MockMerchantId, BankCode: "008",
+				AccountNumber: "1234567890123", HolderName: "Mock Holder", Password: "wrong"},
+			wantErr: true,
+		},
+		{
+			name: "failed with unsupported bank",
+			args: request.BankAccountRequest{MerchantId: bank_account2.MockMerchantId, BankCode: "999",
+				AccountNumber: "1234567890", HolderName: "Mock Holder", Password: user2.MockPassword},
+			wantErr: true,
+		},
+		{
+			name: "failed with account number of wrong length",
+			args: request.BankAccountRequest{MerchantId: bank_account2.MockMerchantId, BankCode: "014",
+				AccountNumber: "12345", HolderName: "Mock Holder", Password: user2.MockPassword},
+			wantErr: true,
+		},
+		{
+			name: "failed with repeated digits",
+			args: request.BankAccountRequest{MerchantId: bank_account2.MockMerchantId, BankCode: "014",
+				AccountNumber: "0000000000", HolderName: "Mock Holder", Password: user2.MockPassword},
+			wantErr: true,
+		},
+		{
+			name: "failed with duplicate account",
+			args: request.BankAccountRequest{MerchantId: bank_account2.MockMerchantId, BankCode: "014",
+				AccountNumber: bank_account2.MockAccountNumber, HolderName: "Mock Holder", Password: user2.MockPassword},
+			wantErr: true,
+		},
+		{
+			name: "failed with empty holder name",
+			args: request.BankAccountRequest{MerchantId: bank_account2.MockMerchantId, BankCode: "008",
+				AccountNumber: "1234567890123", HolderName: "  ", Password: user2.MockPassword},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := newUsecase(ctrl).CreateBankAccount(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("CreateBankAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBankAccountUsecase_SetDefaultBankAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.BankAccountActionRequest
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: request.BankAccountActionRequest{MerchantId: bank_account2.MockMerchantId,
+				AccountId: bank_account2.MockPendingAccountId, Password: user2.MockPassword},
+			wantErr: false,
+		},
+		{
+			name: "failed with wrong password",
+			args: request.BankAccountActionRequest{MerchantId: bank_account2.MockMerchantId,
+				AccountId: bank_account2.MockPendingAccountId, Password: ""},
+			wantErr: true,
+		},
+		{
+			name: "failed with account of another merchant",
+			args: request.BankAccountActionRequest{MerchantId: merchant2.MockDeclinedMerchantId,
+				AccountId: bank_account2.MockPendingAccountId, Password: user2.MockPassword},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := newUsecase(ctrl).SetDefaultBankAccount(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("SetDefaultBankAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBankAccountUsecase_VerifyBankAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.VerifyBankAccountRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    request.VerifyBankAccountRequest{AccountId: bank_account2.MockPendingAccountId, Status: bank_account_status.VERIFIED},
+			wantErr: false,
+		},
+		{
+			name:    "failed with pending status",
+			args:    request.VerifyBankAccountRequest{AccountId: bank_account2.MockPendingAccountId, Status: bank_account_status.PENDING},
+			wantErr: true,
+		},
+		{
+			name:    "failed with unknown account",
+			args:    request.VerifyBankAccountRequest{AccountId: "unknown", Status: bank_account_status.REJECTED},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := newUsecase(ctrl).VerifyBankAccount(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("VerifyBankAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBankAccountUsecase_GetPayoutAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	type args struct {
+		merchantId string
+		accountId  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "success with default account",
+			args:    args{merchantId: bank_account2.MockMerchantId},
+			want:    bank_account2.MockVerifiedAccountId,
+			wantErr: false,
+		},
+		{
+			name:    "success with chosen account",
+			args:    args{merchantId: bank_account2.MockMerchantId, accountId: bank_account2.MockVerifiedAccountId},
+			want:    bank_account2.MockVerifiedAccountId,
+			wantErr: false,
+		},
+		{
+			name:    "failed with pending account",
+			args:    args{merchantId: bank_account2.MockMerchantId, accountId: bank_account2.MockPendingAccountId},
+			wantErr: true,
+		},
+		{
+			name:    "failed without default account",
+			args:    args{merchantId: "other"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with account of another merchant",
+			args:    args{merchantId: "other", accountId: bank_account2.MockVerifiedAccountId},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newUsecase(ctrl).GetPayoutAccount(context.Background(), tt.args.merchantId, tt.args.accountId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetPayoutAccount() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.ID != tt.want {
+				t.Errorf("GetPayoutAccount() got = %v, want %v", got.ID, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package mailer
+
+import (
+	"fmt"
+
+	"github.com/williamchang80/sea-apd/common/mailer"
+	"github.com/williamchang80/sea-apd/domain/bank_account"
+	"github.com/williamchang80/sea-apd/domain/user"
+)
+
+type BankAccountMailer struct {
+}
+
+func (b BankAccountMailer) CreateMail(i ...interface{}) []mailer.Mail {
+	u, _ := i[0].(user.User)
+	account, _ := i[1].(bank_account.BankAccount)
+	action, _ := i[2].(string)
+	return CreateBankAccountChangedMailer(u, account, action)
+}
+
+// maskAccountNumber keeps only the last four digits so the mail does not leak the account
+func maskAccountNumber(number string) string {
+	if len(number) <= 4 {
+		return number
+	}
+	masked := ""
+	for range number[:len(number)-4] {
+		masked += "*"
+	}
+	return masked + number[len(number)-4:]
+}
+
+func CreateBankAccountChangedMailer(u user.User, account bank_account.BankAccount, action string) []mailer.Mail {
+	bankAccountMailer := mailer.Mail{
+		Sender:    mailer.MailSender,
+		Subject:   "Your payout bank account was " + action,
+		Recipient: u.Email,
+		Body: fmt.Sprintf(`Hello %v, the payout bank account %v (%v) of your merchant was %v.
+		If you did not make this change, please contact us immediately`, u.Name,
+			maskAccountNumber(account.AccountNumber), account.HolderName, action),
+	}
+	return []mailer.Mail{
+		bankAccountMailer,
+	}
+}
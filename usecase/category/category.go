@@ -0,0 +1,180 @@
+package category
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/category"
+	"github.com/williamchang80/sea-apd/domain/product"
+	request "github.com/williamchang80/sea-apd/dto/request/category"
+)
+
+type CategoryUsecase struct {
+	repo           category.CategoryRepository
+	productUsecase product.ProductUsecase
+}
+
+func NewCategoryUsecase(repo category.CategoryRepository, productUsecase product.ProductUsecase) category.CategoryUsecase {
+	return &CategoryUsecase{repo: repo, productUsecase: productUsecase}
+}
+
+// Slugify turns a category name into its url friendly form, e.g. "Men's Shoes" into "men-s-shoes"
+func Slugify(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "-")
+}
+
+func convertCategoryRequestToDomain(name string, parentId string) (category.Category, error) {
+	slug := Slugify(name)
+	if slug == "" {
+		return category.Category{}, apperror.Validation("category name cannot be empty")
+	}
+	c := category.Category{
+		Name: strings.TrimSpace(name),
+		Slug: slug,
+	}
+	if parentId != "" {
+		c.ParentId = &parentId
+	}
+	return c, nil
+}
+
+// descendantIds returns the id of the category and every category below it
+func descendantIds(categoryId string, categories []category.Category) []string {
+	children := map[string][]string{}
+	for _, c := range categories {
+		if c.ParentId != nil {
+			children[*c.ParentId] = append(children[*c.ParentId], c.ID)
+		}
+	}
+	ids := []string{categoryId}
+	for i := 0; i < len(ids); i++ {
+		ids = append(ids, children[ids[i]]...)
+	}
+	return ids
+}
+
+// BuildTree nests categories under their parents and rolls product counts up so that
+// every category counts the products of all of its descendants
+func BuildTree(categories []category.Category, counts map[string]int) []category.Category {
+	exist := map[string]bool{}
+	for _, c := range categories {
+		exist[c.ID] = true
+	}
+	children := map[string][]category.Category{}
+	var roots []category.Category
+	for _, c := range categories {
+		if c.ParentId == nil || !exist[*c.ParentId] {
+			roots = append(roots, c)
+			continue
+		}
+		children[*c.ParentId] = append(children[*c.ParentId], c)
+	}
+	var build func(nodes []category.Category) []category.Category
+	build = func(nodes []category.Category) []category.Category {
+		tree := []category.Category{}
+		for _, node := range nodes {
+			node.Children = build(children[node.ID])
+			node.ProductCount = counts[node.ID]
+			for _, child := range node.Children {
+				node.ProductCount += child.ProductCount
+			}
+			tree = append(tree, node)
+		}
+		return tree
+	}
+	return build(roots)
+}
+
+func (c *CategoryUsecase) validateParent(ctx context.Context, categoryId string, parentId *string) error {
+	if parentId == nil {
+		return nil
+	}
+	if _, err := c.repo.GetCategoryById(ctx, *parentId); err != nil {
+		return apperror.NotFound("parent category not found")
+	}
+	if categoryId == "" {
+		return nil
+	}
+	categories, err := c.repo.GetCategories(ctx)
+	if err != nil {
+		return err
+	}
+	for _, id := range descendantIds(categoryId, categories) {
+		if id == *parentId {
+			return apperror.Validation("category cannot be moved under itself or its descendant")
+		}
+	}
+	return nil
+}
+
+func (c *CategoryUsecase) CreateCategory(ctx context.Context, request request.CategoryRequest) error {
+	ctx, span := tracing.Start(ctx, "CategoryUsecase.CreateCategory")
+	defer span.End()
+	cat, err := convertCategoryRequestToDomain(request.Name, request.ParentId)
+	if err != nil {
+		return err
+	}
+	if err := c.validateParent(ctx, "", cat.ParentId); err != nil {
+		return err
+	}
+	return c.repo.CreateCategory(ctx, cat)
+}
+
+func (c *CategoryUsecase) UpdateCategory(ctx context.Context, request request.UpdateCategoryRequest) error {
+	ctx, span := tracing.Start(ctx, "CategoryUsecase.UpdateCategory")
+	defer span.End()
+	if _, err := c.repo.GetCategoryById(ctx, request.CategoryId); err != nil {
+		return err
+	}
+	cat, err := convertCategoryRequestToDomain(request.Name, request.ParentId)
+	if err != nil {
+		return err
+	}
+	if err := c.validateParent(ctx, request.CategoryId, cat.ParentId); err != nil {
+		return err
+	}
+	return c.repo.UpdateCategory(ctx, request.CategoryId, cat)
+}
+
+func (c *CategoryUsecase) DeleteCategory(ctx context.Context, categoryId string) error {
+	ctx, span := tracing.Start(ctx, "CategoryUsecase.DeleteCategory")
+	defer span.End()
+	cat, err := c.repo.GetCategoryById(ctx, categoryId)
+	if err != nil {
+		return err
+	}
+	return c.repo.DeleteCategory(ctx, *cat)
+}
+
+func (c *CategoryUsecase) GetCategoryTree(ctx context.Context) ([]category.Category, error) {
+	ctx, span := tracing.Start(ctx, "CategoryUsecase.GetCategoryTree")
+	defer span.End()
+	categories, err := c.repo.GetCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := c.repo.GetProductCountByCategory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return BuildTree(categories, counts), nil
+}
+
+func (c *CategoryUsecase) GetProductsByCategory(ctx context.Context, categoryId string) ([]product.Product, error) {
+	ctx, span := tracing.Start(ctx, "CategoryUsecase.GetProductsByCategory")
+	defer span.End()
+	if _, err := c.repo.GetCategoryById(ctx, categoryId); err != nil {
+		return nil, err
+	}
+	categories, err := c.repo.GetCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.productUsecase.GetProductsByCategories(ctx, descendantIds(categoryId, categories))
+}
@@ -0,0 +1,113 @@
+package category
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/domain/category"
+	request "github.com/williamchang80/sea-apd/dto/request/category"
+	category2 "github.com/williamchang80/sea-apd/mocks/repository/category"
+	"github.com/williamchang80/sea-apd/mocks/usecase/product"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{name: "success", args: "Men's Shoes", want: "men-s-shoes"},
+		{name: "success with extra spaces", args: "  Home   & Living ", want: "home-living"},
+		{name: "success with empty name", args: " - ", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Slugify(tt.args); got != tt.want {
+				t.Errorf("Slugify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategoryUsecase_GetCategoryTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	u := NewCategoryUsecase(category2.NewMockRepository(ctrl), product.NewMockUsecase(ctrl))
+	tree, err := u.GetCategoryTree(context.Background())
+	if err != nil {
+		t.Fatalf("CategoryUsecase.GetCategoryTree() error = %v", err)
+	}
+	got := map[string]int{}
+	var walk func(nodes []category.Category)
+	walk = func(nodes []category.Category) {
+		for _, n := range nodes {
+			got[n.Slug] = n.ProductCount
+			walk(n.Children)
+		}
+	}
+	walk(tree)
+	want := map[string]int{"fashion": 5, "shoes": 5, "sneakers": 2, "books": 1}
+	if len(tree) != 2 || !reflect.DeepEqual(got, want) {
+		t.Errorf("CategoryUsecase.GetCategoryTree() counts = %v, want %v", got, want)
+	}
+}
+
+func TestDescendantIds(t *testing.T) {
+	categories, _ := category2.NewMockRepository(nil).GetCategories(context.Background())
+	tests := []struct {
+		name string
+		args string
+		want []string
+	}{
+		{name: "success with nested children", args: "1", want: []string{"1", "2", "3"}},
+		{name: "success with leaf", args: "3", want: []string{"3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := descendantIds(tt.args, categories); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("descendantIds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategoryUsecase_UpdateCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.UpdateCategoryRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    request.UpdateCategoryRequest{CategoryId: "3", Name: "Sneakers", ParentId: "1"},
+			wantErr: false,
+		},
+		{
+			name:    "failed with parent as descendant",
+			args:    request.UpdateCategoryRequest{CategoryId: "1", Name: "Fashion", ParentId: "3"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with unknown parent",
+			args:    request.UpdateCategoryRequest{CategoryId: "1", Name: "Fashion", ParentId: "99"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with empty name",
+			args:    request.UpdateCategoryRequest{CategoryId: "1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewCategoryUsecase(category2.NewMockRepository(ctrl), product.NewMockUsecase(ctrl))
+			if err := u.UpdateCategory(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("CategoryUsecase.UpdateCategory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
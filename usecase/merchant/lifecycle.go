@@ -0,0 +1,174 @@
+package merchant
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/williamchang80/sea-apd/common/constants/merchant_status"
+	"github.com/williamchang80/sea-apd/common/constants/user_role"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/merchant"
+	request "github.com/williamchang80/sea-apd/dto/request/merchant"
+	user2 "github.com/williamchang80/sea-apd/dto/request/user"
+)
+
+// SystemActorId is recorded as the actor of status changes nobody asked for, like the
+// end of a suspension
+const SystemActorId = "system"
+
+var ErrUnsettledBalance = apperror.New(apperror.CONFLICT, "unsettled_balance", "merchant balance has to be withdrawn and every order completed before closing")
+
+// clearedSuspension resets the suspension columns when a merchant leaves SUSPENDED
+var clearedSuspension = map[string]interface{}{
+	"suspension_reason": "",
+	"suspended_until":   nil,
+}
+
+// demoteOwner takes the merchant role away from the owner unless another merchant of
+// the owner is still accepted
+func (m MerchantUsecase) demoteOwner(ctx context.Context, merch merchant.Merchant) error {
+	merchants, err := m.mc.GetMerchantsByUser(ctx, merch.UserId)
+	if err != nil {
+		return err
+	}
+	for _, other := range merchants {
+		if other.ID != merch.ID && merchant_status.ParseToEnum(other.Approval) == merchant_status.ACCEPTED {
+			return nil
+		}
+	}
+	return m.usecase.UpdateUserRole(ctx, user2.UpdateUserRoleRequest{Role: user_role.CUSTOMER,
+		UserId: merch.UserId})
+}
+
+func (m MerchantUsecase) promoteOwner(ctx context.Context, merch merchant.Merchant) error {
+	return m.usecase.UpdateUserRole(ctx, user2.UpdateUserRoleRequest{Role: user_role.MERCHANT,
+		UserId: merch.UserId})
+}
+
+func (m MerchantUsecase) reactivate(ctx context.Context, merch merchant.Merchant, actorId string, reason string) error {
+	if err := m.mc.ChangeMerchantStatus(ctx, merchant.MerchantReview{
+		MerchantId: merch.ID,
+		ActorId:    actorId,
+		FromStatus: merch.Approval,
+		ToStatus:   merchant_status.ToString(merchant_status.ACCEPTED),
+		Reason:     reason,
+	}, clearedSuspension); err != nil {
+		return err
+	}
+	return m.promoteOwner(ctx, merch)
+}
+
+// SuspendMerchant hides an accepted merchant until r.Until. While suspended the merchant
+// cannot sell or withdraw and its owner loses the merchant role.
+func (m MerchantUsecase) SuspendMerchant(ctx context.Context, r request.SuspendMerchantRequest) error {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.SuspendMerchant")
+	defer span.End()
+	if r.AdminId == "" {
+		return apperror.Validation("admin id cannot be empty")
+	}
+	reason := strings.TrimSpace(r.Reason)
+	if reason == "" {
+		return apperror.Validation("suspension reason cannot be empty")
+	}
+	if !r.Until.After(time.Now()) {
+		return apperror.Validation("suspension has to end in the future")
+	}
+	merch, err := m.mc.GetMerchantById(ctx, r.MerchantId)
+	if err != nil {
+		return err
+	}
+	if merchant_status.ParseToEnum(merch.Approval) != merchant_status.ACCEPTED {
+		return apperror.InvalidTransition("only accepted merchants can be suspended")
+	}
+	until := r.Until.UTC()
+	if err := m.mc.ChangeMerchantStatus(ctx, merchant.MerchantReview{
+		MerchantId: r.MerchantId,
+		ActorId:    r.AdminId,
+		FromStatus: merch.Approval,
+		ToStatus:   merchant_status.ToString(merchant_status.SUSPENDED),
+		Reason:     reason,
+	}, map[string]interface{}{
+		"suspension_reason": reason,
+		"suspended_until":   &until,
+	}); err != nil {
+		return err
+	}
+	return m.demoteOwner(ctx, *merch)
+}
+
+// ReactivateMerchant lifts a suspension before it expires
+func (m MerchantUsecase) ReactivateMerchant(ctx context.Context, r request.ReactivateMerchantRequest) error {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.ReactivateMerchant")
+	defer span.End()
+	if r.AdminId == "" {
+		return apperror.Validation("admin id cannot be empty")
+	}
+	merch, err := m.mc.GetMerchantById(ctx, r.MerchantId)
+	if err != nil {
+		return err
+	}
+	if merchant_status.ParseToEnum(merch.Approval) != merchant_status.SUSPENDED {
+		return apperror.InvalidTransition("only suspended merchants can be reactivated")
+	}
+	return m.reactivate(ctx, *merch, r.AdminId, "")
+}
+
+// CloseMerchant closes the merchant for good on request of its owner. The balance has
+// to be withdrawn first since a closed merchant cannot withdraw anymore.
+func (m MerchantUsecase) CloseMerchant(ctx context.Context, r request.CloseMerchantRequest) error {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.CloseMerchant")
+	defer span.End()
+	merch, err := m.mc.GetMerchantById(ctx, r.MerchantId)
+	if err != nil {
+		return err
+	}
+	if r.UserId == "" || merch.UserId != r.UserId {
+		return apperror.Forbidden("only the merchant owner can close the merchant")
+	}
+	status := merchant_status.ParseToEnum(merch.Approval)
+	if status != merchant_status.ACCEPTED && status != merchant_status.SUSPENDED {
+		return apperror.InvalidTransition("only accepted or suspended merchants can be closed")
+	}
+	if merch.Balance != 0 || merch.PendingBalance != 0 {
+		return ErrUnsettledBalance
+	}
+	if err := m.mc.ChangeMerchantStatus(ctx, merchant.MerchantReview{
+		MerchantId: r.MerchantId,
+		ActorId:    r.UserId,
+		FromStatus: merch.Approval,
+		ToStatus:   merchant_status.ToString(merchant_status.CLOSED),
+		Reason:     strings.TrimSpace(r.Reason),
+	}, clearedSuspension); err != nil {
+		return err
+	}
+	if status == merchant_status.SUSPENDED {
+		return nil
+	}
+	return m.demoteOwner(ctx, *merch)
+}
+
+// ValidateMerchantActive fails when the merchant may not sell or withdraw right now. A
+// suspension that has run out is lifted here.
+func (m MerchantUsecase) ValidateMerchantActive(ctx context.Context, merchantId string) error {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.ValidateMerchantActive")
+	defer span.End()
+	merch, err := m.mc.GetMerchantById(ctx, merchantId)
+	if err != nil {
+		return err
+	}
+	switch merchant_status.ParseToEnum(merch.Approval) {
+	case merchant_status.CLOSED:
+		return merchant.ErrMerchantClosed
+	case merchant_status.SUSPENDED:
+		if merch.SuspendedUntil != nil && merch.SuspendedUntil.After(time.Now()) {
+			return merchant.ErrMerchantSuspended
+		}
+		err := m.reactivate(ctx, *merch, SystemActorId, "suspension expired")
+		if err != nil && err != merchant.ErrInvalidStatusTransition {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package merchant
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/domain/merchant"
+	request "github.com/williamchang80/sea-apd/dto/request/merchant"
+	merchant2 "github.com/williamchang80/sea-apd/mocks/repository/merchant"
+	user2 "github.com/williamchang80/sea-apd/mocks/usecase/user"
+)
+
+func TestMerchantUsecase_SuspendMerchant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tomorrow := time.Now().Add(24 * time.Hour)
+	tests := []struct {
+		name    string
+		args    request.SuspendMerchantRequest
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: request.SuspendMerchantRequest{MerchantId: merchant2.MockAcceptedMerchantId, AdminId: "admin",
+				Reason: "counterfeit goods", Until: tomorrow},
+			wantErr: false,
+		},
+		{
+			name: "failed without reason",
+			args: request.SuspendMerchantRequest{MerchantId: merchant2.MockAcceptedMerchantId, AdminId: "admin",
+				Until: tomorrow},
+			wantErr: true,
+		},
+		{
+			name: "failed with expiry in the past",
+			args: request.SuspendMerchantRequest{MerchantId: merchant2.MockAcceptedMerchantId, AdminId: "admin",
+				Reason: "counterfeit goods", Until: time.Now().Add(-time.Hour)},
+			wantErr: true,
+		},
+		{
+			name: "failed with merchant that is not accepted",
+			args: request.SuspendMerchantRequest{MerchantId: merchant2.MockWaitingMerchantId, AdminId: "admin",
+				Reason: "counterfeit goods", Until: tomorrow},
+			wantErr: true,
+		},
+		{
+			name: "failed without admin",
+			args: request.SuspendMerchantRequest{MerchantId: merchant2.MockAcceptedMerchantId,
+				Reason: "counterfeit goods", Until: tomorrow},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMerchantUsecase(merchant2.NewMockRepository(ctrl), user2.NewMockUsecase(ctrl), nil)
+			if err := c.SuspendMerchant(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("MerchantUsecase.SuspendMerchant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMerchantUsecase_ReactivateMerchant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.ReactivateMerchantRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    request.ReactivateMerchantRequest{MerchantId: merchant2.MockSuspendedMerchantId, AdminId: "admin"},
+			wantErr: false,
+		},
+		{
+			name:    "failed with merchant that is not suspended",
+			args:    request.ReactivateMerchantRequest{MerchantId: merchant2.MockClosedMerchantId, AdminId: "admin"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMerchantUsecase(merchant2.NewMockRepository(ctrl), user2.NewMockUsecase(ctrl), nil)
+			if err := c.ReactivateMerchant(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("MerchantUsecase.ReactivateMerchant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMerchantUsecase_CloseMerchant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.CloseMerchantRequest
+		wantErr bool
+	}{
+		{
+			name:    "success with settled balance",
+			args:    request.CloseMerchantRequest{MerchantId: merchant2.MockSuspendedMerchantId, UserId: merchant2.MockMerchantUserId},
+			wantErr: false,
+		},
+		{
+			name:    "failed with unsettled balance",
+			args:    request.CloseMerchantRequest{MerchantId: merchant2.MockAcceptedMerchantId, UserId: merchant2.MockMerchantUserId},
+			wantErr: true,
+		},
+		{
+			name:    "failed with orders waiting to complete",
+			args:    request.CloseMerchantRequest{MerchantId: merchant2.MockPendingMerchantId, UserId: merchant2.MockMerchantUserId},
+			wantErr: true,
+		},
+		{
+			name:    "failed with other user",
+			args:    request.CloseMerchantRequest{MerchantId: merchant2.MockSuspendedMerchantId, UserId: "2"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with closed merchant",
+			args:    request.CloseMerchantRequest{MerchantId: merchant2.MockClosedMerchantId, UserId: merchant2.MockMerchantUserId},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMerchantUsecase(merchant2.NewMockRepository(ctrl), user2.NewMockUsecase(ctrl), nil)
+			if err := c.CloseMerchant(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("MerchantUsecase.CloseMerchant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMerchantUsecase_ValidateMerchantActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name       string
+		merchantId string
+		want       error
+	}{
+		{name: "active merchant", merchantId: merchant2.MockAcceptedMerchantId, want: nil},
+		{name: "expired suspension is lifted", merchantId: merchant2.MockExpiredMerchantId, want: nil},
+		{name: "suspended merchant", merchantId: merchant2.MockSuspendedMerchantId, want: merchant.ErrMerchantSuspended},
+		{name: "closed merchant", merchantId: merchant2.MockClosedMerchantId, want: merchant.ErrMerchantClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMerchantUsecase(merchant2.NewMockRepository(ctrl), user2.NewMockUsecase(ctrl), nil)
+			if err := c.ValidateMerchantActive(context.Background(), tt.merchantId); err != tt.want {
+				t.Errorf("MerchantUsecase.ValidateMerchantActive() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
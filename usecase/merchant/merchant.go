@@ -1,29 +1,36 @@
 package merchant
 
 import (
+	"context"
+	"strings"
+
 	"github.com/williamchang80/sea-apd/common/constants/mailer_type"
 	"github.com/williamchang80/sea-apd/common/constants/merchant_status"
 	"github.com/williamchang80/sea-apd/common/constants/user_role"
 	"github.com/williamchang80/sea-apd/common/mailer"
 	"github.com/williamchang80/sea-apd/common/mailer/factory"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	"github.com/williamchang80/sea-apd/domain/merchant"
 	user "github.com/williamchang80/sea-apd/domain/user"
 	request "github.com/williamchang80/sea-apd/dto/request/merchant"
 	"github.com/williamchang80/sea-apd/dto/request/merchant/converter"
 	user2 "github.com/williamchang80/sea-apd/dto/request/user"
+	"github.com/williamchang80/sea-apd/infrastructure/storage"
 )
 
 type MerchantUsecase struct {
 	mc      merchant.MerchantRepository
 	usecase user.UserUsecase
+	bs      storage.BlobStore
 }
 
 type NotifyAdminMailer struct {
 }
 
 func NewMerchantUsecase(m merchant.MerchantRepository, usecase user.
-UserUsecase) merchant.MerchantUsecase {
-	mc := MerchantUsecase{mc: m, usecase: usecase}
+UserUsecase, b storage.BlobStore) merchant.MerchantUsecase {
+	mc := MerchantUsecase{mc: m, usecase: usecase, bs: b}
 	return mc
 }
 
@@ -38,81 +45,152 @@ func ConvertMerchantRequestToEntity(m request.MerchantRequest) merchant.Merchant
 	}
 }
 
-func (m MerchantUsecase) UpdateMerchantBalance(request request.UpdateMerchantBalanceRequest) error {
-	if err := m.mc.UpdateMerchantBalance(request.Amount, request.MerchantId); err != nil {
+func (m MerchantUsecase) UpdateMerchantBalance(ctx context.Context, request request.UpdateMerchantBalanceRequest) error {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.UpdateMerchantBalance")
+	defer span.End()
+	if err := m.mc.UpdateMerchantBalance(ctx, request.Amount, request.MerchantId); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (m MerchantUsecase) GetMerchantBalance(merchantId string) (int, error) {
-	balance, err := m.mc.GetMerchantBalance(merchantId)
+func (m MerchantUsecase) GetMerchantBalance(ctx context.Context, merchantId string) (int, error) {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.GetMerchantBalance")
+	defer span.End()
+	balance, err := m.mc.GetMerchantBalance(ctx, merchantId)
 	if err != nil {
 		return 0, err
 	}
 	return balance, nil
 }
 
-func (m MerchantUsecase) RegisterMerchant(request request.MerchantRequest) error {
+func (m MerchantUsecase) GetMerchantPendingBalance(ctx context.Context, merchantId string) (int, error) {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.GetMerchantPendingBalance")
+	defer span.End()
+	if merchantId == "" {
+		return 0, apperror.Validation("merchant id cannot be empty")
+	}
+	merch, err := m.mc.GetMerchantById(ctx, merchantId)
+	if err != nil {
+		return 0, err
+	}
+	return merch.PendingBalance, nil
+}
+
+func (m MerchantUsecase) UpdateMerchantPendingBalance(ctx context.Context, request request.UpdateMerchantBalanceRequest) error {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.UpdateMerchantPendingBalance")
+	defer span.End()
+	if err := m.mc.UpdateMerchantPendingBalance(ctx, request.Amount, request.MerchantId); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m MerchantUsecase) RegisterMerchant(ctx context.Context, request request.MerchantRequest) error {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.RegisterMerchant")
+	defer span.End()
+	if err := m.usecase.ValidateUserVerified(ctx, request.UserId); err != nil {
+		return err
+	}
 	merch := ConvertMerchantRequestToEntity(request)
-	r, err := m.mc.RegisterMerchant(merch)
+	r, err := m.mc.RegisterMerchant(ctx, merch)
 	if err != nil {
 		return err
 	}
-	u, err := m.usecase.GetUserById(r.UserId)
+	u, err := m.usecase.GetUserById(ctx, r.UserId)
 	if err != nil {
 		return err
 	}
-	notifyAdminOnMerchantRegister(*u)
+	notifyAdminOnMerchantRegister(ctx, *u)
 	return nil
 }
 
-func notifyAdminOnMerchantRegister(u user.User) error {
+func notifyAdminOnMerchantRegister(ctx context.Context, u user.User) error {
 	mail := factory.CreateMailerFactory(mailer_type.AUTH)
 	mails := mail.CreateMail(u, user_role.ToString(user_role.MERCHANT))
-	err := mailer.SendEmail(mails)
+	err := mailer.SendEmail(ctx, mails)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (m MerchantUsecase) GetMerchants() ([]merchant.Merchant, error) {
-	mh, err := m.mc.GetMerchants()
+func (m MerchantUsecase) GetMerchants(ctx context.Context) ([]merchant.Merchant, error) {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.GetMerchants")
+	defer span.End()
+	mh, err := m.mc.GetMerchants(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return mh, nil
 }
 
-func (m MerchantUsecase) GetMerchantById(merchantId string) (*merchant.Merchant, error) {
-	mh, err := m.mc.GetMerchantById(merchantId)
+func (m MerchantUsecase) GetMerchantById(ctx context.Context, merchantId string) (*merchant.Merchant, error) {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.GetMerchantById")
+	defer span.End()
+	mh, err := m.mc.GetMerchantById(ctx, merchantId)
 	if err != nil || mh == nil {
 		return nil, err
 	}
 	return mh, nil
 }
 
-func (m MerchantUsecase) UpdateMerchantApprovalStatus(request request.
+// UpdateMerchantApprovalStatus records the decision of an admin on a waiting merchant.
+// Accepting requires every verification document, declining requires a reason that
+// is shown to the merchant.
+func (m MerchantUsecase) UpdateMerchantApprovalStatus(ctx context.Context, request request.
 UpdateMerchantApprovalStatusRequest) error {
-	if err := m.mc.UpdateMerchantApprovalStatus(request.MerchantId,
-		merchant_status.ToString(request.Status)); err != nil {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.UpdateMerchantApprovalStatus")
+	defer span.End()
+	if request.AdminId == "" {
+		return apperror.Validation("admin id cannot be empty")
+	}
+	merch, err := m.mc.GetMerchantById(ctx, request.MerchantId)
+	if err != nil {
+		return err
+	}
+	if merchant_status.ParseToEnum(merch.Approval) != merchant_status.WAITING {
+		return apperror.InvalidTransition("only merchants waiting for review can be decided")
+	}
+	reason := strings.TrimSpace(request.Reason)
+	declineReason := ""
+	switch request.Status {
+	case merchant_status.ACCEPTED:
+		if err := m.requireDocuments(ctx, request.MerchantId); err != nil {
+			return err
+		}
+	case merchant_status.DECLINED:
+		if reason == "" {
+			return apperror.Validation("decline reason cannot be empty")
+		}
+		declineReason = reason
+	default:
+		return apperror.Validation("status must be accepted or declined")
+	}
+	if err := m.mc.ChangeMerchantStatus(ctx, merchant.MerchantReview{
+		MerchantId: request.MerchantId,
+		ActorId:    request.AdminId,
+		FromStatus: merch.Approval,
+		ToStatus:   merchant_status.ToString(request.Status),
+		Reason:     reason,
+	}, map[string]interface{}{"decline_reason": declineReason}); err != nil {
 		return err
 	}
 	if request.Status == merchant_status.ACCEPTED {
-		merch, _ := m.mc.GetMerchantById(request.MerchantId)
 		updateRequest := user2.UpdateUserRoleRequest{Role: user_role.MERCHANT,
 			UserId: merch.UserId}
-		if err := m.usecase.UpdateUserRole(updateRequest); err != nil {
+		if err := m.usecase.UpdateUserRole(ctx, updateRequest); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (m MerchantUsecase) UpdateMerchant(r request.UpdateMerchantRequest) error {
+func (m MerchantUsecase) UpdateMerchant(ctx context.Context, r request.UpdateMerchantRequest) error {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.UpdateMerchant")
+	defer span.End()
 	merchant := converter.ConvertUpdateMerchantRequestToEntity(r)
-	if err := m.mc.UpdateMerchant(r.MerchantId, merchant); err != nil {
+	if err := m.mc.UpdateMerchant(ctx, r.MerchantId, merchant); err != nil {
 		return err
 	}
 	return nil
@@ -1,6 +1,7 @@
 package merchant
 
 import (
+	"context"
 	"github.com/williamchang80/sea-apd/domain/user"
 	user2 "github.com/williamchang80/sea-apd/mocks/usecase/user"
 	"reflect"
@@ -35,7 +36,7 @@ func TestNewMerchantUsecase(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewMerchantUsecase(tt.args.repository, tt.args.uc);
+			if got := NewMerchantUsecase(tt.args.repository, tt.args.uc, nil);
 				!reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewMerchantUsecase() = %v, want %v", got, tt.want)
 			}
@@ -66,7 +67,7 @@ func TestMerchantUsecase_GetMerchantBalance(t *testing.T) {
 			initMock: func() merchant.MerchantUsecase {
 				r := merchant2.NewMockRepository(ctrl)
 				u := user2.NewMockUsecase(ctrl)
-				return NewMerchantUsecase(r, u)
+				return NewMerchantUsecase(r, u, nil)
 			},
 		},
 		{
@@ -78,7 +79,7 @@ func TestMerchantUsecase_GetMerchantBalance(t *testing.T) {
 			initMock: func() merchant.MerchantUsecase {
 				r := merchant2.NewMockRepository(ctrl)
 				u := user2.NewMockUsecase(ctrl)
-				return NewMerchantUsecase(r, u)
+				return NewMerchantUsecase(r, u, nil)
 			},
 		},
 	}
@@ -86,7 +87,7 @@ func TestMerchantUsecase_GetMerchantBalance(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := tt.initMock()
-			p, err := c.GetMerchantBalance(tt.args.merchantId)
+			p, err := c.GetMerchantBalance(context.Background(), tt.args.merchantId)
 			if err != nil && !tt.wantErr {
 				t.Errorf("MerchantUsecase.GetMerchantBalance() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -122,7 +123,7 @@ func TestMerchantUsecase_UpdateMerchantBalance(t *testing.T) {
 			initMock: func() merchant.MerchantUsecase {
 				r := merchant2.NewMockRepository(ctrl)
 				u := user2.NewMockUsecase(ctrl)
-				return NewMerchantUsecase(r, u)
+				return NewMerchantUsecase(r, u, nil)
 			},
 		},
 		{
@@ -134,14 +135,14 @@ func TestMerchantUsecase_UpdateMerchantBalance(t *testing.T) {
 			initMock: func() merchant.MerchantUsecase {
 				r := merchant2.NewMockRepository(ctrl)
 				u := user2.NewMockUsecase(ctrl)
-				return NewMerchantUsecase(r, u)
+				return NewMerchantUsecase(r, u, nil)
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := tt.initMock()
-			err := c.UpdateMerchantBalance(tt.args.request)
+			err := c.UpdateMerchantBalance(context.Background(), tt.args.request)
 			if err != nil && !tt.wantErr {
 				t.Errorf("MerchantUsecase.UpdateMerchantBalance() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -149,3 +150,32 @@ func TestMerchantUsecase_UpdateMerchantBalance(t *testing.T) {
 		})
 	}
 }
+
+func TestMerchantUsecase_RegisterMerchant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.MerchantRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    request.MerchantRequest{Name: "Mock", UserId: "1", Brand: "Mock", Address: "Mock"},
+			wantErr: false,
+		},
+		{
+			name:    "failed with unverified user",
+			args:    request.MerchantRequest{Name: "Mock", UserId: user2.MockUnverifiedUserId, Brand: "Mock"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMerchantUsecase(merchant2.NewMockRepository(ctrl), user2.NewMockUsecase(ctrl), nil)
+			if err := c.RegisterMerchant(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("MerchantUsecase.RegisterMerchant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package merchant
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/williamchang80/sea-apd/common/constants/document_type"
+	"github.com/williamchang80/sea-apd/common/constants/merchant_status"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/merchant"
+	request "github.com/williamchang80/sea-apd/dto/request/merchant"
+)
+
+const MaxDocumentSize = 10 << 20
+
+var (
+	ErrDocumentTooLarge    = apperror.Validation("document cannot be larger than 10MB")
+	ErrUnsupportedDocument = apperror.Validation("document must be a jpeg, png or pdf file")
+
+	documentContentTypes = map[string]bool{
+		"image/jpeg":      true,
+		"image/png":       true,
+		"application/pdf": true,
+	}
+)
+
+func readDocument(file io.Reader) ([]byte, string, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(file, MaxDocumentSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(data) > MaxDocumentSize {
+		return nil, "", ErrDocumentTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	if !documentContentTypes[contentType] {
+		return nil, "", ErrUnsupportedDocument
+	}
+	return data, contentType, nil
+}
+
+// missingDocuments lists the required document types the merchant has not uploaded yet
+func missingDocuments(documents []merchant.MerchantDocument) []string {
+	uploaded := map[string]bool{}
+	for _, d := range documents {
+		uploaded[d.Type] = true
+	}
+	var missing []string
+	for _, t := range document_type.GetRequiredDocumentTypes() {
+		if !uploaded[t] {
+			missing = append(missing, t)
+		}
+	}
+	return missing
+}
+
+func (m MerchantUsecase) requireDocuments(ctx context.Context, merchantId string) error {
+	documents, err := m.GetMerchantDocuments(ctx, merchantId)
+	if err != nil {
+		return err
+	}
+	if missing := missingDocuments(documents); len(missing) > 0 {
+		return apperror.Validation("missing documents: " + strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// UploadMerchantDocument stores the document in private storage and replaces the
+// document of the same type uploaded before. Documents can only change while the
+// application is waiting or declined.
+func (m MerchantUsecase) UploadMerchantDocument(ctx context.Context, r request.MerchantDocumentRequest) error {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.UploadMerchantDocument")
+	defer span.End()
+	if r.File == nil {
+		return apperror.Validation("document file cannot be empty")
+	}
+	documentType := document_type.ParseToEnum(strings.ToLower(strings.TrimSpace(r.Type)))
+	if documentType == document_type.OTHER {
+		return apperror.Validation("document type must be one of " +
+			strings.Join(document_type.GetRequiredDocumentTypes(), ", "))
+	}
+	merch, err := m.mc.GetMerchantById(ctx, r.MerchantId)
+	if err != nil {
+		return err
+	}
+	status := merchant_status.ParseToEnum(merch.Approval)
+	if status != merchant_status.WAITING && status != merchant_status.DECLINED {
+		return apperror.Conflict("documents cannot be changed after the merchant is accepted")
+	}
+	data, contentType, err := readDocument(r.File)
+	if err != nil {
+		return err
+	}
+	previous, err := m.GetMerchantDocuments(ctx, r.MerchantId)
+	if err != nil {
+		return err
+	}
+
+	key := "merchants/" + r.MerchantId + "/documents/" + uuid.NewV4().String()
+	if err := m.bs.Put(key, contentType, data); err != nil {
+		return err
+	}
+	document := merchant.MerchantDocument{
+		MerchantId:  r.MerchantId,
+		Type:        document_type.ToString(documentType),
+		FileName:    r.FileName,
+		ContentType: contentType,
+		Key:         key,
+	}
+	if err := m.mc.CreateMerchantDocument(ctx, document); err != nil {
+		m.bs.Delete(key)
+		return err
+	}
+	for _, old := range previous {
+		if old.Type != document.Type {
+			continue
+		}
+		if err := m.mc.DeleteMerchantDocument(ctx, old.ID); err == nil {
+			m.bs.Delete(old.Key)
+		}
+	}
+	return nil
+}
+
+func (m MerchantUsecase) GetMerchantDocuments(ctx context.Context, merchantId string) ([]merchant.MerchantDocument, error) {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.GetMerchantDocuments")
+	defer span.End()
+	if merchantId == "" {
+		return nil, apperror.Validation("merchant id cannot be empty")
+	}
+	documents, err := m.mc.GetMerchantDocuments(ctx, []string{merchantId})
+	if err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+func (m MerchantUsecase) ReadMerchantDocument(ctx context.Context, documentId string) (*merchant.MerchantDocument, []byte, error) {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.ReadMerchantDocument")
+	defer span.End()
+	document, err := m.mc.GetMerchantDocumentById(ctx, documentId)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := m.bs.Get(document.Key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return document, data, nil
+}
+
+// GetReviewQueue returns the waiting merchants that uploaded every required document,
+// the ones that waited longest first
+func (m MerchantUsecase) GetReviewQueue(ctx context.Context) ([]merchant.MerchantApplication, error) {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.GetReviewQueue")
+	defer span.End()
+	merchants, err := m.mc.GetMerchantsByStatus(ctx, merchant_status.ToString(merchant_status.WAITING))
+	if err != nil {
+		return nil, err
+	}
+	queue := []merchant.MerchantApplication{}
+	if len(merchants) == 0 {
+		return queue, nil
+	}
+	var ids []string
+	for _, merch := range merchants {
+		ids = append(ids, merch.ID)
+	}
+	documents, err := m.mc.GetMerchantDocuments(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byMerchant := map[string][]merchant.MerchantDocument{}
+	for _, d := range documents {
+		byMerchant[d.MerchantId] = append(byMerchant[d.MerchantId], d)
+	}
+	for _, merch := range merchants {
+		if len(missingDocuments(byMerchant[merch.ID])) > 0 {
+			continue
+		}
+		queue = append(queue, merchant.MerchantApplication{
+			Merchant:  merch,
+			Documents: byMerchant[merch.ID],
+		})
+	}
+	return queue, nil
+}
+
+// ResubmitMerchant puts a declined application back in the review queue once the
+// merchant has fixed its documents
+func (m MerchantUsecase) ResubmitMerchant(ctx context.Context, r request.ResubmitMerchantRequest) error {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.ResubmitMerchant")
+	defer span.End()
+	merch, err := m.mc.GetMerchantById(ctx, r.MerchantId)
+	if err != nil {
+		return err
+	}
+	if r.UserId == "" || merch.UserId != r.UserId {
+		return apperror.Forbidden("only the merchant owner can resubmit the application")
+	}
+	if merchant_status.ParseToEnum(merch.Approval) != merchant_status.DECLINED {
+		return apperror.InvalidTransition("only declined merchants can be resubmitted")
+	}
+	if err := m.requireDocuments(ctx, r.MerchantId); err != nil {
+		return err
+	}
+	return m.mc.ChangeMerchantStatus(ctx, merchant.MerchantReview{
+		MerchantId: r.MerchantId,
+		ActorId:    r.UserId,
+		FromStatus: merch.Approval,
+		ToStatus:   merchant_status.ToString(merchant_status.WAITING),
+	}, map[string]interface{}{"decline_reason": ""})
+}
+
+func (m MerchantUsecase) GetMerchantReviews(ctx context.Context, merchantId string) ([]merchant.MerchantReview, error) {
+	ctx, span := tracing.Start(ctx, "MerchantUsecase.GetMerchantReviews")
+	defer span.End()
+	if merchantId == "" {
+		return nil, apperror.Validation("merchant id cannot be empty")
+	}
+	reviews, err := m.mc.GetMerchantReviews(ctx, merchantId)
+	if err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
@@ -0,0 +1,159 @@
+package merchant
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/common/constants/merchant_status"
+	request "github.com/williamchang80/sea-apd/dto/request/merchant"
+	"github.com/williamchang80/sea-apd/infrastructure/storage"
+	merchant2 "github.com/williamchang80/sea-apd/mocks/repository/merchant"
+	user2 "github.com/williamchang80/sea-apd/mocks/usecase/user"
+)
+
+var mockPdf = []byte("%PDF-1.4\n%mock document\n")
+
+func TestMerchantUsecase_UpdateMerchantApprovalStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.UpdateMerchantApprovalStatusRequest
+		wantErr bool
+	}{
+		{
+			name: "success accepting merchant with documents",
+			args: request.UpdateMerchantApprovalStatusRequest{Status: merchant_status.ACCEPTED,
+				MerchantId: merchant2.MockWaitingMerchantId, AdminId: "admin"},
+			wantErr: false,
+		},
+		{
+			name: "success declining merchant with reason",
+			args: request.UpdateMerchantApprovalStatusRequest{Status: merchant_status.DECLINED,
+				MerchantId: merchant2.MockIncompleteMerchantId, AdminId: "admin", Reason: "blurry id card"},
+			wantErr: false,
+		},
+		{
+			name: "failed accepting merchant without documents",
+			args: request.UpdateMerchantApprovalStatusRequest{Status: merchant_status.ACCEPTED,
+				MerchantId: merchant2.MockIncompleteMerchantId, AdminId: "admin"},
+			wantErr: true,
+		},
+		{
+			name: "failed declining without reason",
+			args: request.UpdateMerchantApprovalStatusRequest{Status: merchant_status.DECLINED,
+				MerchantId: merchant2.MockWaitingMerchantId, AdminId: "admin", Reason: " "},
+			wantErr: true,
+		},
+		{
+			name: "failed deciding merchant that is not waiting",
+			args: request.UpdateMerchantApprovalStatusRequest{Status: merchant_status.ACCEPTED,
+				MerchantId: merchant2.MockDeclinedMerchantId, AdminId: "admin"},
+			wantErr: true,
+		},
+		{
+			name: "failed without admin",
+			args: request.UpdateMerchantApprovalStatusRequest{Status: merchant_status.ACCEPTED,
+				MerchantId: merchant2.MockWaitingMerchantId},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMerchantUsecase(merchant2.NewMockRepository(ctrl), user2.NewMockUsecase(ctrl), nil)
+			if err := c.UpdateMerchantApprovalStatus(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("MerchantUsecase.UpdateMerchantApprovalStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMerchantUsecase_UploadMerchantDocument(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name       string
+		merchantId string
+		docType    string
+		file       io.Reader
+		wantErr    bool
+	}{
+		{name: "success", merchantId: merchant2.MockWaitingMerchantId, docType: "ID Card",
+			file: bytes.NewReader(mockPdf)},
+		{name: "success replacing document of declined merchant", merchantId: merchant2.MockDeclinedMerchantId,
+			docType: "business licence", file: bytes.NewReader(mockPdf)},
+		{name: "failed with unknown type", merchantId: merchant2.MockWaitingMerchantId, docType: "selfie",
+			file: bytes.NewReader(mockPdf), wantErr: true},
+		{name: "failed with unsupported file", merchantId: merchant2.MockWaitingMerchantId, docType: "id card",
+			file: bytes.NewReader([]byte("plain text")), wantErr: true},
+		{name: "failed with accepted merchant", merchantId: "1", docType: "id card",
+			file: bytes.NewReader(mockPdf), wantErr: true},
+		{name: "failed without file", merchantId: merchant2.MockWaitingMerchantId, docType: "id card",
+			wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage.NewLocalStore(t.TempDir(), "/private")
+			c := NewMerchantUsecase(merchant2.NewMockRepository(ctrl), user2.NewMockUsecase(ctrl), store)
+			err := c.UploadMerchantDocument(context.Background(), request.MerchantDocumentRequest{
+				MerchantId: tt.merchantId,
+				Type:       tt.docType,
+				FileName:   "document.pdf",
+				File:       tt.file,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MerchantUsecase.UploadMerchantDocument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMerchantUsecase_GetReviewQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c := NewMerchantUsecase(merchant2.NewMockRepository(ctrl), user2.NewMockUsecase(ctrl), nil)
+	queue, err := c.GetReviewQueue(context.Background())
+	if err != nil {
+		t.Fatalf("MerchantUsecase.GetReviewQueue() error = %v", err)
+	}
+	if len(queue) != 1 || queue[0].Merchant.ID != merchant2.MockWaitingMerchantId || len(queue[0].Documents) != 3 {
+		t.Errorf("MerchantUsecase.GetReviewQueue() = %+v", queue)
+	}
+}
+
+func TestMerchantUsecase_ResubmitMerchant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.ResubmitMerchantRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    request.ResubmitMerchantRequest{MerchantId: merchant2.MockDeclinedMerchantId, UserId: merchant2.MockMerchantUserId},
+			wantErr: false,
+		},
+		{
+			name:    "failed with other user",
+			args:    request.ResubmitMerchantRequest{MerchantId: merchant2.MockDeclinedMerchantId, UserId: "2"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with merchant that is not declined",
+			args:    request.ResubmitMerchantRequest{MerchantId: merchant2.MockWaitingMerchantId, UserId: merchant2.MockMerchantUserId},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMerchantUsecase(merchant2.NewMockRepository(ctrl), user2.NewMockUsecase(ctrl), nil)
+			if err := c.ResubmitMerchant(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("MerchantUsecase.ResubmitMerchant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
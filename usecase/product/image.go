@@ -0,0 +1,86 @@
+package product
+
+import (
+	"bytes"
+	"image"
+	_ "image/jpeg" // register jpeg decoder
+	_ "image/png"  // register png decoder
+	"io"
+	"io/ioutil"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/williamchang80/sea-apd/common/imaging"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/product"
+	"github.com/williamchang80/sea-apd/infrastructure/storage"
+)
+
+const (
+	MaxImageSize       = 5 << 20
+	fullImageSide      = 1200
+	thumbnailImageSide = 300
+	fullImageName      = "full"
+	thumbnailImageName = "thumbnail"
+)
+
+var ErrImageTooLarge = apperror.Validation("image cannot be larger than 5MB")
+
+func imageKey(prefix string, name string) string {
+	return prefix + "/" + name
+}
+
+func readImage(file io.Reader) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(file, MaxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxImageSize {
+		return nil, ErrImageTooLarge
+	}
+	return data, nil
+}
+
+// storeProductImage validates the uploaded image, stores a full size and a thumbnail
+// rendition and fills in the image fields of p
+func storeProductImage(store storage.BlobStore, file io.Reader, p *product.Product) error {
+	data, err := readImage(file)
+	if err != nil {
+		return err
+	}
+	contentType, err := imaging.DetectContentType(data)
+	if err != nil {
+		return err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return imaging.ErrUnsupportedImage
+	}
+
+	prefix := "products/" + uuid.NewV4().String()
+	renditions := map[string]int{
+		fullImageName:      fullImageSide,
+		thumbnailImageName: thumbnailImageSide,
+	}
+	for name, side := range renditions {
+		encoded, err := imaging.Encode(imaging.Fit(img, side), contentType)
+		if err != nil {
+			return err
+		}
+		if err := store.Put(imageKey(prefix, name), contentType, encoded); err != nil {
+			deleteProductImage(store, prefix)
+			return err
+		}
+	}
+	p.ImageKey = prefix
+	p.Image = store.URL(imageKey(prefix, fullImageName))
+	p.Thumbnail = store.URL(imageKey(prefix, thumbnailImageName))
+	return nil
+}
+
+func deleteProductImage(store storage.BlobStore, prefix string) {
+	if prefix == "" {
+		return
+	}
+	store.Delete(imageKey(prefix, fullImageName))
+	store.Delete(imageKey(prefix, thumbnailImageName))
+}
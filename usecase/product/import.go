@@ -0,0 +1,387 @@
+package product
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/williamchang80/sea-apd/common/constants/catalogue_format"
+	"github.com/williamchang80/sea-apd/common/constants/import_status"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/product"
+	request "github.com/williamchang80/sea-apd/dto/request/product"
+)
+
+const (
+	MaxImportSize = 10 << 20
+	// files with more rows than this are imported in the background
+	syncImportRows = 100
+	// how many rows are imported between two progress updates of a job
+	importProgressInterval = 50
+	tagSeparator           = "|"
+	maxJSONLineSize        = 1 << 20
+)
+
+var (
+	catalogueColumns = []string{"sku", "name", "description", "price", "stock", "category_id", "tags"}
+	requiredColumns  = []string{"sku", "name", "price", "stock"}
+
+	ErrImportTooLarge = apperror.Validation("import file cannot be larger than 10MB")
+	ErrEmptyImport    = apperror.Validation("import file has no rows")
+)
+
+// catalogueRecord is one product of an imported or exported catalogue. Tags is nil
+// when the import does not mention tags, which keeps the tags of existing products.
+type catalogueRecord struct {
+	Row         int      `json:"-"`
+	Sku         string   `json:"sku"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Price       int      `json:"price"`
+	Stock       int      `json:"stock"`
+	CategoryId  string   `json:"category_id"`
+	Tags        []string `json:"tags"`
+}
+
+func (r catalogueRecord) toDomain(merchantId string) product.Product {
+	var categoryId *string
+	if r.CategoryId != "" {
+		categoryId = &r.CategoryId
+	}
+	return product.Product{
+		Sku:         r.Sku,
+		Name:        r.Name,
+		Description: r.Description,
+		Price:       r.Price,
+		Stock:       r.Stock,
+		MerchantId:  merchantId,
+		CategoryId:  categoryId,
+	}
+}
+
+func rowError(row int, sku string, message string) product.ImportRowError {
+	return product.ImportRowError{Row: row, Sku: sku, Message: message}
+}
+
+func parseCatalogue(format catalogue_format.CatalogueFormat, file io.Reader) (
+	[]catalogueRecord, []product.ImportRowError, error) {
+	data, err := readImportFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch format {
+	case catalogue_format.CSV:
+		return parseCSV(bytes.NewReader(data))
+	case catalogue_format.JSONL:
+		return parseJSONL(bytes.NewReader(data))
+	}
+	return nil, nil, apperror.Validation("import format must be csv or jsonl")
+}
+
+func readImportFile(file io.Reader) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(file, MaxImportSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxImportSize {
+		return nil, ErrImportTooLarge
+	}
+	return data, nil
+}
+
+// parseCSV reads a csv file whose first row names the columns. Columns may come in any
+// order, unknown columns are ignored and tags are separated by "|".
+func parseCSV(file io.Reader) ([]catalogueRecord, []product.ImportRowError, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, apperror.Validation("csv file must start with a header row")
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredColumns {
+		if _, exist := columns[name]; !exist {
+			return nil, nil, apperror.Validation("csv file is missing the " + name + " column")
+		}
+	}
+
+	var records []catalogueRecord
+	var rowErrors []product.ImportRowError
+	for row := 1; ; row++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, rowError(row, "", err.Error()))
+			continue
+		}
+		value := func(name string) string {
+			i, exist := columns[name]
+			if !exist || i >= len(fields) {
+				return ""
+			}
+			return strings.TrimSpace(fields[i])
+		}
+		record := catalogueRecord{
+			Row:         row,
+			Sku:         value("sku"),
+			Name:        value("name"),
+			Description: value("description"),
+			CategoryId:  value("category_id"),
+		}
+		if record.Price, err = strconv.Atoi(value("price")); err != nil {
+			rowErrors = append(rowErrors, rowError(row, record.Sku, "price must be a whole number"))
+			continue
+		}
+		if record.Stock, err = strconv.Atoi(value("stock")); err != nil {
+			rowErrors = append(rowErrors, rowError(row, record.Sku, "stock must be a whole number"))
+			continue
+		}
+		if _, exist := columns["tags"]; exist {
+			record.Tags = []string{}
+			if tags := value("tags"); tags != "" {
+				record.Tags = strings.Split(tags, tagSeparator)
+			}
+		}
+		records = append(records, record)
+	}
+	return records, rowErrors, nil
+}
+
+// parseJSONL reads one json product object per line, blank lines are skipped
+func parseJSONL(file io.Reader) ([]catalogueRecord, []product.ImportRowError, error) {
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLineSize)
+	var records []catalogueRecord
+	var rowErrors []product.ImportRowError
+	row := 0
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		row++
+		var record catalogueRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			rowErrors = append(rowErrors, rowError(row, "", "invalid json: "+err.Error()))
+			continue
+		}
+		record.Row = row
+		record.Sku = strings.TrimSpace(record.Sku)
+		record.Name = strings.TrimSpace(record.Name)
+		record.CategoryId = strings.TrimSpace(record.CategoryId)
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	return records, rowErrors, nil
+}
+
+// validateRecords drops the records that cannot be imported and reports them as row
+// errors. A sku may only appear once in a file.
+func validateRecords(records []catalogueRecord, rowErrors []product.ImportRowError) (
+	[]catalogueRecord, []product.ImportRowError) {
+	seen := map[string]int{}
+	valid := []catalogueRecord{}
+	for _, r := range records {
+		var message string
+		switch {
+		case r.Sku == "":
+			message = "sku cannot be empty"
+		case seen[r.Sku] > 0:
+			message = "sku is already used in row " + strconv.Itoa(seen[r.Sku])
+		case r.Name == "":
+			message = "name cannot be empty"
+		case r.Price < 0:
+			message = "price cannot be negative"
+		case r.Stock < 0:
+			message = "stock cannot be negative"
+		}
+		if message != "" {
+			rowErrors = append(rowErrors, rowError(r.Row, r.Sku, message))
+			continue
+		}
+		seen[r.Sku] = r.Row
+		valid = append(valid, r)
+	}
+	sort.SliceStable(rowErrors, func(i, j int) bool {
+		return rowErrors[i].Row < rowErrors[j].Row
+	})
+	return valid, rowErrors
+}
+
+func (s *ProductUsecase) ImportProducts(ctx context.Context, r request.ImportProductsRequest) (*product.ImportJob, error) {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.ImportProducts")
+	defer span.End()
+	if r.MerchantId == "" {
+		return nil, apperror.Validation("merchant id cannot be empty")
+	}
+	if r.File == nil {
+		return nil, apperror.Validation("import file cannot be empty")
+	}
+	format := catalogue_format.ParseToEnum(strings.ToLower(r.Format))
+	records, rowErrors, err := parseCatalogue(format, r.File)
+	if err != nil {
+		return nil, err
+	}
+	total := len(records) + len(rowErrors)
+	if total == 0 {
+		return nil, ErrEmptyImport
+	}
+	records, rowErrors = validateRecords(records, rowErrors)
+	job := &product.ImportJob{
+		MerchantId:    r.MerchantId,
+		Format:        catalogue_format.ToString(format),
+		DryRun:        r.DryRun,
+		Status:        import_status.ToString(import_status.PENDING),
+		TotalRows:     total,
+		ProcessedRows: len(rowErrors),
+		FailedRows:    len(rowErrors),
+		Errors:        rowErrors,
+	}
+	if r.DryRun {
+		job.Status = import_status.ToString(import_status.COMPLETED)
+		job.ProcessedRows = total
+		return job, nil
+	}
+	if err := s.pr.CreateImportJob(ctx, job); err != nil {
+		return nil, err
+	}
+	if total <= syncImportRows {
+		if err := s.processImport(ctx, job, records); err != nil {
+			return nil, err
+		}
+		return job, nil
+	}
+	background := *job
+	background.Errors = append([]product.ImportRowError{}, job.Errors...)
+	go s.processImport(ctx, &background, records)
+	return job, nil
+}
+
+// processImport upserts the records by sku and keeps the stored job up to date.
+// Rows that fail are reported on the job and do not stop the import.
+func (s *ProductUsecase) processImport(ctx context.Context, job *product.ImportJob, records []catalogueRecord) error {
+	job.Status = import_status.ToString(import_status.PROCESSING)
+	if err := s.pr.UpdateImportJob(ctx, job); err != nil {
+		return err
+	}
+	for i, record := range records {
+		p := record.toDomain(job.MerchantId)
+		err := s.attachTags(ctx, record.Tags, &p)
+		created := false
+		if err == nil {
+			created, err = s.pr.UpsertProduct(ctx, p)
+		}
+		switch {
+		case err != nil:
+			job.FailedRows++
+			job.Errors = append(job.Errors, rowError(record.Row, record.Sku, err.Error()))
+		case created:
+			job.CreatedRows++
+		default:
+			job.UpdatedRows++
+		}
+		job.ProcessedRows++
+		if (i+1)%importProgressInterval == 0 {
+			if err := s.pr.UpdateImportJob(ctx, job); err != nil {
+				return s.failImport(ctx, job, err)
+			}
+		}
+	}
+	job.Status = import_status.ToString(import_status.COMPLETED)
+	if err := s.pr.UpdateImportJob(ctx, job); err != nil {
+		return s.failImport(ctx, job, err)
+	}
+	return nil
+}
+
+func (s *ProductUsecase) failImport(ctx context.Context, job *product.ImportJob, err error) error {
+	job.Status = import_status.ToString(import_status.FAILED)
+	s.pr.UpdateImportJob(ctx, job)
+	return err
+}
+
+func (s *ProductUsecase) GetImportJob(ctx context.Context, jobId string) (*product.ImportJob, error) {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.GetImportJob")
+	defer span.End()
+	if jobId == "" {
+		return nil, apperror.Validation("job id cannot be empty")
+	}
+	job, err := s.pr.GetImportJobById(ctx, jobId)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func toCatalogueRecord(p product.Product) catalogueRecord {
+	record := catalogueRecord{
+		Sku:         p.Sku,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		Stock:       p.Stock,
+		Tags:        []string{},
+	}
+	if p.CategoryId != nil {
+		record.CategoryId = *p.CategoryId
+	}
+	for _, tag := range p.Tags {
+		record.Tags = append(record.Tags, tag.Name)
+	}
+	return record
+}
+
+// ExportProducts writes the catalogue of the merchant in the same layout ImportProducts
+// reads, so an export can be edited and imported again
+func (s *ProductUsecase) ExportProducts(ctx context.Context, merchantId string, format string, w io.Writer) error {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.ExportProducts")
+	defer span.End()
+	f := catalogue_format.ParseToEnum(strings.ToLower(format))
+	if f != catalogue_format.CSV && f != catalogue_format.JSONL {
+		return apperror.Validation("export format must be csv or jsonl")
+	}
+	products, err := s.GetProductsByMerchant(ctx, merchantId)
+	if err != nil {
+		return err
+	}
+	if f == catalogue_format.JSONL {
+		encoder := json.NewEncoder(w)
+		for _, p := range products {
+			if err := encoder.Encode(toCatalogueRecord(p)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(catalogueColumns); err != nil {
+		return err
+	}
+	for _, p := range products {
+		r := toCatalogueRecord(p)
+		if err := writer.Write([]string{
+			r.Sku, r.Name, r.Description, strconv.Itoa(r.Price), strconv.Itoa(r.Stock),
+			r.CategoryId, strings.Join(r.Tags, tagSeparator),
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,188 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/common/constants/import_status"
+	request "github.com/williamchang80/sea-apd/dto/request/product"
+	product2 "github.com/williamchang80/sea-apd/mocks/repository/product"
+)
+
+const mockCatalogueCSV = `sku,name,price,stock,tags
+NEW,New Shirt,100,5,sale|summer
+EXISTING,Old Shirt,80,0,
+,Missing Sku,10,1,
+BAD,Bad Price,ten,1,
+NEW,Duplicated Sku,10,1,
+`
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       string
+		wantValid  int
+		wantErrors []int
+		wantErr    bool
+	}{
+		{name: "success", args: mockCatalogueCSV, wantValid: 4, wantErrors: []int{4}},
+		{name: "success with columns in any order", args: "stock,price,name,sku\n1,2,Shirt,A\n", wantValid: 1},
+		{name: "failed with missing column", args: "sku,name,price\nA,Shirt,1\n", wantErr: true},
+		{name: "failed with empty file", args: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, rowErrors, err := parseCSV(strings.NewReader(tt.args))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseCSV() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(records) != tt.wantValid {
+				t.Errorf("parseCSV() records = %v, want %v", len(records), tt.wantValid)
+			}
+			var rows []int
+			for _, e := range rowErrors {
+				rows = append(rows, e.Row)
+			}
+			if !reflect.DeepEqual(rows, tt.wantErrors) {
+				t.Errorf("parseCSV() error rows = %v, want %v", rows, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestParseJSONL(t *testing.T) {
+	file := `{"sku":"A","name":"Shirt","price":10,"stock":1,"tags":["sale"]}
+
+{"sku":"B","name":"Hat","price":"free"}
+{"sku":"C","name":"Sock","price":5,"stock":2}
+`
+	records, rowErrors, err := parseJSONL(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("parseJSONL() error = %v", err)
+	}
+	if len(records) != 2 || records[1].Row != 3 || records[1].Tags != nil {
+		t.Errorf("parseJSONL() records = %+v", records)
+	}
+	if len(rowErrors) != 1 || rowErrors[0].Row != 2 {
+		t.Errorf("parseJSONL() row errors = %+v", rowErrors)
+	}
+}
+
+func TestValidateRecords(t *testing.T) {
+	records, rowErrors, _ := parseCSV(strings.NewReader(mockCatalogueCSV))
+	valid, rowErrors := validateRecords(records, rowErrors)
+	if len(valid) != 2 {
+		t.Errorf("validateRecords() valid = %v, want 2", len(valid))
+	}
+	var rows []int
+	for _, e := range rowErrors {
+		rows = append(rows, e.Row)
+	}
+	if !reflect.DeepEqual(rows, []int{3, 4, 5}) {
+		t.Errorf("validateRecords() error rows = %v, want [3 4 5]", rows)
+	}
+}
+
+func TestProductUsecase_ImportProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name        string
+		args        request.ImportProductsRequest
+		wantErr     bool
+		wantStatus  string
+		wantCreated int
+		wantUpdated int
+		wantFailed  int
+	}{
+		{
+			name: "success with dry run",
+			args: request.ImportProductsRequest{MerchantId: "1", Format: "csv", DryRun: true,
+				File: strings.NewReader(mockCatalogueCSV)},
+			wantStatus: import_status.ToString(import_status.COMPLETED),
+			wantFailed: 3,
+		},
+		{
+			name: "success with upsert by sku",
+			args: request.ImportProductsRequest{MerchantId: "1", Format: "CSV",
+				File: strings.NewReader(mockCatalogueCSV)},
+			wantStatus:  import_status.ToString(import_status.COMPLETED),
+			wantCreated: 1,
+			wantUpdated: 1,
+			wantFailed:  3,
+		},
+		{
+			name: "failed with unknown format",
+			args: request.ImportProductsRequest{MerchantId: "1", Format: "xml",
+				File: strings.NewReader(mockCatalogueCSV)},
+			wantErr: true,
+		},
+		{
+			name: "failed with empty file",
+			args: request.ImportProductsRequest{MerchantId: "1", Format: "jsonl",
+				File: strings.NewReader("")},
+			wantErr: true,
+		},
+		{
+			name: "failed with empty merchant id",
+			args: request.ImportProductsRequest{Format: "csv",
+				File: strings.NewReader(mockCatalogueCSV)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewProductUseCase(product2.NewMockRepository(ctrl), nil, nil)
+			job, err := u.ImportProducts(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductUsecase.ImportProducts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if job.Status != tt.wantStatus || job.CreatedRows != tt.wantCreated ||
+				job.UpdatedRows != tt.wantUpdated || job.FailedRows != tt.wantFailed ||
+				job.ProcessedRows != job.TotalRows {
+				t.Errorf("ProductUsecase.ImportProducts() = %+v", job)
+			}
+		})
+	}
+}
+
+func TestProductUsecase_ExportProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name       string
+		merchantId string
+		format     string
+		want       string
+		wantErr    bool
+	}{
+		{name: "success with csv", merchantId: "1", format: "csv",
+			want: "sku,name,description,price,stock,category_id,tags\n"},
+		{name: "success with jsonl", merchantId: "1", format: "jsonl", want: ""},
+		{name: "failed with unknown format", merchantId: "1", format: "xml", wantErr: true},
+		{name: "failed with empty merchant id", merchantId: "", format: "csv", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewProductUseCase(product2.NewMockRepository(ctrl), nil, nil)
+			var buffer bytes.Buffer
+			err := u.ExportProducts(context.Background(), tt.merchantId, tt.format, &buffer)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductUsecase.ExportProducts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && buffer.String() != tt.want {
+				t.Errorf("ProductUsecase.ExportProducts() = %q, want %q", buffer.String(), tt.want)
+			}
+		})
+	}
+}
@@ -1,75 +1,231 @@
 package product
 
 import (
+	"context"
+	"strings"
+
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	"github.com/williamchang80/sea-apd/domain/product"
-	"github.com/williamchang80/sea-apd/domain/transaction"
 	request "github.com/williamchang80/sea-apd/dto/request/product"
+	"github.com/williamchang80/sea-apd/infrastructure/storage"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
 )
 
 type ProductUsecase struct {
 	pr product.ProductRepository
+	ps product.ProductSearcher
+	bs storage.BlobStore
 }
 
 func ConvertToDomain(p request.ProductRequest) product.Product {
+	var categoryId *string
+	if p.CategoryId != "" {
+		categoryId = &p.CategoryId
+	}
 	return product.Product{
+		Sku:         strings.TrimSpace(p.Sku),
 		Name:        p.Name,
 		Description: p.Description,
 		Price:       p.Price,
 		Image:       "",
 		Stock:       p.Stock,
+		WeightGrams: p.WeightGrams,
+		LengthCm:    p.LengthCm,
+		WidthCm:     p.WidthCm,
+		HeightCm:    p.HeightCm,
 		MerchantId:  p.MerchantId,
+		CategoryId:  categoryId,
+	}
+}
+
+// normalizeTags lowercases, trims and de-duplicates tag names so "Sale" and "sale " share a tag
+func normalizeTags(tags []string) []string {
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+func (s *ProductUsecase) attachTags(ctx context.Context, tags []string, p *product.Product) error {
+	if tags == nil {
+		return nil
+	}
+	t, err := s.pr.GetOrCreateTags(ctx, normalizeTags(tags))
+	if err != nil {
+		return err
+	}
+	p.Tags = t
+	return nil
+}
+
+func ConvertSearchRequestToQuery(r request.ProductSearchRequest) (product.ProductSearchQuery, error) {
+	if r.MinPrice < 0 || r.MaxPrice < 0 || r.Limit < 0 || r.Offset < 0 {
+		return product.ProductSearchQuery{}, apperror.Validation("search parameters cannot be negative")
+	}
+	if r.MaxPrice > 0 && r.MinPrice > r.MaxPrice {
+		return product.ProductSearchQuery{}, apperror.Validation("min price cannot be more than max price")
 	}
+	limit := r.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	return product.ProductSearchQuery{
+		Keyword:    r.Query,
+		MinPrice:   r.MinPrice,
+		MaxPrice:   r.MaxPrice,
+		InStock:    r.InStock,
+		MerchantId: r.MerchantId,
+		Sort:       r.Sort,
+		Limit:      limit,
+		Offset:     r.Offset,
+	}, nil
 }
-func NewProductUseCase(p product.ProductRepository) product.ProductUsecase {
+
+func NewProductUseCase(p product.ProductRepository, s product.ProductSearcher,
+	b storage.BlobStore) product.ProductUsecase {
 	return &ProductUsecase{
 		pr: p,
+		ps: s,
+		bs: b,
 	}
 }
-func (s *ProductUsecase) GetProducts() ([]product.Product, error) {
-	p, err := s.pr.GetProducts()
+func (s *ProductUsecase) GetProducts(ctx context.Context) ([]product.Product, error) {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.GetProducts")
+	defer span.End()
+	p, err := s.pr.GetProducts(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return p, nil
 }
-func (s *ProductUsecase) GetProductById(productId string) (*product.Product, error) {
-	p, err := s.pr.GetProductById(productId)
+func (s *ProductUsecase) GetProductById(ctx context.Context, productId string) (*product.Product, error) {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.GetProductById")
+	defer span.End()
+	p, err := s.pr.GetProductById(ctx, productId)
 	if err != nil || p == nil {
 		return nil, err
 	}
 	return p, nil
 }
-func (s *ProductUsecase) CreateProduct(product request.ProductRequest) error {
+func (s *ProductUsecase) CreateProduct(ctx context.Context, product request.ProductRequest) error {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.CreateProduct")
+	defer span.End()
 	p := ConvertToDomain(product)
-	err := s.pr.CreateProduct(p)
+	if err := s.attachTags(ctx, product.Tags, &p); err != nil {
+		return err
+	}
+	if product.Image != nil {
+		if err := storeProductImage(s.bs, product.Image, &p); err != nil {
+			return err
+		}
+	}
+	err := s.pr.CreateProduct(ctx, p)
 	if err != nil {
+		deleteProductImage(s.bs, p.ImageKey)
 		return err
 	}
 	return nil
 }
-func (s *ProductUsecase) UpdateProduct(productId string, request request.ProductRequest) error {
+func (s *ProductUsecase) UpdateProduct(ctx context.Context, productId string, request request.ProductRequest) error {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.UpdateProduct")
+	defer span.End()
 	p := ConvertToDomain(request)
-	err := s.pr.UpdateProduct(productId, p)
+	if err := s.attachTags(ctx, request.Tags, &p); err != nil {
+		return err
+	}
+	if request.Image == nil {
+		return s.pr.UpdateProduct(ctx, productId, p)
+	}
+	old, err := s.pr.GetProductById(ctx, productId)
 	if err != nil {
 		return err
 	}
+	if err := storeProductImage(s.bs, request.Image, &p); err != nil {
+		return err
+	}
+	if err := s.pr.UpdateProduct(ctx, productId, p); err != nil {
+		deleteProductImage(s.bs, p.ImageKey)
+		return err
+	}
+	deleteProductImage(s.bs, old.ImageKey)
 	return nil
 }
-func (s *ProductUsecase) DeleteProduct(productId string) error {
-	err := s.pr.DeleteProduct(productId)
+func (s *ProductUsecase) DeleteProduct(ctx context.Context, productId string) error {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.DeleteProduct")
+	defer span.End()
+	old, _ := s.pr.GetProductById(ctx, productId)
+	err := s.pr.DeleteProduct(ctx, productId)
 	if err != nil {
 		return err
 	}
+	if old != nil {
+		deleteProductImage(s.bs, old.ImageKey)
+	}
 	return nil
 }
-func (s *ProductUsecase) GetProductsByMerchant(merchantId string) ([]product.Product, error) {
-	products, err := s.pr.GetProductsByMerchant(merchantId)
+func (s *ProductUsecase) GetProductsByMerchant(ctx context.Context, merchantId string) ([]product.Product, error) {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.GetProductsByMerchant")
+	defer span.End()
+	products, err := s.pr.GetProductsByMerchant(ctx, merchantId)
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+
+func (s *ProductUsecase) SearchProducts(ctx context.Context, r request.ProductSearchRequest) ([]product.Product, error) {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.SearchProducts")
+	defer span.End()
+	query, err := ConvertSearchRequestToQuery(r)
+	if err != nil {
+		return nil, err
+	}
+	products, err := s.ps.SearchProducts(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 	return products, nil
 }
 
-func (s *ProductUsecase) GetProductPriceTotal(transaction transaction.Transaction) (int, error) {
-	return 2, nil
-}
\ No newline at end of file
+func (s *ProductUsecase) GetProductsByCategories(ctx context.Context, categoryIds []string) ([]product.Product, error) {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.GetProductsByCategories")
+	defer span.End()
+	if len(categoryIds) == 0 {
+		return []product.Product{}, nil
+	}
+	products, err := s.pr.GetProductsByCategories(ctx, categoryIds)
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (s *ProductUsecase) GetProductsByTag(ctx context.Context, tag string) ([]product.Product, error) {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.GetProductsByTag")
+	defer span.End()
+	tags := normalizeTags([]string{tag})
+	if len(tags) == 0 {
+		return nil, apperror.Validation("tag cannot be empty")
+	}
+	products, err := s.pr.GetProductsByTag(ctx, tags[0])
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
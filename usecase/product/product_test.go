@@ -1,10 +1,14 @@
 package product
 
 import (
+	"context"
 	"github.com/golang/mock/gomock"
 	"github.com/williamchang80/sea-apd/domain/product"
 	request "github.com/williamchang80/sea-apd/dto/request/product"
+	"github.com/williamchang80/sea-apd/infrastructure/storage"
 	product2 "github.com/williamchang80/sea-apd/mocks/repository/product"
+	memory "github.com/williamchang80/sea-apd/repository/memory/product"
+	"io/ioutil"
 	"os"
 	"reflect"
 	"testing"
@@ -13,6 +17,8 @@ import (
 func TestNewProductUseCase(t *testing.T) {
 	type args struct {
 		repository product.ProductRepository
+		searcher   product.ProductSearcher
+		store      storage.BlobStore
 	}
 	tests := []struct {
 		name string
@@ -23,15 +29,19 @@ func TestNewProductUseCase(t *testing.T) {
 			name: "success",
 			args: args{
 				repository: nil,
+				searcher:   nil,
+				store:      nil,
 			},
 			want: &ProductUsecase{
 				pr: nil,
+				ps: nil,
+				bs: nil,
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewProductUseCase(tt.args.repository); !reflect.DeepEqual(got, tt.want) {
+			if got := NewProductUseCase(tt.args.repository, tt.args.searcher, tt.args.store); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewProductUseCase() = %v, want %v", got, tt.want)
 			}
 		})
@@ -53,7 +63,7 @@ func TestProductUseCase_GetProducts(t *testing.T) {
 			wantErr: false,
 			initMock: func() product.ProductUsecase {
 				r := product2.NewMockRepository(ctrl)
-				return NewProductUseCase(r)
+				return NewProductUseCase(r, nil, nil)
 			},
 		},
 		{
@@ -62,14 +72,14 @@ func TestProductUseCase_GetProducts(t *testing.T) {
 			wantErr: true,
 			initMock: func() product.ProductUsecase {
 				r := product2.NewMockRepository(ctrl)
-				return NewProductUseCase(r)
+				return NewProductUseCase(r, nil, nil)
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := tt.initMock()
-			p, err := c.GetProducts()
+			p, err := c.GetProducts(context.Background())
 			if err != nil && !tt.wantErr {
 				t.Errorf("ProductUsecase.GetProducts() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -148,7 +158,7 @@ func TestProductUsecase_GetProductById(t *testing.T) {
 			wantErr: false,
 			initMock: func() product.ProductUsecase {
 				r := product2.NewMockRepository(ctrl)
-				return NewProductUseCase(r)
+				return NewProductUseCase(r, nil, nil)
 			},
 		},
 		{
@@ -159,7 +169,7 @@ func TestProductUsecase_GetProductById(t *testing.T) {
 			wantErr: true,
 			initMock: func() product.ProductUsecase {
 				r := product2.NewMockRepository(ctrl)
-				return NewProductUseCase(r)
+				return NewProductUseCase(r, nil, nil)
 			},
 		},
 	}
@@ -167,7 +177,7 @@ func TestProductUsecase_GetProductById(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := tt.initMock()
-			p, err := c.GetProductById(tt.args.id)
+			p, err := c.GetProductById(context.Background(), tt.args.id)
 			if err != nil && !tt.wantErr {
 				t.Errorf("ProductUsecase.GetProductById() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -199,7 +209,7 @@ func TestProductUsecase_DeleteProduct(t *testing.T) {
 			wantErr: false,
 			initMock: func() product.ProductUsecase {
 				r := product2.NewMockRepository(ctrl)
-				return NewProductUseCase(r)
+				return NewProductUseCase(r, nil, nil)
 			},
 		},
 		{
@@ -210,14 +220,14 @@ func TestProductUsecase_DeleteProduct(t *testing.T) {
 			wantErr: true,
 			initMock: func() product.ProductUsecase {
 				r := product2.NewMockRepository(ctrl)
-				return NewProductUseCase(r)
+				return NewProductUseCase(r, nil, nil)
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := tt.initMock()
-			err := c.DeleteProduct(tt.args.id)
+			err := c.DeleteProduct(context.Background(), tt.args.id)
 			if err != nil && !tt.wantErr {
 				t.Errorf("ProductUsecase.DeleteProduct() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -227,11 +237,14 @@ func TestProductUsecase_DeleteProduct(t *testing.T) {
 }
 
 func TestProductUsecase_CreateProduct(t *testing.T) {
-	const FILE_PATH = "../mocks/file/mock_image.jpg"
+	const FILE_PATH = "../../mocks/file/mock_image.jpg"
 	ctrl := gomock.NewController(t)
 	image, _ := os.Open(FILE_PATH)
 	defer image.Close()
 	defer ctrl.Finish()
+	dir, _ := ioutil.TempDir("", "product-images")
+	defer os.RemoveAll(dir)
+	store := storage.NewLocalStore(dir, "/uploads")
 	type args struct {
 		request request.ProductRequest
 	}
@@ -255,7 +268,7 @@ func TestProductUsecase_CreateProduct(t *testing.T) {
 			wantErr: false,
 			initMock: func() product.ProductUsecase {
 				r := product2.NewMockRepository(ctrl)
-				return NewProductUseCase(r)
+				return NewProductUseCase(r, nil, store)
 			},
 		},
 		{
@@ -266,14 +279,14 @@ func TestProductUsecase_CreateProduct(t *testing.T) {
 			wantErr: true,
 			initMock: func() product.ProductUsecase {
 				r := product2.NewMockRepository(ctrl)
-				return NewProductUseCase(r)
+				return NewProductUseCase(r, nil, store)
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := tt.initMock()
-			err := c.CreateProduct(tt.args.request)
+			err := c.CreateProduct(context.Background(), tt.args.request)
 			if err != nil && !tt.wantErr {
 				t.Errorf("ProductUsecase.DeleteProduct() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -283,11 +296,14 @@ func TestProductUsecase_CreateProduct(t *testing.T) {
 }
 
 func TestProductUsecase_UpdateProduct(t *testing.T) {
-	const FILE_PATH = "../mocks/file/mock_image.jpg"
+	const FILE_PATH = "../../mocks/file/mock_image.jpg"
 	ctrl := gomock.NewController(t)
 	image, _ := os.Open(FILE_PATH)
 	defer image.Close()
 	defer ctrl.Finish()
+	dir, _ := ioutil.TempDir("", "product-images")
+	defer os.RemoveAll(dir)
+	store := storage.NewLocalStore(dir, "/uploads")
 	type args struct {
 		request   request.ProductRequest
 		productId string
@@ -313,7 +329,7 @@ func TestProductUsecase_UpdateProduct(t *testing.T) {
 			wantErr: false,
 			initMock: func() product.ProductUsecase {
 				r := product2.NewMockRepository(ctrl)
-				return NewProductUseCase(r)
+				return NewProductUseCase(r, nil, store)
 			},
 		},
 		{
@@ -325,14 +341,14 @@ func TestProductUsecase_UpdateProduct(t *testing.T) {
 			wantErr: true,
 			initMock: func() product.ProductUsecase {
 				r := product2.NewMockRepository(ctrl)
-				return NewProductUseCase(r)
+				return NewProductUseCase(r, nil, store)
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := tt.initMock()
-			err := c.UpdateProduct(tt.args.productId, tt.args.request)
+			err := c.UpdateProduct(context.Background(), tt.args.productId, tt.args.request)
 			if err != nil && !tt.wantErr {
 				t.Errorf("ProductUsecase.UpdateProduct() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -363,7 +379,7 @@ func TestProductUsecase_GetProductsByMerchant(t *testing.T) {
 			},
 			initMock: func() product.ProductUsecase {
 				r := product2.NewMockRepository(ctrl)
-				return NewProductUseCase(r)
+				return NewProductUseCase(r, nil, nil)
 			},
 		},
 		{
@@ -372,14 +388,14 @@ func TestProductUsecase_GetProductsByMerchant(t *testing.T) {
 			wantErr: true,
 			initMock: func() product.ProductUsecase {
 				r := product2.NewMockRepository(ctrl)
-				return NewProductUseCase(r)
+				return NewProductUseCase(r, nil, nil)
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := tt.initMock()
-			p, err := c.GetProductsByMerchant(tt.args.merchantId)
+			p, err := c.GetProductsByMerchant(context.Background(), tt.args.merchantId)
 			if err != nil && !tt.wantErr {
 				t.Errorf("ProductUsecase.GetProductsByMerchant() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -390,3 +406,85 @@ func TestProductUsecase_GetProductsByMerchant(t *testing.T) {
 		})
 	}
 }
+
+var mockSearchCatalogue = []product.Product{
+	{Name: "Red Running Shoes", Description: "Lightweight shoes", Price: 500, Stock: 3, MerchantId: "1"},
+	{Name: "Blue Sneakers", Description: "Canvas shoes for daily use", Price: 300, Stock: 0, MerchantId: "1"},
+	{Name: "Leather Wallet", Description: "Brown leather", Price: 200, Stock: 8, MerchantId: "2"},
+}
+
+func TestProductUsecase_SearchProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	type args struct {
+		request request.ProductSearchRequest
+	}
+	tests := []struct {
+		name     string
+		args     args
+		want     []string
+		wantErr  bool
+		initMock func() product.ProductUsecase
+	}{
+		{
+			name:    "success with name ranked above description",
+			args:    args{request: request.ProductSearchRequest{Query: "shoes"}},
+			want:    []string{"Red Running Shoes", "Blue Sneakers"},
+			wantErr: false,
+			initMock: func() product.ProductUsecase {
+				r := product2.NewMockRepository(ctrl)
+				return NewProductUseCase(r, memory.NewProductSearcher(mockSearchCatalogue), nil)
+			},
+		},
+		{
+			name:    "success with typo and prefix",
+			args:    args{request: request.ProductSearchRequest{Query: "lether wal"}},
+			want:    []string{"Leather Wallet"},
+			wantErr: false,
+			initMock: func() product.ProductUsecase {
+				r := product2.NewMockRepository(ctrl)
+				return NewProductUseCase(r, memory.NewProductSearcher(mockSearchCatalogue), nil)
+			},
+		},
+		{
+			name: "success with filters and price ordering",
+			args: args{request: request.ProductSearchRequest{
+				InStock:  true,
+				MaxPrice: 500,
+				Sort:     "price_desc",
+			}},
+			want:    []string{"Red Running Shoes", "Leather Wallet"},
+			wantErr: false,
+			initMock: func() product.ProductUsecase {
+				r := product2.NewMockRepository(ctrl)
+				return NewProductUseCase(r, memory.NewProductSearcher(mockSearchCatalogue), nil)
+			},
+		},
+		{
+			name:    "failed with min price more than max price",
+			args:    args{request: request.ProductSearchRequest{MinPrice: 10, MaxPrice: 5}},
+			wantErr: true,
+			initMock: func() product.ProductUsecase {
+				r := product2.NewMockRepository(ctrl)
+				return NewProductUseCase(r, memory.NewProductSearcher(mockSearchCatalogue), nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.initMock()
+			p, err := c.SearchProducts(context.Background(), tt.args.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductUsecase.SearchProducts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var names []string
+			for _, product := range p {
+				names = append(names, product.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("ProductUsecase.SearchProducts() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
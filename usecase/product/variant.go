@@ -0,0 +1,225 @@
+package product
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/product"
+	"github.com/williamchang80/sea-apd/domain/transaction"
+	request "github.com/williamchang80/sea-apd/dto/request/product"
+)
+
+func convertOptionsRequestToDomain(r request.ProductOptionsRequest) ([]product.ProductOption, error) {
+	seen := map[string]bool{}
+	options := []product.ProductOption{}
+	for i, o := range r.Options {
+		name := strings.TrimSpace(o.Name)
+		if name == "" || seen[strings.ToLower(name)] {
+			return nil, apperror.Validation("option names must be unique and not empty")
+		}
+		seen[strings.ToLower(name)] = true
+		values := []string{}
+		seenValues := map[string]bool{}
+		for _, v := range o.Values {
+			v = strings.TrimSpace(v)
+			if v == "" || seenValues[v] {
+				return nil, apperror.Validation("option values must be unique and not empty")
+			}
+			seenValues[v] = true
+			values = append(values, v)
+		}
+		if len(values) == 0 {
+			return nil, apperror.Validation("option must have at least one value")
+		}
+		options = append(options, product.ProductOption{
+			Name:     name,
+			Position: i,
+			Values:   values,
+		})
+	}
+	return options, nil
+}
+
+// validateOptionValues checks that the values pick exactly one allowed value for every
+// option and that no other variant of the product already uses the same combination
+func validateOptionValues(p product.Product, values []string) error {
+	if len(values) != len(p.Options) {
+		return apperror.Validation("variant must have one value for every product option")
+	}
+	for i, option := range p.Options {
+		allowed := false
+		for _, v := range option.Values {
+			if v == values[i] {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return apperror.Validation("variant value " + values[i] + " is not allowed for option " + option.Name)
+		}
+	}
+	for _, variant := range p.Variants {
+		if reflect.DeepEqual([]string(variant.OptionValues), values) {
+			return apperror.Conflict("variant with the same option values already exists")
+		}
+	}
+	return nil
+}
+
+func validateVariantPricing(price *int, stock int) error {
+	if price != nil && *price < 0 {
+		return apperror.Validation("variant price cannot be negative")
+	}
+	if stock < 0 {
+		return apperror.Validation("variant stock cannot be negative")
+	}
+	return nil
+}
+
+func (s *ProductUsecase) SetProductOptions(ctx context.Context, r request.ProductOptionsRequest) error {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.SetProductOptions")
+	defer span.End()
+	p, err := s.pr.GetProductById(ctx, r.ProductId)
+	if err != nil {
+		return err
+	}
+	if len(p.Variants) > 0 {
+		return apperror.Conflict("options cannot be changed while the product has variants")
+	}
+	options, err := convertOptionsRequestToDomain(r)
+	if err != nil {
+		return err
+	}
+	return s.pr.ReplaceOptions(ctx, r.ProductId, options)
+}
+
+func (s *ProductUsecase) CreateVariant(ctx context.Context, r request.VariantRequest) error {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.CreateVariant")
+	defer span.End()
+	if strings.TrimSpace(r.Sku) == "" {
+		return apperror.Validation("variant sku cannot be empty")
+	}
+	if err := validateVariantPricing(r.Price, r.Stock); err != nil {
+		return err
+	}
+	p, err := s.pr.GetProductById(ctx, r.ProductId)
+	if err != nil {
+		return err
+	}
+	if err := validateOptionValues(*p, r.OptionValues); err != nil {
+		return err
+	}
+	return s.pr.CreateVariant(ctx, product.ProductVariant{
+		ProductId:    r.ProductId,
+		Sku:          strings.TrimSpace(r.Sku),
+		OptionValues: r.OptionValues,
+		Price:        r.Price,
+		Stock:        r.Stock,
+	})
+}
+
+func (s *ProductUsecase) UpdateVariant(ctx context.Context, r request.UpdateVariantRequest) error {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.UpdateVariant")
+	defer span.End()
+	if strings.TrimSpace(r.Sku) == "" {
+		return apperror.Validation("variant sku cannot be empty")
+	}
+	if err := validateVariantPricing(r.Price, r.Stock); err != nil {
+		return err
+	}
+	if _, err := s.pr.GetVariantById(ctx, r.VariantId); err != nil {
+		return err
+	}
+	return s.pr.UpdateVariant(ctx, r.VariantId, product.ProductVariant{
+		Sku:   strings.TrimSpace(r.Sku),
+		Price: r.Price,
+		Stock: r.Stock,
+	})
+}
+
+func (s *ProductUsecase) DeleteVariant(ctx context.Context, variantId string) error {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.DeleteVariant")
+	defer span.End()
+	if _, err := s.pr.GetVariantById(ctx, variantId); err != nil {
+		return err
+	}
+	return s.pr.DeleteVariant(ctx, variantId)
+}
+
+// GetUnitPrice returns the price of one item of the product or, when given, of its variant.
+// Products that have variants can only be bought through one of them.
+func (s *ProductUsecase) GetUnitPrice(ctx context.Context, productId string, variantId *string) (int, error) {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.GetUnitPrice")
+	defer span.End()
+	p, err := s.pr.GetProductById(ctx, productId)
+	if err != nil {
+		return 0, err
+	}
+	if variantId == nil {
+		if len(p.Variants) > 0 {
+			return 0, apperror.Validation("a variant must be selected for this product")
+		}
+		return p.Price, nil
+	}
+	variant, err := s.pr.GetVariantById(ctx, *variantId)
+	if err != nil {
+		return 0, err
+	}
+	if variant.ProductId != productId {
+		return 0, apperror.Validation("variant does not belong to the product")
+	}
+	if variant.Price != nil {
+		return *variant.Price, nil
+	}
+	return p.Price, nil
+}
+
+func validateQuantities(details []transaction.ProductTransaction) error {
+	for _, d := range details {
+		if d.Quantity <= 0 {
+			return apperror.Validation("quantity must be more than zero")
+		}
+	}
+	return nil
+}
+
+func (s *ProductUsecase) ReserveStock(ctx context.Context, details []transaction.ProductTransaction) error {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.ReserveStock")
+	defer span.End()
+	if err := validateQuantities(details); err != nil {
+		return err
+	}
+	return s.pr.ReserveStock(ctx, details)
+}
+
+func (s *ProductUsecase) ReleaseStock(ctx context.Context, details []transaction.ProductTransaction) error {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.ReleaseStock")
+	defer span.End()
+	if err := validateQuantities(details); err != nil {
+		return err
+	}
+	return s.pr.ReleaseStock(ctx, details)
+}
+
+// GetProductPriceTotal sums the transaction lines, using the unit price captured at
+// checkout when there is one and the current product or variant price otherwise
+func (s *ProductUsecase) GetProductPriceTotal(ctx context.Context, transaction transaction.Transaction) (int, error) {
+	ctx, span := tracing.Start(ctx, "ProductUsecase.GetProductPriceTotal")
+	defer span.End()
+	total := 0
+	for _, d := range transaction.ProductDetails {
+		unitPrice := d.UnitPrice
+		if unitPrice == 0 {
+			price, err := s.GetUnitPrice(ctx, d.ProductId, d.VariantId)
+			if err != nil {
+				return 0, err
+			}
+			unitPrice = price
+		}
+		total += unitPrice * d.Quantity
+	}
+	return total, nil
+}
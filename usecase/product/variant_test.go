@@ -0,0 +1,129 @@
+package product
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/product"
+	"github.com/williamchang80/sea-apd/domain/transaction"
+	request "github.com/williamchang80/sea-apd/dto/request/product"
+	product2 "github.com/williamchang80/sea-apd/mocks/repository/product"
+)
+
+var mockProductWithOptions = product.Product{
+	Base: domain.Base{ID: "1"},
+	Options: []product.ProductOption{
+		{Name: "Size", Position: 0, Values: []string{"S", "M"}},
+		{Name: "Color", Position: 1, Values: []string{"Red", "Blue"}},
+	},
+	Variants: []product.ProductVariant{
+		{Sku: "S-RED", OptionValues: []string{"S", "Red"}},
+	},
+}
+
+func TestValidateOptionValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{name: "success", args: []string{"M", "Red"}, wantErr: false},
+		{name: "failed with missing option", args: []string{"M"}, wantErr: true},
+		{name: "failed with unknown value", args: []string{"XL", "Red"}, wantErr: true},
+		{name: "failed with existing combination", args: []string{"S", "Red"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateOptionValues(mockProductWithOptions, tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("validateOptionValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConvertOptionsRequestToDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    request.ProductOptionsRequest
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: request.ProductOptionsRequest{Options: []request.ProductOptionRequest{
+				{Name: "Size", Values: []string{"S", "M"}},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "failed with duplicated option name",
+			args: request.ProductOptionsRequest{Options: []request.ProductOptionRequest{
+				{Name: "Size", Values: []string{"S"}},
+				{Name: "size", Values: []string{"M"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "failed with option without values",
+			args: request.ProductOptionsRequest{Options: []request.ProductOptionRequest{
+				{Name: "Size"},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := convertOptionsRequestToDomain(tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("convertOptionsRequestToDomain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProductUsecase_GetUnitPrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	variantId := "1"
+	tests := []struct {
+		name      string
+		productId string
+		variantId *string
+		want      int
+		wantErr   bool
+	}{
+		{name: "success with product price", productId: "1", variantId: nil, want: 20},
+		{name: "success with variant price override", productId: "1", variantId: &variantId, want: 30},
+		{name: "failed with variant of other product", productId: "2", variantId: &variantId, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewProductUseCase(product2.NewMockRepository(ctrl), nil, nil)
+			got, err := u.GetUnitPrice(context.Background(), tt.productId, tt.variantId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductUsecase.GetUnitPrice() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ProductUsecase.GetUnitPrice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductUsecase_GetProductPriceTotal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	variantId := "1"
+	u := NewProductUseCase(product2.NewMockRepository(ctrl), nil, nil)
+	got, err := u.GetProductPriceTotal(context.Background(), transaction.Transaction{
+		ProductDetails: []transaction.ProductTransaction{
+			{ProductId: "1", Quantity: 2},
+			{ProductId: "1", VariantId: &variantId, Quantity: 1},
+			{ProductId: "1", Quantity: 3, UnitPrice: 5},
+		},
+	})
+	if err != nil || got != 20*2+30+5*3 {
+		t.Errorf("ProductUsecase.GetProductPriceTotal() = %v, %v", got, err)
+	}
+}
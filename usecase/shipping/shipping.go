@@ -0,0 +1,96 @@
+package shipping
+
+import (
+	"context"
+	"strings"
+
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/shipping"
+	request "github.com/williamchang80/sea-apd/dto/request/shipping"
+	provider "github.com/williamchang80/sea-apd/infrastructure/shipping"
+)
+
+var (
+	ErrEmptyRegion     = apperror.Validation("region cannot be empty")
+	ErrDuplicateRate   = apperror.Validation("rates repeat the weight limit of a region")
+	ErrNegativeParcel  = apperror.Validation("weight and volume of the parcel cannot be negative")
+	ErrEmptyMerchantId = apperror.Validation("merchant id cannot be empty")
+)
+
+type ShippingUsecase struct {
+	repo     shipping.ShippingRepository
+	provider shipping.ShippingRateProvider
+}
+
+func NewShippingUsecase(repo shipping.ShippingRepository, p shipping.ShippingRateProvider) shipping.ShippingUsecase {
+	return &ShippingUsecase{repo: repo, provider: p}
+}
+
+func convertToDomain(merchantId string, rates []request.ShippingRateRequest) ([]shipping.ShippingRate, error) {
+	type rateKey struct {
+		region    string
+		maxWeight int
+	}
+	seen := map[rateKey]bool{}
+	converted := []shipping.ShippingRate{}
+	for _, r := range rates {
+		region := provider.NormalizeRegion(r.Region)
+		if region == "" {
+			return nil, ErrEmptyRegion
+		}
+		key := rateKey{region: strings.ToLower(region), maxWeight: r.MaxWeightGrams}
+		if seen[key] {
+			return nil, ErrDuplicateRate
+		}
+		seen[key] = true
+		converted = append(converted, shipping.ShippingRate{
+			MerchantId:     merchantId,
+			Region:         region,
+			MaxWeightGrams: r.MaxWeightGrams,
+			Cost:           r.Cost,
+		})
+	}
+	return converted, nil
+}
+
+func (s *ShippingUsecase) GetShippingRates(ctx context.Context, merchantId string) ([]shipping.ShippingRate, error) {
+	ctx, span := tracing.Start(ctx, "ShippingUsecase.GetShippingRates")
+	defer span.End()
+	if merchantId == "" {
+		return nil, ErrEmptyMerchantId
+	}
+	rates, err := s.repo.GetShippingRates(ctx, merchantId)
+	if err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// UpdateShippingRates replaces the rate table of the merchant, an empty table makes the
+// merchant ship for free with the table provider
+func (s *ShippingUsecase) UpdateShippingRates(ctx context.Context, r request.UpdateShippingRatesRequest) error {
+	ctx, span := tracing.Start(ctx, "ShippingUsecase.UpdateShippingRates")
+	defer span.End()
+	if r.MerchantId == "" {
+		return ErrEmptyMerchantId
+	}
+	rates, err := convertToDomain(r.MerchantId, r.Rates)
+	if err != nil {
+		return err
+	}
+	return s.repo.ReplaceShippingRates(ctx, r.MerchantId, rates)
+}
+
+// QuoteShipping asks the configured provider for the cost of sending the parcel
+func (s *ShippingUsecase) QuoteShipping(ctx context.Context, parcel shipping.Parcel) (*shipping.Quote, error) {
+	ctx, span := tracing.Start(ctx, "ShippingUsecase.QuoteShipping")
+	defer span.End()
+	if parcel.MerchantId == "" {
+		return nil, ErrEmptyMerchantId
+	}
+	if parcel.WeightGrams < 0 || parcel.VolumeCm3 < 0 {
+		return nil, ErrNegativeParcel
+	}
+	return s.provider.Quote(ctx, parcel)
+}
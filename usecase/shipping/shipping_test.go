@@ -0,0 +1,60 @@
+package shipping
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	request "github.com/williamchang80/sea-apd/dto/request/shipping"
+	provider "github.com/williamchang80/sea-apd/infrastructure/shipping"
+	shipping2 "github.com/williamchang80/sea-apd/mocks/repository/shipping"
+)
+
+func TestShippingUsecase_UpdateShippingRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.UpdateShippingRatesRequest
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: request.UpdateShippingRatesRequest{MerchantId: shipping2.MockMerchantId, Rates: []request.ShippingRateRequest{
+				{Region: "DKI Jakarta", MaxWeightGrams: 1000, Cost: 9000},
+				{Region: "DKI Jakarta", MaxWeightGrams: 5000, Cost: 20000},
+				{Region: "*", MaxWeightGrams: 1000, Cost: 15000},
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "success with empty table",
+			args:    request.UpdateShippingRatesRequest{MerchantId: shipping2.MockMerchantId},
+			wantErr: false,
+		},
+		{
+			name: "failed with repeated weight limit of a region",
+			args: request.UpdateShippingRatesRequest{MerchantId: shipping2.MockMerchantId, Rates: []request.ShippingRateRequest{
+				{Region: "DKI Jakarta", MaxWeightGrams: 1000, Cost: 9000},
+				{Region: "dki  jakarta", MaxWeightGrams: 1000, Cost: 10000},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "failed with blank region",
+			args: request.UpdateShippingRatesRequest{MerchantId: shipping2.MockMerchantId, Rates: []request.ShippingRateRequest{
+				{Region: "  ", MaxWeightGrams: 1000, Cost: 9000},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := shipping2.NewMockRepository(ctrl)
+			u := NewShippingUsecase(repo, provider.NewTableRateProvider(repo))
+			if err := u.UpdateShippingRates(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("UpdateShippingRates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
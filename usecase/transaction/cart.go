@@ -0,0 +1,159 @@
+package transaction
+
+import (
+	"context"
+	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/shipping"
+	"github.com/williamchang80/sea-apd/domain/transaction"
+	transaction2 "github.com/williamchang80/sea-apd/dto/request/transaction"
+)
+
+func (t TransactionUsecase) getOrCreateCart(ctx context.Context, customerId string, merchantId string) (*transaction.Transaction, error) {
+	cart, err := t.tr.GetCart(ctx, customerId, merchantId)
+	if err != nil || cart != nil {
+		return cart, err
+	}
+	if err := t.tr.CreateTransaction(ctx, transaction.Transaction{
+		Status:     transaction_status.ToString(transaction_status.ON_CARTS),
+		CustomerId: customerId,
+		MerchantId: merchantId,
+	}); err != nil {
+		return nil, err
+	}
+	return t.tr.GetCart(ctx, customerId, merchantId)
+}
+
+func (t TransactionUsecase) AddToCart(ctx context.Context, request transaction2.CartItemRequest) error {
+	ctx, span := tracing.Start(ctx, "TransactionUsecase.AddToCart")
+	defer span.End()
+	if request.Quantity <= 0 {
+		return apperror.Validation("quantity must be more than zero")
+	}
+	p, err := t.productUseCase.GetProductById(ctx, request.ProductId)
+	if err != nil || p == nil {
+		return apperror.NotFound("product not found")
+	}
+	if p.MerchantId != request.MerchantId {
+		return apperror.Validation("product does not belong to the merchant")
+	}
+	if err := t.merchantUseCase.ValidateMerchantActive(ctx, request.MerchantId); err != nil {
+		return err
+	}
+	if _, err := t.productUseCase.GetUnitPrice(ctx, request.ProductId, request.VariantId); err != nil {
+		return err
+	}
+	cart, err := t.getOrCreateCart(ctx, request.CustomerId, request.MerchantId)
+	if err != nil {
+		return err
+	}
+	if cart == nil {
+		return apperror.Validation("cart cannot be created")
+	}
+	return t.tr.AddCartItem(ctx, transaction.ProductTransaction{
+		TransactionId: cart.ID,
+		ProductId:     request.ProductId,
+		VariantId:     request.VariantId,
+		Quantity:      request.Quantity,
+	})
+}
+
+func (t TransactionUsecase) GetCart(ctx context.Context, customerId string) ([]transaction.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "TransactionUsecase.GetCart")
+	defer span.End()
+	carts, err := t.tr.GetCartsByCustomer(ctx, customerId)
+	if err != nil {
+		return nil, err
+	}
+	return carts, nil
+}
+
+// getCheckoutCart returns the cart of the customer with the chosen address, or the default
+// address of the customer, copied onto it as its shipping address
+func (t TransactionUsecase) getCheckoutCart(ctx context.Context, request transaction2.CheckoutRequest) (
+	*transaction.Transaction, error) {
+	cart, err := t.tr.GetTransactionById(ctx, request.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	if cart.CustomerId != request.CustomerId ||
+		transaction_status.ParseToEnum(cart.Status) != transaction_status.ON_CARTS {
+		return nil, apperror.NotFound("cart not found")
+	}
+	if len(cart.ProductDetails) == 0 {
+		return nil, apperror.Validation("cart is empty")
+	}
+	addr, err := t.addressUseCase.GetShippingAddress(ctx, request.CustomerId, request.AddressId)
+	if err != nil {
+		return nil, err
+	}
+	cart.ShippingAddress = convertAddressToShippingAddress(*addr)
+	return cart, nil
+}
+
+// Checkout prices every cart line at its current product or variant price, quotes the
+// shipping to the shipping address, reserves the stock and moves the cart to waiting
+// payment. The amount includes the shipping cost. The reservation is released again when
+// the transaction is declined.
+func (t TransactionUsecase) Checkout(ctx context.Context, request transaction2.CheckoutRequest) error {
+	ctx, span := tracing.Start(ctx, "TransactionUsecase.Checkout")
+	defer span.End()
+	cart, err := t.getCheckoutCart(ctx, request)
+	if err != nil {
+		return err
+	}
+	if err := t.userUseCase.ValidateUserVerified(ctx, request.CustomerId); err != nil {
+		return err
+	}
+	if err := t.merchantUseCase.ValidateMerchantActive(ctx, cart.MerchantId); err != nil {
+		return err
+	}
+	total := 0
+	for i, item := range cart.ProductDetails {
+		unitPrice, err := t.productUseCase.GetUnitPrice(ctx, item.ProductId, item.VariantId)
+		if err != nil {
+			return err
+		}
+		cart.ProductDetails[i].UnitPrice = unitPrice
+		total += unitPrice * item.Quantity
+	}
+	quote, err := t.quoteShipping(ctx, *cart)
+	if err != nil {
+		return err
+	}
+	if err := t.productUseCase.ReserveStock(ctx, cart.ProductDetails); err != nil {
+		return err
+	}
+	cart.ShippingCost = quote.Cost
+	cart.ShippingProvider = quote.Provider
+	cart.ShippingService = quote.Service
+	cart.Amount = total + quote.Cost
+	cart.Status = transaction_status.ToString(transaction_status.WAITING_PAYMENT)
+	if err := t.tr.CheckoutTransaction(ctx, *cart); err != nil {
+		t.productUseCase.ReleaseStock(ctx, cart.ProductDetails)
+		return err
+	}
+	return nil
+}
+
+// QuoteShipping quotes the shipping of the cart the way Checkout would, without checking it out
+func (t TransactionUsecase) QuoteShipping(ctx context.Context, request transaction2.CheckoutRequest) (*shipping.Quote, error) {
+	ctx, span := tracing.Start(ctx, "TransactionUsecase.QuoteShipping")
+	defer span.End()
+	cart, err := t.getCheckoutCart(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return t.quoteShipping(ctx, *cart)
+}
+
+// hasReservedStock tells whether stock was taken for the transaction at checkout and
+// not given back yet
+func hasReservedStock(status string) bool {
+	switch transaction_status.ParseToEnum(status) {
+	case transaction_status.ON_CARTS, transaction_status.DECLINED, transaction_status.OTHER:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+package transaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/domain/transaction"
+	request "github.com/williamchang80/sea-apd/dto/request/transaction"
+	transaction2 "github.com/williamchang80/sea-apd/mocks/repository/transaction"
+	"github.com/williamchang80/sea-apd/mocks/usecase/address"
+	"github.com/williamchang80/sea-apd/mocks/usecase/merchant"
+	"github.com/williamchang80/sea-apd/mocks/usecase/product"
+	"github.com/williamchang80/sea-apd/mocks/usecase/shipping"
+	"github.com/williamchang80/sea-apd/mocks/usecase/user"
+)
+
+func TestTransactionUsecase_Checkout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.CheckoutRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    request.CheckoutRequest{CustomerId: "1", TransactionId: transaction2.MockCartId},
+			wantErr: false,
+		},
+		{
+			name: "success with chosen address",
+			args: request.CheckoutRequest{CustomerId: "1", TransactionId: transaction2.MockCartId,
+				AddressId: "1"},
+			wantErr: false,
+		},
+		{
+			name: "failed with unknown address",
+			args: request.CheckoutRequest{CustomerId: "1", TransactionId: transaction2.MockCartId,
+				AddressId: address.MockUnknownAddressId},
+			wantErr: true,
+		},
+		{
+			name:    "failed with cart of other customer",
+			args:    request.CheckoutRequest{CustomerId: "2", TransactionId: transaction2.MockCartId},
+			wantErr: true,
+		},
+		{
+			name:    "failed with transaction that is not a cart",
+			args:    request.CheckoutRequest{CustomerId: "", TransactionId: "1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewTransactionUsecase(transaction2.NewMockRepository(ctrl),
+				merchant.NewMockUsecase(ctrl), product.NewMockUsecase(ctrl), user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
+			if err := c.Checkout(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("TransactionUsecase.Checkout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransactionUsecase_AddToCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.CartItemRequest
+		wantErr bool
+	}{
+		{
+			name:    "failed with zero quantity",
+			args:    request.CartItemRequest{CustomerId: "1", MerchantId: "1", ProductId: "1"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with product of other merchant",
+			args:    request.CartItemRequest{CustomerId: "1", MerchantId: "2", ProductId: "1", Quantity: 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewTransactionUsecase(transaction2.NewMockRepository(ctrl),
+				merchant.NewMockUsecase(ctrl), product.NewMockUsecase(ctrl), user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
+			if err := c.AddToCart(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("TransactionUsecase.AddToCart() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHasReservedStock(t *testing.T) {
+	tests := []struct {
+		name string
+		args transaction.Transaction
+		want bool
+	}{
+		{name: "cart", args: transaction.Transaction{Status: "on carts"}, want: false},
+		{name: "waiting payment", args: transaction.Transaction{Status: "waiting payment"}, want: true},
+		{name: "declined", args: transaction.Transaction{Status: "declined"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasReservedStock(tt.args.Status); got != tt.want {
+				t.Errorf("hasReservedStock() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
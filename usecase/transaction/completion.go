@@ -0,0 +1,117 @@
+package transaction
+
+import (
+	"context"
+	"time"
+
+	"github.com/williamchang80/sea-apd/common/constants/mailer_type"
+	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
+	"github.com/williamchang80/sea-apd/common/logger"
+	"github.com/williamchang80/sea-apd/common/mailer"
+	"github.com/williamchang80/sea-apd/common/mailer/factory"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/transaction"
+	transaction2 "github.com/williamchang80/sea-apd/dto/request/transaction"
+)
+
+const autoCompleteReason = "delivery was not confirmed in time"
+
+var (
+	ErrNotDelivered     = apperror.InvalidTransition("only shipped transactions can be confirmed as delivered")
+	ErrManualCompletion = apperror.InvalidTransition("transactions complete when the customer confirms the delivery")
+)
+
+// ConfirmDelivery completes a shipped transaction on behalf of its customer
+func (t TransactionUsecase) ConfirmDelivery(ctx context.Context, request transaction2.ConfirmDeliveryRequest) error {
+	ctx, span := tracing.Start(ctx, "TransactionUsecase.ConfirmDelivery")
+	defer span.End()
+	tran, err := t.tr.GetTransactionById(ctx, request.TransactionId)
+	if err != nil || request.CustomerId == "" || tran.CustomerId != request.CustomerId {
+		return apperror.NotFound("transaction not found")
+	}
+	if transaction_status.ParseToEnum(tran.Status) != transaction_status.ACCEPTED {
+		return ErrNotDelivered
+	}
+	return t.complete(ctx, *tran, request.CustomerId, "")
+}
+
+// CompleteShippedTransactions completes the transactions shipped before the given time
+// whose delivery the customer never confirmed and returns how many were completed
+func (t TransactionUsecase) CompleteShippedTransactions(ctx context.Context, shippedBefore time.Time) (int, error) {
+	ctx, span := tracing.Start(ctx, "TransactionUsecase.CompleteShippedTransactions")
+	defer span.End()
+	transactions, err := t.tr.GetShippedTransactions(ctx, shippedBefore)
+	if err != nil {
+		return 0, err
+	}
+	completed := 0
+	for _, tran := range transactions {
+		err := t.complete(ctx, tran, "", autoCompleteReason)
+		if err == transaction.ErrInvalidStatusTransition {
+			continue
+		}
+		if err != nil {
+			logger.Error(ctx, "completing transaction failed", logger.Fields{
+				"transaction_id": tran.ID,
+				"error":          err.Error(),
+			})
+			continue
+		}
+		completed++
+	}
+	return completed, nil
+}
+
+// StartAutoCompleteJob completes the transactions shipped longer than after ago now and
+// then every interval
+func StartAutoCompleteJob(u transaction.TransactionUsecase, after time.Duration, interval time.Duration) {
+	go func() {
+		ctx := logger.WithRequestId(context.Background(), "auto-complete-job")
+		for {
+			if _, err := u.CompleteShippedTransactions(ctx, time.Now().Add(-after)); err != nil {
+				logger.Error(ctx, "completing shipped transactions failed", logger.Fields{"error": err.Error()})
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// complete moves the transaction from accepted to completed and releases its amount to
+// the available balance of the merchant in one database transaction. The status change
+// fails when the transaction was completed in the meantime, so the amount is released
+// only once, and a failed release leaves the transaction accepted for the next attempt.
+func (t TransactionUsecase) complete(ctx context.Context, tran transaction.Transaction, actorId string,
+	reason string) error {
+	completed, err := t.tr.CompleteTransaction(ctx, transaction.TransactionStatusChange{
+		TransactionId: tran.ID,
+		FromStatus:    tran.Status,
+		ToStatus:      transaction_status.ToString(transaction_status.COMPLETED),
+		ActorId:       actorId,
+		Reason:        reason,
+	})
+	if err != nil {
+		return err
+	}
+	t.notifyCompletion(ctx, *completed)
+	return nil
+}
+
+// notifyCompletion tells the merchant owner the amount can be withdrawn, a failing mail
+// does not undo the completion
+func (t TransactionUsecase) notifyCompletion(ctx context.Context, tran transaction.Transaction) {
+	customer, err := t.userUseCase.GetUserById(ctx, tran.CustomerId)
+	if err != nil || customer == nil {
+		return
+	}
+	merch, err := t.merchantUseCase.GetMerchantById(ctx, tran.MerchantId)
+	if err != nil || merch == nil {
+		return
+	}
+	owner, err := t.userUseCase.GetUserById(ctx, merch.UserId)
+	if err != nil || owner == nil {
+		return
+	}
+	mails := factory.CreateMailerFactory(mailer_type.TRANSACTION).CreateMail(tran, customer.Email, owner.Email)
+	mailer.SendEmail(ctx, mails)
+}
@@ -0,0 +1,94 @@
+package transaction
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	request "github.com/williamchang80/sea-apd/dto/request/transaction"
+	transaction2 "github.com/williamchang80/sea-apd/mocks/repository/transaction"
+	"github.com/williamchang80/sea-apd/mocks/usecase/address"
+	"github.com/williamchang80/sea-apd/mocks/usecase/merchant"
+	"github.com/williamchang80/sea-apd/mocks/usecase/product"
+	"github.com/williamchang80/sea-apd/mocks/usecase/shipping"
+	"github.com/williamchang80/sea-apd/mocks/usecase/user"
+)
+
+func TestTransactionUsecase_ConfirmDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.ConfirmDeliveryRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    request.ConfirmDeliveryRequest{TransactionId: transaction2.MockShippedId, CustomerId: "1"},
+			wantErr: false,
+		},
+		{
+			name:    "failed with transaction of other customer",
+			args:    request.ConfirmDeliveryRequest{TransactionId: transaction2.MockShippedId, CustomerId: "2"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with pending balance below the amount",
+			args:    request.ConfirmDeliveryRequest{TransactionId: transaction2.MockUnfundedShippedId, CustomerId: "1"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with transaction that is not shipped",
+			args:    request.ConfirmDeliveryRequest{TransactionId: transaction2.MockWaitingDeliveryId, CustomerId: "1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewTransactionUsecase(transaction2.NewMockRepository(ctrl),
+				merchant.NewMockUsecase(ctrl), product.NewMockUsecase(ctrl), user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
+			if err := c.ConfirmDelivery(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("TransactionUsecase.ConfirmDelivery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransactionUsecase_CompleteShippedTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name          string
+		shippedBefore time.Time
+		want          int
+		wantErr       bool
+	}{
+		{
+			name:          "success",
+			shippedBefore: time.Now().Add(-14 * 24 * time.Hour),
+			want:          1,
+			wantErr:       false,
+		},
+		{
+			name:    "failed without time",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewTransactionUsecase(transaction2.NewMockRepository(ctrl),
+				merchant.NewMockUsecase(ctrl), product.NewMockUsecase(ctrl), user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
+			got, err := c.CompleteShippedTransactions(context.Background(), tt.shippedBefore)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TransactionUsecase.CompleteShippedTransactions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("TransactionUsecase.CompleteShippedTransactions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -22,10 +22,18 @@ func (t *TransactionMailer) CreateMail(tr ...interface{}) []mailer.Mail {
 		return CreateRequestMailer(s, customerEmail, merchantEmail)
 	case transaction_status.ACCEPTED:
 		return CreateArrivalMailer(s, customerEmail, merchantEmail)
+	case transaction_status.COMPLETED:
+		return CreateCompletionMailer(s, customerEmail, merchantEmail)
 	}
 	return nil
 }
 
+// formatShippingAddress writes the address on one line as it goes on the package
+func formatShippingAddress(a transaction.ShippingAddress) string {
+	return fmt.Sprintf("%v (%v), %v, %v, %v %v", a.RecipientName, a.Phone, a.Street, a.City,
+		a.Province, a.PostalCode)
+}
+
 func CreateInvoiceAndNotificationMailer(transaction transaction.Transaction, customerEmail string,
 	merchantEmail string) []mailer.Mail {
 	invoiceMailer := mailer.Mail{
@@ -63,9 +71,11 @@ func CreateRequestMailer(transaction transaction.Transaction, customerEmail stri
 		Subject:   fmt.Sprintf("New Request item from transaction id %v", transaction.ID),
 		Recipient: merchantEmail,
 		Body: fmt.Sprintf(`Hello, %v please confirm request with transaction id %v
-			From customer %v, please check your store`,
+			From customer %v, please check your store
+			Ship to: %v`,
 			strings.TrimSuffix(merchantEmail, "@"), transaction.ID,
 			strings.TrimSuffix(customerEmail, "@"),
+			formatShippingAddress(transaction.ShippingAddress),
 		),
 	}
 	mailers := []mailer.Mail{
@@ -81,9 +91,13 @@ func CreateArrivalMailer(transaction transaction.Transaction, customerEmail stri
 		Subject:   "Item confirmed!",
 		Recipient: customerEmail,
 		Body: fmt.Sprintf(`Hello, %v your transaction with id %v
-			has been confimed by %v and delivered! Please wait for item to arrived`,
+			has been confimed by %v and delivered! Please confirm the delivery once the item
+			has arrived, the order completes by itself otherwise
+			Courier: %v
+			Tracking number: %v`,
 			strings.TrimSuffix(customerEmail, "@"),
 			transaction.ID, strings.TrimSuffix(merchantEmail, "@"),
+			transaction.Shipment.Courier, transaction.Shipment.TrackingNumber,
 		),
 	}
 	mailers := []mailer.Mail{
@@ -91,3 +105,21 @@ func CreateArrivalMailer(transaction transaction.Transaction, customerEmail stri
 	}
 	return mailers
 }
+
+func CreateCompletionMailer(transaction transaction.Transaction, customerEmail string,
+	merchantEmail string) []mailer.Mail {
+	createCompletionMailer := mailer.Mail{
+		Sender:    mailer.MailSender,
+		Subject:   fmt.Sprintf("Transaction id %v completed", transaction.ID),
+		Recipient: merchantEmail,
+		Body: fmt.Sprintf(`Hello, %v the transaction with id %v
+			from customer %v is completed, %v is now available for withdrawal`,
+			strings.TrimSuffix(merchantEmail, "@"), transaction.ID,
+			strings.TrimSuffix(customerEmail, "@"), transaction.Amount,
+		),
+	}
+	mailers := []mailer.Mail{
+		createCompletionMailer,
+	}
+	return mailers
+}
@@ -0,0 +1,99 @@
+package transaction
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/williamchang80/sea-apd/common/constants/mailer_type"
+	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
+	"github.com/williamchang80/sea-apd/common/mailer"
+	"github.com/williamchang80/sea-apd/common/mailer/factory"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/address"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/shipping"
+	"github.com/williamchang80/sea-apd/domain/transaction"
+	transaction2 "github.com/williamchang80/sea-apd/dto/request/transaction"
+)
+
+var ErrNotShippable = apperror.InvalidTransition("only transactions waiting for delivery can be shipped")
+
+func convertAddressToShippingAddress(a address.Address) transaction.ShippingAddress {
+	return transaction.ShippingAddress{
+		RecipientName: a.RecipientName,
+		Phone:         a.Phone,
+		Street:        a.Street,
+		City:          a.City,
+		Province:      a.Province,
+		PostalCode:    a.PostalCode,
+	}
+}
+
+// quoteShipping quotes sending every item of the transaction to its shipping address
+func (t TransactionUsecase) quoteShipping(ctx context.Context, tran transaction.Transaction) (*shipping.Quote, error) {
+	parcel := shipping.Parcel{
+		MerchantId: tran.MerchantId,
+		Province:   tran.ShippingAddress.Province,
+		City:       tran.ShippingAddress.City,
+		PostalCode: tran.ShippingAddress.PostalCode,
+	}
+	for _, item := range tran.ProductDetails {
+		p, err := t.productUseCase.GetProductById(ctx, item.ProductId)
+		if err != nil {
+			return nil, err
+		}
+		parcel.WeightGrams += p.WeightGrams * item.Quantity
+		parcel.VolumeCm3 += p.LengthCm * p.WidthCm * p.HeightCm * item.Quantity
+	}
+	return t.shippingUseCase.QuoteShipping(ctx, parcel)
+}
+
+// ShipTransaction records the courier and tracking number the merchant entered and moves
+// the transaction from waiting delivery to accepted
+func (t TransactionUsecase) ShipTransaction(ctx context.Context, request transaction2.ShipmentRequest) error {
+	ctx, span := tracing.Start(ctx, "TransactionUsecase.ShipTransaction")
+	defer span.End()
+	courier := strings.TrimSpace(request.Courier)
+	trackingNumber := strings.TrimSpace(request.TrackingNumber)
+	if courier == "" || trackingNumber == "" {
+		return apperror.Validation("courier and tracking number cannot be empty")
+	}
+	tran, err := t.tr.GetTransactionById(ctx, request.TransactionId)
+	if err != nil || tran.MerchantId != request.MerchantId {
+		return apperror.NotFound("transaction not found")
+	}
+	if transaction_status.ParseToEnum(tran.Status) != transaction_status.WAITING_DELIVERY {
+		return ErrNotShippable
+	}
+	shippedAt := time.Now()
+	shipped, err := t.tr.ShipTransaction(ctx, transaction.TransactionStatusChange{
+		TransactionId: tran.ID,
+		FromStatus:    tran.Status,
+		ToStatus:      transaction_status.ToString(transaction_status.ACCEPTED),
+	}, transaction.Shipment{
+		Courier:        courier,
+		TrackingNumber: trackingNumber,
+		ShippedAt:      &shippedAt,
+	})
+	if err != nil {
+		return err
+	}
+	t.notifyShipment(ctx, *shipped)
+	return nil
+}
+
+// notifyShipment mails the tracking number to the customer, a failing mail does not undo
+// the shipment
+func (t TransactionUsecase) notifyShipment(ctx context.Context, tran transaction.Transaction) {
+	customer, err := t.userUseCase.GetUserById(ctx, tran.CustomerId)
+	if err != nil || customer == nil {
+		return
+	}
+	merch, err := t.merchantUseCase.GetMerchantById(ctx, tran.MerchantId)
+	if err != nil || merch == nil {
+		return
+	}
+	mails := factory.CreateMailerFactory(mailer_type.TRANSACTION).CreateMail(tran, customer.Email, merch.Name)
+	mailer.SendEmail(ctx, mails)
+}
@@ -0,0 +1,104 @@
+package transaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	request "github.com/williamchang80/sea-apd/dto/request/transaction"
+	transaction2 "github.com/williamchang80/sea-apd/mocks/repository/transaction"
+	"github.com/williamchang80/sea-apd/mocks/usecase/address"
+	"github.com/williamchang80/sea-apd/mocks/usecase/merchant"
+	"github.com/williamchang80/sea-apd/mocks/usecase/product"
+	"github.com/williamchang80/sea-apd/mocks/usecase/shipping"
+	"github.com/williamchang80/sea-apd/mocks/usecase/user"
+)
+
+func TestTransactionUsecase_ShipTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.ShipmentRequest
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: request.ShipmentRequest{TransactionId: transaction2.MockWaitingDeliveryId, MerchantId: "1",
+				Courier: "JNE", TrackingNumber: " JNE123456789 "},
+			wantErr: false,
+		},
+		{
+			name: "failed with blank tracking number",
+			args: request.ShipmentRequest{TransactionId: transaction2.MockWaitingDeliveryId, MerchantId: "1",
+				Courier: "JNE", TrackingNumber: "  "},
+			wantErr: true,
+		},
+		{
+			name: "failed with transaction of other merchant",
+			args: request.ShipmentRequest{TransactionId: transaction2.MockWaitingDeliveryId, MerchantId: "2",
+				Courier: "JNE", TrackingNumber: "JNE123456789"},
+			wantErr: true,
+		},
+		{
+			name: "failed with transaction that is not paid",
+			args: request.ShipmentRequest{TransactionId: transaction2.MockCartId, MerchantId: "1",
+				Courier: "JNE", TrackingNumber: "JNE123456789"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewTransactionUsecase(transaction2.NewMockRepository(ctrl),
+				merchant.NewMockUsecase(ctrl), product.NewMockUsecase(ctrl), user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
+			if err := c.ShipTransaction(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("TransactionUsecase.ShipTransaction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransactionUsecase_QuoteShipping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.CheckoutRequest
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    request.CheckoutRequest{CustomerId: "1", TransactionId: transaction2.MockCartId},
+			want:    shipping.MockShippingCost,
+			wantErr: false,
+		},
+		{
+			name: "failed with unknown address",
+			args: request.CheckoutRequest{CustomerId: "1", TransactionId: transaction2.MockCartId,
+				AddressId: address.MockUnknownAddressId},
+			wantErr: true,
+		},
+		{
+			name:    "failed with cart of other customer",
+			args:    request.CheckoutRequest{CustomerId: "2", TransactionId: transaction2.MockCartId},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewTransactionUsecase(transaction2.NewMockRepository(ctrl),
+				merchant.NewMockUsecase(ctrl), product.NewMockUsecase(ctrl), user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
+			got, err := c.QuoteShipping(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TransactionUsecase.QuoteShipping() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.Cost != tt.want {
+				t.Errorf("TransactionUsecase.QuoteShipping() = %v, want %v", got.Cost, tt.want)
+			}
+		})
+	}
+}
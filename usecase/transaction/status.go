@@ -0,0 +1,163 @@
+package transaction
+
+import (
+	"context"
+	"strings"
+
+	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
+	"github.com/williamchang80/sea-apd/common/metrics"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/transaction"
+	merchant2 "github.com/williamchang80/sea-apd/dto/request/merchant"
+	transaction2 "github.com/williamchang80/sea-apd/dto/request/transaction"
+)
+
+// isPending tells whether the amount of a transaction in the status is held in the
+// pending balance of the merchant
+func isPending(status string) bool {
+	switch transaction_status.ParseToEnum(status) {
+	case transaction_status.WAITING_DELIVERY, transaction_status.ACCEPTED:
+		return true
+	}
+	return false
+}
+
+// isAvailable tells whether the amount of a transaction in the status was released to the
+// available balance of the merchant
+func isAvailable(status string) bool {
+	return transaction_status.ParseToEnum(status) == transaction_status.COMPLETED
+}
+
+// balanceDelta is what moving a transaction of the amount from one status to another adds
+// to the balance holding the amounts of the statuses that held reports
+func balanceDelta(from string, to string, amount int, held func(string) bool) int {
+	switch {
+	case !held(from) && held(to):
+		return amount
+	case held(from) && !held(to):
+		return -amount
+	}
+	return 0
+}
+
+// transitions are the status changes UpdateTransactionStatus makes, transactions are shipped
+// by ShipTransaction and completed by ConfirmDelivery or the auto complete job
+var transitions = map[transaction_status.TransactionStatus][]transaction_status.TransactionStatus{
+	transaction_status.WAITING_PAYMENT:      {transaction_status.WAITING_CONFIRMATION, transaction_status.DECLINED},
+	transaction_status.WAITING_CONFIRMATION: {transaction_status.WAITING_DELIVERY, transaction_status.DECLINED},
+	transaction_status.WAITING_DELIVERY:     {transaction_status.DECLINED},
+}
+
+var ErrStatusNotAllowed = apperror.InvalidTransition("transaction cannot move to the status")
+
+// canTransition tells whether UpdateTransactionStatus may move a transaction between the statuses
+func canTransition(from string, to transaction_status.TransactionStatus) bool {
+	for _, allowed := range transitions[transaction_status.ParseToEnum(from)] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// settleBalances credits or debits the pending and available balances of the merchant for a
+// transaction that moved from the status to the one it has now
+func (t TransactionUsecase) settleBalances(ctx context.Context, from string, tran transaction.Transaction) error {
+	pending := balanceDelta(from, tran.Status, tran.Amount, isPending)
+	if pending != 0 {
+		if err := t.merchantUseCase.UpdateMerchantPendingBalance(ctx, merchant2.UpdateMerchantBalanceRequest{
+			Amount:     pending,
+			MerchantId: tran.MerchantId,
+		}); err != nil {
+			return err
+		}
+	}
+	available := balanceDelta(from, tran.Status, tran.Amount, isAvailable)
+	if available != 0 {
+		if err := t.merchantUseCase.UpdateMerchantBalance(ctx, merchant2.UpdateMerchantBalanceRequest{
+			Amount:     available,
+			MerchantId: tran.MerchantId,
+		}); err != nil {
+			return err
+		}
+	}
+	switch credited := pending + available; {
+	case credited > 0:
+		metrics.GmvCredited.Add(float64(credited))
+	case credited < 0:
+		metrics.GmvReversed.Add(float64(-credited))
+	}
+	return nil
+}
+
+// ForceTransactionStatus lets an admin move a transaction to any status. The side effects
+// of the normal flow are settled against the previous status instead of running the
+// observers, so stock is reserved or released and the pending and available balances of
+// the merchant credited or debited exactly once.
+func (t TransactionUsecase) ForceTransactionStatus(ctx context.Context, request transaction2.ForceTransactionStatusRequest) (
+	*transaction.TransactionStatusChange, error) {
+	ctx, span := tracing.Start(ctx, "TransactionUsecase.ForceTransactionStatus")
+	defer span.End()
+	if request.AdminId == "" {
+		return nil, apperror.Validation("admin id cannot be empty")
+	}
+	reason := strings.TrimSpace(request.Reason)
+	if reason == "" {
+		return nil, apperror.Validation("reason cannot be empty")
+	}
+	to := transaction_status.ToString(request.Status)
+	if request.Status == transaction_status.OTHER || to == "" {
+		return nil, apperror.Validation("status is not valid")
+	}
+	previous, err := t.tr.GetTransactionById(ctx, request.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	if previous.Status == to {
+		return nil, apperror.Conflict("transaction already has the status")
+	}
+	change := transaction.TransactionStatusChange{
+		TransactionId: request.TransactionId,
+		FromStatus:    previous.Status,
+		ToStatus:      to,
+		ActorId:       request.AdminId,
+		Reason:        reason,
+	}
+
+	wasReserved, isReserved := hasReservedStock(previous.Status), hasReservedStock(to)
+	if !wasReserved && isReserved && len(previous.ProductDetails) > 0 {
+		if err := t.productUseCase.ReserveStock(ctx, previous.ProductDetails); err != nil {
+			return nil, err
+		}
+	}
+	tran, err := t.tr.ChangeTransactionStatus(ctx, change)
+	if err != nil {
+		if !wasReserved && isReserved && len(previous.ProductDetails) > 0 {
+			t.productUseCase.ReleaseStock(ctx, previous.ProductDetails)
+		}
+		return nil, err
+	}
+	if wasReserved && !isReserved && len(previous.ProductDetails) > 0 {
+		if err := t.productUseCase.ReleaseStock(ctx, previous.ProductDetails); err != nil {
+			return nil, err
+		}
+	}
+	if err := t.settleBalances(ctx, previous.Status, *tran); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (t TransactionUsecase) GetStatusChanges(ctx context.Context, transactionId string) ([]transaction.TransactionStatusChange, error) {
+	ctx, span := tracing.Start(ctx, "TransactionUsecase.GetStatusChanges")
+	defer span.End()
+	if transactionId == "" {
+		return nil, apperror.Validation("transaction id cannot be empty")
+	}
+	changes, err := t.tr.GetStatusChanges(ctx, transactionId)
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,85 @@
+package transaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
+	request "github.com/williamchang80/sea-apd/dto/request/transaction"
+	transaction2 "github.com/williamchang80/sea-apd/mocks/repository/transaction"
+	"github.com/williamchang80/sea-apd/mocks/usecase/address"
+	"github.com/williamchang80/sea-apd/mocks/usecase/merchant"
+	"github.com/williamchang80/sea-apd/mocks/usecase/product"
+	"github.com/williamchang80/sea-apd/mocks/usecase/shipping"
+	"github.com/williamchang80/sea-apd/mocks/usecase/user"
+)
+
+func TestTransactionUsecase_ForceTransactionStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    request.ForceTransactionStatusRequest
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: request.ForceTransactionStatusRequest{TransactionId: mockTransactionId,
+				Status: transaction_status.ACCEPTED, AdminId: "admin", Reason: "paid offline"},
+			wantErr: false,
+		},
+		{
+			name: "success with cart reserving stock",
+			args: request.ForceTransactionStatusRequest{TransactionId: transaction2.MockCartId,
+				Status: transaction_status.WAITING_PAYMENT, AdminId: "admin", Reason: "stuck cart"},
+			wantErr: false,
+		},
+		{
+			name: "success releasing pending balance",
+			args: request.ForceTransactionStatusRequest{TransactionId: transaction2.MockShippedId,
+				Status: transaction_status.COMPLETED, AdminId: "admin", Reason: "delivered"},
+			wantErr: false,
+		},
+		{
+			name: "failed without admin",
+			args: request.ForceTransactionStatusRequest{TransactionId: mockTransactionId,
+				Status: transaction_status.ACCEPTED, Reason: "paid offline"},
+			wantErr: true,
+		},
+		{
+			name: "failed without reason",
+			args: request.ForceTransactionStatusRequest{TransactionId: mockTransactionId,
+				Status: transaction_status.ACCEPTED, AdminId: "admin", Reason: " "},
+			wantErr: true,
+		},
+		{
+			name: "failed with invalid status",
+			args: request.ForceTransactionStatusRequest{TransactionId: mockTransactionId,
+				Status: transaction_status.OTHER, AdminId: "admin", Reason: "paid offline"},
+			wantErr: true,
+		},
+		{
+			name: "failed with current status",
+			args: request.ForceTransactionStatusRequest{TransactionId: transaction2.MockCartId,
+				Status: transaction_status.ON_CARTS, AdminId: "admin", Reason: "no-op"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewTransactionUsecase(transaction2.NewMockRepository(ctrl),
+				merchant.NewMockUsecase(ctrl), product.NewMockUsecase(ctrl), user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
+			change, err := c.ForceTransactionStatus(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TransactionUsecase.ForceTransactionStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && change.ToStatus != transaction_status.ToString(tt.args.Status) {
+				t.Errorf("TransactionUsecase.ForceTransactionStatus() to = %v, want %v", change.ToStatus,
+					transaction_status.ToString(tt.args.Status))
+			}
+		})
+	}
+}
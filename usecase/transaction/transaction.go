@@ -1,11 +1,17 @@
 package transaction
 
 import (
+	"context"
 	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
 	"github.com/williamchang80/sea-apd/common/observer"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/address"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	"github.com/williamchang80/sea-apd/domain/merchant"
 	"github.com/williamchang80/sea-apd/domain/product"
+	"github.com/williamchang80/sea-apd/domain/shipping"
 	"github.com/williamchang80/sea-apd/domain/transaction"
+	"github.com/williamchang80/sea-apd/domain/user"
 	transaction2 "github.com/williamchang80/sea-apd/dto/request/transaction"
 	"github.com/williamchang80/sea-apd/dto/request/transaction/converter"
 )
@@ -16,6 +22,9 @@ type TransactionUsecase struct {
 	tr              transaction.TransactionRepository
 	merchantUseCase merchant.MerchantUsecase
 	productUseCase  product.ProductUsecase
+	userUseCase     user.UserUsecase
+	addressUseCase  address.AddressUsecase
+	shippingUseCase shipping.ShippingUsecase
 }
 
 type TransactionObserver struct {
@@ -24,17 +33,21 @@ type TransactionObserver struct {
 
 func NewTransactionUsecase(repo transaction.TransactionRepository,
 	merchantUseCase merchant.MerchantUsecase, productUsecase product.
-ProductUsecase) transaction.TransactionUsecase {
+ProductUsecase, userUseCase user.UserUsecase, addressUseCase address.AddressUsecase,
+	shippingUseCase shipping.ShippingUsecase) transaction.TransactionUsecase {
 	obs = CreateObserverable()
 	obs.AttachObservers()
 	return &TransactionUsecase{tr: repo,
 		merchantUseCase: merchantUseCase,
-		productUseCase:  productUsecase}
+		productUseCase:  productUsecase,
+		userUseCase:     userUseCase,
+		addressUseCase:  addressUseCase,
+		shippingUseCase: shippingUseCase}
 }
 
 func convertTransactionRequestToDomain(t transaction2.TransactionRequest) transaction.Transaction {
 	return transaction.Transaction{
-		Status:     transaction_status.ToString(transaction_status.WAITING_CONFIRMATION),
+		Status:     transaction_status.ToString(transaction_status.WAITING_PAYMENT),
 		BankNumber: t.BankNumber,
 		BankName:   t.BankName,
 		Amount:     t.Amount,
@@ -43,14 +56,21 @@ func convertTransactionRequestToDomain(t transaction2.TransactionRequest) transa
 	}
 }
 
-func (t TransactionUsecase) CreateTransaction(request transaction2.TransactionRequest) error {
+func (t TransactionUsecase) CreateTransaction(ctx context.Context, request transaction2.TransactionRequest) error {
+	ctx, span := tracing.Start(ctx, "TransactionUsecase.CreateTransaction")
+	defer span.End()
+	if err := t.userUseCase.ValidateUserVerified(ctx, request.CustomerId); err != nil {
+		return err
+	}
+	if err := t.merchantUseCase.ValidateMerchantActive(ctx, request.MerchantId); err != nil {
+		return err
+	}
 	tran := convertTransactionRequestToDomain(request)
-	err := t.tr.CreateTransaction(tran)
+	err := t.tr.CreateTransaction(ctx, tran)
 	return err
 }
 
 func (i *TransactionObserver) AttachObservers() {
-	i.TransactionObservable.AddObserver(&UpdateMerchantBalanceObserver{})
 	i.TransactionObservable.AddObserver(&NotifyAdminObserver{})
 }
 
@@ -58,64 +78,102 @@ func CreateObserverable() *TransactionObserver {
 	return &TransactionObserver{}
 }
 
-func (t TransactionUsecase) UpdateTransactionStatus(request transaction2.
+func (t TransactionUsecase) UpdateTransactionStatus(ctx context.Context, request transaction2.
 UpdateTransactionRequest) error {
-	status := transaction_status.ToString(request.Status)
-	tran, err := t.tr.UpdateTransactionStatus(status, request.TransactionId)
+	ctx, span := tracing.Start(ctx, "TransactionUsecase.UpdateTransactionStatus")
+	defer span.End()
+	if request.Status == transaction_status.COMPLETED {
+		return ErrManualCompletion
+	}
+	previous, err := t.tr.GetTransactionById(ctx, request.TransactionId)
+	if err != nil {
+		return err
+	}
+	if !canTransition(previous.Status, request.Status) {
+		return ErrStatusNotAllowed
+	}
+	tran, err := t.tr.ChangeTransactionStatus(ctx, transaction.TransactionStatusChange{
+		TransactionId: request.TransactionId,
+		FromStatus:    previous.Status,
+		ToStatus:      transaction_status.ToString(request.Status),
+	})
 	if err != nil {
 		return err
 	}
-	if err := obs.NotifyAll(*tran, t.merchantUseCase); err != nil {
+	if hasReservedStock(previous.Status) && !hasReservedStock(tran.Status) && len(previous.ProductDetails) > 0 {
+		if err := t.productUseCase.ReleaseStock(ctx, previous.ProductDetails); err != nil {
+			return err
+		}
+	}
+	if err := t.settleBalances(ctx, previous.Status, *tran); err != nil {
+		return err
+	}
+	if err := obs.NotifyAll(ctx, *tran, t.merchantUseCase); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (t TransactionUsecase) GetTransactionById(id string) (*transaction.Transaction, error) {
-	tr, err := t.tr.GetTransactionById(id)
+func (t TransactionUsecase) GetTransactionById(ctx context.Context, id string) (*transaction.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "TransactionUsecase.GetTransactionById")
+	defer span.End()
+	tr, err := t.tr.GetTransactionById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 	return tr, nil
 }
 
-func (t TransactionUsecase) GetTransactionHistory(userId string) ([]transaction.
+func (t TransactionUsecase) GetTransactionHistory(ctx context.Context, userId string) ([]transaction.
 Transaction, error) {
+	ctx, span := tracing.Start(ctx, "TransactionUsecase.GetTransactionHistory")
+	defer span.End()
 	requiredStatusForTransactionHistory := transaction_status.GetStatusListForTransactionHistory()
-	tr, err := t.tr.GetTransactionByRequiredStatus(requiredStatusForTransactionHistory, userId)
+	tr, err := t.tr.GetTransactionByRequiredStatus(ctx, requiredStatusForTransactionHistory, userId)
 	if err != nil {
 		return nil, err
 	}
 	return tr, nil
 }
 
-func (t TransactionUsecase) GetMerchantRequestItem(merchantId string) ([]transaction.Transaction, error) {
-	tr, err := t.tr.GetMerchantRequestItem(merchantId)
+func (t TransactionUsecase) GetMerchantRequestItem(ctx context.Context, merchantId string) ([]transaction.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "TransactionUsecase.GetMerchantRequestItem")
+	defer span.End()
+	tr, err := t.tr.GetMerchantRequestItem(ctx, merchantId)
 	if err != nil {
 		return nil, err
 	}
 	return tr, nil
 }
 
-func (t TransactionUsecase) PayTransaction(request transaction2.PaymentRequest) error {
-	tr, err := t.tr.GetTransactionById(request.TransactionId)
+var ErrNotPayable = apperror.InvalidTransition("only transactions waiting for their payment can be paid")
+
+// PayTransaction stores the payment of a transaction waiting for it and moves the
+// transaction on to wait for the confirmation of the payment
+func (t TransactionUsecase) PayTransaction(ctx context.Context, request transaction2.PaymentRequest) error {
+	ctx, span := tracing.Start(ctx, "TransactionUsecase.PayTransaction")
+	defer span.End()
+	tr, err := t.tr.GetTransactionById(ctx, request.TransactionId)
 	if err != nil {
 		return err
 	}
-	transactionTotal, err := t.productUseCase.GetProductPriceTotal(*tr)
+	if transaction_status.ParseToEnum(tr.Status) != transaction_status.WAITING_PAYMENT {
+		return ErrNotPayable
+	}
+	transactionTotal, err := t.productUseCase.GetProductPriceTotal(ctx, *tr)
 	if err != nil {
 		return err
 	}
 	mergedTransaction := converter.MergePaymentRequestAndTransactionTotal(
-		request, *tr, transactionTotal)
-	if err := t.tr.UpdateTransaction(mergedTransaction); err != nil {
+		request, *tr, transactionTotal+tr.ShippingCost)
+	if err := t.tr.UpdateTransaction(ctx, mergedTransaction); err != nil {
 		return err
 	}
 	updateRequest := transaction2.UpdateTransactionRequest{
 		TransactionId: mergedTransaction.ID,
 		Status:        transaction_status.WAITING_CONFIRMATION,
 	}
-	if err := t.UpdateTransactionStatus(updateRequest); err != nil {
+	if err := t.UpdateTransactionStatus(ctx, updateRequest); err != nil {
 		return err
 	}
 	return nil
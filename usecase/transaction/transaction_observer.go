@@ -1,41 +1,29 @@
 package transaction
 
 import (
+	"context"
 	"github.com/williamchang80/sea-apd/common/constants/mailer_type"
 	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
 	"github.com/williamchang80/sea-apd/common/mailer"
 	"github.com/williamchang80/sea-apd/common/mailer/factory"
+	"github.com/williamchang80/sea-apd/common/tracing"
 	"github.com/williamchang80/sea-apd/domain/merchant"
 	"github.com/williamchang80/sea-apd/domain/transaction"
-	merchant2 "github.com/williamchang80/sea-apd/dto/request/merchant"
 )
 
 var mail factory.MailFactory
 
-type UpdateMerchantBalanceObserver struct {
-}
-
-func (u *UpdateMerchantBalanceObserver) Update(transaction transaction.Transaction,
-	t merchant.MerchantUsecase) error {
-	var err error
-	if transaction_status.ParseToEnum(transaction.Status) == transaction_status.WAITING_DELIVERY {
-		err = t.UpdateMerchantBalance(merchant2.UpdateMerchantBalanceRequest{
-			Amount:     transaction.Amount,
-			MerchantId: transaction.MerchantId,
-		})
-	}
-	return err
-}
-
 type NotifyAdminObserver struct {
 }
 
-func (n *NotifyAdminObserver) Update(transaction transaction.Transaction,
+func (n *NotifyAdminObserver) Update(ctx context.Context, transaction transaction.Transaction,
 	t merchant.MerchantUsecase) error {
+	ctx, span := tracing.Start(ctx, "NotifyAdminObserver.Update")
+	defer span.End()
 	if transaction_status.ParseToEnum(transaction.Status) == transaction_status.WAITING_CONFIRMATION {
 		mail = factory.CreateMailerFactory(mailer_type.TRANSACTION)
 		mails := mail.CreateMail(transaction, "customer@customer.com", "merchant@merchant.com")
-		err := mailer.SendEmail(mails)
+		err := mailer.SendEmail(ctx, mails)
 		if err != nil {
 			return err
 		}
@@ -46,12 +34,14 @@ func (n *NotifyAdminObserver) Update(transaction transaction.Transaction,
 type SendPaymentInvoiceObserver struct {
 }
 
-func (n *SendPaymentInvoiceObserver) Update(transaction transaction.Transaction,
+func (n *SendPaymentInvoiceObserver) Update(ctx context.Context, transaction transaction.Transaction,
 	t merchant.MerchantUsecase) error {
+	ctx, span := tracing.Start(ctx, "SendPaymentInvoiceObserver.Update")
+	defer span.End()
 	if transaction_status.ParseToEnum(transaction.Status) == transaction_status.WAITING_CONFIRMATION {
 		mail = factory.CreateMailerFactory(mailer_type.TRANSACTION)
 		mails := mail.CreateMail(transaction, "customer@customer.com", "merchant@merchant.com")
-		err := mailer.SendEmail(mails)
+		err := mailer.SendEmail(ctx, mails)
 		if err != nil {
 			return err
 		}
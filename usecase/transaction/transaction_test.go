@@ -1,15 +1,26 @@
 package transaction
 
 import (
+	"context"
 	"github.com/golang/mock/gomock"
+	"github.com/mailgun/mailgun-go/v4"
 	"github.com/williamchang80/sea-apd/common/constants/transaction_status"
+	"github.com/williamchang80/sea-apd/common/mailer"
+	address2 "github.com/williamchang80/sea-apd/domain/address"
 	merchant3 "github.com/williamchang80/sea-apd/domain/merchant"
 	product2 "github.com/williamchang80/sea-apd/domain/product"
+	shipping2 "github.com/williamchang80/sea-apd/domain/shipping"
+	user2 "github.com/williamchang80/sea-apd/domain/user"
 	"github.com/williamchang80/sea-apd/domain/transaction"
 	request "github.com/williamchang80/sea-apd/dto/request/transaction"
 	transaction2 "github.com/williamchang80/sea-apd/mocks/repository/transaction"
+	"github.com/williamchang80/sea-apd/mocks/usecase/address"
 	"github.com/williamchang80/sea-apd/mocks/usecase/merchant"
 	"github.com/williamchang80/sea-apd/mocks/usecase/product"
+	"github.com/williamchang80/sea-apd/mocks/usecase/shipping"
+	"github.com/williamchang80/sea-apd/mocks/usecase/user"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 )
@@ -23,7 +34,7 @@ var (
 		MerchantId: "1",
 	}
 	mockTransactionEntity = transaction.Transaction{
-		Status:     transaction_status.ToString(transaction_status.WAITING_CONFIRMATION),
+		Status:     transaction_status.ToString(transaction_status.WAITING_PAYMENT),
 		BankNumber: "123456789",
 		BankName:   "Mock Bank",
 		Amount:     10000,
@@ -31,8 +42,8 @@ var (
 		MerchantId: "1",
 	}
 	mockUpdateTransaction = request.UpdateTransactionRequest{
-		TransactionId: "1",
-		Status:        transaction_status.ACCEPTED,
+		TransactionId: transaction2.MockWaitingConfirmationId,
+		Status:        transaction_status.WAITING_DELIVERY,
 	}
 	mockTransactionId = "1"
 	mockUserId        = "1"
@@ -45,6 +56,9 @@ func TestNewTransactionUsecase(t *testing.T) {
 		repository     transaction.TransactionRepository
 		usecase        merchant3.MerchantUsecase
 		productUsecase product2.ProductUsecase
+		userUsecase    user2.UserUsecase
+		addressUsecase  address2.AddressUsecase
+		shippingUsecase shipping2.ShippingUsecase
 	}
 	tests := []struct {
 		name string
@@ -57,18 +71,25 @@ func TestNewTransactionUsecase(t *testing.T) {
 				repository:     nil,
 				usecase:        merchant.NewMockUsecase(ctrl),
 				productUsecase: product.NewMockUsecase(ctrl),
+				userUsecase:    user.NewMockUsecase(ctrl),
+				addressUsecase:  address.NewMockUsecase(ctrl),
+				shippingUsecase: shipping.NewMockUsecase(ctrl),
 			},
 			want: &TransactionUsecase{
 				tr: nil,
 				merchantUseCase: merchant.NewMockUsecase(ctrl),
 				productUseCase: product.NewMockUsecase(ctrl),
+				userUseCase:     user.NewMockUsecase(ctrl),
+				addressUseCase:  address.NewMockUsecase(ctrl),
+				shippingUseCase: shipping.NewMockUsecase(ctrl),
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if got := NewTransactionUsecase(tt.args.repository, tt.args.usecase,
-				tt.args.productUsecase); !reflect.DeepEqual(got, tt.want) {
+				tt.args.productUsecase, tt.args.userUsecase, tt.args.addressUsecase,
+				tt.args.shippingUsecase); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewTransactionUseCase() = %v, want %v", got, tt.want)
 			}
 		})
@@ -124,7 +145,23 @@ func TestTransactionUsecase_CreateTransaction(t *testing.T) {
 				t := transaction2.NewMockRepository(ctrl)
 				u := merchant.NewMockUsecase(ctrl)
 				p := product.NewMockUsecase(ctrl)
-				return NewTransactionUsecase(t, u, p)
+				return NewTransactionUsecase(t, u, p, user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
+			},
+		},
+		{
+			name: "failed with unverified customer",
+			args: args{
+				request: request.TransactionRequest{BankNumber: "123456789", BankName: "Mock Bank", Amount: 10000,
+					CustomerId: user.MockUnverifiedUserId, MerchantId: "1"},
+			},
+			wantErr: true,
+			initMock: func() transaction.TransactionUsecase {
+				t := transaction2.NewMockRepository(ctrl)
+				u := merchant.NewMockUsecase(ctrl)
+				p := product.NewMockUsecase(ctrl)
+				return NewTransactionUsecase(t, u, p, user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
 			},
 		},
 		{
@@ -137,15 +174,16 @@ func TestTransactionUsecase_CreateTransaction(t *testing.T) {
 				t := transaction2.NewMockRepository(ctrl)
 				u := merchant.NewMockUsecase(ctrl)
 				p := product.NewMockUsecase(ctrl)
-				return NewTransactionUsecase(t, u, p)
+				return NewTransactionUsecase(t, u, p, user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := tt.initMock()
-			err := c.CreateTransaction(tt.args.request)
-			if err != nil && !tt.wantErr {
+			err := c.CreateTransaction(context.Background(), tt.args.request)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("TransactionUsecase.CreateTransaction() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
@@ -175,7 +213,59 @@ func TestTransactionUsecase_UpdateTransactionStatus(t *testing.T) {
 				t := transaction2.NewMockRepository(ctrl)
 				u := merchant.NewMockUsecase(ctrl)
 				p := product.NewMockUsecase(ctrl)
-				return NewTransactionUsecase(t, u, p)
+				return NewTransactionUsecase(t, u, p, user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
+			},
+		},
+		{
+			name: "success declining a transaction waiting for delivery",
+			args: args{
+				request: request.UpdateTransactionRequest{
+					TransactionId: transaction2.MockWaitingDeliveryId,
+					Status:        transaction_status.DECLINED,
+				},
+			},
+			wantErr: false,
+			initMock: func() transaction.TransactionUsecase {
+				t := transaction2.NewMockRepository(ctrl)
+				u := merchant.NewMockUsecase(ctrl)
+				p := product.NewMockUsecase(ctrl)
+				return NewTransactionUsecase(t, u, p, user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
+			},
+		},
+		{
+			name: "failed declining an accepted transaction",
+			args: args{
+				request: request.UpdateTransactionRequest{
+					TransactionId: transaction2.MockShippedId,
+					Status:        transaction_status.DECLINED,
+				},
+			},
+			wantErr: true,
+			initMock: func() transaction.TransactionUsecase {
+				t := transaction2.NewMockRepository(ctrl)
+				u := merchant.NewMockUsecase(ctrl)
+				p := product.NewMockUsecase(ctrl)
+				return NewTransactionUsecase(t, u, p, user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
+			},
+		},
+		{
+			name: "failed moving to waiting delivery again",
+			args: args{
+				request: request.UpdateTransactionRequest{
+					TransactionId: transaction2.MockWaitingDeliveryId,
+					Status:        transaction_status.WAITING_DELIVERY,
+				},
+			},
+			wantErr: true,
+			initMock: func() transaction.TransactionUsecase {
+				t := transaction2.NewMockRepository(ctrl)
+				u := merchant.NewMockUsecase(ctrl)
+				p := product.NewMockUsecase(ctrl)
+				return NewTransactionUsecase(t, u, p, user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
 			},
 		},
 		{
@@ -191,14 +281,32 @@ func TestTransactionUsecase_UpdateTransactionStatus(t *testing.T) {
 				t := transaction2.NewMockRepository(ctrl)
 				u := merchant.NewMockUsecase(ctrl)
 				p := product.NewMockUsecase(ctrl)
-				return NewTransactionUsecase(t, u, p)
+				return NewTransactionUsecase(t, u, p, user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
+			},
+		},
+		{
+			name: "failed with completed status",
+			args: args{
+				request: request.UpdateTransactionRequest{
+					TransactionId: transaction2.MockShippedId,
+					Status:        transaction_status.COMPLETED,
+				},
+			},
+			wantErr: true,
+			initMock: func() transaction.TransactionUsecase {
+				t := transaction2.NewMockRepository(ctrl)
+				u := merchant.NewMockUsecase(ctrl)
+				p := product.NewMockUsecase(ctrl)
+				return NewTransactionUsecase(t, u, p, user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := tt.initMock()
-			if err := c.UpdateTransactionStatus(tt.args.request); err != nil && !tt.wantErr {
+			if err := c.UpdateTransactionStatus(context.Background(), tt.args.request); (err != nil) != tt.wantErr {
 				t.Errorf("TransactionUsecase.CreateTransaction() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
@@ -230,7 +338,8 @@ func TestTransactionUsecase_GetTransactionById(t *testing.T) {
 				t := transaction2.NewMockRepository(ctrl)
 				u := merchant.NewMockUsecase(ctrl)
 				p := product.NewMockUsecase(ctrl)
-				return NewTransactionUsecase(t, u, p)
+				return NewTransactionUsecase(t, u, p, user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
 			},
 		},
 		{
@@ -243,14 +352,15 @@ func TestTransactionUsecase_GetTransactionById(t *testing.T) {
 				t := transaction2.NewMockRepository(ctrl)
 				u := merchant.NewMockUsecase(ctrl)
 				p := product.NewMockUsecase(ctrl)
-				return NewTransactionUsecase(t, u, p)
+				return NewTransactionUsecase(t, u, p, user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := tt.initMock()
-			if p, err := c.GetTransactionById(tt.args.request); (err != nil || reflect.DeepEqual(p, tt.args)) && !tt.wantErr {
+			if p, err := c.GetTransactionById(context.Background(), tt.args.request); (err != nil || reflect.DeepEqual(p, tt.args)) && !tt.wantErr {
 				t.Errorf("TransactionUsecase.CreateTransaction() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
@@ -282,7 +392,8 @@ func TestTransactionUsecase_GetTransactionHistory(t *testing.T) {
 				t := transaction2.NewMockRepository(ctrl)
 				u := merchant.NewMockUsecase(ctrl)
 				p := product.NewMockUsecase(ctrl)
-				return NewTransactionUsecase(t, u, p)
+				return NewTransactionUsecase(t, u, p, user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
 			},
 		},
 		{
@@ -295,17 +406,137 @@ func TestTransactionUsecase_GetTransactionHistory(t *testing.T) {
 				t := transaction2.NewMockRepository(ctrl)
 				u := merchant.NewMockUsecase(ctrl)
 				p := product.NewMockUsecase(ctrl)
-				return NewTransactionUsecase(t, u, p)
+				return NewTransactionUsecase(t, u, p, user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := tt.initMock()
-			if p, err := c.GetTransactionHistory(tt.args.request); (err != nil || reflect.DeepEqual(p, tt.args)) && !tt.wantErr {
+			if p, err := c.GetTransactionHistory(context.Background(), tt.args.request); (err != nil || reflect.DeepEqual(p, tt.args)) && !tt.wantErr {
 				t.Errorf("TransactionUsecase.GetTransactionHistory() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 		})
 	}
 }
+
+// useMailStub points the mailer at a server queueing every mail and returns the function
+// restoring the previous mailer
+func useMailStub() func() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "<mock@mock.com>", "message": "Queued. Thank you."}`))
+	}))
+	previous := mailer.Mailer
+	mailer.Mailer = mailgun.NewMailgun("mock.com", "mock-key")
+	mailer.Mailer.SetAPIBase(server.URL + "/v3")
+	return func() {
+		mailer.Mailer = previous
+		server.Close()
+	}
+}
+
+func TestTransactionUsecase_PayTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	defer useMailStub()()
+	tests := []struct {
+		name    string
+		args    request.PaymentRequest
+		wantErr error
+	}{
+		{
+			name:    "success",
+			args:    request.PaymentRequest{TransactionId: transaction2.MockWaitingPaymentId, BankName: "Mock Bank", BankNumber: "123456789"},
+			wantErr: nil,
+		},
+		{
+			name:    "failed with transaction that is already paid",
+			args:    request.PaymentRequest{TransactionId: transaction2.MockWaitingConfirmationId, BankName: "Mock Bank", BankNumber: "123456789"},
+			wantErr: ErrNotPayable,
+		},
+		{
+			name:    "failed with cart that is not checked out",
+			args:    request.PaymentRequest{TransactionId: transaction2.MockCartId, BankName: "Mock Bank", BankNumber: "123456789"},
+			wantErr: ErrNotPayable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewTransactionUsecase(transaction2.NewMockRepository(ctrl),
+				merchant.NewMockUsecase(ctrl), product.NewMockUsecase(ctrl), user.NewMockUsecase(ctrl),
+				address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
+			if err := c.PayTransaction(context.Background(), tt.args); err != tt.wantErr {
+				t.Errorf("TransactionUsecase.PayTransaction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// paymentRepository keeps the transaction stored by CreateTransaction so a test can follow
+// it through the payment, status changes only apply from the status it is in
+type paymentRepository struct {
+	*transaction2.MockRepository
+	tran transaction.Transaction
+}
+
+func (r *paymentRepository) CreateTransaction(ctx context.Context, tran transaction.Transaction) error {
+	tran.ID = mockTransactionId
+	r.tran = tran
+	return nil
+}
+
+func (r *paymentRepository) GetTransactionById(ctx context.Context, id string) (*transaction.Transaction, error) {
+	tran := r.tran
+	return &tran, nil
+}
+
+func (r *paymentRepository) UpdateTransaction(ctx context.Context, tran transaction.Transaction) error {
+	r.tran.BankName = tran.BankName
+	r.tran.BankNumber = tran.BankNumber
+	r.tran.Amount = tran.Amount
+	return nil
+}
+
+func (r *paymentRepository) ChangeTransactionStatus(ctx context.Context, change transaction.TransactionStatusChange) (
+	*transaction.Transaction, error) {
+	if change.FromStatus != r.tran.Status {
+		return nil, transaction.ErrInvalidStatusTransition
+	}
+	r.tran.Status = change.ToStatus
+	tran := r.tran
+	return &tran, nil
+}
+
+func TestTransactionUsecase_PayTransactionFlow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	defer useMailStub()()
+	repo := &paymentRepository{MockRepository: transaction2.NewMockRepository(ctrl)}
+	c := NewTransactionUsecase(repo, merchant.NewMockUsecase(ctrl), product.NewMockUsecase(ctrl),
+		user.NewMockUsecase(ctrl), address.NewMockUsecase(ctrl), shipping.NewMockUsecase(ctrl))
+	if err := c.CreateTransaction(context.Background(), mockCreateTransactionRequest); err != nil {
+		t.Fatalf("TransactionUsecase.CreateTransaction() error = %v", err)
+	}
+	payment := request.PaymentRequest{TransactionId: mockTransactionId, BankName: "Paid Bank", BankNumber: "987654321"}
+	if err := c.PayTransaction(context.Background(), payment); err != nil {
+		t.Fatalf("TransactionUsecase.PayTransaction() error = %v", err)
+	}
+	if want := transaction_status.ToString(transaction_status.WAITING_CONFIRMATION); repo.tran.Status != want {
+		t.Errorf("TransactionUsecase.PayTransaction() status = %v, want %v", repo.tran.Status, want)
+	}
+	if repo.tran.BankName != payment.BankName || repo.tran.BankNumber != payment.BankNumber {
+		t.Errorf("TransactionUsecase.PayTransaction() bank = %v %v, want %v %v", repo.tran.BankName,
+			repo.tran.BankNumber, payment.BankName, payment.BankNumber)
+	}
+	again := request.PaymentRequest{TransactionId: mockTransactionId, BankName: "Other Bank", BankNumber: "111111111"}
+	if err := c.PayTransaction(context.Background(), again); err != ErrNotPayable {
+		t.Errorf("TransactionUsecase.PayTransaction() error = %v, want %v", err, ErrNotPayable)
+	}
+	if repo.tran.BankName != payment.BankName {
+		t.Errorf("TransactionUsecase.PayTransaction() paid twice, bank = %v, want %v", repo.tran.BankName,
+			payment.BankName)
+	}
+}
@@ -1,7 +1,12 @@
 package transfer
 
 import (
-	"errors"
+	"context"
+	"github.com/williamchang80/sea-apd/common/bank"
+	"github.com/williamchang80/sea-apd/common/metrics"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/bank_account"
 	"github.com/williamchang80/sea-apd/domain/merchant"
 	"github.com/williamchang80/sea-apd/domain/transfer"
 	merchant2 "github.com/williamchang80/sea-apd/dto/request/merchant"
@@ -9,50 +14,78 @@ import (
 )
 
 type TransferUsecase struct {
-	repo            transfer.TransferRepository
-	merchantUsecase merchant.MerchantUsecase
+	repo               transfer.TransferRepository
+	merchantUsecase    merchant.MerchantUsecase
+	bankAccountUsecase bank_account.BankAccountUsecase
 }
-func convertCreateTransferRequestToDomain(request request.CreateTransferHistoryRequest) transfer.Transfer {
+func convertCreateTransferRequestToDomain(request request.CreateTransferHistoryRequest,
+	account bank_account.BankAccount) transfer.Transfer {
+	bankName := account.BankCode
+	if b, err := bank.GetBank(account.BankCode); err == nil {
+		bankName = b.Name
+	}
 	return transfer.Transfer{
-		Amount:     request.Amount,
-		BankName:   request.BankName,
-		BankNumber: request.BankNumber,
-		MerchantId: request.MerchantId,
+		Amount:        request.Amount,
+		BankAccountId: account.ID,
+		BankName:      bankName,
+		BankNumber:    account.AccountNumber,
+		MerchantId:    request.MerchantId,
 	}
 }
-func NewTransferUsecase(repo transfer.TransferRepository, usecase merchant.MerchantUsecase) transfer.TransferUsecase {
-	return &TransferUsecase{repo: repo, merchantUsecase: usecase}
+func NewTransferUsecase(repo transfer.TransferRepository, usecase merchant.MerchantUsecase,
+	b bank_account.BankAccountUsecase) transfer.TransferUsecase {
+	return &TransferUsecase{repo: repo, merchantUsecase: usecase, bankAccountUsecase: b}
 }
-func (t TransferUsecase) GetTransferHistory(merchantId string) ([]transfer.Transfer, error) {
-	transfers, err := t.repo.GetTransferHistory(merchantId)
+func (t TransferUsecase) GetTransferHistory(ctx context.Context, merchantId string) ([]transfer.Transfer, error) {
+	ctx, span := tracing.Start(ctx, "TransferUsecase.GetTransferHistory")
+	defer span.End()
+	transfers, err := t.repo.GetTransferHistory(ctx, merchantId)
 	if err != nil {
 		return nil, err
 	}
 	return transfers, nil
 }
+// validateMerchantBalanceAmount checks the balance holds the amount to withdraw, amounts are
+// positive and debited from the balance
 func validateMerchantBalanceAmount(amount int, balance int) error {
-	if balance+amount < 0 {
-		return errors.New("deposit amount cannot be more than wallet")
+	if amount <= 0 {
+		return apperror.Validation("amount must be more than zero")
+	}
+	if balance-amount < 0 {
+		return apperror.InsufficientBalance("deposit amount cannot be more than wallet")
 	}
 	return nil
 }
-func (t TransferUsecase) CreateTransferHistory(request request.CreateTransferHistoryRequest) error {
-	balance, err := t.merchantUsecase.GetMerchantBalance(request.MerchantId)
+func (t TransferUsecase) CreateTransferHistory(ctx context.Context, request request.CreateTransferHistoryRequest) error {
+	ctx, span := tracing.Start(ctx, "TransferUsecase.CreateTransferHistory")
+	defer span.End()
+	if err := t.merchantUsecase.ValidateMerchantActive(ctx, request.MerchantId); err != nil {
+		return err
+	}
+	balance, err := t.merchantUsecase.GetMerchantBalance(ctx, request.MerchantId)
 	if err != nil {
 		return err
 	}
 	if err := validateMerchantBalanceAmount(request.Amount, balance); err != nil {
 		return err
 	}
-	if err := t.repo.CreateTransferHistory(convertCreateTransferRequestToDomain(request)); err != nil {
+	account, err := t.bankAccountUsecase.GetPayoutAccount(ctx, request.MerchantId, request.BankAccountId)
+	if err != nil {
+		return err
+	}
+	if err := t.repo.CreateTransferHistory(ctx, convertCreateTransferRequestToDomain(request, *account)); err != nil {
 		return err
 	}
 	updateMerchantBalanceRequest := merchant2.UpdateMerchantBalanceRequest{
-		Amount:     request.Amount,
+		Amount:     -request.Amount,
 		MerchantId: request.MerchantId,
 	}
-	if err := t.merchantUsecase.UpdateMerchantBalance(updateMerchantBalanceRequest); err != nil {
+	if err := t.merchantUsecase.UpdateMerchantBalance(ctx, updateMerchantBalanceRequest); err != nil {
 		return err
 	}
+	// the balance was debited with the negated amount, counters only take positive values
+	withdrawn := -updateMerchantBalanceRequest.Amount
+	metrics.Withdrawals.Inc()
+	metrics.WithdrawalVolume.Add(float64(withdrawn))
 	return nil
 }
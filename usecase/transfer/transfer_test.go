@@ -1,12 +1,19 @@
 package transfer
 
 import (
+	"context"
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/williamchang80/sea-apd/common/metrics"
+	domain2 "github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/bank_account"
 	"github.com/williamchang80/sea-apd/domain/merchant"
 	domain "github.com/williamchang80/sea-apd/domain/transfer"
+	merchantRequest "github.com/williamchang80/sea-apd/dto/request/merchant"
 	"github.com/williamchang80/sea-apd/dto/request/transfer"
 	request "github.com/williamchang80/sea-apd/dto/request/transfer"
 	transfer2 "github.com/williamchang80/sea-apd/mocks/repository/transfer"
+	bank_account2 "github.com/williamchang80/sea-apd/mocks/usecase/bank_account"
 	merchant2 "github.com/williamchang80/sea-apd/mocks/usecase/merchant"
 	"reflect"
 	"testing"
@@ -15,16 +22,21 @@ import (
 var (
 	mockId                       = "1"
 	mockUpdateTransactionRequest = transfer.CreateTransferHistoryRequest{
-		BankNumber: "1",
-		BankName:   "name",
-		Amount:     100,
-		MerchantId: "1",
+		BankAccountId: "1",
+		Amount:        100,
+		MerchantId:    "1",
+	}
+	mockBankAccount = bank_account.BankAccount{
+		Base:          domain2.Base{ID: "1"},
+		BankCode:      "014",
+		AccountNumber: "1234567890",
 	}
 	mockTransferEntity = domain.Transfer{
-		Amount:     100,
-		BankName:   "name",
-		BankNumber: "1",
-		MerchantId: "1",
+		Amount:        100,
+		BankAccountId: "1",
+		BankName:      "BCA",
+		BankNumber:    "1234567890",
+		MerchantId:    "1",
 	}
 )
 
@@ -52,7 +64,7 @@ func TestNewTransferUsecase(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewTransferUsecase(tt.args.repository, tt.args.usecase); !reflect.DeepEqual(got, tt.want) {
+			if got := NewTransferUsecase(tt.args.repository, tt.args.usecase, nil); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewTransferUseCase() = %v, want %v", got, tt.want)
 			}
 		})
@@ -79,7 +91,7 @@ func TestConvertToDomain(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := convertCreateTransferRequestToDomain(tt.args.productRequest); !reflect.DeepEqual(got, tt.want) {
+			if got := convertCreateTransferRequestToDomain(tt.args.productRequest, mockBankAccount); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("convertCreateTransferRequestToDomain() = %v, want %v", got, tt.want)
 			}
 		})
@@ -108,7 +120,7 @@ func TestTransferUsecase_GetTransferHistory(t *testing.T) {
 			want: []domain.Transfer{},
 			initMock: func() domain.TransferUsecase {
 				t := transfer2.NewMockRepository(ctrl)
-				return NewTransferUsecase(t, nil)
+				return NewTransferUsecase(t, nil, nil)
 			},
 		},
 		{
@@ -119,7 +131,7 @@ func TestTransferUsecase_GetTransferHistory(t *testing.T) {
 			},
 			initMock: func() domain.TransferUsecase {
 				t := transfer2.NewMockRepository(ctrl)
-				return NewTransferUsecase(t, nil)
+				return NewTransferUsecase(t, nil, nil)
 			},
 		},
 	}
@@ -127,7 +139,7 @@ func TestTransferUsecase_GetTransferHistory(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := tt.initMock()
-			got, err := c.GetTransferHistory(tt.args.merchantId)
+			got, err := c.GetTransferHistory(context.Background(), tt.args.merchantId)
 			if err != nil && !tt.wantErr {
 				t.Errorf("TransferUsecase.GetTransferHistory() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -161,7 +173,7 @@ func TestTransferUsecase_CreateTransferHistory(t *testing.T) {
 			initMock: func() domain.TransferUsecase {
 				t := transfer2.NewMockRepository(ctrl)
 				u := merchant2.NewMockUsecase(ctrl)
-				return NewTransferUsecase(t, u)
+				return NewTransferUsecase(t, u, bank_account2.NewMockUsecase(ctrl))
 			},
 		},
 		{
@@ -173,7 +185,23 @@ func TestTransferUsecase_CreateTransferHistory(t *testing.T) {
 			initMock: func() domain.TransferUsecase {
 				t := transfer2.NewMockRepository(ctrl)
 				u := merchant2.NewMockUsecase(ctrl)
-				return NewTransferUsecase(t, u)
+				return NewTransferUsecase(t, u, bank_account2.NewMockUsecase(ctrl))
+			},
+		},
+		{
+			name:    "failed with negative amount",
+			wantErr: true,
+			args: args{
+				request: request.CreateTransferHistoryRequest{
+					BankAccountId: "1",
+					Amount:        -100,
+					MerchantId:    "1",
+				},
+			},
+			initMock: func() domain.TransferUsecase {
+				t := transfer2.NewMockRepository(ctrl)
+				u := merchant2.NewMockUsecase(ctrl)
+				return NewTransferUsecase(t, u, bank_account2.NewMockUsecase(ctrl))
 			},
 		},
 		{
@@ -181,16 +209,46 @@ func TestTransferUsecase_CreateTransferHistory(t *testing.T) {
 			wantErr: true,
 			args: args{
 				request: request.CreateTransferHistoryRequest{
-					BankNumber: "test",
-					BankName:   "test",
-					Amount:     -1000000,
-					MerchantId: "1",
+					BankAccountId: "1",
+					Amount:        1000000,
+					MerchantId:    "1",
+				},
+			},
+			initMock: func() domain.TransferUsecase {
+				t := transfer2.NewMockRepository(ctrl)
+				u := merchant2.NewMockUsecase(ctrl)
+				return NewTransferUsecase(t, u, bank_account2.NewMockUsecase(ctrl))
+			},
+		},
+		{
+			name:    "failed with unverified bank account",
+			wantErr: true,
+			args: args{
+				request: request.CreateTransferHistoryRequest{
+					BankAccountId: bank_account2.MockUnverifiedAccountId,
+					Amount:        100,
+					MerchantId:    "1",
+				},
+			},
+			initMock: func() domain.TransferUsecase {
+				t := transfer2.NewMockRepository(ctrl)
+				u := merchant2.NewMockUsecase(ctrl)
+				return NewTransferUsecase(t, u, bank_account2.NewMockUsecase(ctrl))
+			},
+		},
+		{
+			name:    "failed with suspended merchant",
+			wantErr: true,
+			args: args{
+				request: request.CreateTransferHistoryRequest{
+					Amount:     100,
+					MerchantId: merchant2.MockSuspendedMerchantId,
 				},
 			},
 			initMock: func() domain.TransferUsecase {
 				t := transfer2.NewMockRepository(ctrl)
 				u := merchant2.NewMockUsecase(ctrl)
-				return NewTransferUsecase(t, u)
+				return NewTransferUsecase(t, u, bank_account2.NewMockUsecase(ctrl))
 			},
 		},
 	}
@@ -198,11 +256,72 @@ func TestTransferUsecase_CreateTransferHistory(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := tt.initMock()
-			err := c.CreateTransferHistory(tt.args.request)
-			if err != nil && !tt.wantErr {
+			err := c.CreateTransferHistory(context.Background(), tt.args.request)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("TransferUsecase.CreateTransferHistory() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 		})
 	}
 }
+
+// balanceMerchantUsecase keeps the balance the transfers debit
+type balanceMerchantUsecase struct {
+	*merchant2.MockUsecase
+	balance int
+}
+
+func (b *balanceMerchantUsecase) GetMerchantBalance(ctx context.Context, merchantId string) (int, error) {
+	return b.balance, nil
+}
+
+func (b *balanceMerchantUsecase) UpdateMerchantBalance(ctx context.Context,
+	request merchantRequest.UpdateMerchantBalanceRequest) error {
+	b.balance += request.Amount
+	return nil
+}
+
+func TestTransferUsecase_CreateTransferHistoryBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name        string
+		amount      int
+		wantErr     bool
+		wantBalance int
+	}{
+		{
+			name:        "success debits the balance",
+			amount:      100,
+			wantErr:     false,
+			wantBalance: 900,
+		},
+		{
+			name:        "failed with more amount than balance",
+			amount:      1001,
+			wantErr:     true,
+			wantBalance: 1000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			volume := testutil.ToFloat64(metrics.WithdrawalVolume)
+			m := &balanceMerchantUsecase{MockUsecase: merchant2.NewMockUsecase(ctrl), balance: 1000}
+			c := NewTransferUsecase(transfer2.NewMockRepository(ctrl), m, bank_account2.NewMockUsecase(ctrl))
+			err := c.CreateTransferHistory(context.Background(), request.CreateTransferHistoryRequest{
+				BankAccountId: "1",
+				Amount:        tt.amount,
+				MerchantId:    "1",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TransferUsecase.CreateTransferHistory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if m.balance != tt.wantBalance {
+				t.Errorf("merchant balance = %v, want %v", m.balance, tt.wantBalance)
+			}
+			if got := testutil.ToFloat64(metrics.WithdrawalVolume) - volume; got != float64(1000-tt.wantBalance) {
+				t.Errorf("withdrawal volume grew by %v, want %v", got, 1000-tt.wantBalance)
+			}
+		})
+	}
+}
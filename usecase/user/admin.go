@@ -1,17 +1,28 @@
 package user
 
 import (
-	"errors"
-	"os"
-
-	"github.com/williamchang80/sea-apd/dto/request/auth"
+	"context"
+	"strings"
+	"time"
 
+	auth2 "github.com/williamchang80/sea-apd/common/auth"
+	"github.com/williamchang80/sea-apd/common/constants/mailer_type"
 	"github.com/williamchang80/sea-apd/common/constants/user_role"
+	"github.com/williamchang80/sea-apd/common/mailer"
+	"github.com/williamchang80/sea-apd/common/mailer/factory"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	auth_domain "github.com/williamchang80/sea-apd/domain/auth"
 	"github.com/williamchang80/sea-apd/domain/user"
 	"github.com/williamchang80/sea-apd/dto/request/admin"
+	"github.com/williamchang80/sea-apd/dto/request/auth"
 )
 
+// InvitationLifetime is how long an admin invitation can be accepted
+const InvitationLifetime = 72 * time.Hour
+
+var ErrAdminExists = apperror.New(apperror.CONFLICT, "admin_exists", "an admin already exists, invite new admins instead")
+
 // AdminUsecase ...
 type AdminUsecase struct {
 	ur      user.UserRepository
@@ -26,37 +37,144 @@ func NewAdminUseCase(a user.UserRepository, usecase auth_domain.AuthUsecase) use
 	}
 }
 
-// RegisterAdmin ...
-func (s *AdminUsecase) RegisterAdmin(request admin.Admin) error {
+// AcceptAdminInvitation makes the logged in user an admin when the token belongs to a
+// pending invitation of the user's email
+func (s *AdminUsecase) AcceptAdminInvitation(ctx context.Context, request admin.Admin) error {
+	ctx, span := tracing.Start(ctx, "AdminUsecase.AcceptAdminInvitation")
+	defer span.End()
+	if request.Token == "" {
+		return user.ErrInvitationNotValid
+	}
 	authRequest := auth.LoginRequest{
 		Email:    request.Email,
 		Password: request.Password,
 	}
-	if _, err := s.usecase.Login(authRequest); err != nil {
+	if _, err := s.usecase.Login(ctx, authRequest); err != nil {
 		return err
 	}
 
-	u, err := s.ur.GetUserByEmail(request.Email)
+	u, err := s.ur.GetUserByEmail(ctx, request.Email)
 	if err != nil {
-		return errors.New("credential not match")
+		return apperror.Unauthenticated("credential not match")
 	}
 
-	isValid := validateToken(request.Token)
-	if isValid == false {
-		return errors.New("Token not valid")
+	return s.ur.AcceptAdminInvitation(ctx, auth2.HashSecureToken(request.Token), *u, time.Now())
+}
+
+// InviteAdmin mails a single use token to the email, the token itself is not stored
+func (s *AdminUsecase) InviteAdmin(ctx context.Context, request admin.InviteAdminRequest) (*user.AdminInvitation, error) {
+	ctx, span := tracing.Start(ctx, "AdminUsecase.InviteAdmin")
+	defer span.End()
+	if request.AdminId == "" {
+		return nil, apperror.Validation("admin id cannot be empty")
+	}
+	email := strings.TrimSpace(request.Email)
+	if email == "" {
+		return nil, apperror.Validation("email cannot be empty")
 	}
+	if u, err := s.ur.GetUserByEmail(ctx, email); err == nil && u != nil &&
+		user_role.ParseToEnum(u.Role) == user_role.ADMIN {
+		return nil, apperror.Conflict("user is already an admin")
+	}
+	token, err := auth2.GenerateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+	invitation := user.AdminInvitation{
+		Email:     email,
+		TokenHash: auth2.HashSecureToken(token),
+		InvitedBy: request.AdminId,
+		ExpiresAt: time.Now().Add(InvitationLifetime),
+	}
+	if err := s.ur.CreateAdminInvitation(ctx, invitation); err != nil {
+		return nil, err
+	}
+	mails := factory.CreateMailerFactory(mailer_type.ADMIN_INVITATION).CreateMail(invitation, token)
+	mailer.SendEmail(ctx, mails)
+	return &invitation, nil
+}
 
-	if err := s.ur.UpdateUserRole(user_role.ToString(user_role.ADMIN), u.ID); err != nil {
-		return err
+func (s *AdminUsecase) GetAdminInvitations(ctx context.Context) ([]user.AdminInvitation, error) {
+	ctx, span := tracing.Start(ctx, "AdminUsecase.GetAdminInvitations")
+	defer span.End()
+	invitations, err := s.ur.GetAdminInvitations(ctx)
+	if err != nil {
+		return nil, err
 	}
+	return invitations, nil
+}
+
+func (s *AdminUsecase) RevokeAdminInvitation(ctx context.Context, request admin.RevokeInvitationRequest) error {
+	ctx, span := tracing.Start(ctx, "AdminUsecase.RevokeAdminInvitation")
+	defer span.End()
+	if request.AdminId == "" {
+		return apperror.Validation("admin id cannot be empty")
+	}
+	if request.InvitationId == "" {
+		return apperror.Validation("invitation id cannot be empty")
+	}
+	return s.ur.RevokeAdminInvitation(ctx, request.InvitationId, time.Now())
+}
 
-	return nil
+// BootstrapAdmin creates the first admin of a fresh installation and refuses to run once
+// an admin exists
+func (s *AdminUsecase) BootstrapAdmin(ctx context.Context, request admin.BootstrapAdminRequest) error {
+	ctx, span := tracing.Start(ctx, "AdminUsecase.BootstrapAdmin")
+	defer span.End()
+	email := strings.TrimSpace(request.Email)
+	if email == "" || request.Password == "" {
+		return apperror.Validation("email and password cannot be empty")
+	}
+	admins, err := s.ur.CountUsersByRole(ctx, user_role.ToString(user_role.ADMIN))
+	if err != nil {
+		return err
+	}
+	if admins > 0 {
+		return ErrAdminExists
+	}
+	if u, err := s.ur.GetUserByEmail(ctx, email); err == nil && u != nil {
+		return s.ur.UpdateUserRole(ctx, user_role.ToString(user_role.ADMIN), u.ID)
+	}
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		name = "Admin"
+	}
+	now := time.Now()
+	return s.ur.CreateUser(ctx, user.User{
+		Name:            name,
+		Email:           email,
+		Password:        auth2.HashPassword(request.Password),
+		Role:            user_role.ToString(user_role.ADMIN),
+		EmailVerifiedAt: &now,
+	})
 }
 
-func validateToken(token string) bool {
-	if token == os.Getenv("ADMIN_TOKEN") {
-		return true
+func (s *AdminUsecase) GetTwoFactorPolicies(ctx context.Context) ([]user.TwoFactorPolicy, error) {
+	ctx, span := tracing.Start(ctx, "AdminUsecase.GetTwoFactorPolicies")
+	defer span.End()
+	policies, err := s.ur.GetTwoFactorPolicies(ctx)
+	if err != nil {
+		return nil, err
 	}
+	return policies, nil
+}
 
-	return false
+// SetTwoFactorPolicy makes two-factor authentication mandatory, or optional again, for
+// admins or merchants
+func (s *AdminUsecase) SetTwoFactorPolicy(ctx context.Context, request admin.TwoFactorPolicyRequest) error {
+	ctx, span := tracing.Start(ctx, "AdminUsecase.SetTwoFactorPolicy")
+	defer span.End()
+	if request.AdminId == "" {
+		return apperror.Validation("admin id cannot be empty")
+	}
+	role := user_role.ParseToEnum(strings.ToLower(request.Role))
+	if role != user_role.ADMIN && role != user_role.MERCHANT {
+		return apperror.Validation("role must be admin or merchant")
+	}
+	return s.ur.SetTwoFactorPolicy(ctx, user.TwoFactorPolicy{
+		Role:      user_role.ToString(role),
+		Required:  request.Required,
+		UpdatedBy: request.AdminId,
+		UpdatedAt: time.Now(),
+	})
 }
@@ -0,0 +1,201 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/williamchang80/sea-apd/dto/request/admin"
+	"github.com/williamchang80/sea-apd/mocks/repository/user"
+	memory "github.com/williamchang80/sea-apd/repository/memory/auth"
+	"github.com/williamchang80/sea-apd/usecase/auth"
+)
+
+func TestAdminUsecase_AcceptAdminInvitation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    admin.Admin
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    admin.Admin{Token: user.MockInvitationToken, Email: "mock@mock.com", Password: user.MockPassword},
+			wantErr: false,
+		},
+		{
+			name:    "failed with wrong password",
+			args:    admin.Admin{Token: user.MockInvitationToken, Email: "mock@mock.com", Password: "wrong"},
+			wantErr: true,
+		},
+		{
+			name:    "failed with unknown token",
+			args:    admin.Admin{Token: "guess", Email: "mock@mock.com", Password: user.MockPassword},
+			wantErr: true,
+		},
+		{
+			name:    "failed without token",
+			args:    admin.Admin{Email: "mock@mock.com", Password: user.MockPassword},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := user.NewMockRepository(ctrl)
+			a := NewAdminUseCase(repo, auth.NewAuthUsecase(repo, memory.NewLoginAttemptStore()))
+			if err := a.AcceptAdminInvitation(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("AdminUsecase.AcceptAdminInvitation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAdminUsecase_InviteAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    admin.InviteAdminRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    admin.InviteAdminRequest{AdminId: "1", Email: user.MockUnknownEmail},
+			wantErr: false,
+		},
+		{
+			name:    "failed with admin email",
+			args:    admin.InviteAdminRequest{AdminId: "1", Email: user.MockAdminEmail},
+			wantErr: true,
+		},
+		{
+			name:    "failed without admin",
+			args:    admin.InviteAdminRequest{Email: user.MockUnknownEmail},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := user.NewMockRepository(ctrl)
+			a := NewAdminUseCase(repo, auth.NewAuthUsecase(repo, memory.NewLoginAttemptStore()))
+			invitation, err := a.InviteAdmin(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AdminUsecase.InviteAdmin() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && (invitation.TokenHash == "" || !invitation.ExpiresAt.After(time.Now())) {
+				t.Errorf("AdminUsecase.InviteAdmin() = %+v, want hashed token and expiry", invitation)
+			}
+		})
+	}
+}
+
+func TestAdminUsecase_RevokeAdminInvitation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    admin.RevokeInvitationRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    admin.RevokeInvitationRequest{AdminId: "1", InvitationId: "1"},
+			wantErr: false,
+		},
+		{
+			name:    "failed with accepted invitation",
+			args:    admin.RevokeInvitationRequest{AdminId: "1", InvitationId: user.MockAcceptedInvitationId},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := user.NewMockRepository(ctrl)
+			a := NewAdminUseCase(repo, auth.NewAuthUsecase(repo, memory.NewLoginAttemptStore()))
+			if err := a.RevokeAdminInvitation(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("AdminUsecase.RevokeAdminInvitation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAdminUsecase_BootstrapAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		admins  int
+		args    admin.BootstrapAdminRequest
+		wantErr bool
+	}{
+		{
+			name:    "success with new user",
+			args:    admin.BootstrapAdminRequest{Email: user.MockUnknownEmail, Password: "secret"},
+			wantErr: false,
+		},
+		{
+			name:    "success with registered user",
+			args:    admin.BootstrapAdminRequest{Email: "mock@mock.com", Password: "secret"},
+			wantErr: false,
+		},
+		{
+			name:    "failed when an admin exists",
+			admins:  1,
+			args:    admin.BootstrapAdminRequest{Email: user.MockUnknownEmail, Password: "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "failed without password",
+			args:    admin.BootstrapAdminRequest{Email: user.MockUnknownEmail},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := user.NewMockRepository(ctrl)
+			repo.Admins = tt.admins
+			a := NewAdminUseCase(repo, auth.NewAuthUsecase(repo, memory.NewLoginAttemptStore()))
+			if err := a.BootstrapAdmin(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("AdminUsecase.BootstrapAdmin() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAdminUsecase_SetTwoFactorPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    admin.TwoFactorPolicyRequest
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			args:    admin.TwoFactorPolicyRequest{AdminId: "admin", Role: "Merchant", Required: true},
+			wantErr: false,
+		},
+		{
+			name:    "failed with customer role",
+			args:    admin.TwoFactorPolicyRequest{AdminId: "admin", Role: "customer", Required: true},
+			wantErr: true,
+		},
+		{
+			name:    "failed without admin id",
+			args:    admin.TwoFactorPolicyRequest{Role: "admin", Required: true},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := user.NewMockRepository(ctrl)
+			a := NewAdminUseCase(repo, auth.NewAuthUsecase(repo, memory.NewLoginAttemptStore()))
+			if err := a.SetTwoFactorPolicy(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("AdminUsecase.SetTwoFactorPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package mailer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/williamchang80/sea-apd/common/mailer"
+	"github.com/williamchang80/sea-apd/domain/user"
+)
+
+type AdminInvitationMailer struct {
+}
+
+func (a AdminInvitationMailer) CreateMail(i ...interface{}) []mailer.Mail {
+	invitation, _ := i[0].(user.AdminInvitation)
+	token, _ := i[1].(string)
+	return CreateAdminInvitationMailer(invitation, token)
+}
+
+func CreateAdminInvitationMailer(invitation user.AdminInvitation, token string) []mailer.Mail {
+	invitationMailer := mailer.Mail{
+		Sender:    mailer.MailSender,
+		Subject:   "You are invited to become an admin",
+		Recipient: invitation.Email,
+		Body: fmt.Sprintf(`Hello, you were invited to become an admin. Log in with the account of
+		this email address and accept the invitation with the token %v before %v.
+		If you did not expect this invitation, you can ignore this mail`, token,
+			invitation.ExpiresAt.Format(time.RFC1123)),
+	}
+	return []mailer.Mail{
+		invitationMailer,
+	}
+}
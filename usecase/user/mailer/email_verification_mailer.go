@@ -0,0 +1,31 @@
+package mailer
+
+import (
+	"fmt"
+
+	"github.com/williamchang80/sea-apd/common/mailer"
+	"github.com/williamchang80/sea-apd/domain/user"
+)
+
+type EmailVerificationMailer struct {
+}
+
+func (e EmailVerificationMailer) CreateMail(i ...interface{}) []mailer.Mail {
+	u, _ := i[0].(user.User)
+	link, _ := i[1].(string)
+	return CreateEmailVerificationMailer(u, link)
+}
+
+func CreateEmailVerificationMailer(u user.User, link string) []mailer.Mail {
+	verificationMailer := mailer.Mail{
+		Sender:    mailer.MailSender,
+		Subject:   "Please verify your email address",
+		Recipient: u.Email,
+		Body: fmt.Sprintf(`Hello %v, please confirm your email address by opening %v
+		The link is valid for 24 hours. If you did not register, you can ignore this mail`,
+			u.Name, link),
+	}
+	return []mailer.Mail{
+		verificationMailer,
+	}
+}
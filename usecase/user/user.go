@@ -1,11 +1,15 @@
 package user
 
 import (
-	"errors"
+	"context"
+	"time"
 
 	auth2 "github.com/williamchang80/sea-apd/common/auth"
 	"github.com/williamchang80/sea-apd/common/constants/user_role"
+	"github.com/williamchang80/sea-apd/common/mailer"
+	"github.com/williamchang80/sea-apd/common/tracing"
 	"github.com/williamchang80/sea-apd/domain"
+	"github.com/williamchang80/sea-apd/domain/apperror"
 	auth_domain "github.com/williamchang80/sea-apd/domain/auth"
 	"github.com/williamchang80/sea-apd/domain/user"
 	"github.com/williamchang80/sea-apd/dto/request/auth"
@@ -30,50 +34,77 @@ func convertRegisterRequestToUserDomain(request auth.RegisterUserRequest) user.U
 	}
 }
 
-func (u UserUsecase) CreateUser(request auth.RegisterUserRequest) error {
+func (u UserUsecase) CreateUser(ctx context.Context, request auth.RegisterUserRequest) error {
+	ctx, span := tracing.Start(ctx, "UserUsecase.CreateUser")
+	defer span.End()
 	if request.Password != request.PasswordConfirmation {
-		return errors.New("password and confirmation password must be same")
+		return apperror.Validation("password and confirmation password must be same")
+	}
+	if err := mailer.ValidateAddress(request.Email); err != nil {
+		return err
 	}
 	user := convertRegisterRequestToUserDomain(request)
-	if err := u.repo.CreateUser(user); err != nil {
-		return errors.New("email must be unique")
+	now := time.Now()
+	user.VerificationSentAt = &now
+	if err := u.repo.CreateUser(ctx, user); err != nil {
+		return err
 	}
-	return nil
+	return sendVerification(ctx, user)
 }
 
-func (u UserUsecase) UpdateUserRole(request user2.UpdateUserRoleRequest) error {
-	if err := u.repo.UpdateUserRole(user_role.ToString(request.Role), request.UserId); err != nil {
+func (u UserUsecase) UpdateUserRole(ctx context.Context, request user2.UpdateUserRoleRequest) error {
+	ctx, span := tracing.Start(ctx, "UserUsecase.UpdateUserRole")
+	defer span.End()
+	if err := u.repo.UpdateUserRole(ctx, user_role.ToString(request.Role), request.UserId); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (u UserUsecase) GetUserById(userId string) (*user.User, error) {
-	user, err := u.repo.GetUserById(userId)
+func (u UserUsecase) GetUserById(ctx context.Context, userId string) (*user.User, error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.GetUserById")
+	defer span.End()
+	user, err := u.repo.GetUserById(ctx, userId)
 	if err != nil {
 		return nil, err
 	}
 	return user, nil
 }
 
-func (u UserUsecase) UpdateUser(request user2.UpdateUserRequest) error {
+func (u UserUsecase) UpdateUser(ctx context.Context, request user2.UpdateUserRequest) error {
+	ctx, span := tracing.Start(ctx, "UserUsecase.UpdateUser")
+	defer span.End()
 	authRequest := auth.LoginRequest{
 		Email:    request.OldEmail,
 		Password: request.OldPassword,
 	}
-	if _, err := u.usecase.Login(authRequest); err != nil {
+	if _, err := u.usecase.Login(ctx, authRequest); err != nil {
 		return err
 	}
-	us, err := u.GetUserById(request.UserId)
-	if us.Email != request.OldEmail || err != nil {
-		return errors.New("credential doesnt match")
+	us, err := u.GetUserById(ctx, request.UserId)
+	if err != nil || us == nil || us.Email != request.OldEmail {
+		return apperror.Unauthenticated("credential doesnt match")
+	}
+
+	emailChanged := request.NewEmail != "" && request.NewEmail != us.Email
+	if emailChanged {
+		if err := mailer.ValidateAddress(request.NewEmail); err != nil {
+			return err
+		}
 	}
 
 	user := getUpdatedUserLoginInformation(request, us.ID)
-	if err := u.repo.UpdateUser(user); err != nil {
+	if err := u.repo.UpdateUser(ctx, user); err != nil {
 		return err
 	}
-	return nil
+	if !emailChanged {
+		return nil
+	}
+	if err := u.repo.SetEmailVerified(ctx, user.Email, nil); err != nil {
+		return err
+	}
+	us.Email = user.Email
+	return sendVerification(ctx, *us)
 }
 
 func getUpdatedUserLoginInformation(request user2.UpdateUserRequest,
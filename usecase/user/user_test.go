@@ -0,0 +1,44 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	user2 "github.com/williamchang80/sea-apd/dto/request/user"
+	"github.com/williamchang80/sea-apd/mocks/repository/user"
+	memory "github.com/williamchang80/sea-apd/repository/memory/auth"
+	auth3 "github.com/williamchang80/sea-apd/usecase/auth"
+)
+
+func TestUserUsecase_UpdateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    user2.UpdateUserRequest
+		wantErr bool
+	}{
+		{
+			name: "failed with unknown user",
+			args: user2.UpdateUserRequest{OldEmail: "old@mock.com", OldPassword: user.MockPassword,
+				NewPassword: "secret"},
+			wantErr: true,
+		},
+		{
+			name: "failed with email of another user",
+			args: user2.UpdateUserRequest{OldEmail: "old@mock.com", OldPassword: user.MockPassword,
+				NewPassword: "secret", UserId: "1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := user.NewMockRepository(ctrl)
+			u := NewUserUsecase(repo, auth3.NewAuthUsecase(repo, memory.NewLoginAttemptStore()))
+			if err := u.UpdateUser(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("UserUsecase.UpdateUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package user
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	auth2 "github.com/williamchang80/sea-apd/common/auth"
+	"github.com/williamchang80/sea-apd/common/constants/mailer_type"
+	"github.com/williamchang80/sea-apd/common/mailer"
+	"github.com/williamchang80/sea-apd/common/mailer/factory"
+	"github.com/williamchang80/sea-apd/common/tracing"
+	"github.com/williamchang80/sea-apd/domain/apperror"
+	"github.com/williamchang80/sea-apd/domain/user"
+	user2 "github.com/williamchang80/sea-apd/dto/request/user"
+)
+
+const (
+	// VerificationLifetime is how long the link of a verification mail works
+	VerificationLifetime = 24 * time.Hour
+	// ResendCooldown is the minimum time between two verification mails to one address
+	ResendCooldown = time.Minute
+)
+
+// verificationLink points to the verify endpoint on APP_BASE_URL, e.g.
+// https://shop.example.com
+func verificationLink(token string) string {
+	return strings.TrimRight(os.Getenv("APP_BASE_URL"), "/") + "/api/auth/verify-email?token=" +
+		url.QueryEscape(token)
+}
+
+// sendVerification mails a signed link for the email of the user, a failing mail can be
+// retried with ResendVerification
+func sendVerification(ctx context.Context, u user.User) error {
+	token, err := auth2.GeneratePurposeToken(auth2.EmailVerificationPurpose, u.Email,
+		time.Now().Add(VerificationLifetime))
+	if err != nil {
+		return err
+	}
+	mails := factory.CreateMailerFactory(mailer_type.EMAIL_VERIFICATION).CreateMail(u, verificationLink(token))
+	mailer.SendEmail(ctx, mails)
+	return nil
+}
+
+// VerifyEmail confirms the email the token was signed for, verifying twice is fine
+func (u UserUsecase) VerifyEmail(ctx context.Context, token string) error {
+	ctx, span := tracing.Start(ctx, "UserUsecase.VerifyEmail")
+	defer span.End()
+	email, err := auth2.ParsePurposeToken(auth2.EmailVerificationPurpose, token)
+	if err != nil {
+		return apperror.Validation("verification link is not valid or expired")
+	}
+	us, err := u.repo.GetUserByEmail(ctx, email)
+	if err != nil || us == nil {
+		return apperror.Validation("verification link is not valid or expired")
+	}
+	if us.EmailVerifiedAt != nil {
+		return nil
+	}
+	now := time.Now()
+	return u.repo.SetEmailVerified(ctx, email, &now)
+}
+
+// ResendVerification mails a new link, at most once per ResendCooldown
+func (u UserUsecase) ResendVerification(ctx context.Context, request user2.ResendVerificationRequest) error {
+	ctx, span := tracing.Start(ctx, "UserUsecase.ResendVerification")
+	defer span.End()
+	us, err := u.repo.GetUserByEmail(ctx, strings.TrimSpace(request.Email))
+	if err != nil || us == nil {
+		return apperror.NotFound("user not found")
+	}
+	if us.EmailVerifiedAt != nil {
+		return apperror.Conflict("email is already verified")
+	}
+	now := time.Now()
+	if err := u.repo.MarkVerificationSent(ctx, us.Email, now, now.Add(-ResendCooldown)); err != nil {
+		return err
+	}
+	return sendVerification(ctx, *us)
+}
+
+func (u UserUsecase) ValidateUserVerified(ctx context.Context, userId string) error {
+	ctx, span := tracing.Start(ctx, "UserUsecase.ValidateUserVerified")
+	defer span.End()
+	us, err := u.repo.GetUserById(ctx, userId)
+	if err != nil || us == nil {
+		return apperror.NotFound("user not found")
+	}
+	if us.EmailVerifiedAt == nil {
+		return user.ErrEmailNotVerified
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	auth2 "github.com/williamchang80/sea-apd/common/auth"
+	"github.com/williamchang80/sea-apd/dto/request/auth"
+	user2 "github.com/williamchang80/sea-apd/dto/request/user"
+	"github.com/williamchang80/sea-apd/mocks/repository/user"
+	memory "github.com/williamchang80/sea-apd/repository/memory/auth"
+	auth3 "github.com/williamchang80/sea-apd/usecase/auth"
+)
+
+func TestUserUsecase_CreateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		args    auth.RegisterUserRequest
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: auth.RegisterUserRequest{Email: "new@mock.com", Name: "New", Password: "secret",
+				PasswordConfirmation: "secret"},
+			wantErr: false,
+		},
+		{
+			name: "failed with invalid email",
+			args: auth.RegisterUserRequest{Email: "not an email", Name: "New", Password: "secret",
+				PasswordConfirmation: "secret"},
+			wantErr: true,
+		},
+		{
+			name: "failed with taken email",
+			args: auth.RegisterUserRequest{Email: user.MockAdminEmail, Name: "New", Password: "secret",
+				PasswordConfirmation: "secret"},
+			wantErr: true,
+		},
+		{
+			name: "failed with different confirmation",
+			args: auth.RegisterUserRequest{Email: "new@mock.com", Name: "New", Password: "secret",
+				PasswordConfirmation: "other"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := user.NewMockRepository(ctrl)
+			u := NewUserUsecase(repo, auth3.NewAuthUsecase(repo, memory.NewLoginAttemptStore()))
+			if err := u.CreateUser(context.Background(), tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("UserUsecase.CreateUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_VerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	token := func(purpose string, email string, expiresAt time.Time) string {
+		t, _ := auth2.GeneratePurposeToken(purpose, email, expiresAt)
+		return t
+	}
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			token:   token(auth2.EmailVerificationPurpose, user.MockUnverifiedEmail, time.Now().Add(time.Hour)),
+			wantErr: false,
+		},
+		{
+			name:    "success with verified email",
+			token:   token(auth2.EmailVerificationPurpose, "mock@mock.com", time.Now().Add(time.Hour)),
+			wantErr: false,
+		},
+		{
+			name:    "failed with expired token",
+			token:   token(auth2.EmailVerificationPurpose, user.MockUnverifiedEmail, time.Now().Add(-time.Hour)),
+			wantErr: true,
+		},
+		{
+			name:    "failed with token of other purpose",
+			token:   token("other", user.MockUnverifiedEmail, time.Now().Add(time.Hour)),
+			wantErr: true,
+		},
+		{
+			name:    "failed with unknown email",
+			token:   token(auth2.EmailVerificationPurpose, user.MockUnknownEmail, time.Now().Add(time.Hour)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := user.NewMockRepository(ctrl)
+			u := NewUserUsecase(repo, auth3.NewAuthUsecase(repo, memory.NewLoginAttemptStore()))
+			if err := u.VerifyEmail(context.Background(), tt.token); (err != nil) != tt.wantErr {
+				t.Errorf("UserUsecase.VerifyEmail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_ResendVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name    string
+		email   string
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			email:   user.MockUnverifiedEmail,
+			wantErr: false,
+		},
+		{
+			name:    "failed with verified email",
+			email:   "mock@mock.com",
+			wantErr: true,
+		},
+		{
+			name:    "failed with mail sent moments ago",
+			email:   user.MockRecentlySentEmail,
+			wantErr: true,
+		},
+		{
+			name:    "failed with unknown email",
+			email:   user.MockUnknownEmail,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := user.NewMockRepository(ctrl)
+			u := NewUserUsecase(repo, auth3.NewAuthUsecase(repo, memory.NewLoginAttemptStore()))
+			if err := u.ResendVerification(context.Background(), user2.ResendVerificationRequest{Email: tt.email}); (err != nil) != tt.wantErr {
+				t.Errorf("UserUsecase.ResendVerification() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}